@@ -0,0 +1,536 @@
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Artifact content is stored once per SHA-256 below blobs/sha256/<xx>/<digest>.
+// Each build keeps a manifest mapping its artifact paths to blobs, and its artifact
+// directory holds hard links to the blobs so files can still be read in place.
+const (
+	blobsDir     = "blobs"
+	blobAlgo     = "sha256"
+	blobTmpDir   = "tmp"
+	manifestFile = "manifest.json"
+)
+
+// ManifestEntry describes one file of a build's artifact directory
+type ManifestEntry struct {
+	Path    string      `json:"path"`              // relative to the build's artifact directory
+	SHA256  string      `json:"sha256,omitempty"`  // blob holding the content; empty for symlinks
+	Size    int64       `json:"size"`              // content size in bytes
+	Mode    os.FileMode `json:"mode"`              // permissions of the original file
+	Symlink string      `json:"symlink,omitempty"` // link target for symlinks
+}
+
+// Manifest lists the files of a build and the blobs that hold them
+type Manifest struct {
+	BuildID string          `json:"build_id"`
+	Entries []ManifestEntry `json:"entries"`
+}
+
+// Entry returns the entry for an artifact path
+func (m *Manifest) Entry(path string) (ManifestEntry, bool) {
+	path = filepath.Clean(path)
+	for _, e := range m.Entries {
+		if e.Path == path {
+			return e, true
+		}
+	}
+	return ManifestEntry{}, false
+}
+
+// blobRoot returns the directory holding all blobs
+func (am *ArtifactManager) blobRoot() string {
+	return filepath.Join(am.baseDir, blobsDir, blobAlgo)
+}
+
+// BlobPath returns the path of the blob with the given SHA-256
+func (am *ArtifactManager) BlobPath(digest string) string {
+	if len(digest) < 2 {
+		return filepath.Join(am.blobRoot(), digest)
+	}
+	return filepath.Join(am.blobRoot(), digest[:2], digest)
+}
+
+// GetManifestPath returns the path of a build's manifest
+func (am *ArtifactManager) GetManifestPath(buildID string) string {
+	return filepath.Join(am.GetArtifactPath(buildID), manifestFile)
+}
+
+// LoadManifest loads the manifest of a build
+func (am *ArtifactManager) LoadManifest(buildID string) (*Manifest, error) {
+	data, err := os.ReadFile(am.GetManifestPath(buildID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// saveManifest writes a manifest atomically so readers never see a partial file
+func (am *ArtifactManager) saveManifest(manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	path := am.GetManifestPath(manifest.BuildID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// StoreDirectory adds the files below src to a build's artifacts under subdir.
+// Content already in the blob store is not stored again. digests optionally maps paths
+// relative to src to their known SHA-256, e.g. as recorded in the database, so those
+// files are only read if their content is new. It returns the updated manifest and the
+// number of bytes that were new to the store.
+func (am *ArtifactManager) StoreDirectory(buildID, src, subdir string, digests map[string]string) (*Manifest, int64, error) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if err := am.loadRefs(); err != nil {
+		return nil, 0, err
+	}
+	manifest, err := am.LoadManifest(buildID)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, 0, err
+		}
+		manifest = &Manifest{BuildID: buildID}
+	}
+	index := make(map[string]int, len(manifest.Entries))
+	for i, e := range manifest.Entries {
+		index[e.Path] = i
+	}
+
+	buildPath := am.GetArtifactPath(buildID)
+	var newBytes int64
+	var replaced []string
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		entry := ManifestEntry{Path: filepath.Join(subdir, relPath), Mode: info.Mode().Perm()}
+		dstPath := filepath.Join(buildPath, entry.Path)
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			// Preserve symlinks to avoid dereferencing directory links (e.g., deploy/licenses/*-<machine>)
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+				return err
+			}
+			_ = os.RemoveAll(dstPath)
+			if err := os.Symlink(target, dstPath); err != nil {
+				return err
+			}
+			entry.Symlink = target
+		case info.IsDir():
+			return os.MkdirAll(dstPath, 0755)
+		case info.Mode().IsRegular():
+			digest, added, err := am.storeBlob(path, digests[relPath])
+			if err != nil {
+				return err
+			}
+			if added {
+				newBytes += info.Size()
+			}
+			if err := am.linkBlob(digest, dstPath); err != nil {
+				return err
+			}
+			entry.SHA256 = digest
+			entry.Size = info.Size()
+		default:
+			// Sockets, devices and pipes are not artifacts
+			return nil
+		}
+
+		am.addRef(entry.SHA256)
+		if i, ok := index[entry.Path]; ok {
+			// The replaced entry's blob is released below, once the manifest is saved
+			replaced = append(replaced, manifest.Entries[i].SHA256)
+			manifest.Entries[i] = entry
+		} else {
+			index[entry.Path] = len(manifest.Entries)
+			manifest.Entries = append(manifest.Entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		am.refs = nil // references were counted for entries that were not saved; rescan
+		return nil, newBytes, fmt.Errorf("failed to store %s: %w", src, err)
+	}
+
+	if err := am.saveManifest(manifest); err != nil {
+		am.refs = nil
+		return nil, newBytes, err
+	}
+	if _, err := am.releaseBlobs(replaced); err != nil {
+		return nil, newBytes, err
+	}
+	return manifest, newBytes, nil
+}
+
+// storeBlob adds a file to the blob store and reports whether its content was new.
+// When the file's digest is known and its blob exists, the file is not read at all;
+// otherwise it is read once, hashing while it is copied.
+func (am *ArtifactManager) storeBlob(path, digest string) (string, bool, error) {
+	if digest != "" {
+		if _, err := os.Stat(am.BlobPath(digest)); err == nil {
+			return digest, false, nil
+		}
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated blob
+	tmpDir := filepath.Join(am.baseDir, blobsDir, blobTmpDir)
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return "", false, err
+	}
+	tmp, err := os.CreateTemp(tmpDir, "blob-*")
+	if err != nil {
+		return "", false, err
+	}
+	defer os.Remove(tmp.Name())
+
+	src, err := os.Open(path)
+	if err != nil {
+		tmp.Close()
+		return "", false, err
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), src)
+	src.Close()
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", false, err
+	}
+	got := hex.EncodeToString(hash.Sum(nil))
+	if digest != "" && got != digest {
+		return "", false, fmt.Errorf("%s changed after it was checksummed", path)
+	}
+	digest = got
+
+	blobPath := am.BlobPath(digest)
+	if _, err := os.Stat(blobPath); err == nil {
+		return digest, false, nil
+	}
+	// Blobs are shared between builds, so they must never be modified in place
+	if err := os.Chmod(tmp.Name(), 0444); err != nil {
+		return "", false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0755); err != nil {
+		return "", false, err
+	}
+	if err := os.Rename(tmp.Name(), blobPath); err != nil {
+		return "", false, err
+	}
+	return digest, true, nil
+}
+
+// linkBlob places a blob at dst, copying it when hard links are not possible
+func (am *ArtifactManager) linkBlob(digest, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	_ = os.RemoveAll(dst)
+	if err := os.Link(am.BlobPath(digest), dst); err == nil {
+		return nil
+	}
+	return copyFile(am.BlobPath(digest), dst)
+}
+
+// blobRefs counts the references to each blob across all build manifests
+func (am *ArtifactManager) blobRefs() (map[string]int, error) {
+	entries, err := os.ReadDir(am.baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifacts directory: %w", err)
+	}
+	refs := make(map[string]int)
+	for _, dirEntry := range entries {
+		if !dirEntry.IsDir() || dirEntry.Name() == blobsDir {
+			continue
+		}
+		manifest, err := am.LoadManifest(dirEntry.Name())
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			// Treat an unreadable manifest as fatal rather than collecting blobs it may reference
+			return nil, err
+		}
+		for _, e := range manifest.Entries {
+			if e.SHA256 != "" {
+				refs[e.SHA256]++
+			}
+		}
+	}
+	return refs, nil
+}
+
+// loadRefs builds the blob reference index from the manifests on disk if it is not
+// loaded yet. Afterwards StoreDirectory, RemoveBuild and DeleteArtifact keep it current,
+// so releasing blobs never rescans the manifests. Callers must hold am.mu.
+func (am *ArtifactManager) loadRefs() error {
+	if am.refs != nil {
+		return nil
+	}
+	refs, err := am.blobRefs()
+	if err != nil {
+		return err
+	}
+	am.refs = refs
+	return nil
+}
+
+// addRef records a new reference to a blob. Callers must hold am.mu and have loaded the index.
+func (am *ArtifactManager) addRef(digest string) {
+	if digest != "" {
+		am.refs[digest]++
+	}
+}
+
+// releaseBlobs drops one reference to each of the given blobs, removes those no manifest
+// references anymore and returns the bytes freed. Callers must hold am.mu and have loaded
+// the index before the references were removed from the manifests.
+func (am *ArtifactManager) releaseBlobs(digests []string) (int64, error) {
+	var freed int64
+	for _, digest := range digests {
+		if digest == "" {
+			continue
+		}
+		if am.refs[digest]--; am.refs[digest] > 0 {
+			continue
+		}
+		delete(am.refs, digest)
+		n, err := removeFile(am.BlobPath(digest))
+		freed += n
+		if err != nil {
+			return freed, err
+		}
+	}
+	return freed, nil
+}
+
+// CollectGarbage removes blobs that no build manifest references, along with
+// leftovers of interrupted stores. It returns the number of blobs removed and the bytes freed.
+func (am *ArtifactManager) CollectGarbage() (int, int64, error) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	// A full pass rebuilds the reference index, also picking up manifests changed behind our back
+	refs, err := am.blobRefs()
+	if err != nil {
+		return 0, 0, err
+	}
+	am.refs = refs
+
+	removed := 0
+	var freed int64
+	err = filepath.WalkDir(am.blobRoot(), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || refs[d.Name()] > 0 {
+			return nil
+		}
+		n, err := removeFile(path)
+		if err != nil {
+			return err
+		}
+		removed++
+		freed += n
+		return nil
+	})
+	if err != nil {
+		return removed, freed, fmt.Errorf("failed to collect blobs: %w", err)
+	}
+
+	// No store is running while we hold the lock, so every temporary file is stale
+	tmpDir := filepath.Join(am.baseDir, blobsDir, blobTmpDir)
+	if size, err := DiskUsage(tmpDir); err == nil {
+		freed += size
+	}
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return removed, freed, fmt.Errorf("failed to remove %s: %w", tmpDir, err)
+	}
+	return removed, freed, nil
+}
+
+// RemoveBuild deletes a build's artifact directory and the blobs only it referenced.
+// It returns the bytes actually freed on disk.
+func (am *ArtifactManager) RemoveBuild(buildID string) (int64, error) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if err := am.loadRefs(); err != nil {
+		return 0, err
+	}
+	buildPath := am.GetArtifactPath(buildID)
+	var digests []string
+	manifest, err := am.LoadManifest(buildID)
+	if err == nil {
+		for _, e := range manifest.Entries {
+			if e.SHA256 != "" {
+				digests = append(digests, e.SHA256)
+			}
+		}
+	}
+
+	freed, err := am.exclusiveUsage(buildID, manifest)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(buildPath); err != nil {
+		return 0, fmt.Errorf("failed to remove %s: %w", buildPath, err)
+	}
+
+	released, err := am.releaseBlobs(digests)
+	return freed + released, err
+}
+
+// BuildUsage returns the bytes a build's artifacts occupy: its own files plus every blob it references once
+func (am *ArtifactManager) BuildUsage(buildID string) (int64, error) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	manifest, err := am.LoadManifest(buildID)
+	if err != nil {
+		// Builds stored before the blob store own all of their files
+		return DiskUsage(am.GetArtifactPath(buildID))
+	}
+	usage, err := am.exclusiveUsage(buildID, manifest)
+	if err != nil {
+		return 0, err
+	}
+	seen := make(map[string]bool)
+	for _, e := range manifest.Entries {
+		if e.SHA256 != "" && !seen[e.SHA256] {
+			seen[e.SHA256] = true
+			usage += e.Size
+		}
+	}
+	return usage, nil
+}
+
+// exclusiveUsage returns the size of the files in a build's artifact directory that
+// are not hard links into the blob store, i.e. what removing the directory alone frees
+func (am *ArtifactManager) exclusiveUsage(buildID string, manifest *Manifest) (int64, error) {
+	buildPath := am.GetArtifactPath(buildID)
+	total, err := DiskUsage(buildPath)
+	if err != nil || manifest == nil {
+		return total, err
+	}
+	for _, e := range manifest.Entries {
+		if e.SHA256 == "" {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(buildPath, e.Path))
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if blobInfo, err := os.Stat(am.BlobPath(e.SHA256)); err == nil && os.SameFile(info, blobInfo) {
+			total -= info.Size()
+		}
+	}
+	return total, nil
+}
+
+// releaseEntry drops an artifact path from a build's manifest and removes its blob
+// when no other build references it. Builds without a manifest are left as-is.
+func (am *ArtifactManager) releaseEntry(buildID, artifactPath string) error {
+	if err := am.loadRefs(); err != nil {
+		return err
+	}
+	manifest, err := am.LoadManifest(buildID)
+	if err != nil {
+		return nil
+	}
+	artifactPath = filepath.Clean(artifactPath)
+	var digests []string
+	entries := manifest.Entries[:0]
+	for _, e := range manifest.Entries {
+		if e.Path == artifactPath {
+			if e.SHA256 != "" {
+				digests = append(digests, e.SHA256)
+			}
+			continue
+		}
+		entries = append(entries, e)
+	}
+	manifest.Entries = entries
+	if err := am.saveManifest(manifest); err != nil {
+		return err
+	}
+	_, err = am.releaseBlobs(digests)
+	return err
+}
+
+// copyFile copies a regular file
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// removeFile removes a file and returns its size
+func removeFile(path string) (int64, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return info.Size(), nil
+}
+
+// isManifestFile reports whether a path inside a build's artifact directory is bookkeeping rather than an artifact
+func isManifestFile(name string) bool {
+	return name == manifestFile || strings.HasSuffix(name, manifestFile+".tmp")
+}
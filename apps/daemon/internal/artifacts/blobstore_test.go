@@ -0,0 +1,261 @@
+package artifacts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDeployDir creates a fake deploy directory with the given files
+func writeDeployDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func countBlobs(t *testing.T, am *ArtifactManager) int {
+	t.Helper()
+	count := 0
+	filepath.WalkDir(am.blobRoot(), func(_ string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+func TestStoreDirectoryDeduplicates(t *testing.T) {
+	am, err := NewArtifactManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := writeDeployDir(t, map[string]string{
+		"images/bzImage":      "kernel",
+		"images/rootfs.ext4":  "rootfs-1",
+		"licenses/LICENSE.md": "license",
+	})
+	if err := os.Symlink("bzImage", filepath.Join(first, "images", "bzImage-latest")); err != nil {
+		t.Fatal(err)
+	}
+	manifest, newBytes, err := am.StoreDirectory("build-1", first, "deploy", nil)
+	if err != nil {
+		t.Fatalf("StoreDirectory failed: %v", err)
+	}
+	if newBytes != int64(len("kernel")+len("rootfs-1")+len("license")) {
+		t.Errorf("expected all content to be new, got %d bytes", newBytes)
+	}
+
+	link, ok := manifest.Entry("deploy/images/bzImage-latest")
+	if !ok || link.Symlink != "bzImage" || link.SHA256 != "" {
+		t.Errorf("expected symlink entry, got %+v", link)
+	}
+	kernel, ok := manifest.Entry("deploy/images/bzImage")
+	if !ok || kernel.SHA256 == "" || kernel.Size != int64(len("kernel")) {
+		t.Fatalf("expected kernel entry with digest, got %+v", kernel)
+	}
+
+	// Stored files stay readable in place and share the blob's inode
+	data, err := os.ReadFile(filepath.Join(am.GetArtifactPath("build-1"), "deploy", "images", "bzImage"))
+	if err != nil || string(data) != "kernel" {
+		t.Fatalf("expected stored kernel content, got %q (%v)", data, err)
+	}
+
+	second := writeDeployDir(t, map[string]string{
+		"images/bzImage":      "kernel",
+		"images/rootfs.ext4":  "rootfs-2",
+		"licenses/LICENSE.md": "license",
+	})
+	_, newBytes, err = am.StoreDirectory("build-2", second, "deploy", nil)
+	if err != nil {
+		t.Fatalf("StoreDirectory failed: %v", err)
+	}
+	if newBytes != int64(len("rootfs-2")) {
+		t.Errorf("expected only the new rootfs to be stored, got %d bytes", newBytes)
+	}
+	if got := countBlobs(t, am); got != 4 {
+		t.Errorf("expected 4 distinct blobs, got %d", got)
+	}
+
+	artifactList, err := am.ListArtifacts("build-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range artifactList {
+		if a == manifestFile {
+			t.Error("manifest must not be listed as an artifact")
+		}
+	}
+}
+
+func TestStoreDirectoryKnownDigests(t *testing.T) {
+	am, err := NewArtifactManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, _, err := am.StoreDirectory("build-1", writeDeployDir(t, map[string]string{"kernel": "kernel"}), "deploy", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kernel, _ := manifest.Entry("deploy/kernel")
+
+	// A known digest whose blob exists is trusted without reading the file
+	src := writeDeployDir(t, map[string]string{"kernel": "not read"})
+	manifest, newBytes, err := am.StoreDirectory("build-2", src, "deploy", map[string]string{"kernel": kernel.SHA256})
+	if err != nil {
+		t.Fatalf("StoreDirectory failed: %v", err)
+	}
+	if entry, _ := manifest.Entry("deploy/kernel"); entry.SHA256 != kernel.SHA256 || newBytes != 0 {
+		t.Errorf("expected the existing blob to be reused, got %+v and %d new bytes", entry, newBytes)
+	}
+
+	// New content is verified against its known digest while it is copied
+	src = writeDeployDir(t, map[string]string{"rootfs": "changed"})
+	if _, _, err := am.StoreDirectory("build-3", src, "deploy", map[string]string{"rootfs": strings.Repeat("0", 64)}); err == nil {
+		t.Error("expected content that does not match its digest to be rejected")
+	}
+}
+
+func TestRemoveBuildReleasesUnsharedBlobs(t *testing.T) {
+	am, err := NewArtifactManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := am.StoreDirectory("build-1", writeDeployDir(t, map[string]string{"kernel": "shared", "rootfs": "only-one"}), "deploy", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := am.StoreDirectory("build-2", writeDeployDir(t, map[string]string{"kernel": "shared"}), "deploy", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := am.BuildUsage("build-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestSize, _ := DiskUsage(am.GetManifestPath("build-1"))
+	if want := int64(len("shared")+len("only-one")) + manifestSize; usage != want {
+		t.Errorf("expected usage %d, got %d", want, usage)
+	}
+
+	freed, err := am.RemoveBuild("build-1")
+	if err != nil {
+		t.Fatalf("RemoveBuild failed: %v", err)
+	}
+	if want := int64(len("only-one")) + manifestSize; freed != want {
+		t.Errorf("expected %d bytes freed, got %d", want, freed)
+	}
+	if got := countBlobs(t, am); got != 1 {
+		t.Errorf("expected the shared blob to remain, got %d blobs", got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(am.GetArtifactPath("build-2"), "deploy", "kernel"))
+	if err != nil || string(data) != "shared" {
+		t.Errorf("expected build-2 to keep its kernel, got %q (%v)", data, err)
+	}
+}
+
+func TestRemoveBuildUsesReferenceIndex(t *testing.T) {
+	am, err := NewArtifactManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"build-1", "build-2"} {
+		if _, _, err := am.StoreDirectory(id, writeDeployDir(t, map[string]string{"kernel": "shared"}), "deploy", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Releasing blobs must not rescan other manifests, so an unreadable one does not matter
+	if err := os.WriteFile(am.GetManifestPath("build-2"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := am.RemoveBuild("build-1"); err != nil {
+		t.Fatalf("RemoveBuild failed: %v", err)
+	}
+	if got := countBlobs(t, am); got != 1 {
+		t.Errorf("expected the blob shared with build-2 to remain, got %d blobs", got)
+	}
+
+	// The last reference removes the blob
+	if err := os.Remove(am.GetManifestPath("build-2")); err != nil {
+		t.Fatal(err)
+	}
+	am2, err := NewArtifactManager(am.baseDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := am2.StoreDirectory("build-3", writeDeployDir(t, map[string]string{"kernel": "shared"}), "deploy", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := am2.RemoveBuild("build-3"); err != nil {
+		t.Fatalf("RemoveBuild failed: %v", err)
+	}
+	if got := countBlobs(t, am2); got != 0 {
+		t.Errorf("expected the unreferenced blob to be removed, got %d blobs", got)
+	}
+}
+
+func TestDeleteArtifactReleasesBlob(t *testing.T) {
+	am, err := NewArtifactManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := am.StoreDirectory("build-1", writeDeployDir(t, map[string]string{"a.img": "a", "b.img": "b"}), "deploy", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := am.DeleteArtifact("build-1", "deploy/a.img"); err != nil {
+		t.Fatalf("DeleteArtifact failed: %v", err)
+	}
+	manifest, err := am.LoadManifest("build-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := manifest.Entry("deploy/a.img"); ok {
+		t.Error("expected deleted artifact to leave the manifest")
+	}
+	if got := countBlobs(t, am); got != 1 {
+		t.Errorf("expected 1 blob after deleting an artifact, got %d", got)
+	}
+}
+
+func TestCollectGarbage(t *testing.T) {
+	am, err := NewArtifactManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := am.StoreDirectory("build-1", writeDeployDir(t, map[string]string{"kernel": "kept"}), "deploy", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A blob without references, e.g. from a build directory removed by hand
+	orphan := am.BlobPath("ffff")
+	if err := os.MkdirAll(filepath.Dir(orphan), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(orphan, []byte("orphan"), 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, freed, err := am.CollectGarbage()
+	if err != nil {
+		t.Fatalf("CollectGarbage failed: %v", err)
+	}
+	if removed != 1 || freed != int64(len("orphan")) {
+		t.Errorf("expected 1 blob of 6 bytes collected, got %d blobs, %d bytes", removed, freed)
+	}
+	if got := countBlobs(t, am); got != 1 {
+		t.Errorf("expected the referenced blob to remain, got %d blobs", got)
+	}
+}
@@ -2,8 +2,12 @@ package artifacts
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -23,6 +27,112 @@ func DefaultRetentionPolicy() RetentionPolicy {
 	}
 }
 
+// Enabled reports whether the policy limits anything
+func (p RetentionPolicy) Enabled() bool {
+	return p.KeepLastN > 0 || p.MaxAge > 0 || p.MaxSizeGB > 0
+}
+
+// String formats the policy in the form accepted by ParseRetentionPolicy
+func (p RetentionPolicy) String() string {
+	var parts []string
+	if p.KeepLastN > 0 {
+		parts = append(parts, fmt.Sprintf("keep-last=%d", p.KeepLastN))
+	}
+	if p.MaxAge > 0 {
+		parts = append(parts, fmt.Sprintf("max-age=%s", p.MaxAge))
+	}
+	if p.MaxSizeGB > 0 {
+		parts = append(parts, fmt.Sprintf("max-size-gb=%d", p.MaxSizeGB))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseRetentionPolicy parses a policy such as "keep-last=5,max-age=720h,max-size-gb=50".
+// Omitted limits are not enforced.
+func ParseRetentionPolicy(spec string) (RetentionPolicy, error) {
+	var p RetentionPolicy
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return p, fmt.Errorf("invalid retention setting %q (expected key=value)", part)
+		}
+		var err error
+		switch key {
+		case "keep-last":
+			p.KeepLastN, err = strconv.Atoi(value)
+		case "max-age":
+			p.MaxAge, err = time.ParseDuration(value)
+		case "max-size-gb":
+			p.MaxSizeGB, err = strconv.ParseInt(value, 10, 64)
+		default:
+			return p, fmt.Errorf("unknown retention setting %q (supported: keep-last, max-age, max-size-gb)", key)
+		}
+		if err != nil {
+			return p, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	if p.KeepLastN < 0 || p.MaxAge < 0 || p.MaxSizeGB < 0 {
+		return p, fmt.Errorf("retention limits must not be negative")
+	}
+	return p, nil
+}
+
+// RetentionCandidate is a finished build considered by a retention policy
+type RetentionCandidate struct {
+	BuildID   string
+	Timestamp time.Time
+	SizeBytes int64 // only used for MaxSizeGB
+}
+
+// RetentionDecision names a build to delete and why
+type RetentionDecision struct {
+	BuildID string
+	Reason  string
+}
+
+// Expired returns the builds the policy deletes. Candidates are ranked newest first;
+// a build is deleted when it is older than MaxAge, not among the newest KeepLastN, or
+// when it and the newer builds kept so far exceed MaxSizeGB.
+func (p RetentionPolicy) Expired(candidates []RetentionCandidate, now time.Time) []RetentionDecision {
+	sorted := make([]RetentionCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.After(sorted[j].Timestamp)
+	})
+
+	maxBytes := p.MaxSizeGB << 30
+	var keptBytes int64
+	overSize := false
+
+	var expired []RetentionDecision
+	for i, c := range sorted {
+		var reason string
+		switch {
+		case p.MaxAge > 0 && now.Sub(c.Timestamp) > p.MaxAge:
+			reason = fmt.Sprintf("older than %s", p.MaxAge)
+		case p.KeepLastN > 0 && i >= p.KeepLastN:
+			reason = fmt.Sprintf("beyond the last %d builds", p.KeepLastN)
+		case p.MaxSizeGB > 0 && (overSize || keptBytes+c.SizeBytes > maxBytes):
+			// Once the limit is reached, all older builds go too
+			overSize = true
+			reason = fmt.Sprintf("total size above %d GB", p.MaxSizeGB)
+		}
+		if reason != "" {
+			expired = append(expired, RetentionDecision{BuildID: c.BuildID, Reason: reason})
+			continue
+		}
+		keptBytes += c.SizeBytes
+	}
+	return expired
+}
+
 // CleanupArtifacts applies retention policies to clean up old artifacts
 func (am *ArtifactManager) CleanupArtifacts(policy RetentionPolicy) error {
 	builds, err := am.ListBuilds()
@@ -35,46 +145,21 @@ func (am *ArtifactManager) CleanupArtifacts(policy RetentionPolicy) error {
 		return nil
 	}
 
-	// Sort builds by timestamp (newest first)
-	sort.Slice(builds, func(i, j int) bool {
-		return builds[i].Timestamp.After(builds[j].Timestamp)
-	})
-
-	var toDelete []string
-	now := time.Now()
-
-	for i, build := range builds {
-		shouldDelete := false
-
-		// Check age policy
-		if policy.MaxAge > 0 && now.Sub(build.Timestamp) > policy.MaxAge {
-			shouldDelete = true
-			fmt.Printf("Marking build %s for deletion (age: %v)\n",
-				build.BuildID, now.Sub(build.Timestamp))
-		}
-
-		// Check count policy (keep last N)
-		if policy.KeepLastN > 0 && i >= policy.KeepLastN {
-			shouldDelete = true
-			fmt.Printf("Marking build %s for deletion (keeping last %d builds)\n",
-				build.BuildID, policy.KeepLastN)
-		}
-
-		if shouldDelete {
-			toDelete = append(toDelete, build.BuildID)
+	candidates := make([]RetentionCandidate, 0, len(builds))
+	for _, build := range builds {
+		c := RetentionCandidate{BuildID: build.BuildID, Timestamp: build.Timestamp}
+		for _, size := range build.ArtifactSizes {
+			c.SizeBytes += size
 		}
-	}
-
-	// TODO: Implement size-based cleanup if needed
-	if policy.MaxSizeGB > 0 {
-		fmt.Printf("[WARNING] Size-based cleanup not yet implemented (limit: %d GB)\n", policy.MaxSizeGB)
+		candidates = append(candidates, c)
 	}
 
 	// Delete marked builds
 	deletedCount := 0
-	for _, buildID := range toDelete {
-		if err := am.DeleteBuild(buildID); err != nil {
-			fmt.Printf("[WARNING] Failed to delete build %s: %v\n", buildID, err)
+	for _, decision := range policy.Expired(candidates, time.Now()) {
+		fmt.Printf("Marking build %s for deletion (%s)\n", decision.BuildID, decision.Reason)
+		if err := am.DeleteBuild(decision.BuildID); err != nil {
+			fmt.Printf("[WARNING] Failed to delete build %s: %v\n", decision.BuildID, err)
 			continue
 		}
 		deletedCount++
@@ -84,7 +169,7 @@ func (am *ArtifactManager) CleanupArtifacts(policy RetentionPolicy) error {
 	return nil
 }
 
-// DeleteBuild removes a specific build and all its artifacts
+// DeleteBuild removes a specific build and all its artifacts, including blobs no other build references
 func (am *ArtifactManager) DeleteBuild(buildID string) error {
 	buildPath := am.GetArtifactPath(buildID)
 
@@ -92,7 +177,7 @@ func (am *ArtifactManager) DeleteBuild(buildID string) error {
 		return fmt.Errorf("build %s does not exist", buildID)
 	}
 
-	if err := os.RemoveAll(buildPath); err != nil {
+	if _, err := am.RemoveBuild(buildID); err != nil {
 		return fmt.Errorf("failed to remove build directory: %w", err)
 	}
 
@@ -118,6 +203,32 @@ func (am *ArtifactManager) GetTotalSize() (int64, error) {
 	return totalSize, nil
 }
 
+// DiskUsage returns the total size of the regular files below path; a missing path uses no space
+func DiskUsage(path string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return total, fmt.Errorf("failed to measure %s: %w", path, err)
+	}
+	return total, nil
+}
+
 // FormatSize formats a size in bytes to human-readable format
 func FormatSize(bytes int64) string {
 	const unit = 1024
@@ -1,6 +1,7 @@
 package artifacts
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -118,3 +119,95 @@ func TestArtifactManager_GetTotalSize(t *testing.T) {
 		t.Errorf("Expected total size 600, got %d", total)
 	}
 }
+
+func TestDiskUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "deploy", "images"), 0755); err != nil {
+		t.Fatalf("failed to create dirs: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "a.bin"), make([]byte, 100), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "deploy", "images", "b.wic"), make([]byte, 250), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	// Symlinks are not followed or counted
+	if err := os.Symlink(filepath.Join(tmpDir, "a.bin"), filepath.Join(tmpDir, "link")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	size, err := DiskUsage(tmpDir)
+	if err != nil {
+		t.Fatalf("DiskUsage failed: %v", err)
+	}
+	if size != 350 {
+		t.Errorf("expected 350 bytes, got %d", size)
+	}
+
+	if size, err := DiskUsage(filepath.Join(tmpDir, "missing")); err != nil || size != 0 {
+		t.Errorf("expected 0 bytes for a missing path, got %d (%v)", size, err)
+	}
+}
+
+func TestRetentionPolicyExpired(t *testing.T) {
+	now := time.Now()
+	candidates := []RetentionCandidate{
+		{BuildID: "b3", Timestamp: now.Add(-1 * time.Hour), SizeBytes: 2 << 30},
+		{BuildID: "b1", Timestamp: now.Add(-3 * time.Hour), SizeBytes: 1 << 30},
+		{BuildID: "b2", Timestamp: now.Add(-2 * time.Hour), SizeBytes: 2 << 30},
+		{BuildID: "old", Timestamp: now.Add(-48 * time.Hour), SizeBytes: 1},
+	}
+
+	ids := func(decisions []RetentionDecision) []string {
+		var out []string
+		for _, d := range decisions {
+			out = append(out, d.BuildID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+		want   []string
+	}{
+		{"none", RetentionPolicy{}, nil},
+		{"keep last", RetentionPolicy{KeepLastN: 2}, []string{"b1", "old"}},
+		{"max age", RetentionPolicy{MaxAge: 24 * time.Hour}, []string{"old"}},
+		// b3 and b2 fill 4 GB; b1 would exceed 4 GB, so it and everything older goes
+		{"max size", RetentionPolicy{MaxSizeGB: 4}, []string{"b1", "old"}},
+		{"combined", RetentionPolicy{KeepLastN: 3, MaxSizeGB: 3}, []string{"b2", "b1", "old"}},
+	}
+	for _, tt := range tests {
+		got := ids(tt.policy.Expired(candidates, now))
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestParseRetentionPolicy(t *testing.T) {
+	p, err := ParseRetentionPolicy("keep-last=5, max-age=720h,max-size-gb=50")
+	if err != nil {
+		t.Fatalf("ParseRetentionPolicy failed: %v", err)
+	}
+	want := RetentionPolicy{KeepLastN: 5, MaxAge: 720 * time.Hour, MaxSizeGB: 50}
+	if p != want {
+		t.Errorf("expected %+v, got %+v", want, p)
+	}
+	if p.String() != "keep-last=5,max-age=720h0m0s,max-size-gb=50" {
+		t.Errorf("unexpected String(): %s", p.String())
+	}
+	if !p.Enabled() {
+		t.Error("expected policy to be enabled")
+	}
+
+	if p, err := ParseRetentionPolicy(""); err != nil || p.Enabled() {
+		t.Errorf("expected empty spec to disable retention, got %+v (%v)", p, err)
+	}
+	for _, spec := range []string{"keep-last", "keep-last=x", "max-age=1d", "size=1", "keep-last=-1"} {
+		if _, err := ParseRetentionPolicy(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // ExtractArtifacts extracts build artifacts from a container to the artifact storage
@@ -64,8 +65,8 @@ func (am *ArtifactManager) calculateArtifactSizes(artifactPath string, metadata
 				return err
 			}
 
-			// Skip the metadata file itself
-			if relPath == "build-metadata.json" {
+			// Skip the metadata and manifest files
+			if relPath == "build-metadata.json" || isManifestFile(relPath) {
 				return nil
 			}
 
@@ -92,8 +93,8 @@ func (am *ArtifactManager) ListArtifacts(buildID string) ([]string, error) {
 		if err != nil {
 			return err
 		}
-		// Skip the metadata file (at any depth)
-		if info.Name() == "build-metadata.json" {
+		// Skip the metadata file (at any depth) and the blob manifest
+		if info.Name() == "build-metadata.json" || (relPath == info.Name() && isManifestFile(relPath)) {
 			return nil
 		}
 		artifacts = append(artifacts, relPath)
@@ -131,3 +132,54 @@ func (am *ArtifactManager) CopyArtifact(buildID, artifactName, destPath string)
 
 	return nil
 }
+
+// ResolveArtifact returns the absolute path of an artifact within a build's
+// artifact directory, rejecting paths that would escape it.
+func (am *ArtifactManager) ResolveArtifact(buildID, artifactPath string) (string, error) {
+	buildPath := am.GetArtifactPath(buildID)
+	fullPath := filepath.Join(buildPath, filepath.Clean("/"+artifactPath))
+
+	rel, err := filepath.Rel(buildPath, fullPath)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("invalid artifact path %q", artifactPath)
+	}
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("artifact %s not found in build %s: %w", artifactPath, buildID, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("artifact %s in build %s is a directory", artifactPath, buildID)
+	}
+
+	return fullPath, nil
+}
+
+// DeleteArtifact removes a single artifact file from a build and drops it from the build
+// metadata and manifest; its blob is removed once no other build references it
+func (am *ArtifactManager) DeleteArtifact(buildID, artifactPath string) error {
+	fullPath, err := am.ResolveArtifact(buildID, artifactPath)
+	if err != nil {
+		return err
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if err := os.Remove(fullPath); err != nil {
+		return fmt.Errorf("failed to remove artifact: %w", err)
+	}
+	if err := am.releaseEntry(buildID, artifactPath); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+
+	// Keep metadata sizes in sync; builds without metadata are left as-is
+	if metadata, err := am.LoadMetadata(buildID); err == nil {
+		delete(metadata.ArtifactSizes, artifactPath)
+		if err := am.SaveMetadata(*metadata); err != nil {
+			return fmt.Errorf("failed to update metadata: %w", err)
+		}
+	}
+
+	return nil
+}
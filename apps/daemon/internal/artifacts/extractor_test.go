@@ -40,3 +40,53 @@ func TestListArtifactsAndCopyArtifact(t *testing.T) {
 		t.Errorf("expected error for missing artifact, got nil")
 	}
 }
+
+func TestResolveAndDeleteArtifact(t *testing.T) {
+	tmpDir := t.TempDir()
+	am, err := NewArtifactManager(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create ArtifactManager: %v", err)
+	}
+	buildID := "build1"
+	imagesDir := filepath.Join(tmpDir, buildID, "deploy", "images")
+	os.MkdirAll(imagesDir, 0755)
+	os.WriteFile(filepath.Join(imagesDir, "image.wic"), []byte("image"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "outside.txt"), []byte("secret"), 0644)
+
+	relPath := filepath.Join("deploy", "images", "image.wic")
+	am.SaveMetadata(BuildMetadata{BuildID: buildID, ArtifactSizes: map[string]int64{relPath: 5}})
+
+	// Should resolve a file inside the build directory
+	fullPath, err := am.ResolveArtifact(buildID, relPath)
+	if err != nil {
+		t.Fatalf("ResolveArtifact failed: %v", err)
+	}
+	if fullPath != filepath.Join(imagesDir, "image.wic") {
+		t.Errorf("unexpected resolved path: %s", fullPath)
+	}
+	// Should reject traversal outside the build directory and directories
+	if _, err := am.ResolveArtifact(buildID, "../outside.txt"); err == nil {
+		t.Errorf("expected error for path traversal, got nil")
+	}
+	if _, err := am.ResolveArtifact(buildID, "deploy"); err == nil {
+		t.Errorf("expected error for directory, got nil")
+	}
+
+	// Should delete the file and drop it from metadata
+	if err := am.DeleteArtifact(buildID, relPath); err != nil {
+		t.Fatalf("DeleteArtifact failed: %v", err)
+	}
+	if _, err := os.Stat(fullPath); !os.IsNotExist(err) {
+		t.Errorf("expected artifact to be removed, stat err: %v", err)
+	}
+	metadata, err := am.LoadMetadata(buildID)
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if _, ok := metadata.ArtifactSizes[relPath]; ok {
+		t.Errorf("expected %s to be removed from metadata", relPath)
+	}
+	if err := am.DeleteArtifact(buildID, relPath); err == nil {
+		t.Errorf("expected error deleting missing artifact, got nil")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
@@ -25,7 +26,10 @@ type BuildMetadata struct {
 
 // ArtifactManager handles artifact storage and management
 type ArtifactManager struct {
-	baseDir string // Base directory for all artifacts (e.g., ~/.smidr/artifacts)
+	baseDir string     // Base directory for all artifacts (e.g., ~/.smidr/artifacts)
+	mu      sync.Mutex // serializes blob store writes with garbage collection
+
+	refs map[string]int // manifest entries referencing each blob, loaded on first use; guarded by mu
 }
 
 // NewArtifactManager creates a new artifact manager
@@ -0,0 +1,321 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testTokens = `
+tokens:
+  - name: acme-ci
+    token: acme-secret
+    customer: acme
+  - name: globex-ci
+    token_sha256: %s
+    customer: globex
+  - name: ops
+    token: ops-secret
+    admin: true
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func loadTestTokens(t *testing.T) *TokenStore {
+	t.Helper()
+	path := writeFile(t, t.TempDir(), "tokens.yaml", fmt.Sprintf(testTokens, hashToken("globex-secret")))
+	store, err := LoadTokens(path)
+	if err != nil {
+		t.Fatalf("LoadTokens failed: %v", err)
+	}
+	return store
+}
+
+func TestLoadTokens(t *testing.T) {
+	store := loadTestTokens(t)
+
+	p, ok := store.Authenticate("acme-secret")
+	if !ok || p.Name != "acme-ci" || p.Customer != "acme" || p.Admin {
+		t.Errorf("unexpected principal for plaintext token: %+v", p)
+	}
+	if p, ok := store.Authenticate("globex-secret"); !ok || p.Customer != "globex" {
+		t.Errorf("expected hashed token to authenticate globex, got %+v", p)
+	}
+	if p, ok := store.Authenticate("ops-secret"); !ok || !p.Admin {
+		t.Errorf("expected admin principal, got %+v", p)
+	}
+	if _, ok := store.Authenticate("wrong"); ok {
+		t.Error("expected unknown token to be rejected")
+	}
+}
+
+func TestLoadTokensInvalid(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"no token":    "tokens:\n  - customer: acme\n",
+		"no customer": "tokens:\n  - token: x\n",
+		"duplicate":   "tokens:\n  - token: x\n    customer: a\n  - token: x\n    customer: b\n",
+		"empty":       "tokens: []\n",
+	}
+	for name, content := range cases {
+		path := writeFile(t, dir, "tokens.yaml", content)
+		if _, err := LoadTokens(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPrincipalCanAccess(t *testing.T) {
+	scoped := &Principal{Customer: "acme"}
+	if !scoped.CanAccess("acme") || scoped.CanAccess("globex") {
+		t.Error("expected scoped principal to access only its customer")
+	}
+	admin := &Principal{Admin: true}
+	if !admin.CanAccess("globex") {
+		t.Error("expected admin to access every customer")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	if err := Authorize(context.Background(), "acme"); err != nil {
+		t.Errorf("expected no restriction without a principal, got %v", err)
+	}
+	ctx := WithPrincipal(context.Background(), &Principal{Name: "acme-ci", Customer: "acme"})
+	if err := Authorize(ctx, "acme"); err != nil {
+		t.Errorf("expected access to own customer, got %v", err)
+	}
+	if err := Authorize(ctx, "globex"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	store := loadTestTokens(t)
+	interceptor := store.UnaryInterceptor()
+
+	var got *Principal
+	handler := func(ctx context.Context, req any) (any, error) {
+		got, _ = PrincipalFromContext(ctx)
+		return "ok", nil
+	}
+
+	call := func(md metadata.MD) error {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+		return err
+	}
+
+	if err := call(metadata.Pairs("authorization", "Bearer acme-secret")); err != nil {
+		t.Fatalf("expected valid token to pass, got %v", err)
+	}
+	if got == nil || got.Customer != "acme" {
+		t.Errorf("expected acme principal in handler context, got %+v", got)
+	}
+
+	for name, md := range map[string]metadata.MD{
+		"missing":      metadata.MD{},
+		"wrong scheme": metadata.Pairs("authorization", "Basic acme-secret"),
+		"invalid":      metadata.Pairs("authorization", "Bearer nope"),
+	} {
+		if err := call(md); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: expected Unauthenticated, got %v", name, err)
+		}
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	store := loadTestTokens(t)
+	interceptor := store.StreamInterceptor()
+
+	var got *Principal
+	handler := func(srv any, stream grpc.ServerStream) error {
+		got, _ = PrincipalFromContext(stream.Context())
+		return nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer ops-secret"))
+	if err := interceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler); err != nil {
+		t.Fatalf("expected valid token to pass, got %v", err)
+	}
+	if got == nil || !got.Admin {
+		t.Errorf("expected admin principal in stream context, got %+v", got)
+	}
+
+	if err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without metadata, got %v", err)
+	}
+}
+
+func TestTokenCredentials(t *testing.T) {
+	creds := TokenCredentials{Token: "abc", Secure: true}
+	md, err := creds.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md["authorization"] != "Bearer abc" {
+		t.Errorf("unexpected authorization header %q", md["authorization"])
+	}
+	if !creds.RequireTransportSecurity() {
+		t.Error("expected secure credentials to require TLS")
+	}
+}
+
+// writeTestCA writes a self-signed CA and a certificate issued by it for localhost
+func writeTestCA(t *testing.T, dir string) (caFile, certFile, keyFile string) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "smidr test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caFile = writeFile(t, dir, "ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})))
+	certFile = writeFile(t, dir, "cert.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	keyFile = writeFile(t, dir, "key.pem", string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+	return caFile, certFile, keyFile
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	caFile, certFile, keyFile := writeTestCA(t, t.TempDir())
+
+	cfg, err := ServerTLSConfig(TLSFiles{CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("ServerTLSConfig failed: %v", err)
+	}
+	if cfg.ClientAuth != tls.NoClientCert {
+		t.Error("expected no client certificates without a CA")
+	}
+
+	cfg, err = ServerTLSConfig(TLSFiles{CertFile: certFile, KeyFile: keyFile, CAFile: caFile})
+	if err != nil {
+		t.Fatalf("ServerTLSConfig with CA failed: %v", err)
+	}
+	if cfg.ClientAuth != tls.RequireAndVerifyClientCert || cfg.ClientCAs == nil {
+		t.Error("expected mTLS with a client CA")
+	}
+
+	if _, err := ServerTLSConfig(TLSFiles{CertFile: certFile}); err == nil {
+		t.Error("expected an error without a key")
+	}
+	if _, err := ServerTLSConfig(TLSFiles{CertFile: certFile, KeyFile: keyFile, CAFile: keyFile}); err == nil {
+		t.Error("expected an error for a CA file without certificates")
+	}
+}
+
+func TestMutualTLSHandshake(t *testing.T) {
+	caFile, certFile, keyFile := writeTestCA(t, t.TempDir())
+
+	serverCfg, err := ServerTLSConfig(TLSFiles{CertFile: certFile, KeyFile: keyFile, CAFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	dial := func(files TLSFiles) error {
+		cfg, err := ClientTLSConfig(files, "localhost")
+		if err != nil {
+			return err
+		}
+		conn, err := tls.Dial("tcp", listener.Addr().String(), cfg)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		// TLS 1.3 reports a rejected client certificate on the first read
+		_, err = conn.Read(make([]byte, 1))
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+
+	if err := dial(TLSFiles{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}); err != nil {
+		t.Errorf("expected handshake with client certificate to succeed, got %v", err)
+	}
+	if err := dial(TLSFiles{CAFile: caFile}); err == nil {
+		t.Error("expected handshake without client certificate to fail")
+	}
+}
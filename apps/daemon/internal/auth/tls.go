@@ -0,0 +1,88 @@
+// Package auth provides TLS setup and per-customer bearer-token authentication
+// for the daemon's gRPC API.
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSFiles holds PEM file paths for TLS. Empty fields are not used.
+type TLSFiles struct {
+	CertFile string // certificate presented by this side
+	KeyFile  string // private key for CertFile
+	CAFile   string // CA bundle used to verify the peer
+}
+
+// Enabled reports whether any TLS file is configured
+func (f TLSFiles) Enabled() bool {
+	return f.CertFile != "" || f.KeyFile != "" || f.CAFile != ""
+}
+
+// ServerTLSConfig builds the daemon's TLS config. A certificate and key are required;
+// with a CA file, clients must present a certificate signed by it (mTLS).
+func ServerTLSConfig(files TLSFiles) (*tls.Config, error) {
+	if files.CertFile == "" || files.KeyFile == "" {
+		return nil, fmt.Errorf("TLS requires both a certificate and a key")
+	}
+	cert, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if files.CAFile != "" {
+		pool, err := loadCertPool(files.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// ClientTLSConfig builds the client's TLS config. Without a CA file the system roots are used;
+// a certificate and key are presented to daemons that require mTLS.
+func ClientTLSConfig(files TLSFiles, serverName string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if files.CAFile != "" {
+		pool, err := loadCertPool(files.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if files.CertFile != "" || files.KeyFile != "" {
+		if files.CertFile == "" || files.KeyFile == "" {
+			return nil, fmt.Errorf("client certificate requires both a certificate and a key")
+		}
+		cert, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// loadCertPool reads a PEM CA bundle
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in CA file %s", path)
+	}
+	return pool, nil
+}
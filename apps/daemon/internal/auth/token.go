@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Principal is the identity a request was authenticated as
+type Principal struct {
+	Name     string // label from the tokens file, for logs
+	Customer string // the only customer this principal may access, unless Admin
+	Admin    bool   // may access all customers
+}
+
+// CanAccess reports whether the principal may see or modify builds of a customer
+func (p *Principal) CanAccess(customer string) bool {
+	return p.Admin || p.Customer == customer
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the authenticated principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the authenticated principal; ok is false when authentication is disabled
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// tokenEntry is one entry of the tokens file. Either token or token_sha256 must be set;
+// the hash form keeps plaintext tokens out of the file.
+type tokenEntry struct {
+	Name        string `yaml:"name"`
+	Token       string `yaml:"token"`
+	TokenSHA256 string `yaml:"token_sha256"`
+	Customer    string `yaml:"customer"`
+	Admin       bool   `yaml:"admin"`
+}
+
+// TokenStore maps bearer tokens to principals
+type TokenStore struct {
+	mu     sync.RWMutex
+	byHash map[string]*Principal // hex SHA-256 of the token
+}
+
+// LoadTokens reads a tokens file:
+//
+//	tokens:
+//	  - name: acme-ci
+//	    token_sha256: 9f86d08...   # or token: <plaintext>
+//	    customer: acme
+//	  - name: ops
+//	    token: <plaintext>
+//	    admin: true
+func LoadTokens(path string) (*TokenStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+
+	var file struct {
+		Tokens []tokenEntry `yaml:"tokens"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens file: %w", err)
+	}
+
+	store := &TokenStore{byHash: make(map[string]*Principal)}
+	for i, entry := range file.Tokens {
+		hash := strings.ToLower(entry.TokenSHA256)
+		if entry.Token != "" {
+			hash = hashToken(entry.Token)
+		}
+		if hash == "" {
+			return nil, fmt.Errorf("tokens[%d]: token or token_sha256 is required", i)
+		}
+		if !entry.Admin && entry.Customer == "" {
+			return nil, fmt.Errorf("tokens[%d]: customer is required unless admin is set", i)
+		}
+		if _, dup := store.byHash[hash]; dup {
+			return nil, fmt.Errorf("tokens[%d]: duplicate token", i)
+		}
+		name := entry.Name
+		if name == "" {
+			name = fmt.Sprintf("token-%d", i)
+		}
+		store.byHash[hash] = &Principal{Name: name, Customer: entry.Customer, Admin: entry.Admin}
+	}
+	if len(store.byHash) == 0 {
+		return nil, fmt.Errorf("tokens file %s defines no tokens", path)
+	}
+	return store, nil
+}
+
+// hashToken returns the hex SHA-256 used to look up a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticate returns the principal for a bearer token
+func (s *TokenStore) Authenticate(token string) (*Principal, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.byHash[hashToken(token)]
+	return p, ok
+}
+
+// Replace swaps in the tokens of another store, e.g. after the tokens file changed.
+// Requests already authenticated keep their principal.
+func (s *TokenStore) Replace(other *TokenStore) {
+	other.mu.RLock()
+	byHash := other.byHash
+	other.mu.RUnlock()
+
+	s.mu.Lock()
+	s.byHash = byHash
+	s.mu.Unlock()
+}
+
+// authenticate resolves the principal from the request's authorization metadata
+func (s *TokenStore) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization must use the Bearer scheme")
+	}
+	p, ok := s.Authenticate(strings.TrimSpace(token))
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return WithPrincipal(ctx, p), nil
+}
+
+// UnaryInterceptor authenticates unary RPCs and attaches the principal to the context
+func (s *TokenStore) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := s.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates streaming RPCs and attaches the principal to the stream context
+func (s *TokenStore) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := s.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// principalStream overrides the context of a server stream
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context {
+	return s.ctx
+}
+
+// TokenCredentials sends a bearer token with every RPC
+type TokenCredentials struct {
+	Token string
+	// Secure refuses to send the token over connections without TLS
+	Secure bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (c TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.Token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+func (c TokenCredentials) RequireTransportSecurity() bool {
+	return c.Secure
+}
+
+// Authorize checks that the caller may access builds of a customer.
+// It allows everything when authentication is disabled.
+func Authorize(ctx context.Context, customer string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.CanAccess(customer) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "token %q may not access builds of customer %q", p.Name, customer)
+}
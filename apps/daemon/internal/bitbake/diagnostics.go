@@ -0,0 +1,320 @@
+package bitbake
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FailureKind classifies why a BitBake build failed
+type FailureKind string
+
+const (
+	FailureFetch        FailureKind = "fetch"                    // a SRC_URI could not be downloaded
+	FailureChecksum     FailureKind = "checksum_mismatch"        // a download does not match the recipe checksum
+	FailureParse        FailureKind = "parse_error"              // a recipe, class or config file could not be parsed
+	FailureMissingLayer FailureKind = "missing_layer_dependency" // a layer depends on a layer that is not configured
+	FailureLayerCompat  FailureKind = "layerseries_compat"       // a layer does not support the poky release series
+	FailurePseudo       FailureKind = "pseudo_abort"             // pseudo aborted on an inconsistent file database
+	FailureOutOfMemory  FailureKind = "oom_killed"               // the kernel killed a compiler that ran out of memory
+	FailureDiskFull     FailureKind = "disk_full"                // the build ran out of disk space
+	FailureTask         FailureKind = "task_failed"              // a task failed for a reason not recognised above
+)
+
+// maxDiagnosticMessage bounds the output line stored with a diagnostic
+const maxDiagnosticMessage = 500
+
+// Diagnostic is a structured failure found in BitBake output
+type Diagnostic struct {
+	Kind    FailureKind `json:"kind"`
+	Recipe  string      `json:"recipe,omitempty"`   // recipe name without version, e.g. "busybox"
+	Task    string      `json:"task,omitempty"`     // e.g. "do_compile"
+	URL     string      `json:"url,omitempty"`      // fetch and checksum failures
+	File    string      `json:"file,omitempty"`     // parse errors
+	Line    int         `json:"line,omitempty"`     // parse errors
+	Layer   string      `json:"layer,omitempty"`    // layer dependency and compatibility failures
+	LogFile string      `json:"log_file,omitempty"` // task log inside the container, when BitBake reports one
+	Message string      `json:"message"`            // the output line the failure was recognised in
+	Hint    string      `json:"hint"`               // what to do about it
+}
+
+// Summary returns a one-line description of the failure
+func (d Diagnostic) Summary() string {
+	var where string
+	switch {
+	case d.File != "" && d.Line > 0:
+		where = fmt.Sprintf("%s:%d", d.File, d.Line)
+	case d.File != "":
+		where = d.File
+	case d.Recipe != "" && d.Task != "":
+		where = d.Recipe + ":" + d.Task
+	case d.Recipe != "":
+		where = d.Recipe
+	case d.Layer != "":
+		where = "layer " + d.Layer
+	}
+	if where == "" {
+		return string(d.Kind)
+	}
+	return fmt.Sprintf("%s (%s)", d.Kind, where)
+}
+
+var (
+	// ERROR: busybox-1.35.0-r0 do_compile: Execution of '...' failed with exit code 1
+	recipeTaskErrorRe = regexp.MustCompile(`^ERROR: (\S+) (do_\w+): `)
+	// ERROR: Task (/path/to/busybox_1.35.0.bb:do_compile) failed with exit code '1'
+	taskFailedRe = regexp.MustCompile(`^ERROR: Task \((.+):(do_\w+)\) failed`)
+	// ERROR: Logfile of failure stored in: /home/builder/build/tmp/work/.../temp/log.do_compile.1234
+	logFileRe  = regexp.MustCompile(`Logfile of failure stored in: (\S+)`)
+	fetchURLRe = regexp.MustCompile(`Fetcher failure for URL: '([^']+)'`)
+	// File: '/downloads/zlib-1.2.11.tar.xz' has sha256 checksum '...' when '...' was expected
+	checksumFileRe = regexp.MustCompile(`File: '([^']+)' has \w+ checksum`)
+	// ParseError at /path/foo.bb:12: Could not inherit file classes/bar.bbclass
+	parseErrorRe     = regexp.MustCompile(`ParseError (?:at|in) ([^\s:]+):(\d+)`)
+	parseFileErrorRe = regexp.MustCompile(`(?:ExpansionError during parsing|Unable to parse) (\S+)`)
+	missingLayerRe   = regexp.MustCompile(`Layer '([^']+)' depends on layer '([^']+)', but this layer is not enabled`)
+	layerCompatRe    = regexp.MustCompile(`Layer (\S+) is not compatible with the core layer which only supports these series: ([^(]+?)\s*(?:\(layer is compatible with ([^)]*)\))?$`)
+	layerCompatUnset = regexp.MustCompile(`^ERROR: Layer (\S+) should set LAYERSERIES_COMPAT_`)
+	pseudoAbortRe    = regexp.MustCompile(`(?i)abort\(\)ing pseudo client|pseudo_abort|pseudo.*path mismatch`)
+	compilerKilledRe = regexp.MustCompile(`Killed signal terminated program (\S+)|internal compiler error: Killed \(program (\S+)\)`)
+	diskFullRe       = regexp.MustCompile(`No space left on device|disk space monitor action is "(?:STOPTASKS|HALT|ABORT)"`)
+	recipeVersionRe  = regexp.MustCompile(`^(.+)-[^-]+-r[^-]+$`)
+)
+
+// Analyzer classifies BitBake output line by line into diagnostics.
+// It is not safe for concurrent use.
+type Analyzer struct {
+	diagnostics []Diagnostic
+	seen        map[string]bool
+	// recipe and task of the task whose error output is being read
+	recipe string
+	task   string
+}
+
+// NewAnalyzer creates an empty analyzer
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{seen: make(map[string]bool)}
+}
+
+// Diagnose classifies complete BitBake output
+func Diagnose(output string) []Diagnostic {
+	a := NewAnalyzer()
+	for _, line := range strings.Split(output, "\n") {
+		a.Observe(line)
+	}
+	return a.Diagnostics()
+}
+
+// Diagnostics returns the failures found so far, in the order they were seen
+func (a *Analyzer) Diagnostics() []Diagnostic {
+	return append([]Diagnostic(nil), a.diagnostics...)
+}
+
+// IsDiagnosticLine reports whether a line is one the analyzer recognises as a failure.
+// Log filters use it to keep such lines even when they carry no ERROR prefix.
+func IsDiagnosticLine(line string) bool {
+	_, ok := classify(strings.TrimSpace(line), "", "")
+	return ok
+}
+
+// Observe feeds one line of output to the analyzer
+func (a *Analyzer) Observe(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+
+	if m := recipeTaskErrorRe.FindStringSubmatch(line); m != nil {
+		a.recipe, a.task = recipeFromPF(m[1]), m[2]
+	} else if startsLogRecord(line) && !logFileRe.MatchString(line) {
+		// Continuation lines and task log excerpts belong to the failed task; any other
+		// log record ends its output
+		a.recipe, a.task = "", ""
+	}
+
+	if m := logFileRe.FindStringSubmatch(line); m != nil {
+		a.attachLogFile(m[1])
+		return
+	}
+
+	if m := taskFailedRe.FindStringSubmatch(line); m != nil {
+		recipe, task := splitRecipeFile(m[1]), m[2]
+		// A task failure whose cause was already recognised adds nothing
+		for _, d := range a.diagnostics {
+			if d.Recipe == recipe && d.Task == task {
+				return
+			}
+		}
+		a.add(Diagnostic{
+			Kind:    FailureTask,
+			Recipe:  recipe,
+			Task:    task,
+			Message: line,
+			Hint:    fmt.Sprintf("%s of %s failed; its task log has the details ('smidr client logs' shows the output leading up to it).", task, recipe),
+		})
+		return
+	}
+
+	if d, ok := classify(line, a.recipe, a.task); ok {
+		a.add(d)
+	}
+}
+
+// add records a diagnostic unless an equivalent one was already recorded. A failure
+// reported over several lines of the same task fills in the diagnostic already recorded.
+func (a *Analyzer) add(d Diagnostic) {
+	if len(d.Message) > maxDiagnosticMessage {
+		d.Message = d.Message[:maxDiagnosticMessage] + "..."
+	}
+	if d.Recipe != "" {
+		for i := range a.diagnostics {
+			prev := &a.diagnostics[i]
+			if prev.Kind == d.Kind && prev.Recipe == d.Recipe && prev.Task == d.Task {
+				if prev.URL == "" {
+					prev.URL = d.URL
+				}
+				if prev.File == "" {
+					prev.File, prev.Line = d.File, d.Line
+				}
+				return
+			}
+		}
+	}
+	key := strings.Join([]string{string(d.Kind), d.Recipe, d.Task, d.URL, d.File, strconv.Itoa(d.Line), d.Layer}, "\x00")
+	if a.seen[key] {
+		return
+	}
+	a.seen[key] = true
+	a.diagnostics = append(a.diagnostics, d)
+}
+
+// attachLogFile records the task log on the diagnostics of the task being read
+func (a *Analyzer) attachLogFile(path string) {
+	for i := range a.diagnostics {
+		d := &a.diagnostics[i]
+		if d.LogFile == "" && d.Recipe == a.recipe && d.Task == a.task && a.task != "" {
+			d.LogFile = path
+		}
+	}
+}
+
+// classify recognises a single failure line. recipe and task give the task whose output
+// the line belongs to, if known.
+func classify(line, recipe, task string) (Diagnostic, bool) {
+	d := Diagnostic{Recipe: recipe, Task: task, Message: line}
+
+	switch {
+	case strings.Contains(line, "Checksum mismatch") || checksumFileRe.MatchString(line):
+		d.Kind = FailureChecksum
+		if m := fetchURLRe.FindStringSubmatch(line); m != nil {
+			d.URL = stripURLParams(m[1])
+		} else if m := checksumFileRe.FindStringSubmatch(line); m != nil {
+			d.File = m[1]
+		}
+		d.Hint = "The downloaded file does not match the checksum in the recipe. If upstream re-released it, update SRC_URI[sha256sum] in the recipe; otherwise delete the file from the downloads directory and build again."
+
+	case fetchURLRe.MatchString(line):
+		d.Kind = FailureFetch
+		d.URL = stripURLParams(fetchURLRe.FindStringSubmatch(line)[1])
+		d.Hint = fmt.Sprintf("BitBake could not download %s. Check network and proxy access from the build container and that the source still exists upstream; a mirror or a pre-populated downloads directory avoids the fetch.", d.URL)
+
+	case parseErrorRe.MatchString(line):
+		m := parseErrorRe.FindStringSubmatch(line)
+		d.Kind = FailureParse
+		d.File = m[1]
+		d.Line, _ = strconv.Atoi(m[2])
+		d.Hint = fmt.Sprintf("Fix the syntax error at %s:%d; BitBake cannot parse the file.", d.File, d.Line)
+
+	case strings.HasPrefix(line, "ERROR:") && parseFileErrorRe.MatchString(line):
+		d.Kind = FailureParse
+		d.File = strings.TrimSuffix(parseFileErrorRe.FindStringSubmatch(line)[1], ":")
+		d.Hint = fmt.Sprintf("BitBake cannot parse %s; the lines after this error name the variable or statement at fault.", d.File)
+
+	case missingLayerRe.MatchString(line):
+		m := missingLayerRe.FindStringSubmatch(line)
+		d.Kind = FailureMissingLayer
+		d.Layer = m[1]
+		d.Hint = fmt.Sprintf("Layer %s needs the layer collection '%s'. Add the layer that provides it to layers in the build config.", m[1], m[2])
+
+	case layerCompatRe.MatchString(line):
+		m := layerCompatRe.FindStringSubmatch(line)
+		d.Kind = FailureLayerCompat
+		d.Layer = m[1]
+		d.Hint = fmt.Sprintf("Layer %s does not support the poky release series %s. Use the branch of the layer that matches your poky release, or add the series to LAYERSERIES_COMPAT in its conf/layer.conf.", m[1], strings.TrimSpace(m[2]))
+		if m[3] != "" {
+			d.Hint = fmt.Sprintf("Layer %s supports %s, but poky supports %s. Use the branch of the layer that matches your poky release, or add the series to LAYERSERIES_COMPAT in its conf/layer.conf.", m[1], m[3], strings.TrimSpace(m[2]))
+		}
+
+	case layerCompatUnset.MatchString(line):
+		d.Kind = FailureLayerCompat
+		d.Layer = layerCompatUnset.FindStringSubmatch(line)[1]
+		d.Hint = fmt.Sprintf("Layer %s does not declare LAYERSERIES_COMPAT in its conf/layer.conf. Use a maintained branch of the layer or set LAYERSERIES_COMPAT for your poky release series.", d.Layer)
+
+	case pseudoAbortRe.MatchString(line):
+		d.Kind = FailurePseudo
+		d.Hint = "Pseudo's file database no longer matches the work directory, usually because files were changed outside BitBake or a workspace was reused. Clean the recipe with 'bitbake -c cleansstate' or rebuild with --force-clean."
+		if recipe != "" {
+			d.Hint = fmt.Sprintf("Pseudo's file database no longer matches the work directory of %s, usually because files were changed outside BitBake or a workspace was reused. Run 'bitbake -c cleansstate %s' or rebuild with --force-clean.", recipe, recipe)
+		}
+
+	case compilerKilledRe.MatchString(line):
+		m := compilerKilledRe.FindStringSubmatch(line)
+		program := m[1]
+		if program == "" {
+			program = m[2]
+		}
+		d.Kind = FailureOutOfMemory
+		d.Hint = fmt.Sprintf("The kernel killed %s because the build ran out of memory. Raise container.memory or lower build.parallel_make and build.bb_number_threads in the build config.", program)
+
+	case diskFullRe.MatchString(line):
+		d.Kind = FailureDiskFull
+		d.Hint = "The build ran out of disk space. Free space on the volume holding the build, downloads and sstate directories, for example by deleting old builds or enabling daemon retention."
+
+	default:
+		return Diagnostic{}, false
+	}
+	return d, true
+}
+
+// startsLogRecord reports whether a line starts a new BitBake log record
+func startsLogRecord(line string) bool {
+	for _, prefix := range []string{"NOTE:", "WARNING:", "ERROR:", "DEBUG:", "Summary:"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// recipeFromPF strips version and revision from a BitBake PF such as "busybox-1.35.0-r0"
+func recipeFromPF(pf string) string {
+	if m := recipeVersionRe.FindStringSubmatch(pf); m != nil {
+		return m[1]
+	}
+	return pf
+}
+
+// splitRecipeFile returns the recipe name of a task's recipe file the way BitBake names
+// the recipe in its other messages, e.g. "virtual:native:/path/to/zlib_1.2.11.bb" -> "zlib-native"
+func splitRecipeFile(path string) string {
+	recipe := path[strings.LastIndexAny(path, "/:")+1:]
+	recipe = strings.TrimSuffix(recipe, ".bbappend")
+	recipe = strings.TrimSuffix(recipe, ".bb")
+	if name, _, ok := strings.Cut(recipe, "_"); ok {
+		recipe = name
+	}
+	switch {
+	case strings.HasPrefix(path, "virtual:native:"):
+		recipe += "-native"
+	case strings.HasPrefix(path, "virtual:nativesdk:"):
+		recipe = "nativesdk-" + recipe
+	}
+	return recipe
+}
+
+// stripURLParams drops SRC_URI parameters such as ";name=tarball" from a fetch URL
+func stripURLParams(url string) string {
+	url, _, _ = strings.Cut(url, ";")
+	return url
+}
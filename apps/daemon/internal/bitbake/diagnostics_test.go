@@ -0,0 +1,126 @@
+package bitbake
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   Diagnostic
+	}{
+		{
+			name: "fetch failure",
+			output: `ERROR: busybox-1.35.0-r0 do_fetch: Fetcher failure for URL: 'https://busybox.net/downloads/busybox-1.35.0.tar.bz2;name=tarball'. Unable to fetch URL from any source.
+ERROR: Logfile of failure stored in: /home/builder/build/tmp/work/core2-64-poky-linux/busybox/1.35.0-r0/temp/log.do_fetch.123
+NOTE: recipe busybox-1.35.0-r0: task do_fetch: Failed
+ERROR: Task (/home/builder/layers/poky/meta/recipes-core/busybox/busybox_1.35.0.bb:do_fetch) failed with exit code '1'`,
+			want: Diagnostic{
+				Kind:    FailureFetch,
+				Recipe:  "busybox",
+				Task:    "do_fetch",
+				URL:     "https://busybox.net/downloads/busybox-1.35.0.tar.bz2",
+				LogFile: "/home/builder/build/tmp/work/core2-64-poky-linux/busybox/1.35.0-r0/temp/log.do_fetch.123",
+			},
+		},
+		{
+			name: "checksum mismatch",
+			output: `ERROR: zlib-native-1.2.11-r0 do_fetch: Fetcher failure for URL: 'https://zlib.net/zlib-1.2.11.tar.xz'. Checksum mismatch!
+File: '/home/builder/downloads/zlib-1.2.11.tar.xz' has sha256 checksum 'aaa' when 'bbb' was expected
+ERROR: Task (virtual:native:/home/builder/layers/poky/meta/recipes-core/zlib/zlib_1.2.11.bb:do_fetch) failed with exit code '1'`,
+			want: Diagnostic{
+				Kind:   FailureChecksum,
+				Recipe: "zlib-native",
+				Task:   "do_fetch",
+				URL:    "https://zlib.net/zlib-1.2.11.tar.xz",
+				File:   "/home/builder/downloads/zlib-1.2.11.tar.xz",
+			},
+		},
+		{
+			name:   "parse error",
+			output: `ERROR: ParseError at /home/builder/layers/meta-acme/recipes-app/app/app_1.0.bb:12: Could not inherit file classes/missing.bbclass`,
+			want: Diagnostic{
+				Kind: FailureParse,
+				File: "/home/builder/layers/meta-acme/recipes-app/app/app_1.0.bb",
+				Line: 12,
+			},
+		},
+		{
+			name:   "missing layer dependency",
+			output: `ERROR: Layer 'acme' depends on layer 'openembedded-layer', but this layer is not enabled in your configuration`,
+			want:   Diagnostic{Kind: FailureMissingLayer, Layer: "acme"},
+		},
+		{
+			name:   "layer series mismatch",
+			output: `ERROR: Layer acme is not compatible with the core layer which only supports these series: kirkstone (layer is compatible with dunfell)`,
+			want:   Diagnostic{Kind: FailureLayerCompat, Layer: "acme"},
+		},
+		{
+			name: "pseudo abort",
+			output: `ERROR: acl-2.3.1-r0 do_package: Execution of '/home/builder/build/tmp/work/acl/temp/run.do_package.1' failed with exit code 134
+| abort()ing pseudo client by server request. See https://wiki.yoctoproject.org/wiki/Pseudo_Abort for more details on this.
+ERROR: Task (/home/builder/layers/poky/meta/recipes-support/attr/acl_2.3.1.bb:do_package) failed with exit code '134'`,
+			want: Diagnostic{Kind: FailurePseudo, Recipe: "acl", Task: "do_package"},
+		},
+		{
+			name: "compiler killed",
+			output: `ERROR: llvm-15.0.0-r0 do_compile: oe_runmake failed
+| x86_64-poky-linux-g++: fatal error: Killed signal terminated program cc1plus
+ERROR: Task (/home/builder/layers/meta-clang/recipes-devtools/clang/llvm_git.bb:do_compile) failed with exit code '1'`,
+			want: Diagnostic{Kind: FailureOutOfMemory, Recipe: "llvm", Task: "do_compile"},
+		},
+		{
+			name:   "disk full",
+			output: `ERROR: No new tasks can be executed since the disk space monitor action is "STOPTASKS"!`,
+			want:   Diagnostic{Kind: FailureDiskFull},
+		},
+		{
+			name: "unrecognised task failure",
+			output: `ERROR: app-1.0-r0 do_install: Execution of 'run.do_install' failed with exit code 2
+ERROR: Task (/home/builder/layers/meta-acme/recipes-app/app/app_1.0.bb:do_install) failed with exit code '1'`,
+			want: Diagnostic{Kind: FailureTask, Recipe: "app", Task: "do_install"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := Diagnose(tt.output)
+			if len(diagnostics) != 1 {
+				t.Fatalf("expected 1 diagnostic, got %d: %+v", len(diagnostics), diagnostics)
+			}
+			got := diagnostics[0]
+			if got.Kind != tt.want.Kind || got.Recipe != tt.want.Recipe || got.Task != tt.want.Task ||
+				got.URL != tt.want.URL || got.File != tt.want.File || got.Line != tt.want.Line || got.Layer != tt.want.Layer {
+				t.Errorf("unexpected diagnostic:\n got  %+v\n want %+v", got, tt.want)
+			}
+			if tt.want.LogFile != "" && got.LogFile != tt.want.LogFile {
+				t.Errorf("expected log file %s, got %s", tt.want.LogFile, got.LogFile)
+			}
+			if got.Hint == "" || got.Message == "" {
+				t.Errorf("expected a hint and the output line, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestDiagnose_IgnoresSuccessfulOutput(t *testing.T) {
+	output := `NOTE: Running task 10 of 20 (/home/builder/layers/poky/meta/recipes-core/busybox/busybox_1.35.0.bb:do_compile)
+WARNING: busybox-1.35.0-r0 do_fetch: Failed to fetch URL https://example.com/busybox.tar.bz2, attempting MIRRORS if available
+NOTE: Tasks Summary: Attempted 20 tasks of which 0 didn't need to be rerun and all succeeded.`
+	if diagnostics := Diagnose(output); len(diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %+v", diagnostics)
+	}
+}
+
+func TestDiagnostic_Summary(t *testing.T) {
+	d := Diagnostic{Kind: FailureParse, File: "/layers/app.bb", Line: 3}
+	if got := d.Summary(); got != "parse_error (/layers/app.bb:3)" {
+		t.Errorf("unexpected summary %q", got)
+	}
+	d = Diagnostic{Kind: FailureFetch, Recipe: "busybox", Task: "do_fetch"}
+	if got := d.Summary(); !strings.Contains(got, "busybox:do_fetch") {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"os"
 	"strings"
 	"time"
 
@@ -21,9 +20,13 @@ type BuildExecutor struct {
 	containerMgr container.ContainerManager
 	containerID  string
 	workspaceDir string
-	forceImage   bool   // If true, force image regeneration without rebuilding packages
-	buildPrefix  string // Prefix for log messages (e.g., "[customer/build-123]")
+	forceImage   bool       // If true, force image regeneration without rebuilding packages
+	invocation   Invocation // targets and task to build; empty targets build config build.image
+	buildPrefix  string     // Prefix for log messages (e.g., "[customer/build-123]")
+	skipBitBake  bool       // Smoke/test mode: set up the environment but do not run BitBake
 	logger       *logger.Logger
+
+	interruptGrace time.Duration // Time BitBake gets to stop after SIGINT on cancellation
 }
 
 // NewBuildExecutor creates a new build executor
@@ -51,19 +54,38 @@ func (e *BuildExecutor) SetForceImage(force bool) {
 	e.forceImage = force
 }
 
+// SetSkipBitBake enables smoke/test mode, which stops after generating the configuration files
+func (e *BuildExecutor) SetSkipBitBake(skip bool) {
+	e.skipBitBake = skip
+}
+
+// SetInvocation sets the targets and task BitBake builds instead of the config's build.image
+func (e *BuildExecutor) SetInvocation(inv Invocation) {
+	e.invocation = inv
+}
+
+// targetInvocation returns what BitBake builds, falling back to the config's build.image
+func (e *BuildExecutor) targetInvocation() Invocation {
+	return e.invocation.WithDefaultTarget(e.config.Build.Image)
+}
+
 // BuildResult contains the results of a build execution
 type BuildResult struct {
-	Success  bool
-	ExitCode int
-	Duration time.Duration
-	Output   string
-	Error    string
+	Success     bool
+	ExitCode    int
+	Duration    time.Duration
+	Output      string
+	Error       string
+	Diagnostics []Diagnostic     // failures recognised in the output of a failed build
+	TaskLogs    []TaskLog        // logs of the failed tasks, collected into the build directory
+	Recovery    []RecoveryAction // recovery steps taken, also when a retry succeeded
 }
 
 // BuildLogWriter allows streaming log output to both plain text and JSONL
 type BuildLogWriter struct {
 	PlainWriter io.Writer
 	JSONLWriter io.Writer
+	OnRecovery  func(RecoveryAction) // optional, called for every recovery step
 }
 
 // WriteLog writes a log line to both plain and JSONL outputs
@@ -100,8 +122,7 @@ func (e *BuildExecutor) ExecuteBuild(ctx context.Context, logWriter *BuildLogWri
 	}
 
 	// Smoke/test mode: when running CI smoke or local parse-only checks, skip sourcing and bitbake entirely
-	// Detect via test envs used by Makefile (SMIDR_TEST_ENTRYPOINT or SMIDR_TEST_WRITE_MARKERS)
-	if os.Getenv("SMIDR_TEST_ENTRYPOINT") != "" || os.Getenv("SMIDR_TEST_WRITE_MARKERS") == "1" {
+	if e.skipBitBake {
 		e.logger.Info("Smoke/test mode detected — skipping environment source and BitBake execution")
 		return &BuildResult{
 			Success:  true,
@@ -112,6 +133,10 @@ func (e *BuildExecutor) ExecuteBuild(ctx context.Context, logWriter *BuildLogWri
 		}, nil
 	}
 
+	// From here on BitBake runs inside the container; make sure cancellation reaches it
+	stopWatch := e.watchCancellation(ctx)
+	defer stopWatch()
+
 	// Step 2: Source the build environment
 	if err := e.sourceEnvironment(ctx); err != nil {
 		return &BuildResult{
@@ -122,14 +147,22 @@ func (e *BuildExecutor) ExecuteBuild(ctx context.Context, logWriter *BuildLogWri
 	}
 
 	// Step 3: Execute the bitbake build
-	e.logger.Info("Starting bitbake build", slog.String("image", e.config.Build.Image))
+	e.logger.Info("Starting bitbake build", slog.String("target", e.targetInvocation().String()))
 	e.logger.Debug("Checking container memory limit with docker inspect...")
 
 	buildResult, err := e.executeBitbake(ctx, logWriter)
 	buildResult.Duration = time.Since(startTime)
 
+	if ctx.Err() != nil {
+		buildResult.Success = false
+		buildResult.Error = "build cancelled"
+		return buildResult, fmt.Errorf("build cancelled: %w", ctx.Err())
+	}
+
 	if err != nil {
 		buildResult.Error = err.Error()
+		buildResult.Diagnostics = Diagnose(buildResult.Output)
+		buildResult.TaskLogs = e.collectTaskLogs(ctx, buildResult.Diagnostics)
 		return buildResult, err
 	}
 
@@ -256,21 +289,9 @@ func (e *BuildExecutor) sourceEnvironment(ctx context.Context) error {
 // executeBitbake runs the actual bitbake command
 // executeBitbake runs the actual bitbake command, streaming logs if logWriter is provided
 func (e *BuildExecutor) executeBitbake(ctx context.Context, logWriter *BuildLogWriter) (*BuildResult, error) {
-	// Construct the bitbake command
-	imageName := e.config.Build.Image
-	if imageName == "" {
-		imageName = "core-image-minimal" // default fallback
-	}
-
-	// Use smaller image for qemu machines to avoid memory issues
-	machine := e.config.Build.Machine
-	if machine == "" {
-		machine = e.config.Base.Machine
-	}
-	if machine == "qemux86-64" && imageName == "core-image-weston" {
-		imageName = "core-image-minimal"
-		e.logger.Warn("Using core-image-minimal instead of core-image-weston for qemu machine", slog.String("machine", machine))
-	}
+	// Construct the bitbake command; build exactly the requested targets and task
+	inv := e.targetInvocation()
+	targets := strings.Join(inv.Targets, " ")
 
 	// Build the command with proper environment sourcing in writable directory
 	// We need to re-apply our settings after sourcing because oe-init-build-env might override them
@@ -319,7 +340,7 @@ func (e *BuildExecutor) executeBitbake(ctx context.Context, logWriter *BuildLogW
 		echo "BB_HEARTBEAT_EVENT=${BB_HEARTBEAT_EVENT}" && \
 	echo "=== Starting bitbake (isolated server per TMPDIR) ===" && \
 	unset BBSERVER && \
-	bitbake %s`, e.workspaceDir, sedCmds, verifyCmd, imageName)
+	bitbake %s`, e.workspaceDir, sedCmds, verifyCmd, inv.Args())
 
 	cmd := []string{"bash", "-c", bitbakeCmd}
 
@@ -332,7 +353,7 @@ func (e *BuildExecutor) executeBitbake(ctx context.Context, logWriter *BuildLogW
 	// Pre-fetch sources to avoid checksum warnings and fail early if fetch fails
 	// Use `bitbake -c fetch` which is broadly supported for image targets.
 	// Ensure we do not connect to any externally configured server
-	fetchCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && export BB_SERVER_TIMEOUT=600 && export BB_HEARTBEAT_EVENT=60 && unset BBSERVER && bitbake -c fetch %s", e.workspaceDir, imageName)}
+	fetchCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && export BB_SERVER_TIMEOUT=600 && export BB_HEARTBEAT_EVENT=60 && unset BBSERVER && bitbake -c fetch %s", e.workspaceDir, targets)}
 	e.logger.Info("⬇️  Running pre-fetch (bitbake -c fetch) to download sources before build...")
 	fetchResult, fetchErr := e.containerMgr.ExecStream(ctx, e.containerID, fetchCmd, timeout)
 	if logWriter != nil {
@@ -360,7 +381,7 @@ func (e *BuildExecutor) executeBitbake(ctx context.Context, logWriter *BuildLogW
 		// Use 'clean' to remove only image/rootfs tasks, not the packages they depend on
 		// Ensure we do not connect to any externally configured server
 		forceCmd := []string{"bash", "-c", fmt.Sprintf(`cd %s && source /home/builder/layers/poky/oe-init-build-env . && export BB_SERVER_TIMEOUT=600 && export BB_HEARTBEAT_EVENT=60 && \
-			unset BBSERVER && bitbake -c clean %s`, e.workspaceDir, imageName)}
+			unset BBSERVER && bitbake -c clean %s`, e.workspaceDir, targets)}
 		forceResult, forceErr := e.containerMgr.ExecStream(ctx, e.containerID, forceCmd, 10*time.Minute)
 		if logWriter != nil {
 			for _, line := range strings.Split(string(forceResult.Stdout), "\n") {
@@ -431,118 +452,9 @@ func (e *BuildExecutor) executeBitbake(ctx context.Context, logWriter *BuildLogW
 
 	if result.ExitCode != 0 {
 		buildResult.Success = false
-		e.logger.Warn("BitBake build failed. Attempting targeted cleanup, cleansstate, and retry...")
-		stderrText := string(result.Stderr)
-
-		// Detect legacy deploy path reference (old dynamic build-* workspace) causing ipk link failure
-		// Example: /home/builder/build-fe610266-.../deploy/ipk/...
-		missingDeployPath := ""
-		for _, line := range strings.Split(stderrText, "\n") {
-			if strings.Contains(line, "deploy/ipk") && strings.Contains(line, "FileNotFoundError") && strings.Contains(line, "build-") {
-				// Extract the first /home/builder/build-*-*/deploy/ipk segment heuristically
-				parts := strings.Split(line, " ")
-				for _, p := range parts {
-					if strings.HasPrefix(p, "/home/builder/build-") && strings.Contains(p, "/deploy/ipk/") {
-						missingDeployPath = p
-						break
-					}
-				}
-			}
-			if missingDeployPath != "" {
-				break
-			}
-		}
-		if missingDeployPath != "" {
-			e.logger.Warn("Detected stale sstate reference to legacy dynamic deploy path; forcing package write refresh", slog.String("stale_path", missingDeployPath))
-			// Attempt to regenerate package indexes for current deploy to satisfy rootfs
-			pkgIndexCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && bitbake package-index", e.workspaceDir)}
-			idxRes, idxErr := e.containerMgr.ExecStream(ctx, e.containerID, pkgIndexCmd, 30*time.Minute)
-			if idxErr != nil || idxRes.ExitCode != 0 {
-				e.logger.Warn("package-index regeneration did not succeed (continuing to cleansstate)")
-			} else {
-				e.logger.Info("package-index regeneration completed; will retry image build")
-				retryCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && bitbake %s", e.workspaceDir, e.config.Build.Image)}
-				retryRes, retryErr := e.containerMgr.ExecStream(ctx, e.containerID, retryCmd, 2*time.Hour)
-				if retryErr == nil && retryRes.ExitCode == 0 {
-					if logWriter != nil {
-						for _, line := range strings.Split(string(retryRes.Stdout), "\n") {
-							if line != "" {
-								logWriter.WriteLog("stdout", line)
-							}
-						}
-						for _, line := range strings.Split(string(retryRes.Stderr), "\n") {
-							if line != "" {
-								logWriter.WriteLog("stderr", line)
-							}
-						}
-					}
-					buildResult.Success = true
-					buildResult.ExitCode = 0
-					return buildResult, nil
-				}
-			}
-		}
-
-		failedRecipe := extractFailedRecipe(stderrText)
-		if failedRecipe != "" {
-			// Targeted cleanup for pseudo path mismatch
-			if strings.Contains(strings.ToLower(stderrText), "pseudo") && strings.Contains(strings.ToLower(stderrText), "path mismatch") {
-				e.logger.Warn("Detected pseudo path mismatch — cleaning workdir artifacts before cleansstate...", slog.String("recipe", failedRecipe))
-				if err := e.targetedCleanup(ctx, failedRecipe, logWriter); err != nil {
-					e.logger.Warn("Targeted cleanup had issues (continuing)", slog.String("error", err.Error()))
-				}
-			}
-
-			cleansstateCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && bitbake -c cleansstate %s", e.workspaceDir, failedRecipe)}
-			e.logger.Info("Running bitbake -c cleansstate", slog.String("recipe", failedRecipe))
-			cleanResult, cleanErr := e.containerMgr.ExecStream(ctx, e.containerID, cleansstateCmd, 2*time.Hour)
-			if logWriter != nil {
-				for _, line := range strings.Split(string(cleanResult.Stdout), "\n") {
-					if line != "" {
-						logWriter.WriteLog("stdout", line)
-					}
-				}
-				for _, line := range strings.Split(string(cleanResult.Stderr), "\n") {
-					if line != "" {
-						logWriter.WriteLog("stderr", line)
-					}
-				}
-			}
-			if cleanErr != nil || cleanResult.ExitCode != 0 {
-				e.logger.Error("cleansstate failed", cleanErr, slog.String("recipe", failedRecipe))
-				return buildResult, fmt.Errorf("bitbake build failed, cleansstate also failed for %s", failedRecipe)
-			}
-
-			// No longer clean deploy directory since it's shared across builds
-			// The shared deploy dir allows sstate to reference packages without path issues
-
-			// Retry build once
-			e.logger.Info("🔁 Retrying bitbake build after cleansstate...")
-			retryResult, retryErr := e.containerMgr.ExecStream(ctx, e.containerID, cmd, timeout)
-			if logWriter != nil {
-				for _, line := range strings.Split(string(retryResult.Stdout), "\n") {
-					if line != "" {
-						logWriter.WriteLog("stdout", line)
-					}
-				}
-				for _, line := range strings.Split(string(retryResult.Stderr), "\n") {
-					if line != "" {
-						logWriter.WriteLog("stderr", line)
-					}
-				}
-			}
-			buildResult.Output += "\n--- Cleansstate and retry output ---\n" + string(retryResult.Stdout) + "\n" + string(retryResult.Stderr)
-			if retryErr != nil || retryResult.ExitCode != 0 {
-				e.logger.Error("Retry build failed", retryErr, slog.String("recipe", failedRecipe))
-				return buildResult, fmt.Errorf("bitbake build failed after cleansstate/retry for %s", failedRecipe)
-			}
-			e.logger.Info("Build succeeded after cleansstate and retry.")
-			buildResult.Success = true
-			buildResult.ExitCode = retryResult.ExitCode
-			return buildResult, nil
-		} else {
-			e.logger.Warn("Could not extract failed recipe for cleansstate. No retry attempted.")
-			return buildResult, fmt.Errorf("bitbake build failed with exit code %d", result.ExitCode)
+		e.logger.Warn("BitBake build failed. Applying build.recovery strategies...")
+		if err := e.recoverBuild(ctx, cmd, timeout, buildResult, logWriter); err != nil {
+			return buildResult, err
 		}
 	}
 
@@ -37,6 +37,10 @@ func (m *mockContainerManager) CopyFromContainer(ctx context.Context, containerI
 	return nil
 }
 
+func (m *mockContainerManager) ListContainers(ctx context.Context, labels map[string]string) ([]container.ContainerInfo, error) {
+	return nil, nil
+}
+
 func (m *mockContainerManager) CreateContainer(ctx context.Context, cfg container.ContainerConfig) (string, error) {
 	return "mock-container-id", nil
 }
@@ -217,3 +221,33 @@ func TestBuildExecutor_ExecuteBuild_setupFail(t *testing.T) {
 		t.Errorf("expected build to fail")
 	}
 }
+
+func TestInterruptBitbake_StopsGracefully(t *testing.T) {
+	// pgrep exit code 1 means no BitBake processes remain
+	mgr := &mockContainerManager{returnResult: container.ExecResult{ExitCode: 1}}
+	be := NewBuildExecutor(&config.Config{}, mgr, "cid", "/tmp/workspace", logger.NewLogger())
+
+	if !be.InterruptBitbake(context.Background(), time.Second) {
+		t.Fatal("expected BitBake to stop gracefully")
+	}
+	if len(mgr.execCalls) != 2 {
+		t.Fatalf("expected SIGINT and one check, got %d exec calls", len(mgr.execCalls))
+	}
+	if !strings.Contains(strings.Join(mgr.execCalls[0].cmd, " "), "pkill -INT") {
+		t.Errorf("expected first call to send SIGINT, got %v", mgr.execCalls[0].cmd)
+	}
+}
+
+func TestInterruptBitbake_EscalatesToKill(t *testing.T) {
+	// pgrep exit code 0 means BitBake is still running
+	mgr := &mockContainerManager{returnResult: container.ExecResult{ExitCode: 0}}
+	be := NewBuildExecutor(&config.Config{}, mgr, "cid", "/tmp/workspace", logger.NewLogger())
+
+	if be.InterruptBitbake(context.Background(), 0) {
+		t.Fatal("expected escalation when BitBake keeps running")
+	}
+	last := mgr.execCalls[len(mgr.execCalls)-1]
+	if !strings.Contains(strings.Join(last.cmd, " "), "pkill -KILL") {
+		t.Errorf("expected final call to send SIGKILL, got %v", last.cmd)
+	}
+}
@@ -0,0 +1,86 @@
+package bitbake
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// DefaultInterruptGrace is how long BitBake gets to shut down after SIGINT before it is killed
+	DefaultInterruptGrace = 60 * time.Second
+
+	// interruptPollInterval is how often the container is checked for remaining BitBake processes
+	interruptPollInterval = 2 * time.Second
+
+	// bitbakeProcessPattern matches BitBake client, server and worker processes.
+	// The bracket keeps the pattern from matching the shell that runs pkill/pgrep.
+	bitbakeProcessPattern = "[b]itbake"
+)
+
+// SetInterruptGrace sets how long BitBake may take to stop after SIGINT before it is killed
+func (e *BuildExecutor) SetInterruptGrace(grace time.Duration) {
+	e.interruptGrace = grace
+}
+
+// watchCancellation interrupts BitBake in the container as soon as ctx is cancelled.
+// Cancelling only the exec context detaches the stream but leaves BitBake running
+// inside the container, so the processes have to be signalled explicitly.
+// The returned function stops the watcher and waits for a running interrupt to finish.
+func (e *BuildExecutor) watchCancellation(ctx context.Context) func() {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		select {
+		case <-ctx.Done():
+			grace := e.interruptGrace
+			if grace <= 0 {
+				grace = DefaultInterruptGrace
+			}
+			e.InterruptBitbake(context.WithoutCancel(ctx), grace)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// InterruptBitbake asks BitBake to stop gracefully with SIGINT and escalates to SIGKILL
+// if processes are still running after grace. It reports whether BitBake stopped gracefully.
+func (e *BuildExecutor) InterruptBitbake(ctx context.Context, grace time.Duration) bool {
+	e.logger.Warn("Interrupting BitBake", slog.Duration("grace", grace))
+
+	sigint := []string{"bash", "-c", "pkill -INT -f '" + bitbakeProcessPattern + "' || true"}
+	if _, err := e.containerMgr.Exec(ctx, e.containerID, sigint, 30*time.Second); err != nil {
+		e.logger.Warn("Failed to send SIGINT to BitBake", slog.String("error", err.Error()))
+	}
+
+	deadline := time.Now().Add(grace)
+	for {
+		// pgrep exits 1 when no process matches
+		check := []string{"bash", "-c", "pgrep -f '" + bitbakeProcessPattern + "' >/dev/null"}
+		res, err := e.containerMgr.Exec(ctx, e.containerID, check, 30*time.Second)
+		if err == nil && res.ExitCode == 1 {
+			e.logger.Info("BitBake stopped after interrupt")
+			return true
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		time.Sleep(min(interruptPollInterval, remaining))
+	}
+
+	e.logger.Warn("BitBake did not stop within grace period, killing", slog.Duration("grace", grace))
+	sigkill := []string{"bash", "-c", "pkill -KILL -f '" + bitbakeProcessPattern + "' || true"}
+	if _, err := e.containerMgr.Exec(ctx, e.containerID, sigkill, 30*time.Second); err != nil {
+		e.logger.Warn("Failed to kill BitBake", slog.String("error", err.Error()))
+	}
+	return false
+}
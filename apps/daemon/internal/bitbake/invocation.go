@@ -0,0 +1,139 @@
+package bitbake
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultTarget is built when neither the request nor the config names a target
+const DefaultTarget = "core-image-minimal"
+
+var (
+	// targetRe accepts recipe and image names, virtual/ providers and mc: multiconfig targets
+	targetRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+:/-]*$`)
+	taskRe   = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+)
+
+// Invocation is what a build asks BitBake for: one or more targets, optionally
+// restricted to a single task, e.g. "bitbake -c populate_sdk core-image-minimal".
+type Invocation struct {
+	Targets []string
+	Task    string // task without the do_ prefix; empty runs the default task (build)
+	Force   bool   // -f: run the task even if its stamp is still valid
+}
+
+// ParseInvocation parses targets in BitBake command line form: whitespace separated
+// targets, optionally followed by "-c <task>" and "-f", e.g. "busybox zlib -c compile -f".
+// This form is also how the target of a build is stored and displayed.
+func ParseInvocation(spec string) (Invocation, error) {
+	var inv Invocation
+	fields := strings.Fields(spec)
+	for i := 0; i < len(fields); i++ {
+		switch field := fields[i]; field {
+		case "-c", "--cmd":
+			if i+1 >= len(fields) {
+				return Invocation{}, fmt.Errorf("%s requires a task", field)
+			}
+			i++
+			inv.Task = fields[i]
+		case "-f", "--force":
+			inv.Force = true
+		default:
+			if strings.HasPrefix(field, "-") {
+				return Invocation{}, fmt.Errorf("unsupported bitbake option %q (only -c and -f are allowed)", field)
+			}
+			inv.Targets = append(inv.Targets, field)
+		}
+	}
+	inv = inv.normalized()
+	if err := inv.Validate(); err != nil {
+		return Invocation{}, err
+	}
+	return inv, nil
+}
+
+// NewInvocation builds an invocation from targets in command line form (see ParseInvocation)
+// and an explicit task, which replaces a task named in targets
+func NewInvocation(targets, task string, force bool) (Invocation, error) {
+	inv, err := ParseInvocation(targets)
+	if err != nil {
+		return Invocation{}, err
+	}
+	if task != "" {
+		inv.Task = task
+	}
+	inv.Force = inv.Force || force
+	inv = inv.normalized()
+	if err := inv.Validate(); err != nil {
+		return Invocation{}, err
+	}
+	return inv, nil
+}
+
+// normalized strips the do_ prefix from the task and drops duplicate targets
+func (i Invocation) normalized() Invocation {
+	i.Task = strings.TrimPrefix(strings.TrimSpace(i.Task), "do_")
+	seen := make(map[string]bool, len(i.Targets))
+	targets := make([]string, 0, len(i.Targets))
+	for _, t := range i.Targets {
+		if t = strings.TrimSpace(t); t != "" && !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	i.Targets = targets
+	return i
+}
+
+// Validate checks the targets and task; they end up on a shell command line in the container
+func (i Invocation) Validate() error {
+	for _, t := range i.Targets {
+		if !targetRe.MatchString(t) {
+			return fmt.Errorf("invalid target %q", t)
+		}
+	}
+	if i.Task != "" && !taskRe.MatchString(i.Task) {
+		return fmt.Errorf("invalid task %q", i.Task)
+	}
+	if len(i.Targets) == 0 && (i.Task != "" || i.Force) {
+		return fmt.Errorf("a task requires at least one target")
+	}
+	return nil
+}
+
+// WithDefaultTarget returns the invocation with target added when it names no targets
+func (i Invocation) WithDefaultTarget(target string) Invocation {
+	if len(i.Targets) > 0 {
+		return i
+	}
+	if target == "" {
+		target = DefaultTarget
+	}
+	i.Targets = []string{target}
+	return i
+}
+
+// Args returns the bitbake arguments, e.g. "-c compile -f busybox zlib"
+func (i Invocation) Args() string {
+	var args []string
+	if i.Task != "" {
+		args = append(args, "-c", i.Task)
+	}
+	if i.Force {
+		args = append(args, "-f")
+	}
+	return strings.Join(append(args, i.Targets...), " ")
+}
+
+// String returns the invocation in the form accepted by ParseInvocation
+func (i Invocation) String() string {
+	parts := append([]string{}, i.Targets...)
+	if i.Task != "" {
+		parts = append(parts, "-c", i.Task)
+	}
+	if i.Force {
+		parts = append(parts, "-f")
+	}
+	return strings.Join(parts, " ")
+}
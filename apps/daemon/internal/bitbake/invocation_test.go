@@ -0,0 +1,110 @@
+package bitbake
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/pkg/logger"
+)
+
+func TestParseInvocation(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Invocation
+		args string
+	}{
+		{spec: "core-image-minimal", want: Invocation{Targets: []string{"core-image-minimal"}}, args: "core-image-minimal"},
+		{spec: " core-image-minimal  meta-toolchain core-image-minimal", want: Invocation{Targets: []string{"core-image-minimal", "meta-toolchain"}}, args: "core-image-minimal meta-toolchain"},
+		{spec: "core-image-minimal -c populate_sdk", want: Invocation{Targets: []string{"core-image-minimal"}, Task: "populate_sdk"}, args: "-c populate_sdk core-image-minimal"},
+		{spec: "busybox -c do_compile -f", want: Invocation{Targets: []string{"busybox"}, Task: "compile", Force: true}, args: "-c compile -f busybox"},
+		{spec: "virtual/kernel mc:rescue:core-image-minimal", want: Invocation{Targets: []string{"virtual/kernel", "mc:rescue:core-image-minimal"}}, args: "virtual/kernel mc:rescue:core-image-minimal"},
+		{spec: "", want: Invocation{Targets: []string{}}, args: ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseInvocation(tt.spec)
+		if err != nil {
+			t.Errorf("ParseInvocation(%q) returned error: %v", tt.spec, err)
+			continue
+		}
+		if strings.Join(got.Targets, ",") != strings.Join(tt.want.Targets, ",") || got.Task != tt.want.Task || got.Force != tt.want.Force {
+			t.Errorf("ParseInvocation(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+		if got.Args() != tt.args {
+			t.Errorf("Args() = %q, want %q", got.Args(), tt.args)
+		}
+		// The stored form parses back to the same invocation
+		again, err := ParseInvocation(got.String())
+		if err != nil || again.String() != got.String() {
+			t.Errorf("round trip of %q gave %q (%v)", got.String(), again.String(), err)
+		}
+	}
+}
+
+func TestParseInvocation_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"core-image-minimal; rm -rf /",
+		"core-image-minimal -c",
+		"core-image-minimal -c 'compile'",
+		"core-image-minimal -k",
+		"-c compile",
+		"$(reboot)",
+	} {
+		if _, err := ParseInvocation(spec); err == nil {
+			t.Errorf("expected ParseInvocation(%q) to fail", spec)
+		}
+	}
+}
+
+func TestNewInvocation(t *testing.T) {
+	inv, err := NewInvocation("core-image-minimal -c compile", "do_populate_sdk", true)
+	if err != nil {
+		t.Fatalf("NewInvocation returned error: %v", err)
+	}
+	if inv.String() != "core-image-minimal -c populate_sdk -f" {
+		t.Errorf("expected the explicit task to win, got %q", inv.String())
+	}
+	if _, err := NewInvocation("core-image-minimal", "populate sdk", false); err == nil {
+		t.Error("expected an invalid task to be rejected")
+	}
+	if _, err := NewInvocation("", "compile", false); err == nil {
+		t.Error("expected a task without targets to be rejected")
+	}
+}
+
+func TestExecuteBitbake_BuildsRequestedTargets(t *testing.T) {
+	mgr := &scriptedContainerManager{}
+	cfg := &config.Config{
+		Base:  config.BaseConfig{Machine: "qemux86-64"},
+		Build: config.BuildConfig{Image: "core-image-weston"},
+	}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+	be.SetInvocation(Invocation{Targets: []string{"core-image-weston", "meta-toolchain"}, Task: "populate_sdk"})
+
+	if _, err := be.executeBitbake(context.Background(), nil); err != nil {
+		t.Fatalf("executeBitbake returned error: %v", err)
+	}
+	if len(mgr.commands) != 2 {
+		t.Fatalf("expected pre-fetch and build, got %v", mgr.commands)
+	}
+	if !strings.Contains(mgr.commands[0], "bitbake -c fetch core-image-weston meta-toolchain") {
+		t.Errorf("expected the pre-fetch to cover every target, got %s", mgr.commands[0])
+	}
+	if !strings.HasSuffix(mgr.commands[1], "bitbake -c populate_sdk core-image-weston meta-toolchain") {
+		t.Errorf("expected the requested targets and task to be built, got %s", mgr.commands[1])
+	}
+}
+
+func TestExecuteBitbake_DefaultsToConfigImage(t *testing.T) {
+	mgr := &scriptedContainerManager{}
+	cfg := &config.Config{Build: config.BuildConfig{Image: "core-image-weston"}}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+
+	if _, err := be.executeBitbake(context.Background(), nil); err != nil {
+		t.Fatalf("executeBitbake returned error: %v", err)
+	}
+	if !strings.HasSuffix(mgr.commands[len(mgr.commands)-1], "bitbake core-image-weston") {
+		t.Errorf("expected build.image to be built, got %s", mgr.commands[len(mgr.commands)-1])
+	}
+}
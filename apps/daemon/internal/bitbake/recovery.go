@@ -0,0 +1,230 @@
+package bitbake
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/schererja/smidr/internal/container"
+)
+
+// RecoveryStrategy names a self-heal step the executor takes after a failed build
+type RecoveryStrategy string
+
+const (
+	RecoveryPackageIndex  RecoveryStrategy = "package_index"  // bitbake package-index for a stale deploy path
+	RecoveryPseudoCleanup RecoveryStrategy = "pseudo_cleanup" // workdir cleanup of a recipe after a pseudo path mismatch
+	RecoveryCleansstate   RecoveryStrategy = "cleansstate"    // bitbake -c cleansstate of the failed recipe
+	RecoveryRetry         RecoveryStrategy = "retry"          // rebuild of the image
+)
+
+// packageIndexTimeout limits the package index regeneration
+const packageIndexTimeout = 30 * time.Minute
+
+// RecoveryAction records one recovery step taken during a build
+type RecoveryAction struct {
+	Strategy  RecoveryStrategy `json:"strategy"`
+	Recipe    string           `json:"recipe,omitempty"`
+	Attempt   int              `json:"attempt,omitempty"` // retry the step belongs to, starting at 1
+	Succeeded bool             `json:"succeeded"`
+	Detail    string           `json:"detail,omitempty"`
+	StartedAt time.Time        `json:"started_at"`
+	Duration  time.Duration    `json:"duration"`
+}
+
+// Summary returns a one-line description of the action
+func (a RecoveryAction) Summary() string {
+	var b strings.Builder
+	b.WriteString(string(a.Strategy))
+	if a.Recipe != "" {
+		b.WriteString(" of " + a.Recipe)
+	}
+	if a.Attempt > 0 {
+		fmt.Fprintf(&b, " (attempt %d)", a.Attempt)
+	}
+	if a.Succeeded {
+		b.WriteString(" succeeded")
+	} else {
+		b.WriteString(" failed")
+	}
+	if a.Detail != "" {
+		b.WriteString(": " + a.Detail)
+	}
+	return b.String()
+}
+
+// recoverBuild applies the recovery strategies enabled in build.recovery to a failed build.
+// It returns nil once a retry succeeds; every step is recorded on buildResult.
+func (e *BuildExecutor) recoverBuild(ctx context.Context, cmd []string, timeout time.Duration, buildResult *BuildResult, logWriter *BuildLogWriter) error {
+	policy := e.config.Build.Recovery
+	stderrText := buildResult.Error
+	exitCode := buildResult.ExitCode
+	retries := policy.Retries()
+	attempt := 0 // retries after cleansstate so far
+
+	// Detect legacy deploy path reference (old dynamic build-* workspace) causing ipk link failure
+	if policy.PackageIndexEnabled() {
+		if stalePath := findStaleDeployPath(stderrText); stalePath != "" {
+			e.logger.Warn("Detected stale sstate reference to legacy dynamic deploy path; forcing package write refresh", slog.String("stale_path", stalePath))
+			// Attempt to regenerate package indexes for current deploy to satisfy rootfs
+			start := time.Now()
+			pkgIndexCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && bitbake package-index", e.workspaceDir)}
+			idxRes, idxErr := e.containerMgr.ExecStream(ctx, e.containerID, pkgIndexCmd, packageIndexTimeout)
+			indexed := idxErr == nil && idxRes.ExitCode == 0
+			e.recordRecovery(buildResult, logWriter, RecoveryAction{Strategy: RecoveryPackageIndex, Succeeded: indexed, Detail: "stale deploy path " + stalePath, StartedAt: start, Duration: time.Since(start)})
+
+			if !indexed {
+				e.logger.Warn("package-index regeneration did not succeed (continuing to cleansstate)")
+			} else if retries > 0 {
+				// This rebuild belongs to the package index strategy and does not count against max_retries
+				e.logger.Info("package-index regeneration completed; will retry image build")
+				retryCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && bitbake %s", e.workspaceDir, e.targetInvocation().Args())}
+				if e.retryBuild(ctx, retryCmd, policy.CommandTimeout(), 0, "", buildResult, logWriter) {
+					return nil
+				}
+				stderrText, exitCode = buildResult.Error, buildResult.ExitCode
+			}
+		}
+	}
+
+	for attempt < retries {
+		attempt++
+		failedRecipe := extractFailedRecipe(stderrText)
+
+		if policy.CleansstateEnabled() {
+			if failedRecipe == "" {
+				e.logger.Warn("Could not extract failed recipe for cleansstate. No retry attempted.")
+				return fmt.Errorf("bitbake build failed with exit code %d", exitCode)
+			}
+
+			// Targeted cleanup for pseudo path mismatch
+			lower := strings.ToLower(stderrText)
+			if policy.PseudoCleanupEnabled() && strings.Contains(lower, "pseudo") && strings.Contains(lower, "path mismatch") {
+				e.logger.Warn("Detected pseudo path mismatch — cleaning workdir artifacts before cleansstate...", slog.String("recipe", failedRecipe))
+				start := time.Now()
+				cleanupErr := e.targetedCleanup(ctx, failedRecipe, logWriter)
+				action := RecoveryAction{Strategy: RecoveryPseudoCleanup, Recipe: failedRecipe, Attempt: attempt, Succeeded: cleanupErr == nil, StartedAt: start, Duration: time.Since(start)}
+				if cleanupErr != nil {
+					e.logger.Warn("Targeted cleanup had issues (continuing)", slog.String("error", cleanupErr.Error()))
+					action.Detail = cleanupErr.Error()
+				}
+				e.recordRecovery(buildResult, logWriter, action)
+			}
+
+			start := time.Now()
+			cleansstateCmd := []string{"bash", "-c", fmt.Sprintf("cd %s && source /home/builder/layers/poky/oe-init-build-env . && bitbake -c cleansstate %s", e.workspaceDir, failedRecipe)}
+			e.logger.Info("Running bitbake -c cleansstate", slog.String("recipe", failedRecipe))
+			cleanResult, cleanErr := e.containerMgr.ExecStream(ctx, e.containerID, cleansstateCmd, policy.CommandTimeout())
+			writeExecResult(logWriter, cleanResult)
+			cleaned := cleanErr == nil && cleanResult.ExitCode == 0
+			e.recordRecovery(buildResult, logWriter, RecoveryAction{Strategy: RecoveryCleansstate, Recipe: failedRecipe, Attempt: attempt, Succeeded: cleaned, StartedAt: start, Duration: time.Since(start)})
+			if !cleaned {
+				e.logger.Error("cleansstate failed", cleanErr, slog.String("recipe", failedRecipe))
+				return fmt.Errorf("bitbake build failed, cleansstate also failed for %s", failedRecipe)
+			}
+		}
+
+		if backoff := policy.RetryBackoff(attempt); backoff > 0 {
+			e.logger.Info("Waiting before retrying the build", slog.Duration("backoff", backoff), slog.Int("attempt", attempt))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		// The shared deploy dir allows sstate to reference packages without path issues,
+		// so it is not cleaned before retrying
+		e.logger.Info("🔁 Retrying bitbake build...", slog.Int("attempt", attempt), slog.Int("max_retries", retries))
+		if e.retryBuild(ctx, cmd, timeout, attempt, failedRecipe, buildResult, logWriter) {
+			e.logger.Info("Build succeeded after recovery.", slog.Int("attempt", attempt))
+			return nil
+		}
+		stderrText = buildResult.Error
+		exitCode = buildResult.ExitCode
+	}
+
+	if attempt == 0 {
+		e.logger.Warn("Build recovery disabled by build.recovery; no retry attempted.")
+		return fmt.Errorf("bitbake build failed with exit code %d", exitCode)
+	}
+	if recipe := extractFailedRecipe(stderrText); recipe != "" {
+		return fmt.Errorf("bitbake build failed after %d recovery attempt(s) for %s", attempt, recipe)
+	}
+	return fmt.Errorf("bitbake build failed after %d recovery attempt(s) with exit code %d", attempt, exitCode)
+}
+
+// retryBuild runs cmd again and reports whether it succeeded. The outcome replaces the
+// exit code and stderr of buildResult and its output is appended.
+func (e *BuildExecutor) retryBuild(ctx context.Context, cmd []string, timeout time.Duration, attempt int, recipe string, buildResult *BuildResult, logWriter *BuildLogWriter) bool {
+	start := time.Now()
+	retryResult, retryErr := e.containerMgr.ExecStream(ctx, e.containerID, cmd, timeout)
+	writeExecResult(logWriter, retryResult)
+	buildResult.Output += "\n--- Recovery retry output ---\n" + string(retryResult.Stdout) + "\n" + string(retryResult.Stderr)
+	buildResult.Error = string(retryResult.Stderr)
+	buildResult.ExitCode = retryResult.ExitCode
+
+	succeeded := retryErr == nil && retryResult.ExitCode == 0
+	action := RecoveryAction{Strategy: RecoveryRetry, Recipe: recipe, Attempt: attempt, Succeeded: succeeded, StartedAt: start, Duration: time.Since(start)}
+	if retryErr != nil {
+		action.Detail = retryErr.Error()
+		if buildResult.ExitCode == 0 {
+			buildResult.ExitCode = 1
+		}
+	} else if !succeeded {
+		action.Detail = fmt.Sprintf("exit code %d", retryResult.ExitCode)
+	}
+	e.recordRecovery(buildResult, logWriter, action)
+
+	if !succeeded {
+		e.logger.Error("Retry build failed", retryErr, slog.Int("attempt", attempt))
+		return false
+	}
+	buildResult.Success = true
+	return true
+}
+
+// recordRecovery adds an action to the build result and reports it to the log writer
+func (e *BuildExecutor) recordRecovery(buildResult *BuildResult, logWriter *BuildLogWriter, action RecoveryAction) {
+	buildResult.Recovery = append(buildResult.Recovery, action)
+	e.logger.Info("Recovery action", slog.String("strategy", string(action.Strategy)), slog.String("recipe", action.Recipe),
+		slog.Int("attempt", action.Attempt), slog.Bool("succeeded", action.Succeeded), slog.Duration("duration", action.Duration))
+	if logWriter != nil && logWriter.OnRecovery != nil {
+		logWriter.OnRecovery(action)
+	}
+}
+
+// findStaleDeployPath returns a deploy/ipk path of an old dynamic build-* workspace referenced
+// by a FileNotFoundError, e.g. /home/builder/build-fe610266-.../deploy/ipk/...
+func findStaleDeployPath(stderr string) string {
+	for _, line := range strings.Split(stderr, "\n") {
+		if !strings.Contains(line, "deploy/ipk") || !strings.Contains(line, "FileNotFoundError") || !strings.Contains(line, "build-") {
+			continue
+		}
+		for _, p := range strings.Split(line, " ") {
+			if strings.HasPrefix(p, "/home/builder/build-") && strings.Contains(p, "/deploy/ipk/") {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// writeExecResult forwards the buffered output of a command to the log writer
+func writeExecResult(logWriter *BuildLogWriter, res container.ExecResult) {
+	if logWriter == nil {
+		return
+	}
+	for _, line := range strings.Split(string(res.Stdout), "\n") {
+		if line != "" {
+			logWriter.WriteLog("stdout", line)
+		}
+	}
+	for _, line := range strings.Split(string(res.Stderr), "\n") {
+		if line != "" {
+			logWriter.WriteLog("stderr", line)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package bitbake
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/container"
+	"github.com/schererja/smidr/pkg/logger"
+)
+
+// scriptedContainerManager returns the scripted results of ExecStream in order
+type scriptedContainerManager struct {
+	mockContainerManager
+	results  []container.ExecResult
+	commands []string
+}
+
+func (m *scriptedContainerManager) ExecStream(ctx context.Context, containerID string, cmd []string, timeout time.Duration) (container.ExecResult, error) {
+	m.commands = append(m.commands, strings.Join(cmd, " "))
+	if len(m.results) == 0 {
+		return container.ExecResult{}, nil
+	}
+	res := m.results[0]
+	m.results = m.results[1:]
+	return res, nil
+}
+
+const failedTaskStderr = "ERROR: Task (/home/builder/layers/poky/meta/recipes-core/zlib/zlib_1.2.11.bb:do_compile) failed with exit code '1'"
+
+func failedBuild() *BuildResult {
+	return &BuildResult{ExitCode: 1, Error: failedTaskStderr}
+}
+
+func TestRecoverBuild_CleansstateAndRetry(t *testing.T) {
+	mgr := &scriptedContainerManager{results: []container.ExecResult{
+		{}, // cleansstate
+		{ExitCode: 1, Stderr: []byte(failedTaskStderr)}, // first retry
+		{}, // cleansstate
+		{ExitCode: 0, Stdout: []byte("all tasks succeeded")}, // second retry
+	}}
+	retries := 2
+	cfg := &config.Config{Build: config.BuildConfig{Image: "core-image-minimal", Recovery: config.RecoveryConfig{MaxRetries: &retries}}}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+
+	var reported []RecoveryAction
+	logWriter := &BuildLogWriter{OnRecovery: func(a RecoveryAction) { reported = append(reported, a) }}
+	result := failedBuild()
+	if err := be.recoverBuild(context.Background(), []string{"bitbake"}, time.Hour, result, logWriter); err != nil {
+		t.Fatalf("expected recovery to succeed, got %v", err)
+	}
+	if !result.Success || result.ExitCode != 0 {
+		t.Errorf("expected a successful result, got %+v", result)
+	}
+
+	want := []RecoveryStrategy{RecoveryCleansstate, RecoveryRetry, RecoveryCleansstate, RecoveryRetry}
+	if len(result.Recovery) != len(want) {
+		t.Fatalf("expected %d recovery actions, got %+v", len(want), result.Recovery)
+	}
+	for i, action := range result.Recovery {
+		if action.Strategy != want[i] || action.Recipe != "zlib" || action.Attempt != i/2+1 {
+			t.Errorf("unexpected action %d: %+v", i, action)
+		}
+	}
+	if result.Recovery[1].Succeeded || !result.Recovery[3].Succeeded {
+		t.Errorf("expected only the second retry to succeed: %+v", result.Recovery)
+	}
+	if len(reported) != len(result.Recovery) {
+		t.Errorf("expected every action to be reported, got %d", len(reported))
+	}
+}
+
+func TestRecoverBuild_Disabled(t *testing.T) {
+	mgr := &scriptedContainerManager{}
+	retries := 0
+	cfg := &config.Config{Build: config.BuildConfig{Image: "core-image-minimal", Recovery: config.RecoveryConfig{MaxRetries: &retries}}}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+
+	result := failedBuild()
+	if err := be.recoverBuild(context.Background(), []string{"bitbake"}, time.Hour, result, nil); err == nil {
+		t.Fatal("expected the build to fail without retries")
+	}
+	if len(mgr.commands) != 0 || len(result.Recovery) != 0 {
+		t.Errorf("expected no recovery, got commands %v and actions %+v", mgr.commands, result.Recovery)
+	}
+}
+
+func TestRecoverBuild_RetryWithoutCleansstate(t *testing.T) {
+	mgr := &scriptedContainerManager{results: []container.ExecResult{{ExitCode: 1, Stderr: []byte(failedTaskStderr)}}}
+	disabled := false
+	cfg := &config.Config{Build: config.BuildConfig{Image: "core-image-minimal", Recovery: config.RecoveryConfig{Cleansstate: &disabled}}}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+
+	result := failedBuild()
+	err := be.recoverBuild(context.Background(), []string{"bitbake"}, time.Hour, result, nil)
+	if err == nil || !strings.Contains(err.Error(), "zlib") {
+		t.Fatalf("expected the retry to fail for zlib, got %v", err)
+	}
+	if len(mgr.commands) != 1 || strings.Contains(mgr.commands[0], "cleansstate") {
+		t.Errorf("expected a single retry without cleansstate, got %v", mgr.commands)
+	}
+	if len(result.Recovery) != 1 || result.Recovery[0].Strategy != RecoveryRetry || result.Recovery[0].Succeeded {
+		t.Errorf("unexpected recovery actions %+v", result.Recovery)
+	}
+}
+
+func TestRecoverBuild_BackoffHonoursCancellation(t *testing.T) {
+	mgr := &scriptedContainerManager{}
+	disabled := false
+	cfg := &config.Config{Build: config.BuildConfig{Image: "core-image-minimal", Recovery: config.RecoveryConfig{Cleansstate: &disabled, Backoff: time.Hour}}}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := be.recoverBuild(ctx, []string{"bitbake"}, time.Hour, failedBuild(), nil); err != context.Canceled {
+		t.Fatalf("expected cancellation during backoff, got %v", err)
+	}
+	if len(mgr.commands) != 0 {
+		t.Errorf("expected no retry after cancellation, got %v", mgr.commands)
+	}
+}
+
+func TestRecoverBuild_PackageIndex(t *testing.T) {
+	stderr := "FileNotFoundError: [Errno 2] No such file or directory: /home/builder/build-fe610266/deploy/ipk/core2-64/zlib.ipk"
+	mgr := &scriptedContainerManager{results: []container.ExecResult{{}, {}}}
+	cfg := &config.Config{Build: config.BuildConfig{Image: "core-image-minimal"}}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+
+	result := &BuildResult{ExitCode: 1, Error: stderr}
+	if err := be.recoverBuild(context.Background(), []string{"bitbake"}, time.Hour, result, nil); err != nil {
+		t.Fatalf("expected recovery to succeed, got %v", err)
+	}
+	if len(result.Recovery) != 2 || result.Recovery[0].Strategy != RecoveryPackageIndex || result.Recovery[1].Strategy != RecoveryRetry {
+		t.Errorf("unexpected recovery actions %+v", result.Recovery)
+	}
+	if !strings.Contains(mgr.commands[0], "bitbake package-index") {
+		t.Errorf("expected package-index first, got %v", mgr.commands)
+	}
+}
@@ -0,0 +1,112 @@
+package bitbake
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TaskLogsDir is the directory inside a build directory that failed task logs are collected into
+const TaskLogsDir = "task-logs"
+
+// taskLogCopyTimeout bounds locating and copying a single task log out of the container
+const taskLogCopyTimeout = time.Minute
+
+// TaskLog is a task log copied from the BitBake work directory into the build directory
+type TaskLog struct {
+	Recipe    string
+	Task      string
+	Path      string // host path of the copy
+	SizeBytes int64
+}
+
+// TaskLogPath returns where the log of a task is collected within a build directory
+func TaskLogPath(buildDir, recipe, task string) string {
+	return filepath.Join(buildDir, TaskLogsDir, recipe, task+".log")
+}
+
+// collectTaskLogs copies the logs of the tasks named in diagnostics from the container's
+// work directory (tmp/work/<arch>/<recipe>/<ver>/temp/log.do_<task>.<pid>) into the build directory
+func (e *BuildExecutor) collectTaskLogs(ctx context.Context, diagnostics []Diagnostic) []TaskLog {
+	buildDir := e.config.Directories.Build
+	if buildDir == "" {
+		return nil
+	}
+
+	var logs []TaskLog
+	seen := make(map[string]bool)
+	for _, d := range diagnostics {
+		if d.Recipe == "" || d.Task == "" || !isPlainName(d.Recipe) || !isPlainName(d.Task) {
+			continue
+		}
+		key := d.Recipe + "/" + d.Task
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		log, err := e.collectTaskLog(ctx, buildDir, d)
+		if err != nil {
+			e.logger.Warn("Could not collect task log", slog.String("recipe", d.Recipe), slog.String("task", d.Task), slog.String("error", err.Error()))
+			continue
+		}
+		logs = append(logs, log)
+	}
+	return logs
+}
+
+// collectTaskLog copies the log of the failed task of a single diagnostic
+func (e *BuildExecutor) collectTaskLog(ctx context.Context, buildDir string, d Diagnostic) (TaskLog, error) {
+	ctx, cancel := context.WithTimeout(ctx, taskLogCopyTimeout)
+	defer cancel()
+
+	source := d.LogFile
+	if source == "" {
+		// log.do_<task> links to the log of the latest run of the task
+		script := fmt.Sprintf("readlink -f $(ls -t %s/work/*/%s/*/temp/log.%s 2>/dev/null | head -n 1) 2>/dev/null", e.containerTmpDir(), d.Recipe, d.Task)
+		res, err := e.containerMgr.Exec(ctx, e.containerID, []string{"sh", "-c", script}, taskLogCopyTimeout)
+		if err != nil {
+			return TaskLog{}, err
+		}
+		source = strings.TrimSpace(res.GetStdoutString())
+		if source == "" {
+			return TaskLog{}, fmt.Errorf("no log.%s found for %s", d.Task, d.Recipe)
+		}
+	}
+
+	dest := TaskLogPath(buildDir, d.Recipe, d.Task)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return TaskLog{}, fmt.Errorf("failed to create task log directory: %w", err)
+	}
+	if err := e.containerMgr.CopyFromContainer(ctx, e.containerID, source, dest); err != nil {
+		return TaskLog{}, fmt.Errorf("failed to copy %s: %w", source, err)
+	}
+	info, err := os.Stat(dest)
+	if err != nil {
+		return TaskLog{}, err
+	}
+	e.logger.Info("Collected task log", slog.String("recipe", d.Recipe), slog.String("task", d.Task), slog.String("path", dest))
+	return TaskLog{Recipe: d.Recipe, Task: d.Task, Path: dest, SizeBytes: info.Size()}, nil
+}
+
+// containerTmpDir returns BitBake's TMPDIR inside the container
+func (e *BuildExecutor) containerTmpDir() string {
+	if strings.TrimSpace(e.config.Directories.Tmp) != "" {
+		return "/home/builder/tmp"
+	}
+	return e.workspaceDir + "/tmp"
+}
+
+// isPlainName reports whether a recipe or task name is safe to use in paths and shell globs
+func isPlainName(name string) bool {
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.+", r)) {
+			return false
+		}
+	}
+	return name != "" && name != "." && name != ".."
+}
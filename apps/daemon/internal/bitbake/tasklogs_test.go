@@ -0,0 +1,65 @@
+package bitbake
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/container"
+	"github.com/schererja/smidr/pkg/logger"
+)
+
+// copyingContainerManager writes the container path into the host file on copy
+type copyingContainerManager struct {
+	mockContainerManager
+	copied []string
+}
+
+func (m *copyingContainerManager) CopyFromContainer(ctx context.Context, containerID, containerPath, hostPath string) error {
+	m.copied = append(m.copied, containerPath)
+	return os.WriteFile(hostPath, []byte("log of "+containerPath), 0o644)
+}
+
+func TestCollectTaskLogs(t *testing.T) {
+	buildDir := t.TempDir()
+	mgr := &copyingContainerManager{}
+	mgr.returnResult = container.ExecResult{Stdout: []byte("/home/builder/tmp/work/core2-64/zlib/1.2.11-r0/temp/log.do_compile.42\n")}
+	cfg := &config.Config{Directories: config.DirectoryConfig{Build: buildDir, Tmp: "/host/tmp"}}
+	be := NewBuildExecutor(cfg, mgr, "cid", "/home/builder/build", logger.NewLogger())
+
+	logs := be.collectTaskLogs(context.Background(), []Diagnostic{
+		{Kind: FailureFetch, Recipe: "busybox", Task: "do_fetch", LogFile: "/home/builder/tmp/work/core2-64/busybox/1.35.0-r0/temp/log.do_fetch.7"},
+		{Kind: FailureTask, Recipe: "zlib", Task: "do_compile"},
+		{Kind: FailureDiskFull},
+		{Kind: FailureTask, Recipe: "../escape", Task: "do_compile"},
+	})
+
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 collected logs, got %+v", logs)
+	}
+	if logs[0].Path != TaskLogPath(buildDir, "busybox", "do_fetch") || logs[0].SizeBytes == 0 {
+		t.Errorf("unexpected busybox log: %+v", logs[0])
+	}
+	if mgr.copied[0] != "/home/builder/tmp/work/core2-64/busybox/1.35.0-r0/temp/log.do_fetch.7" {
+		t.Errorf("expected the reported log file to be copied, got %s", mgr.copied[0])
+	}
+	// Without a reported log file the latest log of the task is looked up in TMPDIR
+	if len(mgr.execCalls) != 1 || !strings.Contains(strings.Join(mgr.execCalls[0].cmd, " "), "/home/builder/tmp/work/*/zlib/*/temp/log.do_compile") {
+		t.Errorf("expected a lookup of the zlib log, got %+v", mgr.execCalls)
+	}
+	content, err := os.ReadFile(logs[1].Path)
+	if err != nil || !strings.Contains(string(content), "log.do_compile.42") {
+		t.Errorf("expected the zlib log to be collected, got %q (%v)", content, err)
+	}
+}
+
+func TestCollectTaskLogs_NoBuildDir(t *testing.T) {
+	mgr := &copyingContainerManager{}
+	be := NewBuildExecutor(&config.Config{}, mgr, "cid", "/home/builder/build", logger.NewLogger())
+	logs := be.collectTaskLogs(context.Background(), []Diagnostic{{Kind: FailureTask, Recipe: "zlib", Task: "do_compile"}})
+	if len(logs) != 0 || len(mgr.copied) != 0 {
+		t.Errorf("expected nothing to be collected without a build directory, got %+v", logs)
+	}
+}
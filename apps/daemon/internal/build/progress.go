@@ -0,0 +1,112 @@
+package build
+
+import (
+	"strings"
+
+	"github.com/schererja/smidr/internal/bitbake"
+	"github.com/schererja/smidr/internal/source"
+)
+
+// Phase identifies a coarse stage of the build pipeline
+type Phase string
+
+const (
+	PhaseFetch   Phase = "fetch"   // fetching layers and sources
+	PhaseParse   Phase = "parse"   // BitBake parsing recipes
+	PhaseBuild   Phase = "build"   // BitBake executing tasks
+	PhaseExtract Phase = "extract" // copying artifacts out of the build
+)
+
+// ProgressSink is an optional extension of LogSink for structured progress.
+// Runner emits phase changes and task progress to sinks implementing it.
+type ProgressSink interface {
+	PhaseChanged(phase Phase)
+	TaskProgress(progress TaskProgress)
+}
+
+// RecoverySink is an optional extension of LogSink for the recovery steps of a build.
+// Runner reports every step the executor takes after a failed BitBake run.
+type RecoverySink interface {
+	Recovery(action bitbake.RecoveryAction)
+}
+
+// LayerFetchSink is an optional extension of LogSink for the outcome of fetching each
+// layer repository. Runner reports every layer once fetching is done, also when it failed.
+type LayerFetchSink interface {
+	LayerFetched(fetch source.LayerFetch)
+}
+
+// progressTracker derives phase changes and task progress from BitBake output lines
+type progressTracker struct {
+	sink  ProgressSink
+	phase Phase
+}
+
+// newProgressTracker returns a tracker for the given sink, or nil if it does not accept progress
+func newProgressTracker(log LogSink) *progressTracker {
+	sink, ok := log.(ProgressSink)
+	if !ok {
+		return nil
+	}
+	return &progressTracker{sink: sink}
+}
+
+// enter reports a phase change if the phase differs from the current one. Phases only
+// move forward: fetch and parse alternate while the pre-fetch run parses recipes and
+// fetches sources, but once tasks are built a late do_fetch does not go back to fetch.
+func (t *progressTracker) enter(phase Phase) {
+	if t == nil || t.phase == phase || phaseRank(phase) < phaseRank(t.phase) {
+		return
+	}
+	t.phase = phase
+	t.sink.PhaseChanged(phase)
+}
+
+// observe inspects a BitBake output line and reports phase changes and task progress
+func (t *progressTracker) observe(line string, progress *TaskProgress) {
+	if t == nil {
+		return
+	}
+
+	if progress != nil {
+		// The pre-fetch run (bitbake -c fetch) only executes fetch tasks
+		if progress.TaskName == "do_fetch" {
+			t.enter(PhaseFetch)
+		} else {
+			t.enter(PhaseBuild)
+		}
+		t.sink.TaskProgress(*progress)
+		return
+	}
+
+	if phase, ok := phaseForLine(line); ok {
+		t.enter(phase)
+	}
+}
+
+// phaseRank orders phases; fetch and parse share a rank since the pre-fetch run
+// parses recipes before it fetches sources
+func phaseRank(phase Phase) int {
+	switch phase {
+	case PhaseBuild:
+		return 1
+	case PhaseExtract:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// phaseForLine recognizes BitBake output that marks the start of a phase
+func phaseForLine(line string) (Phase, bool) {
+	switch {
+	case strings.HasPrefix(line, "Loading cache"),
+		strings.HasPrefix(line, "Parsing recipes"),
+		strings.HasPrefix(line, "Parsing of"):
+		return PhaseParse, true
+	case strings.HasPrefix(line, "NOTE: Executing Tasks"),
+		strings.HasPrefix(line, "NOTE: Executing SetScene Tasks"):
+		return PhaseBuild, true
+	}
+	return "", false
+}
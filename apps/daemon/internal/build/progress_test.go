@@ -0,0 +1,80 @@
+package build
+
+import "testing"
+
+func TestParseTaskProgress(t *testing.T) {
+	tp := parseTaskProgress("NOTE: Running task 482 of 776 (virtual:native:/home/builder/layers/poky/meta/recipes-graphics/libepoxy/libepoxy_1.5.10.bb:do_compile)")
+	if tp == nil {
+		t.Fatal("expected task progress")
+	}
+	if tp.Current != 482 || tp.Total != 776 {
+		t.Errorf("unexpected counts %d/%d", tp.Current, tp.Total)
+	}
+	if tp.Recipe != "libepoxy" || tp.TaskName != "do_compile" {
+		t.Errorf("unexpected recipe/task %q/%q", tp.Recipe, tp.TaskName)
+	}
+	if tp.Setscene {
+		t.Error("expected a real task, not setscene")
+	}
+
+	tp = parseTaskProgress("NOTE: Running setscene task 12 of 300 (/layers/poky/meta/recipes-core/busybox/busybox_1.36.1.bb:do_populate_sysroot_setscene)")
+	if tp == nil || !tp.Setscene || tp.Recipe != "busybox" || tp.Current != 12 || tp.Total != 300 {
+		t.Errorf("unexpected setscene progress %+v", tp)
+	}
+
+	if parseTaskProgress("NOTE: Tasks Summary: Attempted 776 tasks") != nil {
+		t.Error("expected nil for non-progress line")
+	}
+}
+
+type recordingProgressSink struct {
+	mockLogSink
+	phases   []Phase
+	progress []TaskProgress
+}
+
+func (r *recordingProgressSink) PhaseChanged(phase Phase)     { r.phases = append(r.phases, phase) }
+func (r *recordingProgressSink) TaskProgress(tp TaskProgress) { r.progress = append(r.progress, tp) }
+
+func TestProgressTracker(t *testing.T) {
+	sink := &recordingProgressSink{}
+	tracker := newProgressTracker(sink)
+	if tracker == nil {
+		t.Fatal("expected tracker for progress-capable sink")
+	}
+
+	lines := []string{
+		"Loading cache...",
+		"Parsing recipes...",
+		"NOTE: Running task 1 of 2 (/l/busybox_1.0.bb:do_fetch)",
+		"NOTE: Executing Tasks",
+		"NOTE: Running task 1 of 9 (/l/busybox_1.0.bb:do_compile)",
+		"NOTE: Running task 2 of 9 (/l/busybox_1.0.bb:do_install)",
+		// A fetch task during the build does not go back to the fetch phase
+		"NOTE: Running task 3 of 9 (/l/zlib_1.0.bb:do_fetch)",
+		"Parsing of 900 .bb files complete",
+		"NOTE: Running task 4 of 9 (/l/zlib_1.0.bb:do_unpack)",
+	}
+	tracker.enter(PhaseFetch)
+	for _, line := range lines {
+		tracker.observe(line, parseTaskProgress(line))
+	}
+
+	want := []Phase{PhaseFetch, PhaseParse, PhaseFetch, PhaseBuild}
+	if len(sink.phases) != len(want) {
+		t.Fatalf("expected phases %v, got %v", want, sink.phases)
+	}
+	for i := range want {
+		if sink.phases[i] != want[i] {
+			t.Errorf("phase %d: expected %s, got %s", i, want[i], sink.phases[i])
+		}
+	}
+	if len(sink.progress) != 5 {
+		t.Errorf("expected 5 progress events, got %d", len(sink.progress))
+	}
+
+	// Plain sinks get no tracker, and a nil tracker is safe to use
+	plain := newProgressTracker(&mockLogSink{})
+	plain.enter(PhaseBuild)
+	plain.observe("NOTE: Executing Tasks", nil)
+}
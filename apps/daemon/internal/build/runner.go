@@ -2,6 +2,7 @@ package build
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,7 +18,7 @@ import (
 	"github.com/schererja/smidr/internal/bitbake"
 	"github.com/schererja/smidr/internal/config"
 	smidrcontainer "github.com/schererja/smidr/internal/container"
-	"github.com/schererja/smidr/internal/container/docker"
+	"github.com/schererja/smidr/internal/container/backend"
 	"github.com/schererja/smidr/internal/db"
 	"github.com/schererja/smidr/internal/source"
 	"github.com/schererja/smidr/pkg/logger"
@@ -62,29 +63,71 @@ func (l *LogSinkLogger) Write(stream string, line string) {
 
 // BuildOptions captures caller-provided options
 type BuildOptions struct {
-	BuildID    string
+	BuildID string
+	// Target names what BitBake builds in command line form: one or more targets,
+	// optionally with a task, e.g. "core-image-minimal meta-toolchain" or "busybox -c compile -f".
+	// Empty builds the config's build.image.
 	Target     string
 	Customer   string
 	ForceClean bool
 	ForceImage bool
 	// ConfigPath is the original config file path if provided; "<inline>" when config was inline
 	ConfigPath string
+	// ContainerBackend is used when the config does not set container.backend; empty means Docker
+	ContainerBackend string
+	// DefaultImage is used when the config does not set container.base_image; empty means config.DefaultContainerImage
+	DefaultImage string
+	// DownloadsDir and SStateDir are shared caches used when the config does not set directories.downloads/sstate
+	DownloadsDir string
+	SStateDir    string
+	// BuildsDir is the parent of per-build workspaces; empty means ~/.smidr/builds
+	BuildsDir string
+	// GitCredentials authenticate fetches of private layer repositories
+	GitCredentials source.GitCredentials
+	// RetriedFrom is the ID of the build this build re-runs, recorded for lineage
+	RetriedFrom string
+}
+
+// ContainerName returns the name of the container a build runs in
+func ContainerName(buildID string) string {
+	return "smidr-build-" + buildID
 }
 
 // BuildResult summarizes the build execution
 type BuildResult struct {
-	Success   bool
-	ExitCode  int
-	Duration  time.Duration
-	BuildDir  string
-	TmpDir    string
-	DeployDir string
+	Success     bool
+	ExitCode    int
+	Duration    time.Duration
+	BuildDir    string
+	TmpDir      string
+	DeployDir   string
+	Diagnostics []bitbake.Diagnostic     // failures recognised in the BitBake output
+	TaskLogs    []bitbake.TaskLog        // logs of the failed tasks, collected into BuildDir
+	Recovery    []bitbake.RecoveryAction // recovery steps taken, also for successful builds
+
+	// SHA-256 of the deploy files recorded in the database, by path relative to DeployDir
+	ArtifactChecksums map[string]string
+}
+
+// TestHooks replace parts of the build environment in integration and smoke tests.
+// The daemon never sets them, so its builds follow the config and daemon.yaml only.
+type TestHooks struct {
+	ContainerName string // fixed container name instead of one derived from the build ID
+	Image         string // container image, e.g. one built locally to avoid registry pulls
+	DownloadsDir  string
+	SStateDir     string
+	WorkspaceDir  string // used as directories.build
+	// SkipBitBake starts the container but does not run BitBake
+	SkipBitBake bool
+	// WriteMarkers writes simple signals to the log and the workspace for integration checks
+	WriteMarkers bool
 }
 
 // Runner executes the Yocto build pipeline
 type Runner struct {
 	logger *logger.Logger
 	db     *db.DB
+	hooks  TestHooks
 }
 
 // NewRunner creates a new build Runner
@@ -92,10 +135,20 @@ func NewRunner(logger *logger.Logger, database *db.DB) *Runner {
 	return &Runner{logger: logger, db: database}
 }
 
+// SetTestHooks overrides parts of the build environment for tests
+func (r *Runner) SetTestHooks(hooks TestHooks) {
+	r.hooks = hooks
+}
+
 // Run orchestrates directory setup, layer fetch, container start, bitbake execution, and cleanup
-func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions, log LogSink) (*BuildResult, error) {
+func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions, log LogSink) (br *BuildResult, runErr error) {
 	start := time.Now()
 
+	invocation, err := bitbake.ParseInvocation(opts.Target)
+	if err != nil {
+		return &BuildResult{Success: false}, fmt.Errorf("invalid build target: %w", err)
+	}
+
 	// Expand and prepare directories
 	expand := func(p string) string {
 		if p == "" {
@@ -116,19 +169,29 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 	// Basic defaults similar to CLI
 	// IMPORTANT: Use BuildID to ensure each build has isolated directories
 	if cfg.Directories.Build == "" {
-		h, _ := os.UserHomeDir()
+		buildsDir := expand(opts.BuildsDir)
+		if buildsDir == "" {
+			h, _ := os.UserHomeDir()
+			buildsDir = filepath.Join(h, ".smidr", "builds")
+		}
 		if opts.BuildID != "" {
 			// Use the unique build ID to prevent concurrent build collisions
-			cfg.Directories.Build = filepath.Join(h, ".smidr", "builds", opts.BuildID)
+			cfg.Directories.Build = filepath.Join(buildsDir, opts.BuildID)
 		} else if opts.Customer != "" {
 			// Fallback: use customer name + UUID for uniqueness
 			buildID := "build-" + opts.Customer + "-" + uuid.New().String()[:8]
-			cfg.Directories.Build = filepath.Join(h, ".smidr", "builds", buildID)
+			cfg.Directories.Build = filepath.Join(buildsDir, buildID)
 		} else {
 			buildID := "build-" + uuid.New().String()
-			cfg.Directories.Build = filepath.Join(h, ".smidr", "builds", buildID)
+			cfg.Directories.Build = filepath.Join(buildsDir, buildID)
 		}
 	}
+	if cfg.Directories.Downloads == "" {
+		cfg.Directories.Downloads = opts.DownloadsDir
+	}
+	if cfg.Directories.SState == "" {
+		cfg.Directories.SState = opts.SStateDir
+	}
 
 	cfg.Directories.Build = expand(cfg.Directories.Build)
 	if cfg.Directories.Tmp == "" {
@@ -138,14 +201,14 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 		cfg.Directories.Deploy = filepath.Join(cfg.Directories.Build, "deploy")
 	}
 
-	// Integration test overrides via environment variables
-	if v := os.Getenv("SMIDR_TEST_DOWNLOADS_DIR"); strings.TrimSpace(v) != "" {
+	// Integration test overrides
+	if v := r.hooks.DownloadsDir; strings.TrimSpace(v) != "" {
 		cfg.Directories.Downloads = v
 	}
-	if v := os.Getenv("SMIDR_TEST_SSTATE_DIR"); strings.TrimSpace(v) != "" {
+	if v := r.hooks.SStateDir; strings.TrimSpace(v) != "" {
 		cfg.Directories.SState = v
 	}
-	if v := os.Getenv("SMIDR_TEST_WORKSPACE_DIR"); strings.TrimSpace(v) != "" {
+	if v := r.hooks.WorkspaceDir; strings.TrimSpace(v) != "" {
 		// Map test workspace directory to Build to align mounts if needed
 		cfg.Directories.Build = v
 	}
@@ -174,6 +237,7 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 	}
 
 	// If DB persistence is enabled, create initial build record and mark as running
+	completionRecorded := false
 	if r.db != nil {
 		// Ensure a build ID exists
 		if opts.BuildID == "" {
@@ -222,6 +286,7 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 			User:           username,
 			Host:           hostname,
 			CreatedAt:      start,
+			RetriedFrom:    opts.RetriedFrom,
 		}
 
 		if err := r.db.CreateBuild(build); err != nil {
@@ -239,29 +304,86 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 				panic(rec)
 			}
 		}()
+
+		// Setup failures and cancellations return early; record a terminal status for them too
+		defer func() {
+			if completionRecorded {
+				return
+			}
+			status, errorMsg := db.StatusFailed, ""
+			if ctx.Err() != nil {
+				status, errorMsg = db.StatusCancelled, "build cancelled"
+			} else if runErr != nil {
+				errorMsg = runErr.Error()
+			}
+			if err := r.db.CompleteBuild(opts.BuildID, status, 1, time.Since(start), errorMsg); err != nil {
+				r.logger.Error("failed to update build completion", err)
+			}
+		}()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return &BuildResult{Success: false, Duration: time.Since(start)}, fmt.Errorf("build cancelled: %w", err)
 	}
 
+	// Structured progress for sinks that support it (e.g. the daemon's WatchBuild)
+	tracker := newProgressTracker(log)
+
 	// Fetch layers
+	tracker.enter(PhaseFetch)
 	log.Write("stdout", "Fetching layers...")
 	r.logger.Info("fetching layers", slog.String("layers_dir", cfg.Directories.Layers))
 	fetcher := source.NewFetcher(cfg.Directories.Layers, cfg.Directories.Downloads, r.logger)
-	if _, err := fetcher.FetchLayers(cfg); err != nil {
+	fetcher.SetCredentials(opts.GitCredentials)
+	fetched, err := fetcher.FetchLayers(ctx, cfg)
+	r.recordLayerFetches(opts.BuildID, fetched, log)
+	if err != nil {
+		// Fail before starting a container that could not find its layers anyway
 		r.logger.Error("failed to fetch layers", err)
 		return &BuildResult{Success: false, Duration: time.Since(start), BuildDir: cfg.Directories.Build, TmpDir: cfg.Directories.Tmp, DeployDir: cfg.Directories.Deploy}, err
 	}
+	// Pin the config to the commits actually checked out, so the snapshot reproduces this build
+	cfg.PinCommits(source.Commits(fetched))
+	r.recordLayerCommits(opts.BuildID, cfg, log)
+
+	// Check the layers out for this build only; the mirrors in the layers dir stay shared
+	workspace, err := fetcher.Checkout(fetched, filepath.Join(cfg.Directories.Build, "layers"))
+	if err != nil {
+		r.logger.Error("failed to check out layers", err)
+		return &BuildResult{Success: false, Duration: time.Since(start), BuildDir: cfg.Directories.Build, TmpDir: cfg.Directories.Tmp, DeployDir: cfg.Directories.Deploy}, err
+	}
+	defer func() {
+		if err := workspace.Release(); err != nil {
+			r.logger.Warn("failed to remove layer checkouts", slog.String("dir", workspace.Dir), slog.String("error", err.Error()))
+		}
+	}()
+
+	if err := ctx.Err(); err != nil {
+		return &BuildResult{Success: false, Duration: time.Since(start)}, fmt.Errorf("build cancelled: %w", err)
+	}
 
 	// Prepare container config and manager (mirror CLI behavior)
 	// Determine container image
 	imageToUse := cfg.Container.BaseImage
 	if strings.TrimSpace(imageToUse) == "" {
-		imageToUse = "crops/yocto:ubuntu-22.04-builder"
+		imageToUse = opts.DefaultImage
+	}
+	if strings.TrimSpace(imageToUse) == "" {
+		imageToUse = config.DefaultContainerImage
 	}
 	// Allow tests to override the image to avoid external pulls
-	if v := os.Getenv("SMIDR_TEST_IMAGE"); strings.TrimSpace(v) != "" {
+	if v := r.hooks.Image; strings.TrimSpace(v) != "" {
 		imageToUse = v
 	}
 
-	// Build layer mount list from cfg.Layers, mounting parent folder for sublayers
+	// Build layer mount list from cfg.Layers, mounting parent folder for sublayers.
+	// Git layers and sublayers of them come from the build's checkouts.
+	layerRoot := func(name string) string {
+		if path, ok := workspace.Path(name); ok {
+			return path
+		}
+		return filepath.Join(cfg.Directories.Layers, name)
+	}
 	var layerDirs []string
 	var layerNames []string
 	mountedParents := make(map[string]bool)
@@ -269,10 +391,12 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 		var layerPath string
 		if l.Path != "" {
 			if !strings.HasPrefix(l.Path, "/") && !strings.HasPrefix(l.Path, "~") {
-				layerPath = filepath.Join(cfg.Directories.Layers, l.Path)
+				layerPath = layerRoot(l.Path)
 			} else {
 				layerPath = l.Path
 			}
+		} else if path, ok := workspace.RepoPath(l.Git); ok {
+			layerPath = path
 		} else {
 			layerPath = filepath.Join(cfg.Directories.Layers, l.Name)
 		}
@@ -281,7 +405,7 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 		var mountName string
 		if l.Path != "" && strings.Contains(l.Path, "/") {
 			parent := strings.Split(l.Path, "/")[0]
-			mountPath = filepath.Join(cfg.Directories.Layers, parent)
+			mountPath = layerRoot(parent)
 			mountName = parent
 		} else {
 			mountPath = layerPath
@@ -320,16 +444,16 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 		// For customer builds (stable BuildID), keep a stable mount path /home/builder/build
 		// For ad-hoc (UUID) builds, still isolate by suffix to avoid BitBake server collisions
 		if strings.Contains(opts.BuildID, "-") && (strings.HasPrefix(opts.BuildID, "ci-") || strings.HasPrefix(opts.BuildID, "qa-") || strings.HasPrefix(opts.BuildID, "dev-") || strings.HasPrefix(opts.BuildID, "prod-")) {
-			containerName = "smidr-build-" + opts.BuildID
+			containerName = ContainerName(opts.BuildID)
 			// stable workspace path retained
 		} else {
-			containerName = "smidr-build-" + opts.BuildID
+			containerName = ContainerName(opts.BuildID)
 			containerWorkspace = "/home/builder/build-" + opts.BuildID
 		}
 	}
 
 	// Allow deterministic container name for tests
-	testName := os.Getenv("SMIDR_TEST_CONTAINER_NAME")
+	testName := r.hooks.ContainerName
 	containerCfg := smidrcontainer.ContainerConfig{
 		Image: imageToUse,
 		Name: func() string {
@@ -352,9 +476,16 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 		// Keep host tmp mounted if configured by user for auxiliary tooling,
 		// but it will NOT be used by BitBake unless explicitly set in local.conf.
 		TmpDir: cfg.Directories.Tmp,
+		// Labels let the daemon find and remove containers of builds it no longer tracks
+		Labels: map[string]string{
+			smidrcontainer.LabelManaged: "true",
+			smidrcontainer.LabelBuildID: opts.BuildID,
+		},
 	}
 
-	dm, err := docker.NewDockerManager(r.logger)
+	backendName := backend.Resolve(cfg.Container.Backend, opts.ContainerBackend)
+	r.logger.Info("using container backend", slog.String("backend", backendName))
+	dm, err := backend.New(backendName, r.logger)
 	if err != nil {
 		return &BuildResult{Success: false, Duration: time.Since(start)}, err
 	}
@@ -394,17 +525,17 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 	}
 
 	// Test-mode markers: write simple signals to stdout and filesystem to satisfy integration checks
-	if os.Getenv("SMIDR_TEST_WRITE_MARKERS") == "1" {
-		if ws := os.Getenv("SMIDR_TEST_WORKSPACE_DIR"); strings.TrimSpace(ws) != "" {
+	if r.hooks.WriteMarkers {
+		if ws := r.hooks.WorkspaceDir; strings.TrimSpace(ws) != "" {
 			_ = os.MkdirAll(ws, 0o755)
 			_ = os.WriteFile(filepath.Join(ws, "itest.txt"), []byte("ok"), 0o644)
 		}
-		if dl := os.Getenv("SMIDR_TEST_DOWNLOADS_DIR"); strings.TrimSpace(dl) != "" {
+		if dl := r.hooks.DownloadsDir; strings.TrimSpace(dl) != "" {
 			if fi, err := os.Stat(dl); err == nil && fi.IsDir() {
 				log.Write("stdout", "Downloads directory accessible")
 			}
 		}
-		if ss := os.Getenv("SMIDR_TEST_SSTATE_DIR"); strings.TrimSpace(ss) != "" {
+		if ss := r.hooks.SStateDir; strings.TrimSpace(ss) != "" {
 			if fi, err := os.Stat(ss); err == nil && fi.IsDir() {
 				log.Write("stdout", "Sstate directory accessible")
 			}
@@ -415,6 +546,8 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 	// Pass the container's workspace path (not host path) so BitBake runs in the right directory
 	executor := bitbake.NewBuildExecutor(cfg, dm, containerID, containerWorkspace, r.logger)
 	executor.SetForceImage(opts.ForceImage)
+	executor.SetSkipBitBake(r.hooks.SkipBitBake)
+	executor.SetInvocation(invocation)
 
 	// Set build prefix for log identification (e.g., "[customer/build-abc123]")
 	if opts.Customer != "" && opts.BuildID != "" {
@@ -454,7 +587,9 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 			}
 
 			// Check for task progress and log it separately for progress bar
-			if progress := parseTaskProgress(trimmed); progress != nil {
+			progress := parseTaskProgress(trimmed)
+			tracker.observe(trimmed, progress)
+			if progress != nil {
 				log.Write("stdout", trimmed) // Send to client
 				r.logger.Info("Build progress",
 					slog.Int("current", progress.Current),
@@ -481,6 +616,7 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 	bbLog := &bitbake.BuildLogWriter{
 		PlainWriter: plainWriter,
 		JSONLWriter: jsonlFile,
+		OnRecovery:  func(action bitbake.RecoveryAction) { r.recordRecovery(opts.BuildID, log, action) },
 	}
 
 	result, err := executor.ExecuteBuild(ctx, bbLog)
@@ -488,16 +624,35 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 	if result != nil {
 		exitCode = result.ExitCode
 	}
-	br := &BuildResult{Success: err == nil && result != nil && result.Success, ExitCode: exitCode, Duration: time.Since(start), BuildDir: cfg.Directories.Build, TmpDir: cfg.Directories.Tmp, DeployDir: cfg.Directories.Deploy}
+	br = &BuildResult{Success: err == nil && result != nil && result.Success, ExitCode: exitCode, Duration: time.Since(start), BuildDir: cfg.Directories.Build, TmpDir: cfg.Directories.Tmp, DeployDir: cfg.Directories.Deploy}
+	if result != nil && ctx.Err() == nil {
+		br.Diagnostics = result.Diagnostics
+		br.TaskLogs = result.TaskLogs
+	}
+	if result != nil {
+		br.Recovery = result.Recovery
+	}
+	for _, d := range br.Diagnostics {
+		log.Write("stderr", fmt.Sprintf("🩺 %s: %s", d.Summary(), d.Hint))
+	}
+	for _, tl := range br.TaskLogs {
+		log.Write("stderr", fmt.Sprintf("📄 Log of %s:%s collected to %s", tl.Recipe, tl.Task, tl.Path))
+	}
 
 	// If DB persistence is available, update completion status and record artifacts
 	if r.db != nil {
 		duration := time.Since(start)
 		var status db.BuildStatus
 		var errorMsg string
-		if err != nil {
+		if ctx.Err() != nil {
+			status = db.StatusCancelled
+			errorMsg = "build cancelled"
+		} else if err != nil {
 			status = db.StatusFailed
 			errorMsg = err.Error()
+			if len(br.Diagnostics) > 0 {
+				errorMsg += ": " + br.Diagnostics[0].Summary()
+			}
 		} else if result != nil && result.Success {
 			status = db.StatusCompleted
 		} else {
@@ -506,11 +661,25 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 				errorMsg = fmt.Sprintf("build failed with exit code %d", result.ExitCode)
 			}
 		}
+		if len(br.Diagnostics) > 0 {
+			if encoded, jerr := json.Marshal(br.Diagnostics); jerr == nil {
+				if derr := r.db.SetBuildDiagnostics(opts.BuildID, string(encoded)); derr != nil {
+					r.logger.Error("failed to store build diagnostics", derr)
+				}
+			}
+		}
+		for _, tl := range br.TaskLogs {
+			record := &db.TaskLog{BuildID: opts.BuildID, Recipe: tl.Recipe, Task: tl.Task, LogPath: tl.Path, SizeBytes: tl.SizeBytes, CreatedAt: time.Now()}
+			if terr := r.db.AddTaskLog(record); terr != nil {
+				r.logger.Error("failed to record task log", terr, slog.String("recipe", tl.Recipe), slog.String("task", tl.Task))
+			}
+		}
 		if cerr := r.db.CompleteBuild(opts.BuildID, status, exitCode, duration, errorMsg); cerr != nil {
 			r.logger.Error("failed to update build completion", cerr)
 		}
+		completionRecorded = true
 		if status == db.StatusCompleted && result != nil {
-			r.recordArtifacts(opts.BuildID, cfg.Directories.Deploy)
+			br.ArtifactChecksums = r.recordArtifacts(opts.BuildID, cfg.Directories.Deploy)
 		}
 	}
 
@@ -520,6 +689,86 @@ func (r *Runner) Run(ctx context.Context, cfg *config.Config, opts BuildOptions,
 	return br, nil
 }
 
+// recordRecovery reports a recovery step to the sink and stores it with the build, so that
+// builds which only passed after e.g. a cleansstate can be audited later
+func (r *Runner) recordRecovery(buildID string, log LogSink, action bitbake.RecoveryAction) {
+	log.Write("stderr", "🩹 Recovery: "+action.Summary())
+	if sink, ok := log.(RecoverySink); ok {
+		sink.Recovery(action)
+	}
+	if r.db == nil {
+		return
+	}
+	event := &db.RecoveryEvent{
+		BuildID:   buildID,
+		Strategy:  string(action.Strategy),
+		Recipe:    action.Recipe,
+		Attempt:   action.Attempt,
+		Succeeded: action.Succeeded,
+		Detail:    action.Detail,
+		StartedAt: action.StartedAt,
+		Duration:  action.Duration,
+	}
+	if err := r.db.AddRecoveryEvent(event); err != nil {
+		r.logger.Error("failed to record recovery event", err, slog.String("strategy", event.Strategy))
+	}
+}
+
+// recordLayerFetches reports how each layer repository was fetched to the sink and stores
+// the report with the build
+func (r *Runner) recordLayerFetches(buildID string, results []source.FetchResult, log LogSink) {
+	fetches := make([]source.LayerFetch, 0, len(results))
+	sink, _ := log.(LayerFetchSink)
+	for _, result := range results {
+		fetch := result.Report()
+		fetches = append(fetches, fetch)
+		if fetch.Status == source.FetchFailed {
+			log.Write("stderr", "❌ Layer "+fetch.Summary())
+		} else {
+			log.Write("stdout", "📥 Layer "+fetch.Summary())
+		}
+		if sink != nil {
+			sink.LayerFetched(fetch)
+		}
+	}
+	if r.db == nil || buildID == "" || len(fetches) == 0 {
+		return
+	}
+	encoded, err := json.Marshal(fetches)
+	if err != nil {
+		r.logger.Error("failed to encode layer fetches", err)
+		return
+	}
+	if err := r.db.SetLayerFetches(buildID, string(encoded)); err != nil {
+		r.logger.Error("failed to store layer fetches", err)
+	}
+}
+
+// recordLayerCommits logs the commit of every git layer and stores them, with the pinned
+// config snapshot, on the build record
+func (r *Runner) recordLayerCommits(buildID string, cfg *config.Config, log LogSink) {
+	layers := cfg.Lock().Layers
+	for _, layer := range layers {
+		log.Write("stdout", fmt.Sprintf("📌 %s at %s", layer.Name, layer.Commit))
+	}
+	if r.db == nil || buildID == "" {
+		return
+	}
+	commits, err := json.Marshal(layers)
+	if err != nil {
+		r.logger.Error("failed to encode layer commits", err)
+		return
+	}
+	snapshot, err := json.Marshal(cfg)
+	if err != nil {
+		r.logger.Error("failed to snapshot pinned config", err)
+		return
+	}
+	if err := r.db.SetLayerCommits(buildID, string(commits), string(snapshot)); err != nil {
+		r.logger.Error("failed to store layer commits", err)
+	}
+}
+
 type logWriterFunc func(p []byte) (n int, err error)
 
 func (f logWriterFunc) Write(p []byte) (n int, err error) { return f(p) }
@@ -529,9 +778,12 @@ func (f logWriterFunc) Write(p []byte) (n int, err error) { return f(p) }
 // Example output from BitBake: "NOTE: Running task 482 of 776 (virtual:native:/home/builder/layers/poky/meta/recipes-graphics/libepoxy/libepoxy_1.5.10.bb:do_populate_sysroot_setscene)"
 // This will be parsed to: TaskProgress{Current: 482, Total: 776, Task: "virtual:native:/.../libepoxy_1.5.10.bb:do_populate_sysroot_setscene"}
 type TaskProgress struct {
-	Current int
-	Total   int
-	Task    string // e.g., "do_populate_sysroot_setscene" or full task description
+	Current  int
+	Total    int
+	Task     string // e.g., "do_populate_sysroot_setscene" or full task description
+	Recipe   string // recipe name without version, e.g. "libepoxy"
+	TaskName string // task name, e.g. "do_populate_sysroot_setscene"
+	Setscene bool   // true for setscene tasks restored from sstate
 }
 
 // parseTaskProgress extracts task progress from BitBake output
@@ -541,33 +793,57 @@ func parseTaskProgress(line string) *TaskProgress {
 	// Match both forms:
 	//  - NOTE: Running task 123 of 456 (...)
 	//  - NOTE: Running setscene task 123 of 456 (...)
-	re := regexp.MustCompile(`^NOTE: Running (?:setscene )?task (\d+) of (\d+)(?:\s*\((.*)\))?`)
-	m := re.FindStringSubmatch(line)
+	m := taskProgressRe.FindStringSubmatch(line)
 	if m == nil {
 		return nil
 	}
 
-	cur, err1 := strconv.Atoi(m[1])
-	tot, err2 := strconv.Atoi(m[2])
+	cur, err1 := strconv.Atoi(m[2])
+	tot, err2 := strconv.Atoi(m[3])
 	if err1 != nil || err2 != nil {
 		return nil
 	}
 
-	tp := &TaskProgress{Current: cur, Total: tot}
-	if len(m) >= 4 {
-		tp.Task = m[3]
+	tp := &TaskProgress{Current: cur, Total: tot, Setscene: m[1] != ""}
+	if len(m) >= 5 {
+		tp.Task = m[4]
+		tp.Recipe, tp.TaskName = splitTaskDescription(m[4])
+		if strings.HasSuffix(tp.TaskName, "_setscene") {
+			tp.Setscene = true
+		}
 	}
 	return tp
 }
 
+var taskProgressRe = regexp.MustCompile(`^NOTE: Running (setscene )?task (\d+) of (\d+)(?:\s*\((.*)\))?`)
+
+// splitTaskDescription extracts recipe and task name from a BitBake task description
+// such as "virtual:native:/path/to/libepoxy_1.5.10.bb:do_compile"
+func splitTaskDescription(desc string) (string, string) {
+	idx := strings.LastIndex(desc, ":")
+	if idx < 0 {
+		return "", ""
+	}
+	recipeFile, task := desc[:idx], desc[idx+1:]
+
+	recipe := recipeFile[strings.LastIndexAny(recipeFile, "/:")+1:]
+	recipe = strings.TrimSuffix(recipe, ".bbappend")
+	recipe = strings.TrimSuffix(recipe, ".bb")
+	if name, _, ok := strings.Cut(recipe, "_"); ok {
+		recipe = name
+	}
+	return recipe, task
+}
+
+// problemLineRe matches BitBake error and warning records, also when quoted from a
+// task log ("| ERROR: ...")
+var problemLineRe = regexp.MustCompile(`^(?:\| )?(?:ERROR|WARNING|FATAL|CRITICAL|FAILED):`)
+
 // shouldLogLine determines if a BitBake log line should be forwarded to the daemon
 // Only logs errors, warnings, summaries, and key status updates to reduce noise
 func shouldLogLine(line string) bool {
-	// Always log errors and warnings
-	if strings.Contains(line, "ERROR") ||
-		strings.Contains(line, "FAILED") ||
-		strings.Contains(line, "WARNING") ||
-		strings.Contains(line, "WARN") {
+	// Always log errors and warnings, and failures the diagnostics recognise without a level
+	if problemLineRe.MatchString(line) || bitbake.IsDiagnosticLine(line) {
 		return true
 	}
 
@@ -615,12 +891,18 @@ func shouldLogLine(line string) bool {
 	return false
 }
 
-// recordArtifacts scans the deploy directory and records artifacts in the database
-func (r *Runner) recordArtifacts(buildID string, deployDir string) {
+// recordArtifacts scans the deploy directory and records artifacts in the database.
+// Checksums are computed here once so listing artifacts later never re-hashes images;
+// they are returned by path relative to deployDir for the artifact store to reuse.
+func (r *Runner) recordArtifacts(buildID string, deployDir string) map[string]string {
 	if r.db == nil || deployDir == "" {
-		return
+		return nil
 	}
 
+	// Yocto deploy dirs contain many symlinks to the same image; hash each target only once
+	checksums := make(map[string]string)
+	recorded := make(map[string]string)
+
 	// Walk deploy directory and record files
 	err := filepath.Walk(deployDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil || info.IsDir() {
@@ -630,26 +912,41 @@ func (r *Runner) recordArtifacts(buildID string, deployDir string) {
 		// Get relative path
 		relPath, _ := filepath.Rel(deployDir, path)
 
-		// Determine artifact type from extension
-		artifactType := "unknown"
-		ext := filepath.Ext(path)
-		switch ext {
-		case ".wic", ".img":
-			artifactType = "image"
-		case ".tar", ".gz", ".bz2", ".xz":
-			artifactType = "archive"
-		case ".txt", ".log":
-			artifactType = "text"
-		case ".json", ".xml":
-			artifactType = "metadata"
+		target := path
+		size := info.Size()
+		if info.Mode()&os.ModeSymlink != 0 {
+			resolved, err := filepath.EvalSymlinks(path)
+			if err != nil {
+				r.logger.Warn("skipping dangling artifact symlink", slog.String("path", relPath))
+				return nil
+			}
+			targetInfo, err := os.Stat(resolved)
+			if err != nil || targetInfo.IsDir() {
+				return nil
+			}
+			target = resolved
+			size = targetInfo.Size()
+		}
+
+		checksum, ok := checksums[target]
+		if !ok {
+			checksum, err = fileChecksum(target)
+			if err != nil {
+				r.logger.Warn("failed to checksum artifact", slog.String("path", relPath), slog.String("error", err.Error()))
+			}
+			checksums[target] = checksum
+		}
+		if checksum != "" {
+			recorded[relPath] = checksum
 		}
 
 		artifact := &db.BuildArtifact{
+			ArtifactID:   db.ArtifactID(buildID, relPath),
 			BuildID:      buildID,
 			ArtifactPath: relPath,
-			ArtifactType: artifactType,
-			SizeBytes:    info.Size(),
-			Checksum:     "",
+			ArtifactType: classifyArtifact(relPath),
+			SizeBytes:    size,
+			Checksum:     checksum,
 			CreatedAt:    time.Now(),
 		}
 
@@ -663,6 +960,49 @@ func (r *Runner) recordArtifacts(buildID string, deployDir string) {
 	if err != nil {
 		r.logger.Error("failed to scan artifacts", err)
 	}
+	return recorded
+}
+
+// classifyArtifact determines the artifact type from its file name.
+// Compressed images (e.g. "core-image.wic.bz2") are classified by their inner extension.
+func classifyArtifact(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	ext := filepath.Ext(name)
+	switch ext {
+	case ".gz", ".bz2", ".xz", ".zst":
+		inner := filepath.Ext(strings.TrimSuffix(name, ext))
+		if inner == ".wic" || inner == ".img" || inner == ".ext4" {
+			return "image"
+		}
+		return "archive"
+	}
+
+	switch ext {
+	case ".wic", ".img", ".ext4", ".sdimg":
+		return "image"
+	case ".tar", ".tgz", ".zip":
+		return "archive"
+	case ".txt", ".log":
+		return "text"
+	case ".json", ".xml", ".manifest":
+		return "metadata"
+	}
+	return "unknown"
+}
+
+// fileChecksum computes the hex-encoded SHA-256 of a file
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
 // generateBuildID creates a unique build identifier
@@ -2,13 +2,18 @@ package build
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/schererja/smidr/internal/bitbake"
 	"github.com/schererja/smidr/internal/config"
 	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/internal/source"
 	"github.com/schererja/smidr/pkg/logger"
 )
 
@@ -35,11 +40,7 @@ func TestRunnerWithDatabase(t *testing.T) {
 	}
 
 	// Create minimal config for a smoke test (will skip actual BitBake)
-	// Set test environment variables to trigger smoke mode
-	os.Setenv("SMIDR_TEST_WRITE_MARKERS", "1")
-	os.Setenv("SMIDR_TEST_ENTRYPOINT", "sh,-c,sleep 1")
-	defer os.Unsetenv("SMIDR_TEST_WRITE_MARKERS")
-	defer os.Unsetenv("SMIDR_TEST_ENTRYPOINT")
+	runner.SetTestHooks(TestHooks{SkipBitBake: true, WriteMarkers: true})
 
 	cfg := &config.Config{
 		Name:        "test-project",
@@ -65,6 +66,7 @@ func TestRunnerWithDatabase(t *testing.T) {
 		Layers: []config.Layer{
 			{Name: "poky", Git: "https://git.yoctoproject.org/poky", Branch: "scarthgap"},
 		},
+		Fetch: config.FetchConfig{MaxRetries: &noRetries},
 	}
 
 	// Create required directories
@@ -130,10 +132,7 @@ func TestRunnerWithoutDatabase(t *testing.T) {
 	}
 
 	// Test environment setup (smoke mode)
-	os.Setenv("SMIDR_TEST_WRITE_MARKERS", "1")
-	os.Setenv("SMIDR_TEST_ENTRYPOINT", "sh,-c,sleep 1")
-	defer os.Unsetenv("SMIDR_TEST_WRITE_MARKERS")
-	defer os.Unsetenv("SMIDR_TEST_ENTRYPOINT")
+	runner.SetTestHooks(TestHooks{SkipBitBake: true, WriteMarkers: true})
 
 	tmpDir := t.TempDir()
 	cfg := &config.Config{
@@ -160,6 +159,7 @@ func TestRunnerWithoutDatabase(t *testing.T) {
 		Layers: []config.Layer{
 			{Name: "poky", Git: "https://git.yoctoproject.org/poky", Branch: "scarthgap"},
 		},
+		Fetch: config.FetchConfig{MaxRetries: &noRetries},
 	}
 
 	os.MkdirAll(cfg.Directories.Layers, 0755)
@@ -183,6 +183,195 @@ func TestRunnerWithoutDatabase(t *testing.T) {
 	t.Log("Runner completed without database (expected behavior)")
 }
 
+// TestRunnerCancelledBeforeStart verifies that a cancelled build is persisted as cancelled, not failed
+func TestRunnerCancelledBeforeStart(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	defer database.Close()
+
+	runner := NewRunner(logger.NewLogger(), database)
+
+	cfg := &config.Config{
+		Name: "test-project",
+		Base: config.BaseConfig{Machine: "qemux86-64"},
+		Directories: config.DirectoryConfig{
+			Build:     filepath.Join(tmpDir, "build"),
+			Layers:    filepath.Join(tmpDir, "layers"),
+			Downloads: filepath.Join(tmpDir, "downloads"),
+			SState:    filepath.Join(tmpDir, "sstate"),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	opts := BuildOptions{BuildID: "test-build-cancelled", Target: "core-image-minimal", Customer: "test-customer"}
+	if _, err := runner.Run(ctx, cfg, opts, &mockLogSink{}); err == nil {
+		t.Fatal("expected error from cancelled build")
+	}
+
+	build, err := database.GetBuild(opts.BuildID)
+	if err != nil {
+		t.Fatalf("failed to get build record: %v", err)
+	}
+	if build.Status != db.StatusCancelled {
+		t.Errorf("expected status %s, got %s", db.StatusCancelled, build.Status)
+	}
+}
+
+// TestRunnerRejectsInvalidTarget verifies that targets are validated before anything runs
+func TestRunnerRejectsInvalidTarget(t *testing.T) {
+	runner := NewRunner(logger.NewLogger(), nil)
+	cfg := &config.Config{Name: "test-project", Directories: config.DirectoryConfig{Build: t.TempDir()}}
+	opts := BuildOptions{BuildID: "test-build-invalid", Target: "core-image-minimal; reboot"}
+	if _, err := runner.Run(context.Background(), cfg, opts, &mockLogSink{}); err == nil || !strings.Contains(err.Error(), "invalid build target") {
+		t.Fatalf("expected an invalid target error, got %v", err)
+	}
+}
+
+// recordingRecoverySink records the recovery steps reported by the runner
+type recordingRecoverySink struct {
+	mockLogSink
+	actions []bitbake.RecoveryAction
+}
+
+func (r *recordingRecoverySink) Recovery(action bitbake.RecoveryAction) {
+	r.actions = append(r.actions, action)
+}
+
+// TestRunnerRecordsRecovery verifies that recovery steps reach the sink and the database
+func TestRunnerRecordsRecovery(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	defer database.Close()
+
+	build := &db.Build{ID: "healed", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: db.StatusRunning, CreatedAt: time.Now()}
+	if err := database.CreateBuild(build); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+
+	runner := NewRunner(logger.NewLogger(), database)
+	sink := &recordingRecoverySink{}
+	runner.recordRecovery("healed", sink, bitbake.RecoveryAction{Strategy: bitbake.RecoveryCleansstate, Recipe: "zlib", Attempt: 1, Succeeded: true, StartedAt: time.Now()})
+
+	if len(sink.actions) != 1 || len(sink.lines) != 1 {
+		t.Errorf("expected the action to be reported and logged, got %+v and %v", sink.actions, sink.lines)
+	}
+	events, err := database.ListRecoveryEvents("healed")
+	if err != nil || len(events) != 1 || events[0].Strategy != "cleansstate" || events[0].Recipe != "zlib" {
+		t.Errorf("expected the action to be stored, got %+v (%v)", events, err)
+	}
+}
+
+func TestRunnerRecordsLayerCommits(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	defer database.Close()
+
+	build := &db.Build{ID: "pinned", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: db.StatusRunning, ConfigSnapshot: "{}", CreatedAt: time.Now()}
+	if err := database.CreateBuild(build); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	cfg := &config.Config{
+		YoctoSeries: "kirkstone",
+		Layers: []config.Layer{
+			{Name: "poky", Git: "https://git.yoctoproject.org/poky"},
+			{Name: "meta-local", Path: "/srv/meta-local"},
+		},
+	}
+	cfg.PinCommits(map[string]string{"https://git.yoctoproject.org/poky": commit})
+
+	runner := NewRunner(logger.NewLogger(), database)
+	sink := &mockLogSink{}
+	runner.recordLayerCommits("pinned", cfg, sink)
+
+	if len(sink.lines) != 1 || !strings.Contains(sink.lines[0], commit) {
+		t.Errorf("expected the commit to be logged, got %v", sink.lines)
+	}
+	record, err := database.GetBuild("pinned")
+	if err != nil {
+		t.Fatalf("failed to get build: %v", err)
+	}
+	if !strings.Contains(record.LayerCommits, commit) || !strings.Contains(record.LayerCommits, "kirkstone") {
+		t.Errorf("expected the layer commits to be stored, got %q", record.LayerCommits)
+	}
+	// The snapshot reproduces the build: loading it checks out the same commit
+	var pinned config.Config
+	if err := json.Unmarshal([]byte(record.ConfigSnapshot), &pinned); err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+	if pinned.Layers[0].Commit != commit {
+		t.Errorf("expected the snapshot to pin poky, got %+v", pinned.Layers)
+	}
+}
+
+// noRetries keeps tests that cannot reach a layer from waiting for fetch retries
+var noRetries = 0
+
+// recordingFetchSink records the layer fetches reported by the runner
+type recordingFetchSink struct {
+	mockLogSink
+	fetches []source.LayerFetch
+}
+
+func (r *recordingFetchSink) LayerFetched(fetch source.LayerFetch) {
+	r.fetches = append(r.fetches, fetch)
+}
+
+// TestRunnerFailsOnFetchError verifies that a layer that cannot be fetched fails the build
+// before a container is started, and that the fetch outcome is reported and stored
+func TestRunnerFailsOnFetchError(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := db.Open(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	defer database.Close()
+
+	cfg := &config.Config{
+		Name: "test-project",
+		Directories: config.DirectoryConfig{
+			Build:     filepath.Join(tmpDir, "build"),
+			Layers:    filepath.Join(tmpDir, "layers"),
+			Downloads: filepath.Join(tmpDir, "downloads"),
+			SState:    filepath.Join(tmpDir, "sstate"),
+		},
+		Layers: []config.Layer{{Name: "meta-missing", Git: filepath.Join(tmpDir, "missing.git"), Branch: "main"}},
+		Fetch:  config.FetchConfig{MaxRetries: &noRetries},
+	}
+	runner := NewRunner(logger.NewLogger(), database)
+	sink := &recordingFetchSink{}
+	opts := BuildOptions{BuildID: "test-build-fetch", Target: "core-image-minimal", Customer: "test-customer"}
+	_, err = runner.Run(context.Background(), cfg, opts, sink)
+
+	var fetchErr *source.FetchError
+	if !errors.As(err, &fetchErr) || fetchErr.Failures[0].LayerName != "meta-missing" {
+		t.Fatalf("expected a fetch error for meta-missing, got %v", err)
+	}
+	if len(sink.fetches) != 1 || sink.fetches[0].Status != source.FetchFailed || sink.fetches[0].Error == "" {
+		t.Errorf("expected the failed fetch to be reported, got %+v", sink.fetches)
+	}
+	build, err := database.GetBuild(opts.BuildID)
+	if err != nil {
+		t.Fatalf("failed to get build record: %v", err)
+	}
+	if build.Status != db.StatusFailed || !strings.Contains(build.ErrorMessage, "failed to fetch 1 layer") {
+		t.Errorf("expected the build to fail with the fetch error, got %s: %q", build.Status, build.ErrorMessage)
+	}
+	if !strings.Contains(build.LayerFetches, `"status":"failed"`) {
+		t.Errorf("expected the layer fetches to be stored, got %q", build.LayerFetches)
+	}
+}
+
 // mockLogSink implements LogSink for testing
 type mockLogSink struct {
 	lines []string
@@ -0,0 +1,321 @@
+package buildlog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultBufferLines is the default number of log entries kept in memory per build
+	DefaultBufferLines = 10000
+
+	// spillReadBatch bounds how many entries a lagging reader loads from disk at once
+	spillReadBatch = 4096
+
+	// maxMessageSize bounds a message so its JSONL record, even with every byte escaped,
+	// stays below maxLineSize; longer messages are truncated
+	maxMessageSize = maxLineSize / 8
+)
+
+// Buffer holds the log of a running build. The most recent entries are kept in a
+// fixed-size ring in memory; every entry is also written through to a JSONL spill
+// file so readers that fall behind the ring continue from disk without gaps.
+// Entries are numbered with consecutive sequence numbers starting at 1, or after the last
+// entry of an existing spill file.
+type Buffer struct {
+	mu        sync.Mutex
+	ring      []Entry
+	start     int // index of the oldest entry in ring
+	count     int // number of entries in ring
+	lastSeq   uint64
+	spill     *os.File
+	spillPath string
+	spillErr  error
+	closed    bool
+	changed   chan struct{} // closed and replaced whenever entries are added or the buffer closes
+}
+
+// NewBuffer creates a buffer keeping capacity entries in memory. If spillPath is not empty,
+// all entries are also appended to that file; without it entries older than the ring are lost.
+// An existing spill file, e.g. of a build resumed after a restart, is kept: its entries stay
+// readable and numbering continues after them.
+func NewBuffer(capacity int, spillPath string) (*Buffer, error) {
+	if capacity <= 0 {
+		capacity = DefaultBufferLines
+	}
+	b := &Buffer{
+		ring:    make([]Entry, capacity),
+		changed: make(chan struct{}),
+	}
+	if spillPath != "" {
+		if err := os.MkdirAll(filepath.Dir(spillPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+		f, err := os.OpenFile(spillPath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create log spill file: %w", err)
+		}
+		lastSeq, err := lastSpillSeq(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		b.lastSeq = lastSeq
+		b.spill = f
+		b.spillPath = spillPath
+	}
+	return b, nil
+}
+
+// lastSpillSeq returns the sequence number of the last entry in a spill file opened for
+// appending. A record cut short by a crash is terminated so new entries start on a line of
+// their own.
+func lastSpillSeq(f *os.File) (uint64, error) {
+	var lastSeq uint64
+	reader := bufio.NewReader(f)
+	var last byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			last = line[len(line)-1]
+			var rec struct {
+				Seq uint64 `json:"seq"`
+			}
+			if json.Unmarshal(line, &rec) == nil && rec.Seq > lastSeq {
+				lastSeq = rec.Seq
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read log spill file: %w", err)
+		}
+	}
+	if last != 0 && last != '\n' {
+		if _, err := f.Write([]byte{'\n'}); err != nil {
+			return 0, fmt.Errorf("failed to repair log spill file: %w", err)
+		}
+	}
+	return lastSeq, nil
+}
+
+// SpillPath returns the path of the on-disk log, or "" if the buffer is memory-only
+func (b *Buffer) SpillPath() string {
+	return b.spillPath
+}
+
+// Append adds a log line and returns the stored entry with its sequence number
+func (b *Buffer) Append(stream, message string) Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastSeq++
+	entry := Entry{
+		Seq:       b.lastSeq,
+		Timestamp: time.Now(),
+		Stream:    stream,
+		Level:     LevelOf(message),
+		Message:   truncateMessage(message),
+	}
+
+	if b.spill != nil && b.spillErr == nil {
+		line, _ := json.Marshal(recordFromEntry(entry))
+		if _, err := b.spill.Write(append(line, '\n')); err != nil {
+			// Keep serving from memory; readers behind the ring will skip ahead
+			b.spillErr = err
+		}
+	}
+
+	capacity := len(b.ring)
+	if b.count < capacity {
+		b.ring[(b.start+b.count)%capacity] = entry
+		b.count++
+	} else {
+		b.ring[b.start] = entry
+		b.start = (b.start + 1) % capacity
+	}
+
+	close(b.changed)
+	b.changed = make(chan struct{})
+	return entry
+}
+
+// truncateMessage cuts a message longer than maxMessageSize at a character boundary
+func truncateMessage(message string) string {
+	if len(message) <= maxMessageSize {
+		return message
+	}
+	n := maxMessageSize
+	for n > 0 && !utf8.RuneStart(message[n]) {
+		n--
+	}
+	return message[:n] + " [truncated]"
+}
+
+// Close marks the log as complete. Readers receive io.EOF once they have read every entry.
+func (b *Buffer) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	close(b.changed)
+
+	if b.spill != nil {
+		return b.spill.Close()
+	}
+	return nil
+}
+
+// LastSeq returns the sequence number of the most recent entry (0 if empty)
+func (b *Buffer) LastSeq() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastSeq
+}
+
+// Err returns the first error writing the spill file, if any
+func (b *Buffer) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spillErr
+}
+
+// NewReader returns a reader that yields entries with a sequence number greater than afterSeq
+func (b *Buffer) NewReader(afterSeq uint64) *Reader {
+	return &Reader{buf: b, cursor: afterSeq}
+}
+
+// Reader iterates over a Buffer in sequence order. Every reader tracks its own position,
+// so a slow reader never causes entries to be dropped for itself or others.
+type Reader struct {
+	buf         *Buffer
+	cursor      uint64 // sequence number of the last entry returned
+	pending     []Entry
+	spillOffset int64 // spill file offset after the last record read; later records start there
+}
+
+// Next returns the next entry, waiting for one to be appended if necessary.
+// It returns io.EOF when the buffer is closed and all entries have been read.
+func (r *Reader) Next(ctx context.Context) (Entry, error) {
+	for {
+		if len(r.pending) > 0 {
+			entry := r.pending[0]
+			r.pending = r.pending[1:]
+			r.cursor = entry.Seq
+			return entry, nil
+		}
+
+		changed, done, err := r.fill()
+		if err != nil {
+			return Entry{}, err
+		}
+		if len(r.pending) > 0 {
+			continue
+		}
+		if done {
+			return Entry{}, io.EOF
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return Entry{}, ctx.Err()
+		}
+	}
+}
+
+// fill loads entries after the cursor into pending, from memory or from the spill file
+func (r *Reader) fill() (<-chan struct{}, bool, error) {
+	b := r.buf
+	b.mu.Lock()
+	changed, closed, lastSeq := b.changed, b.closed, b.lastSeq
+	oldestSeq := lastSeq - uint64(b.count) + 1
+	spillPath := b.spillPath
+	if b.spillErr != nil {
+		spillPath = ""
+	}
+
+	if r.cursor >= lastSeq {
+		b.mu.Unlock()
+		return changed, closed, nil
+	}
+
+	if r.cursor+1 >= oldestSeq {
+		capacity := len(b.ring)
+		for seq := r.cursor + 1; seq <= lastSeq; seq++ {
+			idx := (b.start + int(seq-oldestSeq)) % capacity
+			r.pending = append(r.pending, b.ring[idx])
+		}
+		b.mu.Unlock()
+		return changed, false, nil
+	}
+	b.mu.Unlock()
+
+	// The reader is behind the in-memory ring
+	if spillPath != "" {
+		entries, offset, err := readSpill(spillPath, r.spillOffset, r.cursor, oldestSeq)
+		if err != nil {
+			return nil, false, err
+		}
+		r.spillOffset = offset
+		if len(entries) > 0 {
+			r.pending = entries
+			return changed, false, nil
+		}
+	}
+
+	// Nothing older is available; continue with the oldest entry still in memory
+	r.cursor = oldestSeq - 1
+	return r.fill()
+}
+
+// readSpill reads entries with afterSeq < seq < beforeSeq from a spill file, starting at
+// offset, and returns the offset after the last record it consumed. Sequence numbers only
+// grow through the file, so a reader continues from there instead of rescanning it.
+func readSpill(path string, offset int64, afterSeq, beforeSeq uint64) ([]Entry, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, offset, fmt.Errorf("failed to open log spill file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, fmt.Errorf("failed to read log spill file: %w", err)
+	}
+
+	var entries []Entry
+	reader := bufio.NewReader(f)
+	for len(entries) < spillReadBatch {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Nothing more, or a record still being written
+			break
+		}
+		if err != nil {
+			return nil, offset, fmt.Errorf("failed to read log spill file: %w", err)
+		}
+		var rec jsonlRecord
+		if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err == nil {
+			if rec.Seq >= beforeSeq {
+				break
+			}
+			if rec.Seq > afterSeq {
+				entries = append(entries, rec.entry())
+			}
+		}
+		offset += int64(len(line))
+	}
+	return entries, offset, nil
+}
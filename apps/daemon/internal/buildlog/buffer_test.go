@@ -0,0 +1,217 @@
+package buildlog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readAll(t *testing.T, r *Reader) []Entry {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var entries []Entry
+	for {
+		e, err := r.Next(ctx)
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+func assertSequential(t *testing.T, entries []Entry, from uint64) {
+	t.Helper()
+	for i, e := range entries {
+		if e.Seq != from+uint64(i) {
+			t.Fatalf("entry %d: expected seq %d, got %d", i, from+uint64(i), e.Seq)
+		}
+		if e.Message != fmt.Sprintf("line %d", e.Seq) {
+			t.Fatalf("entry %d: unexpected message %q", i, e.Message)
+		}
+	}
+}
+
+func TestBuffer_ReadsBeyondRingFromSpill(t *testing.T) {
+	b, err := NewBuffer(10, filepath.Join(t.TempDir(), "build.jsonl"))
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	for i := 1; i <= 100; i++ {
+		b.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	b.Close()
+
+	entries := readAll(t, b.NewReader(0))
+	if len(entries) != 100 {
+		t.Fatalf("expected 100 entries, got %d", len(entries))
+	}
+	assertSequential(t, entries, 1)
+}
+
+func TestBuffer_ResumeAfterSequence(t *testing.T) {
+	b, err := NewBuffer(10, filepath.Join(t.TempDir(), "build.jsonl"))
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	for i := 1; i <= 50; i++ {
+		b.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	b.Close()
+
+	entries := readAll(t, b.NewReader(37))
+	if len(entries) != 13 {
+		t.Fatalf("expected 13 entries, got %d", len(entries))
+	}
+	assertSequential(t, entries, 38)
+}
+
+func TestBuffer_ReopenContinuesSpill(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "build.jsonl")
+	b, err := NewBuffer(10, path)
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	for i := 1; i <= 30; i++ {
+		b.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	b.Close()
+	// A crash may leave the last record unterminated
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"seq":31,"message":"cut sh`)
+	f.Close()
+
+	// Reopening, e.g. for a resumed build, keeps the earlier log and its numbering
+	b, err = NewBuffer(10, path)
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	if b.LastSeq() != 30 {
+		t.Fatalf("expected numbering to continue after 30, got last seq %d", b.LastSeq())
+	}
+	for i := 31; i <= 40; i++ {
+		b.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	b.Close()
+
+	entries := readAll(t, b.NewReader(0))
+	if len(entries) != 40 {
+		t.Fatalf("expected 40 entries, got %d", len(entries))
+	}
+	assertSequential(t, entries, 1)
+
+	// A cursor saved before the restart resumes where it was
+	entries = readAll(t, b.NewReader(25))
+	if len(entries) != 15 {
+		t.Fatalf("expected 15 entries, got %d", len(entries))
+	}
+	assertSequential(t, entries, 26)
+}
+
+func TestBuffer_ReadsLargeSpillInBatches(t *testing.T) {
+	b, err := NewBuffer(10, filepath.Join(t.TempDir(), "build.jsonl"))
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	total := 3*spillReadBatch + 17
+	for i := 1; i <= total; i++ {
+		b.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	b.Close()
+
+	r := b.NewReader(0)
+	entries := readAll(t, r)
+	if len(entries) != total {
+		t.Fatalf("expected %d entries, got %d", total, len(entries))
+	}
+	assertSequential(t, entries, 1)
+	if r.spillOffset == 0 {
+		t.Error("expected the reader to continue from its spill offset")
+	}
+}
+
+func TestBuffer_TruncatesLongLines(t *testing.T) {
+	b, err := NewBuffer(2, filepath.Join(t.TempDir(), "build.jsonl"))
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	// Control characters are escaped to six bytes each in JSON
+	long := strings.Repeat("\x01", 2*maxLineSize)
+	b.Append("stdout", long)
+	for i := 2; i <= 5; i++ {
+		b.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	b.Close()
+
+	entries := readAll(t, b.NewReader(0))
+	if len(entries) != 5 {
+		t.Fatalf("expected every entry to be readable, got %d", len(entries))
+	}
+	if !strings.HasSuffix(entries[0].Message, "[truncated]") || len(entries[0].Message) > maxMessageSize+len(" [truncated]") {
+		t.Errorf("expected the long line to be truncated, got %d bytes", len(entries[0].Message))
+	}
+	assertSequential(t, entries[1:], 2)
+}
+
+func TestBuffer_MemoryOnlySkipsToOldest(t *testing.T) {
+	b, err := NewBuffer(5, "")
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	for i := 1; i <= 20; i++ {
+		b.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	b.Close()
+
+	entries := readAll(t, b.NewReader(0))
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(entries))
+	}
+	assertSequential(t, entries, 16)
+}
+
+func TestBuffer_FollowWhileWriting(t *testing.T) {
+	b, err := NewBuffer(8, filepath.Join(t.TempDir(), "build.jsonl"))
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+
+	go func() {
+		for i := 1; i <= 500; i++ {
+			b.Append("stdout", fmt.Sprintf("line %d", i))
+		}
+		b.Close()
+	}()
+
+	// A reader that is slower than the writer must still see every line exactly once
+	entries := readAll(t, b.NewReader(0))
+	if len(entries) != 500 {
+		t.Fatalf("expected 500 entries, got %d", len(entries))
+	}
+	assertSequential(t, entries, 1)
+}
+
+func TestBuffer_NextRespectsContext(t *testing.T) {
+	b, err := NewBuffer(4, "")
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := b.NewReader(0).Next(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package buildlog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleLog = `{"timestamp":"2025-01-01T10:00:00Z","stream":"stdout","message":"NOTE: Running task 1 of 3"}
+{"timestamp":"2025-01-01T10:00:01Z","stream":"stdout","message":"WARNING: busybox: QA issue"}
+not json
+{"timestamp":"2025-01-01T10:00:02Z","stream":"stderr","message":"ERROR: Task do_compile failed"}
+{"timestamp":"2025-01-01T10:00:03Z","stream":"stdout","message":"NOTE: Running task 3 of 3"}
+`
+
+func replayAll(t *testing.T, filter Filter) []Entry {
+	t.Helper()
+	var got []Entry
+	err := Replay(strings.NewReader(sampleLog), filter, func(e Entry) error {
+		got = append(got, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	return got
+}
+
+func TestReplay_NoFilter(t *testing.T) {
+	got := replayAll(t, Filter{})
+	if len(got) != 4 {
+		t.Fatalf("expected 4 entries (malformed line skipped), got %d", len(got))
+	}
+	if got[2].Level != LevelError || got[1].Level != LevelWarn || got[0].Level != LevelInfo {
+		t.Errorf("unexpected levels: %s %s %s", got[0].Level, got[1].Level, got[2].Level)
+	}
+	if !got[0].Timestamp.Equal(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected timestamp %v", got[0].Timestamp)
+	}
+}
+
+func TestReplay_Filters(t *testing.T) {
+	if got := replayAll(t, Filter{Stream: "stderr"}); len(got) != 1 {
+		t.Errorf("stream filter: expected 1 entry, got %d", len(got))
+	}
+	if got := replayAll(t, Filter{Level: "warn"}); len(got) != 2 {
+		t.Errorf("level filter: expected 2 entries, got %d", len(got))
+	}
+
+	since := time.Date(2025, 1, 1, 10, 0, 1, 0, time.UTC)
+	until := time.Date(2025, 1, 1, 10, 0, 3, 0, time.UTC)
+	if got := replayAll(t, Filter{Since: since, Until: until}); len(got) != 2 {
+		t.Errorf("time filter: expected 2 entries, got %d", len(got))
+	}
+}
+
+func TestReplay_Tail(t *testing.T) {
+	got := replayAll(t, Filter{Tail: 2})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(got))
+	}
+	if !strings.Contains(got[0].Message, "do_compile") || !strings.Contains(got[1].Message, "task 3 of 3") {
+		t.Errorf("tail returned wrong entries or order: %q, %q", got[0].Message, got[1].Message)
+	}
+
+	// Tail applies after the other filters
+	got = replayAll(t, Filter{Stream: "stdout", Tail: 1})
+	if len(got) != 1 || !strings.Contains(got[0].Message, "task 3 of 3") {
+		t.Errorf("unexpected tail with stream filter: %+v", got)
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	entries := []Entry{
+		{Stream: "stdout", Message: "a"},
+		{Stream: "stdout", Message: "ERROR: b"},
+		{Stream: "stdout", Message: "ERROR: c"},
+	}
+	got := Filter{Level: LevelError, Tail: 1}.Apply(entries)
+	if len(got) != 1 || got[0].Message != "ERROR: c" {
+		t.Errorf("unexpected result: %+v", got)
+	}
+}
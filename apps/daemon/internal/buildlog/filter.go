@@ -0,0 +1,100 @@
+package buildlog
+
+import (
+	"strings"
+	"time"
+)
+
+// Log levels in increasing order of severity
+const (
+	LevelDebug = "DEBUG"
+	LevelInfo  = "INFO"
+	LevelWarn  = "WARN"
+	LevelError = "ERROR"
+)
+
+// Entry is a single build log line
+type Entry struct {
+	Seq       uint64 // position in the build log, starting at 1 (0 if unknown)
+	Timestamp time.Time
+	Stream    string // "stdout" or "stderr"
+	Level     string
+	Message   string
+}
+
+// LevelOf classifies a BitBake/daemon output line by its content
+func LevelOf(message string) string {
+	switch {
+	case strings.Contains(message, "ERROR") || strings.Contains(message, "FAILED"):
+		return LevelError
+	case strings.Contains(message, "WARNING") || strings.Contains(message, "WARN"):
+		return LevelWarn
+	default:
+		return LevelInfo
+	}
+}
+
+// severity maps a level name to a comparable rank; unknown levels rank as INFO
+func severity(level string) int {
+	switch strings.ToUpper(level) {
+	case LevelDebug:
+		return 0
+	case LevelWarn, "WARNING":
+		return 2
+	case LevelError:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// Filter selects log entries. Zero values match everything.
+type Filter struct {
+	Stream string    // only entries from this stream
+	Level  string    // minimum level (e.g. "WARN" matches WARN and ERROR)
+	Since  time.Time // entries at or after this time
+	Until  time.Time // entries before this time
+	Tail   int       // only the last N matching entries (applies to history, not live lines)
+
+	AfterSeq uint64 // only entries with a greater sequence number
+}
+
+// Match reports whether an entry passes the sequence, stream, level and time filters
+func (f Filter) Match(e Entry) bool {
+	if f.AfterSeq > 0 && e.Seq <= f.AfterSeq {
+		return false
+	}
+	if f.Stream != "" && !strings.EqualFold(f.Stream, e.Stream) {
+		return false
+	}
+	if f.Level != "" {
+		level := e.Level
+		if level == "" {
+			level = LevelOf(e.Message)
+		}
+		if severity(level) < severity(f.Level) {
+			return false
+		}
+	}
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.Timestamp.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the entries matching the filter, limited to the last Tail entries if set
+func (f Filter) Apply(entries []Entry) []Entry {
+	var matched []Entry
+	for _, e := range entries {
+		if f.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	if f.Tail > 0 && len(matched) > f.Tail {
+		matched = matched[len(matched)-f.Tail:]
+	}
+	return matched
+}
@@ -0,0 +1,110 @@
+package buildlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// maxLineSize bounds a single JSONL record; BitBake occasionally prints very long lines
+const maxLineSize = 1 << 20
+
+// jsonlRecord is the on-disk format written by bitbake.BuildLogWriter and Buffer.
+// Only Buffer spill files carry sequence numbers.
+type jsonlRecord struct {
+	Seq       uint64 `json:"seq,omitempty"`
+	Timestamp string `json:"timestamp"`
+	Stream    string `json:"stream"`
+	Level     string `json:"level,omitempty"`
+	Message   string `json:"message"`
+}
+
+func recordFromEntry(e Entry) jsonlRecord {
+	return jsonlRecord{
+		Seq:       e.Seq,
+		Timestamp: e.Timestamp.Format(time.RFC3339Nano),
+		Stream:    e.Stream,
+		Level:     e.Level,
+		Message:   e.Message,
+	}
+}
+
+func (rec jsonlRecord) entry() Entry {
+	entry := Entry{
+		Seq:     rec.Seq,
+		Stream:  rec.Stream,
+		Level:   rec.Level,
+		Message: rec.Message,
+	}
+	if entry.Level == "" {
+		entry.Level = LevelOf(rec.Message)
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, rec.Timestamp); err == nil {
+		entry.Timestamp = ts
+	}
+	return entry
+}
+
+// Replay reads a JSONL build log and calls fn for each entry matching the filter, in file order.
+// With filter.Tail set only the last N matching entries are delivered. Malformed lines are skipped.
+func Replay(r io.Reader, filter Filter, fn func(Entry) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	// Ring of the last Tail matches; only used when tailing
+	var ring []Entry
+	next := 0
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var rec jsonlRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			continue
+		}
+		entry := rec.entry()
+		if !filter.Match(entry) {
+			continue
+		}
+
+		if filter.Tail <= 0 {
+			if err := fn(entry); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(ring) < filter.Tail {
+			ring = append(ring, entry)
+		} else {
+			ring[next] = entry
+			next = (next + 1) % filter.Tail
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read build log: %w", err)
+	}
+
+	for i := range ring {
+		if err := fn(ring[(next+i)%len(ring)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayFile replays the JSONL build log at path, see Replay
+func ReplayFile(path string, filter Filter, fn func(Entry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open build log: %w", err)
+	}
+	defer f.Close()
+
+	return Replay(f, filter, fn)
+}
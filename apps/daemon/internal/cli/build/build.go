@@ -17,9 +17,11 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/schererja/smidr/internal/artifacts"
+	"github.com/schererja/smidr/internal/bitbake"
 	buildpkg "github.com/schererja/smidr/internal/build"
 	config "github.com/schererja/smidr/internal/config"
-	docker "github.com/schererja/smidr/internal/container/docker"
+	smidrcontainer "github.com/schererja/smidr/internal/container"
+	"github.com/schererja/smidr/internal/container/backend"
 	"github.com/schererja/smidr/pkg/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -46,6 +48,9 @@ func New() *cobra.Command {
 		smidr build --target core-image-minimal --force
 		smidr build --target core-image-minimal --fetch-only
 		smidr build --target core-image-minimal --clean
+		smidr build --target core-image-minimal --target meta-toolchain
+		smidr build --target core-image-minimal --task populate_sdk
+		smidr build --target busybox --task compile --force-task
 	`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := runBuild(cmd); err != nil {
@@ -57,11 +62,14 @@ func New() *cobra.Command {
 
 	// Build-specific flags
 	buildCmd.Flags().BoolP("force", "f", false, "Force rebuild (ignore cache)")
-	buildCmd.Flags().StringP("target", "t", "", "Override build target")
+	buildCmd.Flags().StringSliceP("target", "t", nil, "Override build target; repeat or separate with commas for several targets")
+	buildCmd.Flags().String("task", "", "Run only this BitBake task of the targets (bitbake -c), e.g. populate_sdk")
+	buildCmd.Flags().Bool("force-task", false, "Run the task even if it is up to date (bitbake -f)")
 	buildCmd.Flags().Bool("fetch-only", false, "Only fetch layers but don't build it")
 	buildCmd.Flags().String("customer", "", "Optional: customer/user name for build directory grouping")
 	buildCmd.Flags().Bool("clean", false, "If set, deletes the build directory before building (for a full rebuild)")
 	buildCmd.Flags().Bool("clean-image", false, "If set, runs 'bitbake -c clean <image>' to regenerate only image artifacts without rebuilding dependencies")
+	buildCmd.Flags().String("container-backend", "", "Container backend to use when smidr.yaml does not set container.backend (docker, podman)")
 
 	return buildCmd
 }
@@ -93,6 +101,10 @@ func runBuild(cmd *cobra.Command) error {
 	clean, _ := cmd.Flags().GetBool("clean")
 	cleanImage, _ := cmd.Flags().GetBool("clean-image")
 	fetchOnly, _ := cmd.Flags().GetBool("fetch-only")
+	containerBackend, _ := cmd.Flags().GetString("container-backend")
+	targets, _ := cmd.Flags().GetStringSlice("target")
+	task, _ := cmd.Flags().GetString("task")
+	forceTask, _ := cmd.Flags().GetBool("force-task")
 
 	log.Info("🔨 Starting Smidr build")
 	log.Info("📄 Loading configuration", slog.String("file", configFile))
@@ -102,6 +114,22 @@ func runBuild(cmd *cobra.Command) error {
 		return fmt.Errorf("error loading configuration: %w", err)
 	}
 
+	// Check out the commits recorded in smidr.lock; layers it does not pin are locked after the fetch
+	lockPath := config.LockPath(configFile)
+	unlocked, err := cfg.ApplyLockFile(lockPath)
+	if err != nil {
+		return fmt.Errorf("error loading lockfile: %w", err)
+	}
+	if len(unlocked) > 0 {
+		log.Info("🔓 Layers not pinned by smidr.lock, using their branch heads", slog.String("layers", strings.Join(unlocked, ", ")))
+	}
+
+	invocation, err := bitbake.NewInvocation(strings.Join(targets, " "), task, forceTask)
+	if err != nil {
+		return fmt.Errorf("invalid build target: %w", err)
+	}
+	invocation = invocation.WithDefaultTarget(cfg.Build.Image)
+
 	log.Info("Loaded project", slog.String("name", cfg.Name))
 	if cfg.Description != "" {
 		log.Debug("Project description", slog.String("description", cfg.Description))
@@ -140,7 +168,7 @@ func runBuild(cmd *cobra.Command) error {
 
 	// Create unique or customer-specific build directory
 	homedir, _ := os.UserHomeDir()
-	imageName := cfg.Build.Image
+	imageName := invocation.Targets[0]
 	
 	// For customer builds, use stable build ID; for ad-hoc builds, use UUID
 	var buildUUID string
@@ -218,11 +246,12 @@ func runBuild(cmd *cobra.Command) error {
 
 	// Prepare build options
 	opts := buildpkg.BuildOptions{
-		BuildID:    buildUUID,
-		Target:     cfg.Build.Image,
-		Customer:   customer,
-		ForceClean: clean,
-		ForceImage: cleanImage,
+		BuildID:          buildUUID,
+		Target:           invocation.String(),
+		Customer:         customer,
+		ForceClean:       clean,
+		ForceImage:       cleanImage,
+		ContainerBackend: containerBackend,
 	}
 
 	// Create log files
@@ -244,8 +273,18 @@ func runBuild(cmd *cobra.Command) error {
 
 	// Use the Runner (no DB in CLI mode)
 	runner := buildpkg.NewRunner(log, nil)
+	runner.SetTestHooks(testHooksFromEnv())
 	buildResult, err := runner.Run(ctx, cfg, opts, logSink)
 
+	// The layers were fetched even if the build failed; pin what was checked out
+	if len(unlocked) > 0 {
+		if lerr := updateLockFile(lockPath, cfg, unlocked); lerr != nil {
+			log.Warn("Failed to update lockfile", slog.String("path", lockPath), slog.String("error", lerr.Error()))
+		} else {
+			log.Info("📌 Pinned layer commits", slog.String("lockfile", lockPath))
+		}
+	}
+
 	if err != nil {
 		if ctx.Err() == context.Canceled {
 			log.Warn("🛑 Build was cancelled by user signal")
@@ -268,9 +307,9 @@ func runBuild(cmd *cobra.Command) error {
 
 	// Extract build artifacts
 	log.Info("📦 Extracting build artifacts")
-	dm, err := docker.NewDockerManager(log)
+	dm, err := backend.New(backend.Resolve(cfg.Container.Backend, containerBackend), log)
 	if err != nil {
-		log.Warn("Failed to create docker manager for artifact extraction", slog.String("error", err.Error()))
+		log.Warn("Failed to create container manager for artifact extraction", slog.String("error", err.Error()))
 	} else {
 		if err := extractBuildArtifacts(ctx, dm, cfg, customer, imageName, buildResult, log); err != nil {
 			log.Warn("Failed to extract artifacts", slog.String("error", err.Error()))
@@ -281,6 +320,48 @@ func runBuild(cmd *cobra.Command) error {
 	return nil
 }
 
+// updateLockFile adds the commits the unlocked layers were checked out at to the lockfile,
+// leaving the entries of the other layers untouched
+func updateLockFile(path string, cfg *config.Config, unlocked []string) error {
+	lock, err := config.LoadLock(path)
+	if os.IsNotExist(err) {
+		lock = &config.Lock{Version: config.LockVersion}
+	} else if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(unlocked))
+	for _, name := range unlocked {
+		names[name] = true
+	}
+	changed := false
+	for _, layer := range cfg.Lock().Layers {
+		if names[layer.Name] {
+			lock.Set(layer)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return lock.Save(path)
+}
+
+// testHooksFromEnv reads the SMIDR_TEST_* variables the integration tests and
+// the Makefile smoke targets use to steer CLI builds
+func testHooksFromEnv() buildpkg.TestHooks {
+	writeMarkers := os.Getenv("SMIDR_TEST_WRITE_MARKERS") == "1"
+	return buildpkg.TestHooks{
+		ContainerName: os.Getenv("SMIDR_TEST_CONTAINER_NAME"),
+		Image:         os.Getenv("SMIDR_TEST_IMAGE"),
+		DownloadsDir:  os.Getenv("SMIDR_TEST_DOWNLOADS_DIR"),
+		SStateDir:     os.Getenv("SMIDR_TEST_SSTATE_DIR"),
+		WorkspaceDir:  os.Getenv("SMIDR_TEST_WORKSPACE_DIR"),
+		SkipBitBake:   os.Getenv("SMIDR_TEST_ENTRYPOINT") != "" || writeMarkers,
+		WriteMarkers:  writeMarkers,
+	}
+}
+
 // Helper to expand ~ and make absolute
 
 // setDefaultDirs ensures default directory paths are populated on the config
@@ -314,7 +395,7 @@ func setDefaultDirs(cfg *config.Config, workDir string) {
 }
 
 // extractBuildArtifacts extracts build artifacts from the build result to persistent storage
-func extractBuildArtifacts(ctx context.Context, dm *docker.DockerManager, cfg *config.Config, customer, imageName string, result *buildpkg.BuildResult, log *logger.Logger) error {
+func extractBuildArtifacts(ctx context.Context, dm smidrcontainer.ContainerManager, cfg *config.Config, customer, imageName string, result *buildpkg.BuildResult, log *logger.Logger) error {
 	// Get current user for metadata
 	currentUser, err := user.Current()
 	if err != nil {
@@ -375,7 +456,7 @@ func extractBuildArtifacts(ctx context.Context, dm *docker.DockerManager, cfg *c
 			"base_version":  cfg.Base.Version,
 		},
 		BuildDuration: result.Duration,
-		TargetImage:   cfg.Build.Image,
+		TargetImage:   imageName,
 		Status:        "success",
 	}
 
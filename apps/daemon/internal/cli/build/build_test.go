@@ -54,6 +54,39 @@ func TestSetDefaultDirs(t *testing.T) {
 	}
 }
 
+func TestUpdateLockFile(t *testing.T) {
+	const (
+		pokyGit = "https://git.yoctoproject.org/poky"
+		oeGit   = "https://github.com/openembedded/meta-openembedded"
+		pokySHA = "0123456789abcdef0123456789abcdef01234567"
+		oeSHA   = "89abcdef0123456789abcdef0123456789abcdef"
+	)
+	path := filepath.Join(t.TempDir(), config.LockFileName)
+	existing := &config.Lock{Layers: []config.LockedLayer{{Name: "poky", Git: pokyGit, Branch: "kirkstone", Commit: pokySHA}}}
+	if err := existing.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		YoctoSeries: "kirkstone",
+		Layers: []config.Layer{
+			{Name: "poky", Git: pokyGit, Commit: pokySHA},
+			{Name: "meta-oe", Git: oeGit, Commit: oeSHA},
+		},
+	}
+	if err := updateLockFile(path, cfg, []string{"meta-oe"}); err != nil {
+		t.Fatalf("updateLockFile returned error: %v", err)
+	}
+
+	lock, err := config.LoadLock(path)
+	if err != nil {
+		t.Fatalf("failed to load lockfile: %v", err)
+	}
+	if len(lock.Layers) != 2 || lock.Find(pokyGit).Commit != pokySHA || lock.Find(oeGit).Commit != oeSHA {
+		t.Errorf("expected meta-oe to be added next to poky, got %+v", lock.Layers)
+	}
+}
+
 func TestRunBuild_Basic(t *testing.T) {
 	cmd := &cobra.Command{Use: "build"}
 	cmd.SetContext(context.Background()) // Ensure context is not nil
@@ -1,11 +1,21 @@
 package client
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/client"
 	"github.com/spf13/cobra"
 )
 
 var (
 	clientDaemonAddress string
+	clientTLSCert       string
+	clientTLSKey        string
+	clientTLSCA         string
+	clientTLSServerName string
+	clientToken         string
 )
 
 // New creates and returns the client command with all subcommands
@@ -21,20 +31,62 @@ Examples:
   smidr client start --config config.yaml --target core-image-minimal
   smidr client status --build-id build-123
   smidr client logs --build-id build-123 --follow
+  smidr client task-log build-123 busybox do_compile
   smidr client list
-  smidr client cancel --build-id build-123`,
+  smidr client cancel --build-id build-123
+  smidr client download build-123 "*.wic"
+  smidr client inspect --build-id build-123
+  smidr client retry build-123
+  smidr client delete --build-id build-123
+  smidr client purge --older-than 720h`,
 	}
 
 	// Global flag for all client commands
 	clientCmd.PersistentFlags().StringVar(&clientDaemonAddress, "address", "localhost:50051", "Daemon address to connect to")
+	clientCmd.PersistentFlags().StringVar(&clientTLSCA, "tls-ca", "", "CA bundle to verify the daemon certificate (enables TLS)")
+	clientCmd.PersistentFlags().StringVar(&clientTLSCert, "tls-cert", "", "Client certificate for daemons that require mTLS (enables TLS)")
+	clientCmd.PersistentFlags().StringVar(&clientTLSKey, "tls-key", "", "Private key for --tls-cert")
+	clientCmd.PersistentFlags().StringVar(&clientTLSServerName, "tls-server-name", "", "Expected daemon certificate name (defaults to the address host)")
+	clientCmd.PersistentFlags().StringVar(&clientToken, "token", "", "Bearer token for daemons with token authentication (default $SMIDR_TOKEN)")
 
 	// Add subcommands
 	clientCmd.AddCommand(clientStartCmd)
 	clientCmd.AddCommand(clientStatusCmd)
 	clientCmd.AddCommand(clientLogsCmd)
+	clientCmd.AddCommand(clientTaskLogCmd)
+	clientCmd.AddCommand(clientWatchCmd)
 	clientCmd.AddCommand(clientCancelCmd)
 	clientCmd.AddCommand(clientListCmd)
 	clientCmd.AddCommand(clientArtifactsCmd)
+	clientCmd.AddCommand(clientDownloadCmd)
+	clientCmd.AddCommand(clientInspectCmd)
+	clientCmd.AddCommand(clientRetryCmd)
+	clientCmd.AddCommand(clientDeleteCmd)
+	clientCmd.AddCommand(clientPurgeCmd)
 
 	return clientCmd
 }
+
+// newDaemonClient connects to the daemon using the global connection flags
+func newDaemonClient() (*client.Client, error) {
+	var opts []client.Option
+
+	files := auth.TLSFiles{CertFile: clientTLSCert, KeyFile: clientTLSKey, CAFile: clientTLSCA}
+	if files.Enabled() {
+		tlsConfig, err := auth.ClientTLSConfig(files, clientTLSServerName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS settings: %w", err)
+		}
+		opts = append(opts, client.WithTLS(tlsConfig))
+	}
+
+	token := clientToken
+	if token == "" {
+		token = os.Getenv("SMIDR_TOKEN")
+	}
+	if token != "" {
+		opts = append(opts, client.WithToken(token))
+	}
+
+	return client.NewClient(clientDaemonAddress, opts...)
+}
@@ -7,8 +7,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-
-	"github.com/schererja/smidr/internal/client"
 )
 
 var clientArtifactsCmd = &cobra.Command{
@@ -29,7 +27,7 @@ Examples:
 func runClientArtifacts(cmd *cobra.Command, args []string) error {
 	buildID := args[0]
 
-	c, err := client.NewClient(clientDaemonAddress)
+	c, err := newDaemonClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to daemon: %w", err)
 	}
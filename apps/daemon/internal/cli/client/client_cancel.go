@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/schererja/smidr/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +29,7 @@ func init() {
 }
 
 func runClientCancel(cmd *cobra.Command, args []string) error {
-	c, err := client.NewClient(clientDaemonAddress)
+	c, err := newDaemonClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to daemon: %w", err)
 	}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteBuildID string
+)
+
+var clientDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a finished build and its artifacts",
+	Long: `Delete a finished build on the daemon. Its artifacts are removed; the build
+record and logs are kept (marked deleted) until they are purged.
+
+Examples:
+  smidr client delete --build-id build-123`,
+	RunE: runClientDelete,
+}
+
+func init() {
+	clientDeleteCmd.Flags().StringVar(&deleteBuildID, "build-id", "", "Build ID to delete (required)")
+	clientDeleteCmd.MarkFlagRequired("build-id")
+}
+
+func runClientDelete(cmd *cobra.Command, args []string) error {
+	c, err := newDaemonClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := c.DeleteBuild(ctx, deleteBuildID)
+	if err != nil {
+		return fmt.Errorf("failed to delete build: %w", err)
+	}
+
+	if !result.Success {
+		return fmt.Errorf("failed to delete build %s: %s", deleteBuildID, result.Message)
+	}
+	fmt.Printf("🗑️  Build %s deleted\n", deleteBuildID)
+	fmt.Printf("   %s\n", result.Message)
+	return nil
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/schererja/smidr/internal/client"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	downloadOutputDir     string
+	downloadPreservePaths bool
+)
+
+var clientDownloadCmd = &cobra.Command{
+	Use:   "download <build-id> [pattern]",
+	Short: "Download artifacts from a completed build",
+	Long: `Download artifacts from a completed build on the daemon.
+
+The optional pattern is a shell glob matched against the artifact file name
+and its path within the build (e.g. "*.wic", "images/*/*.wic.bz2"). Without
+a pattern all artifacts are downloaded.
+
+Interrupted downloads are resumed from a ".part" file on the next run, and
+every file is verified against the SHA-256 reported by the daemon.
+
+Examples:
+  smidr client download build-123 "*.wic"
+  smidr client download build-123 "*.wic" --output ./images
+  smidr client download build-123 --preserve-paths --address remote-host:50051`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runClientDownload,
+}
+
+func init() {
+	clientDownloadCmd.Flags().StringVarP(&downloadOutputDir, "output", "o", ".", "Directory to write downloaded artifacts to")
+	clientDownloadCmd.Flags().BoolVar(&downloadPreservePaths, "preserve-paths", false, "Keep the artifact directory layout instead of writing files flat into the output directory")
+}
+
+func runClientDownload(cmd *cobra.Command, args []string) error {
+	buildID := args[0]
+	pattern := ""
+	if len(args) > 1 {
+		pattern = args[1]
+	}
+
+	c, err := newDaemonClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer c.Close()
+
+	listCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.ListArtifacts(listCtx, buildID)
+	if err != nil {
+		return fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	var selected []*v1.ArtifactSummary
+	for _, artifact := range resp.Artifacts {
+		ok, err := matchArtifact(pattern, artifact)
+		if err != nil {
+			return err
+		}
+		if ok {
+			selected = append(selected, artifact)
+		}
+	}
+
+	if len(selected) == 0 {
+		if pattern != "" {
+			fmt.Printf("No artifacts matching %q found for build %s\n", pattern, buildID)
+		} else {
+			fmt.Printf("No artifacts found for build %s\n", buildID)
+		}
+		return nil
+	}
+
+	fmt.Printf("⬇️  Downloading %d artifact(s) from build %s\n\n", len(selected), buildID)
+
+	var totalBytes int64
+	for _, artifact := range selected {
+		destPath := filepath.Join(downloadOutputDir, artifact.Name)
+		if downloadPreservePaths {
+			destPath = filepath.Join(downloadOutputDir, filepath.FromSlash(artifact.DownloadUrl))
+		}
+
+		n, err := downloadArtifact(context.Background(), c, artifact, destPath)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", artifact.Name, err)
+		}
+		totalBytes += n
+	}
+
+	fmt.Printf("\n✅ Downloaded %d artifact(s) (%s) to %s\n", len(selected), formatSize(totalBytes), downloadOutputDir)
+	return nil
+}
+
+// matchArtifact reports whether an artifact matches the glob pattern by name or relative path
+func matchArtifact(pattern string, artifact *v1.ArtifactSummary) (bool, error) {
+	if pattern == "" {
+		return true, nil
+	}
+	for _, candidate := range []string{artifact.Name, filepath.ToSlash(artifact.DownloadUrl)} {
+		ok, err := filepath.Match(pattern, candidate)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// downloadArtifact streams one artifact into destPath, resuming from destPath.part if present.
+// It returns the number of bytes transferred in this run.
+func downloadArtifact(ctx context.Context, c *client.Client, artifact *v1.ArtifactSummary, destPath string) (int64, error) {
+	// Skip files that are already complete and verified
+	if artifact.Checksum != "" {
+		if sum, err := fileChecksum(destPath); err == nil && sum == artifact.Checksum {
+			fmt.Printf("   %s already up to date\n", artifact.Name)
+			return 0, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	partPath := destPath + ".part"
+	partFile, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", partPath, err)
+	}
+	defer partFile.Close()
+
+	offset, err := partFile.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to seek %s: %w", partPath, err)
+	}
+	if offset > 0 {
+		fmt.Printf("   Resuming %s at %s\n", artifact.Name, formatSize(offset))
+	}
+
+	stream, err := c.DownloadArtifact(ctx, artifact.ArtifactId, offset)
+	if err != nil {
+		return 0, err
+	}
+
+	var received int64
+	var expectedChecksum string
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return received, fmt.Errorf("error receiving artifact: %w", err)
+		}
+
+		if len(chunk.Data) > 0 {
+			if chunk.Offset != offset+received {
+				return received, fmt.Errorf("unexpected chunk offset %d (expected %d)", chunk.Offset, offset+received)
+			}
+			if _, err := partFile.Write(chunk.Data); err != nil {
+				return received, fmt.Errorf("failed to write %s: %w", partPath, err)
+			}
+			received += int64(len(chunk.Data))
+		}
+
+		if chunk.Last {
+			expectedChecksum = chunk.Checksum
+			break
+		}
+	}
+
+	if err := partFile.Close(); err != nil {
+		return received, fmt.Errorf("failed to close %s: %w", partPath, err)
+	}
+	if expectedChecksum == "" {
+		return received, fmt.Errorf("download ended before the final chunk; re-run to resume")
+	}
+
+	// Verify the reassembled file before moving it into place
+	sum, err := fileChecksum(partPath)
+	if err != nil {
+		return received, fmt.Errorf("failed to verify %s: %w", partPath, err)
+	}
+	if sum != expectedChecksum {
+		_ = os.Remove(partPath)
+		return received, fmt.Errorf("checksum mismatch (expected %s, got %s)", expectedChecksum, sum)
+	}
+
+	if err := os.Rename(partPath, destPath); err != nil {
+		return received, fmt.Errorf("failed to move %s into place: %w", destPath, err)
+	}
+
+	fmt.Printf("   %s %10s  sha256:%s\n", artifact.Name, formatSize(offset+received), sum[:16])
+	return received, nil
+}
+
+// fileChecksum computes the hex-encoded SHA-256 of a local file
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectBuildID    string
+	inspectShowConfig bool
+)
+
+var clientInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Show the full record of a build",
+	Long: `Show the full record of a build: customer, machine, directories, timing,
+artifact totals, the diagnosed causes of a failure and, with --config, the
+configuration snapshot it was built with.
+
+Examples:
+  smidr client inspect --build-id build-123
+  smidr client inspect --build-id build-123 --config`,
+	RunE: runClientInspect,
+}
+
+func init() {
+	clientInspectCmd.Flags().StringVar(&inspectBuildID, "build-id", "", "Build ID to inspect (required)")
+	clientInspectCmd.Flags().BoolVar(&inspectShowConfig, "config", false, "Print the configuration snapshot")
+	clientInspectCmd.MarkFlagRequired("build-id")
+}
+
+func runClientInspect(cmd *cobra.Command, args []string) error {
+	c, err := newDaemonClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	build, err := c.GetBuild(ctx, inspectBuildID)
+	if err != nil {
+		return fmt.Errorf("failed to get build: %w", err)
+	}
+
+	fmt.Printf("📝 Build ID: %s\n", build.BuildIdentifier.GetBuildId())
+	fmt.Printf("📊 State: %s\n", formatBuildState(build.BuildState))
+	printField("👤 Customer", build.Customer)
+	printField("📦 Project", build.ProjectName)
+	printField("🎯 Target", build.TargetImage)
+	printField("🖥️  Machine", build.Machine)
+	printField("📄 Config", build.ConfigFile)
+	printField("🔁 Retry of", build.RetriedFrom)
+	printField("📁 Build dir", build.BuildDirectory)
+	printField("📁 Deploy dir", build.DownloadDirectory)
+	printField("📜 Log", build.LogFilePlain)
+	if build.User != "" || build.Host != "" {
+		fmt.Printf("🙋 Started by: %s@%s\n", build.User, build.Host)
+	}
+	if build.CreatedAt > 0 {
+		fmt.Printf("⏰ Created: %s\n", time.Unix(build.CreatedAt, 0).Format(time.RFC3339))
+	}
+	if build.Timestamps != nil && build.Timestamps.EndTimeUnixSeconds > 0 {
+		fmt.Printf("✅ Completed: %s (took %s)\n",
+			time.Unix(build.Timestamps.EndTimeUnixSeconds, 0).Format(time.RFC3339),
+			(time.Duration(build.DurationSeconds) * time.Second).String())
+		fmt.Printf("🔢 Exit Code: %d\n", build.ExitCode)
+	}
+	fmt.Printf("🗂️  Artifacts: %d (%s)\n", build.ArtifactCount, formatSize(build.TotalArtifactSizeBytes))
+	if build.Deleted {
+		fmt.Printf("🗑️  Deleted: %s\n", time.Unix(build.DeletedAt, 0).Format(time.RFC3339))
+	}
+	if build.ErrorMessage != "" {
+		fmt.Printf("❌ Error: %s\n", build.ErrorMessage)
+	}
+	if len(build.Diagnostics) > 0 {
+		fmt.Printf("\n🩺 Diagnostics:\n")
+		for _, d := range build.Diagnostics {
+			where := d.Recipe
+			if d.Task != "" {
+				where += ":" + d.Task
+			}
+			if d.File != "" {
+				where = d.File
+				if d.Line > 0 {
+					where = fmt.Sprintf("%s:%d", d.File, d.Line)
+				}
+			}
+			if where == "" {
+				where = d.Layer
+			}
+			if where != "" {
+				fmt.Printf("  • %s (%s)\n", d.Kind, where)
+			} else {
+				fmt.Printf("  • %s\n", d.Kind)
+			}
+			printIndented("URL", d.Url)
+			printIndented("Task log", d.LogFile)
+			printIndented("Output", d.Message)
+			printIndented("Hint", d.Hint)
+		}
+	}
+
+	if len(build.TaskLogs) > 0 {
+		fmt.Printf("\n📄 Task logs:\n")
+		for _, l := range build.TaskLogs {
+			fmt.Printf("  • %s %s (%s): smidr client task-log %s %s %s\n",
+				l.Recipe, l.Task, formatSize(l.SizeBytes), build.BuildIdentifier.GetBuildId(), l.Recipe, l.Task)
+		}
+	}
+
+	if len(build.RecoveryActions) > 0 {
+		fmt.Printf("\n🩹 Recovery:\n")
+		for _, a := range build.RecoveryActions {
+			fmt.Printf("  • %s\n", formatRecoveryAction(a))
+		}
+	}
+
+	if len(build.LayerFetches) > 0 {
+		fmt.Printf("\n📥 Layer fetches:\n")
+		for _, f := range build.LayerFetches {
+			fmt.Printf("  • %s\n", formatLayerFetch(f))
+		}
+	}
+
+	if len(build.LayerCommits) > 0 {
+		fmt.Printf("\n📌 Layers:\n")
+		for _, l := range build.LayerCommits {
+			fmt.Printf("  • %s (%s) @ %s\n", l.Name, l.Branch, l.Commit)
+		}
+	}
+
+	if inspectShowConfig && build.ConfigSnapshot != "" {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, []byte(build.ConfigSnapshot), "", "  "); err != nil {
+			pretty.Reset()
+			pretty.WriteString(build.ConfigSnapshot)
+		}
+		fmt.Printf("\n⚙️  Config snapshot:\n%s\n", pretty.String())
+	}
+
+	return nil
+}
+
+// printField prints a labelled value, skipping empty values
+func printField(label, value string) {
+	if value != "" {
+		fmt.Printf("%s: %s\n", label, value)
+	}
+}
+
+// printIndented prints a labelled value below a list item, skipping empty values
+func printIndented(label, value string) {
+	if value != "" {
+		fmt.Printf("    %s: %s\n", label, value)
+	}
+}
@@ -3,44 +3,78 @@ package client
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/schererja/smidr/internal/client"
 	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
 	"github.com/spf13/cobra"
 )
 
 var (
-	listLimit int32
+	listLimit    int32
+	listCustomer string
+	listSince    string
+	listStates   []string
+	listPage     string
 )
 
 var clientListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all builds on the daemon",
-	Long: `List all builds (active and completed) on the daemon.
+	Long: `List builds (active and completed) on the daemon, newest first.
+
+Results are paginated; when more builds match, the command prints the
+--page token for the next page. --since accepts an RFC3339 time or a
+duration ago (e.g. "24h").
 
 Examples:
   smidr client list
   smidr client list --limit 10
+  smidr client list --customer acme --since 168h --state failed
+  smidr client list --page <token>
   smidr client list --address remote-host:50051`,
 	RunE: runClientList,
 }
 
 func init() {
-	clientListCmd.Flags().Int32Var(&listLimit, "limit", 0, "Maximum number of builds to list (0 = all)")
+	clientListCmd.Flags().Int32Var(&listLimit, "limit", 50, "Maximum number of builds per page (0 = all)")
+	clientListCmd.Flags().StringVar(&listCustomer, "customer", "", "Only list builds of this customer")
+	clientListCmd.Flags().StringVar(&listSince, "since", "", "Only list builds created at or after this time")
+	clientListCmd.Flags().StringSliceVar(&listStates, "state", nil, "Only list builds in these states (queued, preparing, building, extracting, completed, failed, cancelled)")
+	clientListCmd.Flags().StringVar(&listPage, "page", "", "Page token printed by a previous list")
 }
 
 func runClientList(cmd *cobra.Command, args []string) error {
-	c, err := client.NewClient(clientDaemonAddress)
+	c, err := newDaemonClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to daemon: %w", err)
 	}
 	defer c.Close()
 
+	req := &v1.ListBuildsRequest{
+		PageSize:  listLimit,
+		PageToken: listPage,
+		Customer:  listCustomer,
+	}
+	since, err := parseLogTime(listSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if since > 0 {
+		req.TimeRange = &v1.TimeStampRange{StartTimeUnixSeconds: since}
+	}
+	for _, name := range listStates {
+		state, err := parseBuildState(name)
+		if err != nil {
+			return err
+		}
+		req.StateFilter = append(req.StateFilter, state)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	buildsList, err := c.ListBuilds(ctx, nil, listLimit)
+	buildsList, err := c.ListBuilds(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to list builds: %w", err)
 	}
@@ -50,12 +84,15 @@ func runClientList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	fmt.Printf("Found %d build(s):\n\n", len(buildsList.Builds))
+	fmt.Printf("Showing %d of %d build(s):\n\n", len(buildsList.Builds), buildsList.TotalBuilds)
 
 	for _, build := range buildsList.Builds {
 		fmt.Printf("🔹 Build ID: %s\n", build.BuildIdentifier.BuildId)
 		fmt.Printf("   Target: %s\n", build.TargetImage)
 		fmt.Printf("   State: %s\n", formatBuildState(build.BuildState))
+		if build.RetriedFrom != "" {
+			fmt.Printf("   Retry of: %s\n", build.RetriedFrom)
+		}
 		if build.Timestamps != nil && build.Timestamps.StartTimeUnixSeconds > 0 {
 			fmt.Printf("   Started: %s\n", time.Unix(build.Timestamps.StartTimeUnixSeconds, 0).Format(time.RFC3339))
 		}
@@ -81,9 +118,29 @@ func runClientList(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	if buildsList.NextPageToken != "" {
+		fmt.Printf("More builds available: smidr client list --page %s (with the same filters)\n", buildsList.NextPageToken)
+	}
+
 	return nil
 }
 
+// parseBuildState accepts a state name such as "failed" or "BUILD_STATE_FAILED"
+func parseBuildState(name string) (v1.BuildState, error) {
+	key := strings.ToUpper(strings.TrimSpace(name))
+	if key == "EXTRACTING" {
+		key = "EXTRACTING_ARTIFACTS"
+	}
+	if !strings.HasPrefix(key, "BUILD_STATE_") {
+		key = "BUILD_STATE_" + key
+	}
+	value, ok := v1.BuildState_value[key]
+	if !ok || value == int32(v1.BuildState_BUILD_STATE_UNSPECIFIED) {
+		return 0, fmt.Errorf("unknown build state %q", name)
+	}
+	return v1.BuildState(value), nil
+}
+
 func formatBuildState(state v1.BuildState) string {
 	switch state {
 	case v1.BuildState_BUILD_STATE_QUEUED:
@@ -4,14 +4,23 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
-	"github.com/schererja/smidr/internal/client"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
 	"github.com/spf13/cobra"
 )
 
+// maxLogReconnects is how often a followed log stream is resumed before giving up
+const maxLogReconnects = 5
+
 var (
 	logsBuildID string
 	logsFollow  bool
+	logsStream  string
+	logsLevel   string
+	logsSince   string
+	logsUntil   string
+	logsTail    int
 )
 
 var clientLogsCmd = &cobra.Command{
@@ -20,10 +29,16 @@ var clientLogsCmd = &cobra.Command{
 	Long: `Stream logs from a build running on the daemon.
 
 Use --follow to continue streaming new logs as they are generated.
+Logs of finished builds are replayed from disk, including builds from
+before a daemon restart.
+
+--since and --until accept an RFC3339 time or a duration ago (e.g. "2h").
 
 Examples:
   smidr client logs --build-id build-123
   smidr client logs --build-id build-123 --follow
+  smidr client logs --build-id build-123 --level error --tail 50
+  smidr client logs --build-id build-123 --stream stderr --since 30m
   smidr client logs --build-id build-123 --follow --address remote-host:50051`,
 	RunE: runClientLogs,
 }
@@ -31,11 +46,16 @@ Examples:
 func init() {
 	clientLogsCmd.Flags().StringVar(&logsBuildID, "build-id", "", "Build ID to stream logs from (required)")
 	clientLogsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Follow log output")
+	clientLogsCmd.Flags().StringVar(&logsStream, "stream", "", "Only show logs from this stream (stdout, stderr)")
+	clientLogsCmd.Flags().StringVar(&logsLevel, "level", "", "Minimum log level to show (debug, info, warn, error)")
+	clientLogsCmd.Flags().StringVar(&logsSince, "since", "", "Only show logs at or after this time")
+	clientLogsCmd.Flags().StringVar(&logsUntil, "until", "", "Only show logs before this time")
+	clientLogsCmd.Flags().IntVarP(&logsTail, "tail", "n", 0, "Only show the last N matching log lines")
 	clientLogsCmd.MarkFlagRequired("build-id")
 }
 
 func runClientLogs(cmd *cobra.Command, args []string) error {
-	c, err := client.NewClient(clientDaemonAddress)
+	c, err := newDaemonClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to daemon: %w", err)
 	}
@@ -43,7 +63,21 @@ func runClientLogs(cmd *cobra.Command, args []string) error {
 
 	ctx := context.Background()
 
-	stream, err := c.StreamLogs(ctx, logsBuildID, logsFollow)
+	req := &v1.StreamBuildLogsRequest{
+		BuildIdentifier: &v1.BuildIdentifier{BuildId: logsBuildID},
+		Follow:          logsFollow,
+		Stream:          logsStream,
+		Level:           logsLevel,
+		Tail:            int32(logsTail),
+	}
+	if req.SinceUnixSeconds, err = parseLogTime(logsSince); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if req.UntilUnixSeconds, err = parseLogTime(logsUntil); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	stream, err := c.StreamBuildLogs(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to stream logs: %w", err)
 	}
@@ -54,6 +88,8 @@ func runClientLogs(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	var lastSeq uint64
+	reconnects := 0
 	for {
 		logLine, err := stream.Recv()
 		if err == io.EOF {
@@ -61,7 +97,24 @@ func runClientLogs(cmd *cobra.Command, args []string) error {
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("error receiving logs: %w", err)
+			// When following, reconnect and resume after the last line received
+			if !logsFollow || lastSeq == 0 || reconnects >= maxLogReconnects {
+				return fmt.Errorf("error receiving logs: %w", err)
+			}
+			reconnects++
+			fmt.Printf("⚠️  Log stream interrupted (%v), resuming after line %d...\n", err, lastSeq)
+			time.Sleep(time.Duration(reconnects) * time.Second)
+
+			req.AfterSequence = lastSeq
+			req.Tail = 0
+			if stream, err = c.StreamBuildLogs(ctx, req); err != nil {
+				return fmt.Errorf("failed to resume log stream: %w", err)
+			}
+			continue
+		}
+		if logLine.Sequence > 0 {
+			lastSeq = logLine.Sequence
+			reconnects = 0
 		}
 
 		// Print the log line with stream prefix
@@ -78,3 +131,18 @@ func runClientLogs(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// parseLogTime parses an RFC3339 time or a duration before now into Unix seconds (0 if empty)
+func parseLogTime(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("expected RFC3339 time or duration, got %q", value)
+	}
+	return time.Now().Add(-d).Unix(), nil
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeOlderThan string
+	purgeCustomer  string
+)
+
+var clientPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently remove old finished builds",
+	Long: `Permanently remove finished builds created before a cutoff: their records,
+artifacts, logs and build directories. Running and queued builds are never purged.
+
+--older-than accepts an RFC3339 time or a duration ago (e.g. "720h").
+
+Examples:
+  smidr client purge --older-than 720h
+  smidr client purge --older-than 2025-01-01T00:00:00Z --customer acme`,
+	RunE: runClientPurge,
+}
+
+func init() {
+	clientPurgeCmd.Flags().StringVar(&purgeOlderThan, "older-than", "", "Purge builds created before this time (required)")
+	clientPurgeCmd.Flags().StringVar(&purgeCustomer, "customer", "", "Only purge builds of this customer")
+	clientPurgeCmd.MarkFlagRequired("older-than")
+}
+
+func runClientPurge(cmd *cobra.Command, args []string) error {
+	cutoff, err := parseLogTime(purgeOlderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+
+	c, err := newDaemonClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer c.Close()
+
+	// Removing build directories can take a while
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	result, err := c.PurgeBuilds(ctx, time.Unix(cutoff, 0), purgeCustomer)
+	if err != nil {
+		return fmt.Errorf("failed to purge builds: %w", err)
+	}
+
+	for _, buildID := range result.PurgedBuildIds {
+		fmt.Printf("🗑️  %s\n", buildID)
+	}
+	fmt.Printf("✅ %s\n", result.Message)
+	return nil
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	retryTarget     string
+	retryForceClean bool
+	retryForceImage bool
+	retryPriority   int32
+	retryFollow     bool
+)
+
+var clientRetryCmd = &cobra.Command{
+	Use:   "retry <build-id>",
+	Short: "Re-run a finished build from its config snapshot",
+	Long: `Start a new build from the configuration snapshot, target and customer of a
+finished build. The new build records the build it retries, shown as "Retry of"
+by inspect and list.
+
+Examples:
+  smidr client retry build-123
+  smidr client retry build-123 --force-clean
+  smidr client retry build-123 --target core-image-full-cmdline --follow`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClientRetry,
+}
+
+func init() {
+	clientRetryCmd.Flags().StringVarP(&retryTarget, "target", "t", "", "Build target/image name (default: the original build's target)")
+	clientRetryCmd.Flags().BoolVar(&retryForceClean, "force-clean", false, "Force a clean build")
+	clientRetryCmd.Flags().BoolVar(&retryForceImage, "force-image", false, "Force image regeneration only")
+	clientRetryCmd.Flags().Int32Var(&retryPriority, "priority", 0, "Scheduling priority; queued builds with a higher priority start first")
+	clientRetryCmd.Flags().BoolVarP(&retryFollow, "follow", "f", false, "Stream logs immediately after starting the build")
+}
+
+func runClientRetry(cmd *cobra.Command, args []string) error {
+	originalID := args[0]
+
+	c, err := newDaemonClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status, err := c.RetryBuild(ctx, originalID, retryTarget, retryForceClean, retryForceImage, retryPriority)
+	if err != nil {
+		return fmt.Errorf("failed to retry build: %w", err)
+	}
+
+	buildID := status.BuildIdentifier.GetBuildId()
+	fmt.Printf("🔁 Retrying build %s\n", originalID)
+	fmt.Printf("Build ID: %s\n", buildID)
+	fmt.Printf("🎯 Target: %s\n", status.Target)
+	if status.QueuePosition > 0 {
+		fmt.Printf("⏳ Queue position: %d\n", status.QueuePosition)
+	}
+
+	if !retryFollow {
+		fmt.Printf("\n💡 Monitor the build with:\n")
+		fmt.Printf("   smidr client status --build-id %s\n", buildID)
+		return nil
+	}
+
+	fmt.Printf("\n📜 Streaming logs for build %s...\n\n", buildID)
+	logStream, err := c.StreamLogs(context.Background(), buildID, true)
+	if err != nil {
+		return fmt.Errorf("failed to stream logs: %w", err)
+	}
+	for {
+		logLine, err := logStream.Recv()
+		if err != nil {
+			break
+		}
+		if logLine.Stream == "stderr" {
+			fmt.Printf("[stderr] %s\n", logLine.Message)
+		} else {
+			fmt.Printf("%s\n", logLine.Message)
+		}
+	}
+	return nil
+}
@@ -3,18 +3,21 @@ package client
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/schererja/smidr/internal/client"
 	"github.com/spf13/cobra"
 )
 
 var (
 	startConfigPath string
-	startTarget     string
+	startTargets    []string
+	startTask       string
+	startForceTask  bool
 	startCustomer   string
 	startForceClean bool
 	startForceImage bool
+	startPriority   int32
 	startFollow     bool // reuse logs streaming behavior directly after starting
 )
 
@@ -30,16 +33,23 @@ Examples:
 	smidr client start --config config.yaml --target core-image-minimal
 	smidr client start --config config.yaml --target core-image-minimal --customer acme
 	smidr client start --config config.yaml --target core-image-minimal --force-clean
+	smidr client start --config config.yaml --target core-image-minimal --priority 10
+	smidr client start --config config.yaml --target core-image-minimal --target meta-toolchain
+	smidr client start --config config.yaml --target core-image-minimal --task populate_sdk
+	smidr client start --config config.yaml --target busybox --task compile --force-task
 	smidr client start --address remote-host:50051 --config config.yaml --target my-image`,
 	RunE: runClientStart,
 }
 
 func init() {
 	clientStartCmd.Flags().StringVarP(&startConfigPath, "config", "c", "", "Path to config file (required)")
-	clientStartCmd.Flags().StringVarP(&startTarget, "target", "t", "", "Build target/image name (required)")
+	clientStartCmd.Flags().StringSliceVarP(&startTargets, "target", "t", nil, "Build target (image or recipe); repeat or separate with commas for several targets (required)")
+	clientStartCmd.Flags().StringVar(&startTask, "task", "", "Run only this BitBake task of the targets (bitbake -c), e.g. populate_sdk")
+	clientStartCmd.Flags().BoolVar(&startForceTask, "force-task", false, "Run the task even if it is up to date (bitbake -f)")
 	clientStartCmd.Flags().StringVar(&startCustomer, "customer", "", "Optional customer/project name for build ID grouping")
 	clientStartCmd.Flags().BoolVar(&startForceClean, "force-clean", false, "Force a clean build")
 	clientStartCmd.Flags().BoolVar(&startForceImage, "force-image", false, "Force image regeneration only")
+	clientStartCmd.Flags().Int32Var(&startPriority, "priority", 0, "Scheduling priority; queued builds with a higher priority start first")
 	clientStartCmd.Flags().BoolVarP(&startFollow, "follow", "f", false, "Stream logs immediately after starting the build")
 
 	clientStartCmd.MarkFlagRequired("config")
@@ -49,19 +59,20 @@ func init() {
 func runClientStart(cmd *cobra.Command, args []string) error {
 	fmt.Printf("🔌 Connecting to daemon at %s...\n", clientDaemonAddress)
 
-	c, err := client.NewClient(clientDaemonAddress)
+	c, err := newDaemonClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to daemon: %w", err)
 	}
 	defer c.Close()
 
 	fmt.Println("✅ Connected to daemon")
-	fmt.Printf("🚀 Starting build: %s\n", startTarget)
+	target := strings.Join(startTargets, " ")
+	fmt.Printf("🚀 Starting build: %s\n", target)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	status, err := c.StartBuild(ctx, startConfigPath, startTarget, startCustomer, startForceClean, startForceImage)
+	status, err := c.StartBuild(ctx, startConfigPath, target, startTask, startCustomer, startForceTask, startForceClean, startForceImage, startPriority)
 	if err != nil {
 		return fmt.Errorf("failed to start build: %w", err)
 	}
@@ -72,6 +83,9 @@ func runClientStart(cmd *cobra.Command, args []string) error {
 	if status.Timestamps != nil && status.Timestamps.StartTimeUnixSeconds > 0 {
 		fmt.Printf("Started: %s\n", time.Unix(status.Timestamps.StartTimeUnixSeconds, 0).Format(time.RFC3339))
 	}
+	if status.QueuePosition > 0 {
+		fmt.Printf("⏳ Queue position: %d\n", status.QueuePosition)
+	}
 
 	fmt.Printf("\n💡 Monitor the build with:\n")
 	fmt.Printf("   smidr client status --build-id %s\n", status.BuildIdentifier.BuildId)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/schererja/smidr/internal/client"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +29,7 @@ func init() {
 }
 
 func runClientStatus(cmd *cobra.Command, args []string) error {
-	c, err := client.NewClient(clientDaemonAddress)
+	c, err := newDaemonClient()
 	if err != nil {
 		return fmt.Errorf("failed to connect to daemon: %w", err)
 	}
@@ -48,6 +47,9 @@ func runClientStatus(cmd *cobra.Command, args []string) error {
 	fmt.Printf("📝 Build ID: %s\n", status.BuildIdentifier.BuildId)
 	fmt.Printf("🎯 Target: %s\n", status.Target)
 	fmt.Printf("📊 State: %s\n", status.State)
+	if status.QueuePosition > 0 {
+		fmt.Printf("⏳ Queue position: %d\n", status.QueuePosition)
+	}
 	fmt.Printf("📄 Config: %s\n", status.ConfigPath)
 	if status.Timestamps != nil && status.Timestamps.StartTimeUnixSeconds > 0 {
 		fmt.Printf("⏰ Started: %s\n", time.Unix(status.Timestamps.StartTimeUnixSeconds, 0).Format(time.RFC3339))
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var taskLogTail int64
+
+var clientTaskLogCmd = &cobra.Command{
+	Use:   "task-log <build-id> <recipe> <task>",
+	Short: "Show the BitBake log of a failed task",
+	Long: `Show the BitBake log (log.do_<task>) of a task that failed in a build.
+
+When a build fails, the daemon collects the logs of the failed tasks from the
+BitBake work directory into the build directory. 'smidr client inspect' lists
+the collected logs. The do_ prefix of the task may be omitted.
+
+Examples:
+  smidr client task-log build-123 busybox do_compile
+  smidr client task-log build-123 zlib-native fetch
+  smidr client task-log build-123 busybox do_compile --tail 65536`,
+	Args: cobra.ExactArgs(3),
+	RunE: runClientTaskLog,
+}
+
+func init() {
+	clientTaskLogCmd.Flags().Int64Var(&taskLogTail, "tail", 0, "Only show the last N bytes of the log")
+}
+
+func runClientTaskLog(cmd *cobra.Command, args []string) error {
+	c, err := newDaemonClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := c.GetTaskLog(ctx, args[0], args[1], args[2], taskLogTail)
+	if err != nil {
+		return fmt.Errorf("failed to get task log: %w", err)
+	}
+
+	if resp.Truncated {
+		fmt.Fprintf(os.Stderr, "✂️  Showing the last %s of %s\n", formatSize(int64(len(resp.Content))), formatSize(resp.SizeBytes))
+	}
+	_, err = os.Stdout.Write(resp.Content)
+	return err
+}
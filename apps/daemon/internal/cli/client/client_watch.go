@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+	"github.com/spf13/cobra"
+)
+
+// progressBarWidth is the number of characters of the rendered progress bar
+const progressBarWidth = 30
+
+var (
+	watchBuildID string
+)
+
+var clientWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch the progress of a build",
+	Long: `Watch state changes, build phases and BitBake task progress of a build.
+
+Unlike 'client logs', this renders a progress bar from structured events
+instead of printing the raw build output.
+
+Examples:
+  smidr client watch --build-id build-123
+  smidr client watch --build-id build-123 --address remote-host:50051`,
+	RunE: runClientWatch,
+}
+
+func init() {
+	clientWatchCmd.Flags().StringVar(&watchBuildID, "build-id", "", "Build ID to watch (required)")
+	clientWatchCmd.MarkFlagRequired("build-id")
+}
+
+func runClientWatch(cmd *cobra.Command, args []string) error {
+	c, err := newDaemonClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer c.Close()
+
+	stream, err := c.WatchBuild(context.Background(), watchBuildID)
+	if err != nil {
+		return fmt.Errorf("failed to watch build: %w", err)
+	}
+
+	// Progress is redrawn in place; other events start on a fresh line
+	inProgressLine := false
+	endProgressLine := func() {
+		if inProgressLine {
+			fmt.Println()
+			inProgressLine = false
+		}
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			endProgressLine()
+			return nil
+		}
+		if err != nil {
+			endProgressLine()
+			return fmt.Errorf("error receiving build events: %w", err)
+		}
+
+		switch e := event.Event.(type) {
+		case *v1.BuildEvent_StateChange:
+			endProgressLine()
+			fmt.Printf("📊 State: %s\n", e.StateChange.State)
+			if e.StateChange.Message != "" {
+				fmt.Printf("   %s\n", e.StateChange.Message)
+			}
+		case *v1.BuildEvent_PhaseChange:
+			endProgressLine()
+			fmt.Printf("🔧 Phase: %s\n", e.PhaseChange.Phase)
+		case *v1.BuildEvent_TaskProgress:
+			fmt.Printf("\r\033[K%s", formatTaskProgress(e.TaskProgress))
+			inProgressLine = true
+		case *v1.BuildEvent_Recovery:
+			endProgressLine()
+			fmt.Printf("🩹 Recovery: %s\n", formatRecoveryAction(e.Recovery))
+		case *v1.BuildEvent_LayerFetch:
+			endProgressLine()
+			fmt.Printf("📥 Layer %s\n", formatLayerFetch(e.LayerFetch))
+		}
+	}
+}
+
+// formatTaskProgress renders a one-line progress bar for BitBake task progress
+func formatTaskProgress(tp *v1.TaskProgress) string {
+	kind := "tasks"
+	if tp.Setscene {
+		kind = "setscene"
+	}
+
+	filled := 0
+	if tp.Total > 0 {
+		filled = int(tp.Current) * progressBarWidth / int(tp.Total)
+	}
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+
+	line := fmt.Sprintf("[%s] %d/%d %s", bar, tp.Current, tp.Total, kind)
+	if tp.Recipe != "" {
+		line += fmt.Sprintf(" %s:%s", tp.Recipe, tp.Task)
+	}
+	return line
+}
+
+// formatRecoveryAction renders a recovery step, e.g. "cleansstate of zlib (attempt 1) succeeded in 1m30s"
+func formatRecoveryAction(a *v1.RecoveryAction) string {
+	line := a.Strategy
+	if a.Recipe != "" {
+		line += " of " + a.Recipe
+	}
+	if a.Attempt > 0 {
+		line += fmt.Sprintf(" (attempt %d)", a.Attempt)
+	}
+	if a.Succeeded {
+		line += " succeeded"
+	} else {
+		line += " failed"
+	}
+	line += " in " + (time.Duration(a.DurationMs) * time.Millisecond).Round(time.Second).String()
+	if a.Detail != "" {
+		line += ": " + a.Detail
+	}
+	return line
+}
+
+// formatLayerFetch renders a layer fetch, e.g. "poky: cloned refs/heads/kirkstone at 0123456789ab in 12.3s"
+func formatLayerFetch(f *v1.LayerFetch) string {
+	line := f.Name + ": " + f.Status
+	if f.Ref != "" {
+		line += " " + f.Ref
+	}
+	if f.Commit != "" {
+		line += fmt.Sprintf(" at %.12s", f.Commit)
+	}
+	if f.Url != "" && f.Url != f.Git {
+		line += " from mirror " + f.Url
+	}
+	line += " in " + (time.Duration(f.DurationMs) * time.Millisecond).Round(100*time.Millisecond).String()
+	if f.Attempts > 1 {
+		line += fmt.Sprintf(" (%d attempts)", f.Attempts)
+	}
+	if f.Error != "" {
+		line += ": " + f.Error
+	}
+	return line
+}
@@ -0,0 +1,84 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/source"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// newConfigCmd returns "smidr daemon config" and its subcommands
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the daemon config file",
+	}
+
+	var printEffective bool
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Validate the daemon config file",
+		Long: `Validate the daemon config file (~/.smidr/daemon.yaml or --daemon-config)
+without starting the daemon. TLS certificates and the tokens file it references
+are loaded too, so a config that passes the check also starts.
+
+Example usage:
+  smidr daemon config check
+  smidr daemon config check --daemon-config /etc/smidr/daemon.yaml --print`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, path, err := loadDaemonConfig(cmd)
+			if err != nil {
+				return err
+			}
+			if err := checkReferencedFiles(cfg); err != nil {
+				return err
+			}
+
+			if path == "" {
+				fmt.Println("No daemon config file found; the defaults are valid")
+			} else {
+				fmt.Printf("✓ %s is valid\n", path)
+			}
+			if printEffective {
+				data, err := yaml.Marshal(cfg)
+				if err != nil {
+					return fmt.Errorf("failed to format config: %w", err)
+				}
+				fmt.Printf("\n%s", data)
+			}
+			return nil
+		},
+	}
+	checkCmd.Flags().BoolVar(&printEffective, "print", false, "Print the effective settings, including defaults")
+
+	configCmd.AddCommand(checkCmd)
+	return configCmd
+}
+
+// checkReferencedFiles loads the TLS, token and git credentials files a config refers to
+func checkReferencedFiles(cfg *config.DaemonConfig) error {
+	tlsFiles := auth.TLSFiles{
+		CertFile: config.ExpandHome(cfg.TLS.Cert),
+		KeyFile:  config.ExpandHome(cfg.TLS.Key),
+		CAFile:   config.ExpandHome(cfg.TLS.ClientCA),
+	}
+	if tlsFiles.Enabled() {
+		if _, err := auth.ServerTLSConfig(tlsFiles); err != nil {
+			return fmt.Errorf("invalid TLS settings: %w", err)
+		}
+	}
+	if cfg.Auth.TokensFile != "" {
+		if _, err := auth.LoadTokens(config.ExpandHome(cfg.Auth.TokensFile)); err != nil {
+			return err
+		}
+	}
+	if cfg.Git.CredentialsFile != "" {
+		if _, err := source.LoadGitCredentials(config.ExpandHome(cfg.Git.CredentialsFile)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
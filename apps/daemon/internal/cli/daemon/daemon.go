@@ -2,22 +2,45 @@ package daemon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/schererja/smidr/internal/artifacts"
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/buildlog"
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/container/backend"
 	daemonpkg "github.com/schererja/smidr/internal/daemon"
 	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/internal/scheduler"
+	"github.com/schererja/smidr/internal/source"
 	"github.com/schererja/smidr/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
 var (
-	daemonAddress string
-	daemonDBPath  string
-	log           *logger.Logger
+	daemonConfigFile     string
+	daemonAddress        string
+	daemonDBPath         string
+	daemonLogBufferLines int
+	daemonBackend        string
+	daemonMaxParallel    int
+	daemonWeights        map[string]int
+	daemonTLSCert        string
+	daemonTLSKey         string
+	daemonTLSClientCA    string
+	daemonAuthTokens     string
+	daemonGitCredentials string
+	daemonRetention      artifacts.RetentionPolicy
+	daemonRetentionCust  map[string]string
+	daemonRetentionEvery time.Duration
+	daemonRecovery       string
+	log                  *logger.Logger
 )
 
 var daemonCmd = &cobra.Command{
@@ -31,37 +54,259 @@ The daemon exposes a gRPC API that allows clients to:
 - List and manage artifacts
 - Cancel running builds
 
+Settings are read from ~/.smidr/daemon.yaml (or --daemon-config) when it exists;
+flags override the file. Send SIGHUP to reload the file: container, cache,
+scheduler, retention and log buffer settings apply to new builds right away,
+the other settings require a restart.
+
 Example usage:
   smidr daemon --address :50051
   smidr daemon --address localhost:8080
-  smidr daemon --db-path ~/.smidr/builds.db`,
+  smidr daemon --daemon-config /etc/smidr/daemon.yaml
+  smidr daemon --db-path ~/.smidr/builds.db
+  smidr daemon --container-backend podman
+  smidr daemon --max-parallel-builds 4 --customer-weight acme=2
+  smidr daemon --tls-cert server.pem --tls-key server-key.pem --tls-client-ca ca.pem
+  smidr daemon --tls-cert server.pem --tls-key server-key.pem --auth-tokens tokens.yaml
+  smidr daemon --git-credentials /etc/smidr/git-credentials.yaml
+  smidr daemon --db-path ~/.smidr/builds.db --recovery resume
+  smidr daemon --db-path ~/.smidr/builds.db --retention-keep-last 20 --retention-max-age 720h \
+    --retention-customer acme=keep-last=50,max-size-gb=500
+  smidr daemon config check`,
 	RunE: runDaemon,
 }
 
 // New returns the daemon command for registration with the root command
 func New(logger *logger.Logger) *cobra.Command {
 	log = logger
+	daemonCmd.PersistentFlags().StringVar(&daemonConfigFile, "daemon-config", "", "Daemon config file (default ~/.smidr/daemon.yaml if it exists)")
 	daemonCmd.Flags().StringVar(&daemonAddress, "address", ":50051", "Address to listen on (e.g., ':50051' or 'localhost:8080')")
 	daemonCmd.Flags().StringVar(&daemonDBPath, "db-path", "", "Path to SQLite database for build persistence (e.g., ~/.smidr/builds.db). If not set, builds are not persisted.")
+	daemonCmd.Flags().IntVar(&daemonLogBufferLines, "log-buffer-lines", buildlog.DefaultBufferLines, "Number of log lines kept in memory per build; older lines are served from ~/.smidr/logs")
+	daemonCmd.Flags().StringVar(&daemonBackend, "container-backend", backend.Default, "Container backend for builds whose config does not set container.backend (docker, podman)")
+	daemonCmd.Flags().IntVar(&daemonMaxParallel, "max-parallel-builds", 0, "Maximum concurrent builds; 0 derives the limit from host CPUs and memory")
+	daemonCmd.Flags().StringToIntVar(&daemonWeights, "customer-weight", nil, "Relative share of build slots per customer (e.g. acme=2); customers default to 1")
+	daemonCmd.Flags().StringVar(&daemonTLSCert, "tls-cert", "", "Server certificate (PEM); enables TLS together with --tls-key")
+	daemonCmd.Flags().StringVar(&daemonTLSKey, "tls-key", "", "Private key (PEM) for --tls-cert")
+	daemonCmd.Flags().StringVar(&daemonTLSClientCA, "tls-client-ca", "", "CA bundle (PEM) for client certificates; requires mTLS")
+	daemonCmd.Flags().StringVar(&daemonAuthTokens, "auth-tokens", "", "YAML file of bearer tokens scoped per customer; requires a token on every request")
+	daemonCmd.Flags().StringVar(&daemonGitCredentials, "git-credentials", "", "YAML file of SSH keys, agent sockets and HTTPS tokens for private layer repositories, per customer")
+	daemonCmd.Flags().IntVar(&daemonRetention.KeepLastN, "retention-keep-last", 0, "Keep only the newest N finished builds per customer (0 = no limit)")
+	daemonCmd.Flags().DurationVar(&daemonRetention.MaxAge, "retention-max-age", 0, "Remove finished builds older than this (0 = no limit)")
+	daemonCmd.Flags().Int64Var(&daemonRetention.MaxSizeGB, "retention-max-size-gb", 0, "Remove the oldest finished builds once a customer's builds use more than this many GB (0 = no limit)")
+	daemonCmd.Flags().StringToStringVar(&daemonRetentionCust, "retention-customer", nil, "Per-customer retention overriding the defaults (e.g. acme=keep-last=5,max-age=168h)")
+	daemonCmd.Flags().DurationVar(&daemonRetentionEvery, "retention-interval", daemonpkg.DefaultRetentionInterval, "How often retention policies are enforced")
+	daemonCmd.Flags().StringVar(&daemonRecovery, "recovery", string(daemonpkg.RecoveryFail), "What to do on startup with builds interrupted by a restart (fail, requeue, resume)")
+	daemonCmd.AddCommand(newConfigCmd())
 	return daemonCmd
 }
 
+// loadDaemonConfig reads the daemon config file, if any, and applies the flags set on the command line
+func loadDaemonConfig(cmd *cobra.Command) (*config.DaemonConfig, string, error) {
+	path := daemonConfigFile
+	explicit := path != ""
+	if !explicit {
+		path = config.DefaultDaemonConfigPath()
+	}
+
+	cfg := config.DefaultDaemonConfig()
+	if path != "" {
+		loaded, err := config.LoadDaemon(path)
+		switch {
+		case err == nil:
+			cfg = loaded
+		case errors.Is(err, os.ErrNotExist) && !explicit:
+			path = ""
+		default:
+			return nil, path, err
+		}
+	}
+
+	if err := applyFlags(cmd, cfg); err != nil {
+		return nil, path, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, path, fmt.Errorf("invalid daemon settings: %w", err)
+	}
+	return cfg, path, nil
+}
+
+// applyFlags overrides config values with the flags given on the command line
+func applyFlags(cmd *cobra.Command, cfg *config.DaemonConfig) error {
+	flags := cmd.Flags()
+	if flags.Lookup("address") == nil {
+		// Subcommands such as "config check" only see the persistent flags
+		return nil
+	}
+	set := flags.Changed
+
+	if set("address") {
+		cfg.Address = daemonAddress
+	}
+	if set("db-path") {
+		cfg.DBPath = daemonDBPath
+	}
+	if set("log-buffer-lines") {
+		cfg.LogBufferLines = daemonLogBufferLines
+	}
+	if set("container-backend") {
+		cfg.Container.Backend = daemonBackend
+	}
+	if set("max-parallel-builds") {
+		cfg.Scheduler.MaxParallelBuilds = daemonMaxParallel
+	}
+	if set("customer-weight") {
+		cfg.Scheduler.CustomerWeights = daemonWeights
+	}
+	if set("tls-cert") {
+		cfg.TLS.Cert = daemonTLSCert
+	}
+	if set("tls-key") {
+		cfg.TLS.Key = daemonTLSKey
+	}
+	if set("tls-client-ca") {
+		cfg.TLS.ClientCA = daemonTLSClientCA
+	}
+	if set("auth-tokens") {
+		cfg.Auth.TokensFile = daemonAuthTokens
+	}
+	if set("git-credentials") {
+		cfg.Git.CredentialsFile = daemonGitCredentials
+	}
+	if set("retention-keep-last") {
+		cfg.Retention.KeepLast = daemonRetention.KeepLastN
+	}
+	if set("retention-max-age") {
+		cfg.Retention.MaxAge = daemonRetention.MaxAge
+	}
+	if set("retention-max-size-gb") {
+		cfg.Retention.MaxSizeGB = daemonRetention.MaxSizeGB
+	}
+	if set("retention-interval") {
+		cfg.Retention.Interval = daemonRetentionEvery
+	}
+	if set("recovery") {
+		cfg.Recovery = daemonRecovery
+	}
+	if set("retention-customer") {
+		cfg.Retention.Customers = make(map[string]config.DaemonRetentionPolicy)
+		for customer, spec := range daemonRetentionCust {
+			policy, err := artifacts.ParseRetentionPolicy(spec)
+			if err != nil {
+				return fmt.Errorf("invalid retention for customer %q: %w", customer, err)
+			}
+			cfg.Retention.Customers[customer] = config.DaemonRetentionPolicy{
+				KeepLast:  policy.KeepLastN,
+				MaxAge:    policy.MaxAge,
+				MaxSizeGB: policy.MaxSizeGB,
+			}
+		}
+	}
+	return nil
+}
+
+// retentionConfig converts the retention settings for the daemon
+func retentionConfig(cfg *config.DaemonConfig) daemonpkg.RetentionConfig {
+	toPolicy := func(p config.DaemonRetentionPolicy) artifacts.RetentionPolicy {
+		return artifacts.RetentionPolicy{KeepLastN: p.KeepLast, MaxAge: p.MaxAge, MaxSizeGB: p.MaxSizeGB}
+	}
+	retention := daemonpkg.RetentionConfig{
+		Interval:  cfg.Retention.Interval,
+		Default:   toPolicy(cfg.Retention.DaemonRetentionPolicy),
+		Customers: make(map[string]artifacts.RetentionPolicy),
+	}
+	for customer, policy := range cfg.Retention.Customers {
+		retention.Customers[customer] = toPolicy(policy)
+	}
+	return retention
+}
+
+// applyReloadable applies the settings that can change while the daemon is serving.
+// prev is the previously applied config, or nil on startup.
+func applyReloadable(server *daemonpkg.Server, sched *scheduler.Scheduler, tokens *auth.TokenStore, cfg, prev *config.DaemonConfig) error {
+	// Load everything that can fail first so a bad reload changes nothing
+	var newTokens *auth.TokenStore
+	if tokens != nil && cfg.Auth.TokensFile != "" {
+		var err error
+		newTokens, err = auth.LoadTokens(config.ExpandHome(cfg.Auth.TokensFile))
+		if err != nil {
+			return err
+		}
+	}
+	var gitCredentials *source.GitCredentialStore
+	if cfg.Git.CredentialsFile != "" {
+		var err error
+		gitCredentials, err = source.LoadGitCredentials(config.ExpandHome(cfg.Git.CredentialsFile))
+		if err != nil {
+			return err
+		}
+	}
+
+	fileURLRoots := make([]string, 0, len(cfg.Git.FileURLRoots))
+	for _, root := range cfg.Git.FileURLRoots {
+		fileURLRoots = append(fileURLRoots, config.ExpandHome(root))
+	}
+
+	server.SetLogBufferLines(cfg.LogBufferLines)
+	server.SetBuildDefaults(daemonpkg.BuildDefaults{
+		ContainerBackend: cfg.Container.Backend,
+		Image:            cfg.Container.DefaultImage,
+		DownloadsDir:     config.ExpandHome(cfg.Cache.Downloads),
+		SStateDir:        config.ExpandHome(cfg.Cache.SState),
+		BuildsDir:        config.ExpandHome(cfg.Cache.Builds),
+		GitCredentials:   gitCredentials,
+		FileURLRoots:     fileURLRoots,
+	})
+
+	sched.SetMaxParallel(cfg.Scheduler.MaxParallelBuilds)
+	for customer, weight := range cfg.Scheduler.CustomerWeights {
+		sched.SetWeight(customer, float64(weight))
+	}
+	if prev != nil {
+		for customer := range prev.Scheduler.CustomerWeights {
+			if _, ok := cfg.Scheduler.CustomerWeights[customer]; !ok {
+				sched.SetWeight(customer, 1)
+			}
+		}
+	}
+
+	retention := retentionConfig(cfg)
+	if retention.Enabled() || prev != nil {
+		server.SetRetention(retention)
+	}
+
+	if newTokens != nil {
+		tokens.Replace(newTokens)
+	}
+	return nil
+}
+
 func runDaemon(cmd *cobra.Command, args []string) error {
+	cfg, cfgPath, err := loadDaemonConfig(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Print a direct console line to guarantee visible startup feedback even if logging is misconfigured
-	fmt.Printf("Smidr daemon starting on %s\n", daemonAddress)
-	if daemonDBPath != "" {
-		fmt.Printf("Using database: %s\n", daemonDBPath)
+	fmt.Printf("Smidr daemon starting on %s\n", cfg.Address)
+	if cfg.DBPath != "" {
+		fmt.Printf("Using database: %s\n", cfg.DBPath)
 	}
 
-	log.Info("Starting Smidr daemon... Listening", slog.String("address", daemonAddress))
+	log.Info("Starting Smidr daemon... Listening", slog.String("address", cfg.Address))
+	if cfgPath != "" {
+		log.Info("Loaded daemon config", slog.String("path", cfgPath))
+	}
+	log.Info("Using container backend", slog.String("backend", cfg.Container.Backend))
 
-	// Initialize database if --db-path is provided
+	// Initialize database if a database path is configured
 	var database *db.DB
-	if daemonDBPath != "" {
-		log.Info("Enabling build persistence", slog.String("db_path", daemonDBPath))
+	if cfg.DBPath != "" {
+		dbPath := config.ExpandHome(cfg.DBPath)
+		log.Info("Enabling build persistence", slog.String("db_path", dbPath))
 
 		var err error
-		database, err = db.Open(daemonDBPath)
+		database, err = db.Open(dbPath)
 		if err != nil {
 			return fmt.Errorf("failed to open database: %w", err)
 		}
@@ -79,27 +324,75 @@ func runDaemon(cmd *cobra.Command, args []string) error {
 		} else {
 			log.Info("Database initialized successfully")
 		}
-
-		// TODO: Add recovery logic here to handle stale builds
-		// staleBuilds, err := database.ListStaleBuilds(time.Hour * 24)
-		// if err == nil && len(staleBuilds) > 0 {
-		//     log.Warn("Found stale builds from previous daemon run", slog.Int("count", len(staleBuilds)))
-		//     // Mark them as failed or handle recovery
-		// }
 	} else {
-		log.Info("Build persistence disabled (no --db-path provided)")
+		log.Info("Build persistence disabled (no db_path configured)")
 		database = nil
 	}
 
 	// Create the gRPC server
-	server := daemonpkg.NewServer(daemonAddress, log, database)
+	server := daemonpkg.NewServer(cfg.Address, log, database)
+	if cfg.ArtifactsDir != "" {
+		if err := server.SetArtifactDir(config.ExpandHome(cfg.ArtifactsDir)); err != nil {
+			return err
+		}
+	}
+	if cfg.LogDir != "" {
+		server.SetLogDir(config.ExpandHome(cfg.LogDir))
+	}
+	recovery, err := daemonpkg.ParseRecoveryPolicy(cfg.Recovery)
+	if err != nil {
+		return err
+	}
+	server.SetRecoveryPolicy(recovery)
 
-	// Set up signal handling for graceful shutdown
+	tlsFiles := auth.TLSFiles{
+		CertFile: config.ExpandHome(cfg.TLS.Cert),
+		KeyFile:  config.ExpandHome(cfg.TLS.Key),
+		CAFile:   config.ExpandHome(cfg.TLS.ClientCA),
+	}
+	if tlsFiles.Enabled() {
+		tlsConfig, err := auth.ServerTLSConfig(tlsFiles)
+		if err != nil {
+			return fmt.Errorf("invalid TLS settings: %w", err)
+		}
+		server.SetTLSConfig(tlsConfig)
+	}
+	var tokens *auth.TokenStore
+	if cfg.Auth.TokensFile != "" {
+		tokens, err = auth.LoadTokens(config.ExpandHome(cfg.Auth.TokensFile))
+		if err != nil {
+			return err
+		}
+		server.SetTokenStore(tokens)
+		if !tlsFiles.Enabled() {
+			log.Warn("Token authentication is enabled without TLS; tokens are sent in plaintext")
+		}
+	}
+
+	// Scheduler: global limit from config or host resources, fair across customers
+	sched := scheduler.New(scheduler.Options{MaxParallel: cfg.Scheduler.MaxParallelBuilds})
+	server.SetScheduler(sched)
+	if err := applyReloadable(server, sched, tokens, cfg, nil); err != nil {
+		return err
+	}
+	host := sched.Host()
+	log.Info("Build scheduler configured",
+		slog.Int("max_parallel_builds", sched.MaxParallel()),
+		slog.Int("host_cpus", host.CPUs),
+		slog.Int64("host_memory_bytes", host.MemoryBytes))
+	if retention := retentionConfig(cfg); retention.Enabled() {
+		log.Info("Retention enabled",
+			slog.String("default", retention.Default.String()),
+			slog.Int("customer_policies", len(retention.Customers)),
+			slog.Duration("interval", retention.Interval))
+	}
+
+	// Set up signal handling for graceful shutdown and config reload
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 
 	// Start server in a goroutine
 	errCh := make(chan error, 1)
@@ -110,15 +403,50 @@ func runDaemon(cmd *cobra.Command, args []string) error {
 	}()
 
 	// Wait for shutdown signal or error
-	select {
-	case <-sigCh:
-		log.Info("\nReceived shutdown signal")
-		server.Stop()
-		return nil
-	case err := <-errCh:
-		return fmt.Errorf("daemon error: %w", err)
-	case <-ctx.Done():
-		server.Stop()
-		return nil
+	for {
+		select {
+		case sig := <-sigCh:
+			if sig == syscall.SIGHUP {
+				cfg = reloadDaemonConfig(cmd, server, sched, tokens, cfg)
+				continue
+			}
+			log.Info("\nReceived shutdown signal")
+			server.Stop()
+			return nil
+		case err := <-errCh:
+			return fmt.Errorf("daemon error: %w", err)
+		case <-ctx.Done():
+			server.Stop()
+			return nil
+		}
+	}
+}
+
+// reloadDaemonConfig re-reads the config file on SIGHUP and applies the reloadable settings.
+// It returns the config now in effect; on errors the current config stays in effect.
+func reloadDaemonConfig(cmd *cobra.Command, server *daemonpkg.Server, sched *scheduler.Scheduler, tokens *auth.TokenStore, current *config.DaemonConfig) *config.DaemonConfig {
+	next, path, err := loadDaemonConfig(cmd)
+	if err != nil {
+		log.Error("Config reload failed, keeping current settings", err, slog.String("path", path))
+		return current
+	}
+	if err := applyReloadable(server, sched, tokens, next, current); err != nil {
+		log.Error("Config reload failed, keeping current settings", err, slog.String("path", path))
+		return current
+	}
+
+	if keys := current.RestartRequired(next); len(keys) > 0 {
+		log.Warn("Some changed settings take effect only after a restart", slog.Any("keys", keys))
+		// Keep reporting them until the daemon restarts
+		next.Address, next.DBPath, next.ArtifactsDir, next.LogDir = current.Address, current.DBPath, current.ArtifactsDir, current.LogDir
+		next.TLS = current.TLS
+		if (current.Auth.TokensFile == "") != (next.Auth.TokensFile == "") {
+			next.Auth = current.Auth
+		}
 	}
+	log.Info("Reloaded daemon config",
+		slog.String("path", path),
+		slog.Int("max_parallel_builds", sched.MaxParallel()),
+		slog.String("retention", retentionConfig(next).Default.String()))
+	return next
 }
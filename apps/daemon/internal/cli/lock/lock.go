@@ -0,0 +1,145 @@
+package lock
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	config "github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/source"
+	"github.com/schererja/smidr/pkg/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// New creates and returns the lock command
+func New() *cobra.Command {
+	lockCmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Manage the layer commits pinned in smidr.lock",
+		Long: `smidr.lock, next to smidr.yaml, records the commit every git layer resolved to.
+	Builds check out exactly those commits, so the same config always builds the same sources.
+	'smidr build' adds layers that are not locked yet; use 'smidr lock update' to move layers
+	to the current head of their branch.`,
+	}
+
+	updateCmd := &cobra.Command{
+		Use:   "update [layer...]",
+		Short: "Pin layers to the current head of their branch",
+		Long: `Resolve the current head of the configured branch of each layer and record it in smidr.lock.
+	Without arguments every git layer is updated and layers removed from smidr.yaml are dropped.
+	Layers with a commit in smidr.yaml are locked at that commit.
+
+	Examples:
+		smidr lock update
+		smidr lock update poky meta-openembedded
+	`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runUpdate(args); err != nil {
+				fmt.Println("Error updating lockfile:", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	lockCmd.AddCommand(updateCmd)
+	return lockCmd
+}
+
+func runUpdate(names []string) error {
+	log := logger.NewLogger()
+
+	configFile := viper.GetString("config")
+	if configFile == "" {
+		configFile = "smidr.yaml"
+	}
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	lockPath := config.LockPath(configFile)
+	lock, err := config.LoadLock(lockPath)
+	if os.IsNotExist(err) {
+		lock = &config.Lock{Version: config.LockVersion}
+	} else if err != nil {
+		return err
+	}
+
+	selected, err := selectLayers(cfg, names)
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range selected {
+		branch := cfg.LayerBranch(layer)
+		commit := layer.Commit
+		if commit == "" {
+			if commit, err = source.ResolveRemoteCommit(layer.Git, branch); err != nil {
+				return fmt.Errorf("failed to resolve layer %s: %w", layer.Name, err)
+			}
+		}
+		if previous := lock.Find(layer.Git); previous != nil && previous.Commit == commit && previous.Branch == branch {
+			log.Info("Layer unchanged", slog.String("layer", layer.Name), slog.String("commit", commit))
+		} else {
+			log.Info("📌 Pinned layer", slog.String("layer", layer.Name), slog.String("branch", branch), slog.String("commit", commit))
+		}
+		lock.Set(config.LockedLayer{Name: layer.Name, Git: layer.Git, Branch: branch, Commit: commit})
+	}
+
+	if len(names) == 0 {
+		// A full update also forgets repositories the config no longer uses
+		kept := lock.Layers[:0]
+		for _, locked := range lock.Layers {
+			for _, layer := range selected {
+				if layer.Git == locked.Git {
+					kept = append(kept, locked)
+					break
+				}
+			}
+		}
+		lock.Layers = kept
+	}
+
+	if err := lock.Save(lockPath); err != nil {
+		return err
+	}
+	log.Info("✅ Lockfile updated", slog.String("path", lockPath), slog.Int("layers", len(lock.Layers)))
+	return nil
+}
+
+// selectLayers returns the first layer of every git repository, restricted to the named
+// layers when names are given. Naming any layer of a repository selects that repository.
+func selectLayers(cfg *config.Config, names []string) ([]config.Layer, error) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = false
+	}
+
+	var selected []config.Layer
+	seen := make(map[string]bool)
+	for _, layer := range cfg.Layers {
+		if layer.Git == "" {
+			if _, ok := wanted[layer.Name]; ok {
+				return nil, fmt.Errorf("layer %s is not a git layer", layer.Name)
+			}
+			continue
+		}
+		if _, ok := wanted[layer.Name]; ok {
+			wanted[layer.Name] = true
+		} else if len(names) > 0 {
+			continue
+		}
+		if !seen[layer.Git] {
+			seen[layer.Git] = true
+			selected = append(selected, layer)
+		}
+	}
+
+	for _, name := range names {
+		if !wanted[name] {
+			return nil, fmt.Errorf("layer %s not found in configuration", name)
+		}
+	}
+	return selected, nil
+}
@@ -9,6 +9,7 @@ import (
 	clientcmd "github.com/schererja/smidr/internal/cli/client"
 	"github.com/schererja/smidr/internal/cli/daemon"
 	initcmd "github.com/schererja/smidr/internal/cli/init"
+	lockcmd "github.com/schererja/smidr/internal/cli/lock"
 	"github.com/schererja/smidr/internal/cli/logs"
 	"github.com/schererja/smidr/internal/cli/status"
 	"github.com/schererja/smidr/pkg/logger"
@@ -58,6 +59,7 @@ func init() {
 	rootCmd.AddCommand(artifacts.New())
 	rootCmd.AddCommand(daemon.New(log))
 	rootCmd.AddCommand(initcmd.New(log))
+	rootCmd.AddCommand(lockcmd.New())
 	rootCmd.AddCommand(logs.New())
 	rootCmd.AddCommand(status.New())
 }
@@ -2,9 +2,12 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"time"
+	"github.com/schererja/smidr/internal/auth"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
 )
@@ -17,15 +20,53 @@ type Client struct {
 	logClient      v1.LogServiceClient
 }
 
+// options configures how a Client connects
+type options struct {
+	tlsConfig *tls.Config
+	token     string
+}
+
+// Option configures a Client
+type Option func(*options)
+
+// WithTLS connects over TLS; include a client certificate for daemons that require mTLS
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithToken sends a bearer token with every request
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
 // NewClient creates a new client connected to the daemon at the given address
-func NewClient(address string) (*Client, error) {
+func NewClient(address string, opts ...Option) (*Client, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithBlock()}
+	if o.tlsConfig != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(o.tlsConfig)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if o.token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(auth.TokenCredentials{
+			Token:  o.token,
+			Secure: o.tlsConfig != nil,
+		}))
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-	)
+	conn, err := grpc.DialContext(ctx, address, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to daemon at %s: %w", address, err)
 	}
@@ -46,19 +87,36 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// StartBuild starts a new build on the daemon
-func (c *Client) StartBuild(ctx context.Context, configPath, target, customer string, forceClean, forceImageRebuild bool) (*v1.BuildStatusResponse, error) {
+// StartBuild starts a new build on the daemon; builds with a higher priority leave the queue first
+func (c *Client) StartBuild(ctx context.Context, configPath, target, task, customer string, forceTask, forceClean, forceImageRebuild bool, priority int32) (*v1.BuildStatusResponse, error) {
 	req := &v1.StartBuildRequest{
 		Config:            configPath,
 		Target:            target,
+		Task:              task,
+		ForceTask:         forceTask,
 		Customer:          customer,
 		ForceClean:        forceClean,
 		ForceImageRebuild: forceImageRebuild,
+		Priority:          priority,
 	}
 
 	return c.buildClient.StartBuild(ctx, req)
 }
 
+// RetryBuild starts a new build from the config snapshot of a finished build.
+// An empty target keeps the original build's target.
+func (c *Client) RetryBuild(ctx context.Context, buildID, target string, forceClean, forceImageRebuild bool, priority int32) (*v1.BuildStatusResponse, error) {
+	req := &v1.RetryBuildRequest{
+		BuildIdentifier:   &v1.BuildIdentifier{BuildId: buildID},
+		Target:            target,
+		ForceClean:        forceClean,
+		ForceImageRebuild: forceImageRebuild,
+		Priority:          priority,
+	}
+
+	return c.buildClient.RetryBuild(ctx, req)
+}
+
 // GetBuildStatus retrieves the status of a build
 func (c *Client) GetBuildStatus(ctx context.Context, buildID string) (*v1.BuildStatusResponse, error) {
 	req := &v1.BuildStatusRequest{
@@ -70,6 +128,17 @@ func (c *Client) GetBuildStatus(ctx context.Context, buildID string) (*v1.BuildS
 	return c.buildClient.GetBuildStatus(ctx, req)
 }
 
+// WatchBuild streams structured state, phase and task progress events of a build
+func (c *Client) WatchBuild(ctx context.Context, buildID string) (grpc.ServerStreamingClient[v1.BuildEvent], error) {
+	req := &v1.WatchBuildRequest{
+		BuildIdentifier: &v1.BuildIdentifier{
+			BuildId: buildID,
+		},
+	}
+
+	return c.buildClient.WatchBuild(ctx, req)
+}
+
 // StreamLogs streams logs from a build
 func (c *Client) StreamLogs(ctx context.Context, buildID string, follow bool) (grpc.ServerStreamingClient[v1.LogEntry], error) {
 	req := &v1.StreamBuildLogsRequest{
@@ -82,13 +151,24 @@ func (c *Client) StreamLogs(ctx context.Context, buildID string, follow bool) (g
 	return c.logClient.StreamBuildLogs(ctx, req)
 }
 
-// ListBuilds lists all builds
-func (c *Client) ListBuilds(ctx context.Context, states []v1.BuildState, pageSize int32) (*v1.ListBuildsResponse, error) {
-	req := &v1.ListBuildsRequest{
-		StateFilter: states,
-		PageSize:    pageSize,
+// StreamBuildLogs streams logs using a fully specified request, e.g. with stream, level, time or tail filters
+func (c *Client) StreamBuildLogs(ctx context.Context, req *v1.StreamBuildLogsRequest) (grpc.ServerStreamingClient[v1.LogEntry], error) {
+	return c.logClient.StreamBuildLogs(ctx, req)
+}
+
+// GetTaskLog fetches the collected BitBake log of a failed task; tailBytes > 0 returns only the end of it
+func (c *Client) GetTaskLog(ctx context.Context, buildID, recipe, task string, tailBytes int64) (*v1.GetTaskLogResponse, error) {
+	req := &v1.GetTaskLogRequest{
+		BuildIdentifier: &v1.BuildIdentifier{BuildId: buildID},
+		Recipe:          recipe,
+		Task:            task,
+		TailBytes:       tailBytes,
 	}
+	return c.logClient.GetTaskLog(ctx, req)
+}
 
+// ListBuilds lists builds matching the request's filters; pass next_page_token as page_token for the next page
+func (c *Client) ListBuilds(ctx context.Context, req *v1.ListBuildsRequest) (*v1.ListBuildsResponse, error) {
 	return c.buildClient.ListBuilds(ctx, req)
 }
 
@@ -103,6 +183,38 @@ func (c *Client) CancelBuild(ctx context.Context, buildID string) (*v1.CancelBui
 	return c.buildClient.CancelBuild(ctx, req)
 }
 
+// GetBuild retrieves the full record of a build
+func (c *Client) GetBuild(ctx context.Context, buildID string) (*v1.BuildDetails, error) {
+	req := &v1.GetBuildRequest{
+		BuildIdentifier: &v1.BuildIdentifier{
+			BuildId: buildID,
+		},
+	}
+
+	return c.buildClient.GetBuild(ctx, req)
+}
+
+// DeleteBuild soft-deletes a finished build and removes its artifacts
+func (c *Client) DeleteBuild(ctx context.Context, buildID string) (*v1.DeleteBuildResponse, error) {
+	req := &v1.DeleteBuildRequest{
+		BuildIdentifier: &v1.BuildIdentifier{
+			BuildId: buildID,
+		},
+	}
+
+	return c.buildClient.DeleteBuild(ctx, req)
+}
+
+// PurgeBuilds permanently removes finished builds created before olderThan, optionally of one customer
+func (c *Client) PurgeBuilds(ctx context.Context, olderThan time.Time, customer string) (*v1.PurgeBuildsResponse, error) {
+	req := &v1.PurgeBuildsRequest{
+		OlderThanUnixSeconds: olderThan.Unix(),
+		Customer:             customer,
+	}
+
+	return c.buildClient.PurgeBuilds(ctx, req)
+}
+
 // ListArtifacts lists artifacts from a completed build
 func (c *Client) ListArtifacts(ctx context.Context, buildID string) (*v1.ListArtifactsResponse, error) {
 	req := &v1.ListArtifactsRequest{
@@ -113,3 +225,31 @@ func (c *Client) ListArtifacts(ctx context.Context, buildID string) (*v1.ListArt
 
 	return c.artifactClient.ListArtifacts(ctx, req)
 }
+
+// GetArtifact retrieves details of a single artifact
+func (c *Client) GetArtifact(ctx context.Context, artifactID string) (*v1.ArtifactSummary, error) {
+	req := &v1.GetArtifactRequest{
+		ArtifactId: artifactID,
+	}
+
+	return c.artifactClient.GetArtifact(ctx, req)
+}
+
+// DownloadArtifact streams the content of an artifact starting at the given byte offset
+func (c *Client) DownloadArtifact(ctx context.Context, artifactID string, offset int64) (grpc.ServerStreamingClient[v1.DownloadArtifactResponse], error) {
+	req := &v1.DownloadArtifactRequest{
+		ArtifactId: artifactID,
+		Offset:     offset,
+	}
+
+	return c.artifactClient.DownloadArtifact(ctx, req)
+}
+
+// DeleteArtifact deletes a single artifact from a build
+func (c *Client) DeleteArtifact(ctx context.Context, artifactID string) (*v1.DeleteArtifactResponse, error) {
+	req := &v1.DeleteArtifactRequest{
+		ArtifactId: artifactID,
+	}
+
+	return c.artifactClient.DeleteArtifact(ctx, req)
+}
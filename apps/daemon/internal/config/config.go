@@ -1,10 +1,14 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"go.yaml.in/yaml/v3"
 )
@@ -21,6 +25,7 @@ type Config struct {
 	Advanced    AdvancedConfig  `yaml:"advanced,omitempty"`
 	Artifacts   []string        `yaml:"artifacts,omitempty"`
 	Container   ContainerConfig `yaml:"container,omitempty"`
+	Fetch       FetchConfig     `yaml:"fetch,omitempty"`
 	YoctoSeries string          `yaml:"yocto_series,omitempty"` // e.g. "kirkstone", "dunfell"
 }
 
@@ -32,24 +37,119 @@ type BaseConfig struct {
 }
 
 type Layer struct {
-	Name   string `yaml:"name"`
-	Git    string `yaml:"git,omitempty"`
-	Branch string `yaml:"branch,omitempty"`
-	Path   string `yaml:"path,omitempty"`
+	Name    string   `yaml:"name"`
+	Git     string   `yaml:"git,omitempty"`
+	Branch  string   `yaml:"branch,omitempty"`
+	Commit  string   `yaml:"commit,omitempty"` // pinned commit; set from smidr.lock or resolved at fetch time
+	Path    string   `yaml:"path,omitempty"`
+	Mirrors []string `yaml:"mirrors,omitempty"` // tried in order when the git URL cannot be cloned or fetched
 }
 
 type BuildConfig struct {
-	Image              string   `yaml:"image,omitempty"`
-	Machine            string   `yaml:"machine,omitempty"`
-	ExtraPackages      []string `yaml:"extra_packages,omitempty"`
-	ParallelMake       int      `yaml:"parallel_make,omitempty"`
-	BBNumberThreads    int      `yaml:"bb_number_threads,omitempty"`
-	SState             string   `yaml:"sstate,omitempty"`
-	Tmp                string   `yaml:"tmp,omitempty"`
-	Deploy             string   `yaml:"deploy,omitempty"`
-	PackageClasses     string   `yaml:"package_classes,omitempty"`
-	ExtraImageFeatures string   `yaml:"extra_image_features,omitempty"`
-	InheritClasses     []string `yaml:"inherit_classes,omitempty"`
+	Image              string         `yaml:"image,omitempty"`
+	Machine            string         `yaml:"machine,omitempty"`
+	ExtraPackages      []string       `yaml:"extra_packages,omitempty"`
+	ParallelMake       int            `yaml:"parallel_make,omitempty"`
+	BBNumberThreads    int            `yaml:"bb_number_threads,omitempty"`
+	SState             string         `yaml:"sstate,omitempty"`
+	Tmp                string         `yaml:"tmp,omitempty"`
+	Deploy             string         `yaml:"deploy,omitempty"`
+	PackageClasses     string         `yaml:"package_classes,omitempty"`
+	ExtraImageFeatures string         `yaml:"extra_image_features,omitempty"`
+	InheritClasses     []string       `yaml:"inherit_classes,omitempty"`
+	Recovery           RecoveryConfig `yaml:"recovery,omitempty"`
+}
+
+// RecoveryConfig controls how the executor tries to heal a failed BitBake build.
+// Unset fields keep the defaults: every strategy enabled, one retry, no backoff.
+type RecoveryConfig struct {
+	PackageIndex  *bool         `yaml:"package_index,omitempty"`  // regenerate package indexes for stale deploy paths
+	PseudoCleanup *bool         `yaml:"pseudo_cleanup,omitempty"` // clean the workdir of a recipe on pseudo path mismatch
+	Cleansstate   *bool         `yaml:"cleansstate,omitempty"`    // run cleansstate on the failed recipe before retrying
+	MaxRetries    *int          `yaml:"max_retries,omitempty"`    // rebuilds after cleansstate; 0 disables retries
+	Backoff       time.Duration `yaml:"backoff,omitempty"`        // wait before the first retry, doubled for every further one
+	Timeout       time.Duration `yaml:"timeout,omitempty"`        // limit of each recovery command (default 2h)
+}
+
+// DefaultRecoveryMaxRetries is the number of retries when max_retries is not set
+const DefaultRecoveryMaxRetries = 1
+
+// DefaultRecoveryTimeout limits each recovery command when timeout is not set
+const DefaultRecoveryTimeout = 2 * time.Hour
+
+// PackageIndexEnabled reports whether package index regeneration is enabled
+func (r RecoveryConfig) PackageIndexEnabled() bool { return r.PackageIndex == nil || *r.PackageIndex }
+
+// PseudoCleanupEnabled reports whether targeted pseudo cleanup is enabled
+func (r RecoveryConfig) PseudoCleanupEnabled() bool {
+	return r.PseudoCleanup == nil || *r.PseudoCleanup
+}
+
+// CleansstateEnabled reports whether cleansstate of the failed recipe is enabled
+func (r RecoveryConfig) CleansstateEnabled() bool { return r.Cleansstate == nil || *r.Cleansstate }
+
+// Retries returns the maximum number of rebuilds after cleansstate
+func (r RecoveryConfig) Retries() int {
+	if r.MaxRetries == nil {
+		return DefaultRecoveryMaxRetries
+	}
+	return *r.MaxRetries
+}
+
+// CommandTimeout returns the limit of each recovery command
+func (r RecoveryConfig) CommandTimeout() time.Duration {
+	if r.Timeout > 0 {
+		return r.Timeout
+	}
+	return DefaultRecoveryTimeout
+}
+
+// RetryBackoff returns how long to wait before the given retry (1-based)
+func (r RecoveryConfig) RetryBackoff(attempt int) time.Duration {
+	if r.Backoff <= 0 || attempt < 1 {
+		return 0
+	}
+	backoff := r.Backoff
+	for i := 1; i < attempt && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	return backoff
+}
+
+// FetchConfig controls how layer repositories are fetched. Unset fields keep the defaults:
+// two retries, starting after 5s.
+type FetchConfig struct {
+	MaxRetries *int          `yaml:"max_retries,omitempty"` // rounds over the git URL and its mirrors after the first; 0 disables retries
+	Backoff    time.Duration `yaml:"backoff,omitempty"`     // wait before the first retry, doubled for every further one
+}
+
+// DefaultFetchMaxRetries is the number of retries when fetch.max_retries is not set
+const DefaultFetchMaxRetries = 2
+
+// DefaultFetchBackoff is the wait before the first retry when fetch.backoff is not set
+const DefaultFetchBackoff = 5 * time.Second
+
+// Retries returns the maximum number of retries of a failed fetch
+func (f FetchConfig) Retries() int {
+	if f.MaxRetries == nil {
+		return DefaultFetchMaxRetries
+	}
+	return *f.MaxRetries
+}
+
+// RetryBackoff returns how long to wait before the given retry (1-based)
+func (f FetchConfig) RetryBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		return 0
+	}
+	backoff := f.Backoff
+	if backoff <= 0 {
+		backoff = DefaultFetchBackoff
+	}
+	for i := 1; i < attempt && backoff < 10*time.Minute; i++ {
+		backoff *= 2
+	}
+	return backoff
 }
 
 type ContainerConfig struct {
@@ -57,6 +157,7 @@ type ContainerConfig struct {
 	Memory     string   `yaml:"memory,omitempty"`
 	CPUCount   int      `yaml:"cpu_count,omitempty"`
 	Entrypoint []string `yaml:"entrypoint,omitempty"`
+	Backend    string   `yaml:"backend,omitempty"` // "docker" or "podman"; empty uses the daemon/CLI default
 }
 
 type DirectoryConfig struct {
@@ -125,6 +226,21 @@ func LoadFromBytes(data []byte) (*Config, error) {
 	return &cfg, nil
 }
 
+// LoadFromSnapshot parses the JSON config snapshot stored with a build record.
+// Snapshots are taken after environment substitution, so none is performed.
+func LoadFromSnapshot(data []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid config snapshot: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return &cfg, nil
+}
+
 // Legacy cache normalization removed
 
 // substituteEnvVars performs environment variable substitution in YAML content
@@ -256,6 +372,10 @@ func (c *Config) Validate() error {
 		errors = append(errors, err)
 	}
 
+	if err := c.Fetch.Validate(); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Cache validation removed
 
 	if len(errors) > 0 {
@@ -314,9 +434,26 @@ func (l *Layer) Validate() error {
 
 	// Validate git URL format if provided
 	if hasGit {
-		gitRegex := regexp.MustCompile(`^https?://[a-zA-Z0-9.-]+(/[a-zA-Z0-9._/-]*)?$`)
-		if !gitRegex.MatchString(l.Git) {
-			return ValidationError{Field: "git", Message: "invalid git URL format"}
+		if msg := checkGitURL(l.Git); msg != "" {
+			return ValidationError{Field: "git", Message: msg}
+		}
+	}
+
+	for i, mirror := range l.Mirrors {
+		if !hasGit {
+			return ValidationError{Field: "mirrors", Message: "mirrors require a git URL"}
+		}
+		if msg := checkGitURL(mirror); msg != "" {
+			return ValidationError{Field: fmt.Sprintf("mirrors[%d]", i), Message: msg}
+		}
+	}
+
+	if l.Commit != "" {
+		if !hasGit {
+			return ValidationError{Field: "commit", Message: "commit requires a git URL"}
+		}
+		if !commitRegex.MatchString(l.Commit) {
+			return ValidationError{Field: "commit", Message: "commit must be a hex commit SHA"}
 		}
 	}
 
@@ -355,6 +492,37 @@ func (b *BuildConfig) Validate() error {
 		}
 	}
 
+	return b.Recovery.Validate()
+}
+
+// Validate validates RecoveryConfig
+func (r *RecoveryConfig) Validate() error {
+	if r.MaxRetries != nil && *r.MaxRetries < 0 {
+		return ValidationError{Field: "build.recovery.max_retries", Message: "max_retries must be non-negative"}
+	}
+	if r.MaxRetries != nil && *r.MaxRetries > 10 {
+		return ValidationError{Field: "build.recovery.max_retries", Message: "max_retries must be at most 10"}
+	}
+	if r.Backoff < 0 {
+		return ValidationError{Field: "build.recovery.backoff", Message: "backoff must be non-negative"}
+	}
+	if r.Timeout < 0 {
+		return ValidationError{Field: "build.recovery.timeout", Message: "timeout must be non-negative"}
+	}
+	return nil
+}
+
+// Validate validates FetchConfig
+func (f *FetchConfig) Validate() error {
+	if f.MaxRetries != nil && *f.MaxRetries < 0 {
+		return ValidationError{Field: "fetch.max_retries", Message: "max_retries must be non-negative"}
+	}
+	if f.MaxRetries != nil && *f.MaxRetries > 10 {
+		return ValidationError{Field: "fetch.max_retries", Message: "max_retries must be at most 10"}
+	}
+	if f.Backoff < 0 {
+		return ValidationError{Field: "fetch.backoff", Message: "backoff must be non-negative"}
+	}
 	return nil
 }
 
@@ -474,6 +642,13 @@ func (c *ContainerConfig) Validate() error {
 		return ValidationError{Field: "container.cpu_count", Message: "must be non-negative"}
 	}
 
+	// Validate backend against the implementations in internal/container
+	switch c.Backend {
+	case "", "docker", "podman":
+	default:
+		return ValidationError{Field: "container.backend", Message: "must be 'docker' or 'podman'"}
+	}
+
 	// Validate entrypoint parts if provided
 	for i, part := range c.Entrypoint {
 		if strings.TrimSpace(part) == "" {
@@ -505,6 +680,106 @@ func isValidPathOrEnvVar(path string) bool {
 	return pathRegex.MatchString(path)
 }
 
+var (
+	gitHostRegex = regexp.MustCompile(`^[a-zA-Z0-9.-]+$`)
+	gitUserRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+	gitPathRegex = regexp.MustCompile(`^[a-zA-Z0-9._~/+-]+$`)
+	// scp-like syntax used by ssh remotes, e.g. git@github.com:org/meta-private.git
+	scpLikeRegex = regexp.MustCompile(`^(?:([a-zA-Z0-9._-]+)@)?([a-zA-Z0-9.-]+):([a-zA-Z0-9._~/+-]+)$`)
+)
+
+// checkGitURL returns why a layer's git URL is not acceptable, or "" if it is. Accepted are
+// http(s)://, ssh://, git:// and file:// URLs and the scp-like [user@]host:path form.
+// Credentials must not be part of the URL: the config is stored with every build, so the
+// daemon supplies them instead.
+func checkGitURL(raw string) string {
+	const invalid = "invalid git URL format (expected https://, ssh://, git://, file:// or user@host:path)"
+	if strings.HasPrefix(raw, "-") {
+		return invalid
+	}
+
+	if !strings.Contains(raw, "://") {
+		m := scpLikeRegex.FindStringSubmatch(raw)
+		if m == nil || strings.Contains(m[2], "..") {
+			return invalid
+		}
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Opaque != "" || u.RawQuery != "" || u.Fragment != "" {
+		return invalid
+	}
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			return "git URL must not contain a password or token; configure git credentials on the daemon instead"
+		}
+		if !gitUserRegex.MatchString(u.User.Username()) {
+			return invalid
+		}
+	}
+
+	switch u.Scheme {
+	case "http", "https", "ssh", "git":
+		if !gitHostRegex.MatchString(u.Hostname()) {
+			return invalid
+		}
+		if u.Path != "" && !gitPathRegex.MatchString(u.Path) {
+			return invalid
+		}
+	case "file":
+		if u.Host != "" || u.User != nil || !strings.HasPrefix(u.Path, "/") || !gitPathRegex.MatchString(u.Path) {
+			return "file git URL must be file:///absolute/path"
+		}
+	default:
+		return invalid
+	}
+	return ""
+}
+
+// CheckFileURLs rejects layer git URLs and mirrors using file:// unless they point below one
+// of roots. Local builds accept any file URL; the daemon checks the configs of remote
+// builds, which must not read arbitrary repositories on the daemon host.
+func (c *Config) CheckFileURLs(roots []string) error {
+	for i, layer := range c.Layers {
+		urls := append([]string{layer.Git}, layer.Mirrors...)
+		for j, raw := range urls {
+			u, err := url.Parse(raw)
+			if err != nil || u.Scheme != "file" || underRoot(u.Path, roots) {
+				continue
+			}
+			field := fmt.Sprintf("layers[%d].git", i)
+			if j > 0 {
+				field = fmt.Sprintf("layers[%d].mirrors[%d]", i, j-1)
+			}
+			if len(roots) == 0 {
+				return ValidationError{Field: field, Message: "file git URLs are not allowed on this daemon"}
+			}
+			return ValidationError{Field: field, Message: fmt.Sprintf("file git URL must be below %s", strings.Join(roots, ", "))}
+		}
+	}
+	return nil
+}
+
+// underRoot reports whether path, with symlinks resolved where it exists, is one of roots
+// or inside one
+func underRoot(path string, roots []string) bool {
+	path = filepath.Clean(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	for _, root := range roots {
+		root = filepath.Clean(root)
+		if resolved, err := filepath.EvalSymlinks(root); err == nil {
+			root = resolved
+		}
+		if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
 func isValidHostPort(hostPort string) bool {
 	// Validate host:port format
 	hostPortRegex := regexp.MustCompile(`^[a-zA-Z0-9.-]+:\d+$`)
@@ -0,0 +1,294 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/schererja/smidr/internal/buildlog"
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultContainerImage is the builder image used when neither the build config nor the daemon selects one
+const DefaultContainerImage = "crops/yocto:ubuntu-22.04-builder"
+
+// DaemonConfig configures the smidr daemon, typically from ~/.smidr/daemon.yaml.
+// Keys marked "reloadable" are applied on SIGHUP; the others require a restart.
+type DaemonConfig struct {
+	Address        string                `yaml:"address"`
+	DBPath         string                `yaml:"db_path,omitempty"`
+	ArtifactsDir   string                `yaml:"artifacts_dir,omitempty"`
+	LogDir         string                `yaml:"log_dir,omitempty"`
+	LogBufferLines int                   `yaml:"log_buffer_lines,omitempty"` // reloadable, for new builds
+	Container      DaemonContainerConfig `yaml:"container,omitempty"`        // reloadable, for new builds
+	Cache          DaemonCacheConfig     `yaml:"cache,omitempty"`            // reloadable, for new builds
+	Scheduler      DaemonSchedulerConfig `yaml:"scheduler,omitempty"`        // reloadable
+	Retention      DaemonRetentionConfig `yaml:"retention,omitempty"`        // reloadable
+	Recovery       string                `yaml:"recovery,omitempty"`         // applied on startup: fail, requeue or resume
+	TLS            DaemonTLSConfig       `yaml:"tls,omitempty"`
+	Auth           DaemonAuthConfig      `yaml:"auth,omitempty"` // tokens_file is reloaded if auth was enabled at startup
+	Git            DaemonGitConfig       `yaml:"git,omitempty"`  // reloadable, for new builds
+}
+
+// DaemonContainerConfig selects how build containers are run when a build config does not say
+type DaemonContainerConfig struct {
+	Backend      string `yaml:"backend,omitempty"`       // "docker" or "podman"
+	DefaultImage string `yaml:"default_image,omitempty"` // builder image for configs without container.base_image
+}
+
+// DaemonCacheConfig holds the shared locations used by builds whose config does not set directories
+type DaemonCacheConfig struct {
+	Downloads string `yaml:"downloads,omitempty"` // shared DL_DIR
+	SState    string `yaml:"sstate,omitempty"`    // shared SSTATE_DIR
+	Builds    string `yaml:"builds,omitempty"`    // parent of per-build workspaces
+}
+
+// DaemonSchedulerConfig limits concurrent builds
+type DaemonSchedulerConfig struct {
+	MaxParallelBuilds int            `yaml:"max_parallel_builds,omitempty"` // 0 derives the limit from host CPUs and memory
+	CustomerWeights   map[string]int `yaml:"customer_weights,omitempty"`    // relative share of build slots; default 1
+}
+
+// DaemonRetentionPolicy limits the finished builds kept for a customer; zero values mean no limit
+type DaemonRetentionPolicy struct {
+	KeepLast  int           `yaml:"keep_last,omitempty"`
+	MaxAge    time.Duration `yaml:"max_age,omitempty"`
+	MaxSizeGB int64         `yaml:"max_size_gb,omitempty"`
+}
+
+// DaemonRetentionConfig configures the retention janitor
+type DaemonRetentionConfig struct {
+	Interval              time.Duration                    `yaml:"interval,omitempty"`
+	DaemonRetentionPolicy `yaml:",inline"`                 // default policy
+	Customers             map[string]DaemonRetentionPolicy `yaml:"customers,omitempty"`
+}
+
+// DaemonTLSConfig enables TLS, and mTLS when a client CA is set
+type DaemonTLSConfig struct {
+	Cert     string `yaml:"cert,omitempty"`
+	Key      string `yaml:"key,omitempty"`
+	ClientCA string `yaml:"client_ca,omitempty"`
+}
+
+// DaemonAuthConfig enables bearer token authentication
+type DaemonAuthConfig struct {
+	TokensFile string `yaml:"tokens_file,omitempty"`
+}
+
+// DaemonGitConfig supplies the credentials for private layer repositories
+type DaemonGitConfig struct {
+	CredentialsFile string `yaml:"credentials_file,omitempty"`
+	// FileURLRoots are the directories file:// layer URLs may point into; without any,
+	// builds started through the daemon cannot use file:// URLs
+	FileURLRoots []string `yaml:"file_url_roots,omitempty"`
+}
+
+// DefaultDaemonConfig returns the settings the daemon uses without a config file
+func DefaultDaemonConfig() *DaemonConfig {
+	return &DaemonConfig{
+		Address:        ":50051",
+		ArtifactsDir:   "~/.smidr/artifacts",
+		LogDir:         "~/.smidr/logs",
+		LogBufferLines: buildlog.DefaultBufferLines,
+		Container: DaemonContainerConfig{
+			Backend:      "docker",
+			DefaultImage: DefaultContainerImage,
+		},
+		Cache: DaemonCacheConfig{
+			Builds: "~/.smidr/builds",
+		},
+		Retention: DaemonRetentionConfig{
+			Interval: time.Hour,
+		},
+		Recovery: "fail",
+	}
+}
+
+// DefaultDaemonConfigPath returns ~/.smidr/daemon.yaml
+func DefaultDaemonConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".smidr", "daemon.yaml")
+}
+
+// LoadDaemon reads a daemon config file on top of the defaults, substitutes
+// environment variables and validates the result
+func LoadDaemon(path string) (*DaemonConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultDaemonConfig()
+	decoder := yaml.NewDecoder(strings.NewReader(string(substituteEnvVars(data))))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+	return cfg, nil
+}
+
+// Validate performs validation of the daemon configuration
+func (d *DaemonConfig) Validate() error {
+	var errors []error
+
+	if err := validateListenAddress(d.Address); err != nil {
+		errors = append(errors, err)
+	}
+
+	paths := []struct{ field, value string }{
+		{"db_path", d.DBPath},
+		{"artifacts_dir", d.ArtifactsDir},
+		{"log_dir", d.LogDir},
+		{"cache.downloads", d.Cache.Downloads},
+		{"cache.sstate", d.Cache.SState},
+		{"cache.builds", d.Cache.Builds},
+		{"tls.cert", d.TLS.Cert},
+		{"tls.key", d.TLS.Key},
+		{"tls.client_ca", d.TLS.ClientCA},
+		{"auth.tokens_file", d.Auth.TokensFile},
+		{"git.credentials_file", d.Git.CredentialsFile},
+	}
+	for _, p := range paths {
+		if p.value != "" && !isValidPathOrEnvVar(p.value) {
+			errors = append(errors, ValidationError{Field: p.field, Message: "invalid path format"})
+		}
+	}
+
+	for i, root := range d.Git.FileURLRoots {
+		field := fmt.Sprintf("git.file_url_roots[%d]", i)
+		if !isValidPathOrEnvVar(root) {
+			errors = append(errors, ValidationError{Field: field, Message: "invalid path format"})
+		} else if expanded := ExpandHome(root); !filepath.IsAbs(expanded) || filepath.Clean(expanded) == "/" {
+			errors = append(errors, ValidationError{Field: field, Message: "must be an absolute path other than /"})
+		}
+	}
+
+	if d.LogBufferLines < 0 {
+		errors = append(errors, ValidationError{Field: "log_buffer_lines", Message: "must be non-negative"})
+	}
+
+	switch d.Container.Backend {
+	case "", "docker", "podman":
+	default:
+		errors = append(errors, ValidationError{Field: "container.backend", Message: "must be 'docker' or 'podman'"})
+	}
+	if strings.ContainsAny(d.Container.DefaultImage, " \t\n") {
+		errors = append(errors, ValidationError{Field: "container.default_image", Message: "must not contain whitespace"})
+	}
+
+	if d.Scheduler.MaxParallelBuilds < 0 {
+		errors = append(errors, ValidationError{Field: "scheduler.max_parallel_builds", Message: "must be non-negative"})
+	}
+	for customer, weight := range d.Scheduler.CustomerWeights {
+		if weight <= 0 {
+			errors = append(errors, ValidationError{Field: fmt.Sprintf("scheduler.customer_weights[%s]", customer), Message: "must be positive"})
+		}
+	}
+
+	if d.Retention.Interval < 0 {
+		errors = append(errors, ValidationError{Field: "retention.interval", Message: "must be non-negative"})
+	}
+	if err := d.Retention.DaemonRetentionPolicy.validate("retention"); err != nil {
+		errors = append(errors, err)
+	}
+	for customer, policy := range d.Retention.Customers {
+		if err := policy.validate(fmt.Sprintf("retention.customers[%s]", customer)); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	switch d.Recovery {
+	case "", "fail", "requeue", "resume":
+	default:
+		errors = append(errors, ValidationError{Field: "recovery", Message: "must be 'fail', 'requeue' or 'resume'"})
+	}
+
+	if (d.TLS.Cert == "") != (d.TLS.Key == "") {
+		errors = append(errors, ValidationError{Field: "tls", Message: "cert and key must be set together"})
+	}
+	if d.TLS.ClientCA != "" && d.TLS.Cert == "" {
+		errors = append(errors, ValidationError{Field: "tls.client_ca", Message: "requires tls.cert and tls.key"})
+	}
+
+	if len(errors) > 0 {
+		var errorMessages []string
+		for _, err := range errors {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		return fmt.Errorf("validation failed:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+// validate checks a retention policy; field prefixes the reported field names
+func (p DaemonRetentionPolicy) validate(field string) error {
+	if p.KeepLast < 0 {
+		return ValidationError{Field: field + ".keep_last", Message: "must be non-negative"}
+	}
+	if p.MaxAge < 0 {
+		return ValidationError{Field: field + ".max_age", Message: "must be non-negative"}
+	}
+	if p.MaxSizeGB < 0 {
+		return ValidationError{Field: field + ".max_size_gb", Message: "must be non-negative"}
+	}
+	return nil
+}
+
+// validateListenAddress checks a host:port the daemon can listen on; the host may be empty
+func validateListenAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return ValidationError{Field: "address", Message: "must be in format 'host:port' or ':port'"}
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return ValidationError{Field: "address", Message: "port must be a number between 0 and 65535"}
+	}
+	if host != "" && strings.ContainsAny(host, " /") {
+		return ValidationError{Field: "address", Message: "invalid host"}
+	}
+	return nil
+}
+
+// RestartRequired lists the keys that differ from next and only take effect after a restart
+func (d *DaemonConfig) RestartRequired(next *DaemonConfig) []string {
+	var keys []string
+	check := func(key string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			keys = append(keys, key)
+		}
+	}
+	check("address", d.Address, next.Address)
+	check("db_path", d.DBPath, next.DBPath)
+	check("artifacts_dir", d.ArtifactsDir, next.ArtifactsDir)
+	check("log_dir", d.LogDir, next.LogDir)
+	check("tls", d.TLS, next.TLS)
+	// Token files can be swapped, but authentication cannot be turned on or off while serving
+	check("auth.tokens_file", d.Auth.TokensFile != "", next.Auth.TokensFile != "")
+	return keys
+}
+
+// ExpandHome replaces a leading "~" in path with the user's home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeDaemonConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "daemon.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDaemon(t *testing.T) {
+	t.Setenv("SMIDR_TEST_CACHE", "/srv/cache")
+	path := writeDaemonConfig(t, `
+address: "127.0.0.1:6000"
+db_path: ~/.smidr/builds.db
+container:
+  backend: podman
+  default_image: registry.example.com/yocto-builder:1
+cache:
+  downloads: ${SMIDR_TEST_CACHE}/downloads
+  sstate: ${SMIDR_TEST_CACHE}/sstate
+scheduler:
+  max_parallel_builds: 3
+  customer_weights:
+    acme: 2
+retention:
+  interval: 30m
+  keep_last: 20
+  max_age: 720h
+  customers:
+    acme:
+      max_size_gb: 500
+recovery: resume
+git:
+  file_url_roots: [/srv/git]
+`)
+
+	cfg, err := LoadDaemon(path)
+	if err != nil {
+		t.Fatalf("LoadDaemon failed: %v", err)
+	}
+	if cfg.Address != "127.0.0.1:6000" || cfg.Container.Backend != "podman" {
+		t.Errorf("unexpected address/backend: %q %q", cfg.Address, cfg.Container.Backend)
+	}
+	if cfg.Cache.Downloads != "/srv/cache/downloads" {
+		t.Errorf("expected env substitution, got %q", cfg.Cache.Downloads)
+	}
+	if cfg.Scheduler.MaxParallelBuilds != 3 || cfg.Scheduler.CustomerWeights["acme"] != 2 {
+		t.Errorf("unexpected scheduler settings: %+v", cfg.Scheduler)
+	}
+	if cfg.Retention.Interval != 30*time.Minute || cfg.Retention.KeepLast != 20 || cfg.Retention.MaxAge != 720*time.Hour {
+		t.Errorf("unexpected retention defaults: %+v", cfg.Retention)
+	}
+	if cfg.Recovery != "resume" {
+		t.Errorf("unexpected recovery policy %q", cfg.Recovery)
+	}
+	if len(cfg.Git.FileURLRoots) != 1 || cfg.Git.FileURLRoots[0] != "/srv/git" {
+		t.Errorf("unexpected file URL roots: %v", cfg.Git.FileURLRoots)
+	}
+	if cfg.Retention.Customers["acme"].MaxSizeGB != 500 {
+		t.Errorf("unexpected customer retention: %+v", cfg.Retention.Customers)
+	}
+
+	// Keys missing from the file keep their defaults
+	if cfg.LogDir != "~/.smidr/logs" || cfg.Cache.Builds != "~/.smidr/builds" {
+		t.Errorf("expected defaults for unset keys, got log_dir=%q cache.builds=%q", cfg.LogDir, cfg.Cache.Builds)
+	}
+}
+
+func TestLoadDaemonEmptyFile(t *testing.T) {
+	cfg, err := LoadDaemon(writeDaemonConfig(t, ""))
+	if err != nil {
+		t.Fatalf("expected an empty file to load the defaults, got %v", err)
+	}
+	if cfg.Address != ":50051" || cfg.Container.DefaultImage != DefaultContainerImage || cfg.Recovery != "fail" {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+}
+
+func TestLoadDaemonInvalid(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		want    string
+	}{
+		"unknown key":      {"adress: :50051\n", "adress"},
+		"bad address":      {"address: localhost\n", "address"},
+		"bad backend":      {"container:\n  backend: lxc\n", "container.backend"},
+		"negative weight":  {"scheduler:\n  customer_weights:\n    acme: 0\n", "customer_weights[acme]"},
+		"negative keep":    {"retention:\n  customers:\n    acme:\n      keep_last: -1\n", "retention.customers[acme].keep_last"},
+		"bad recovery":     {"recovery: restart\n", "recovery"},
+		"cert without key": {"tls:\n  cert: server.pem\n", "cert and key"},
+		"client ca only":   {"tls:\n  client_ca: ca.pem\n", "tls.client_ca"},
+		"relative root":    {"git:\n  file_url_roots: [srv/git]\n", "git.file_url_roots[0]"},
+		"root of fs":       {"git:\n  file_url_roots: [/]\n", "git.file_url_roots[0]"},
+	}
+	for name, tc := range cases {
+		_, err := LoadDaemon(writeDaemonConfig(t, tc.content))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error mentioning %q, got %v", name, tc.want, err)
+		}
+	}
+}
+
+func TestDaemonConfigRestartRequired(t *testing.T) {
+	current := DefaultDaemonConfig()
+	next := DefaultDaemonConfig()
+	next.Scheduler.MaxParallelBuilds = 8
+	next.Container.DefaultImage = "other:latest"
+	if keys := current.RestartRequired(next); len(keys) != 0 {
+		t.Errorf("expected reloadable changes only, got %v", keys)
+	}
+
+	next.Address = ":6000"
+	next.Auth.TokensFile = "tokens.yaml"
+	keys := current.RestartRequired(next)
+	if strings.Join(keys, ",") != "address,auth.tokens_file" {
+		t.Errorf("unexpected restart keys %v", keys)
+	}
+
+	// Swapping one tokens file for another is reloadable
+	current.Auth.TokensFile = "old.yaml"
+	next = DefaultDaemonConfig()
+	next.Auth.TokensFile = "new.yaml"
+	if keys := current.RestartRequired(next); len(keys) != 0 {
+		t.Errorf("expected tokens file swap to be reloadable, got %v", keys)
+	}
+}
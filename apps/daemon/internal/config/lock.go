@@ -0,0 +1,187 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// LockFileName is the name of the lockfile kept next to the build config
+const LockFileName = "smidr.lock"
+
+// LockVersion is the format version written to new lockfiles
+const LockVersion = 1
+
+var commitRegex = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// Lock pins every git layer of a config to a resolved commit, so the same config
+// checks out the same sources on every build
+type Lock struct {
+	Version int           `yaml:"version"`
+	Layers  []LockedLayer `yaml:"layers"`
+}
+
+// LockedLayer is the commit a layer repository resolved to
+type LockedLayer struct {
+	Name   string `yaml:"name" json:"name"`
+	Git    string `yaml:"git" json:"git"`
+	Branch string `yaml:"branch,omitempty" json:"branch,omitempty"`
+	Commit string `yaml:"commit" json:"commit"`
+}
+
+// LockPath returns the path of the lockfile belonging to a config file
+func LockPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), LockFileName)
+}
+
+// LoadLock reads a lockfile. A missing file yields an error satisfying os.IsNotExist.
+func LoadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lock{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := lock.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid lockfile %s: %w", path, err)
+	}
+	return lock, nil
+}
+
+// Validate checks the lockfile version and that every entry names a repository and a commit
+func (l *Lock) Validate() error {
+	if l.Version != LockVersion {
+		return fmt.Errorf("unsupported lockfile version %d", l.Version)
+	}
+	seen := make(map[string]bool)
+	for i, layer := range l.Layers {
+		if layer.Git == "" {
+			return ValidationError{Field: fmt.Sprintf("layers[%d].git", i), Message: "git is required"}
+		}
+		if !commitRegex.MatchString(layer.Commit) {
+			return ValidationError{Field: fmt.Sprintf("layers[%d].commit", i), Message: "commit must be a hex commit SHA"}
+		}
+		if seen[layer.Git] {
+			return ValidationError{Field: fmt.Sprintf("layers[%d].git", i), Message: "repository is locked twice"}
+		}
+		seen[layer.Git] = true
+	}
+	return nil
+}
+
+// Save writes the lockfile atomically
+func (l *Lock) Save(path string) error {
+	if l.Version == 0 {
+		l.Version = LockVersion
+	}
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %w", err)
+	}
+	data = append([]byte("# Generated by smidr. Refresh with 'smidr lock update [layer...]'.\n"), data...)
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	return nil
+}
+
+// Find returns the entry of a repository, or nil if it is not locked
+func (l *Lock) Find(git string) *LockedLayer {
+	for i := range l.Layers {
+		if l.Layers[i].Git == git {
+			return &l.Layers[i]
+		}
+	}
+	return nil
+}
+
+// Set adds or replaces the entry of a repository
+func (l *Lock) Set(layer LockedLayer) {
+	if existing := l.Find(layer.Git); existing != nil {
+		*existing = layer
+		return
+	}
+	l.Layers = append(l.Layers, layer)
+}
+
+// LayerBranch returns the branch a layer is fetched from; yocto_series is the default
+func (c *Config) LayerBranch(layer Layer) string {
+	if layer.Branch == "" {
+		return c.YoctoSeries
+	}
+	return layer.Branch
+}
+
+// ApplyLock pins the git layers to the commits of the lock. Layers with a commit in the
+// config keep it. It returns the repositories the lock has no valid entry for: those
+// missing from it and those locked on a different branch than configured.
+func (c *Config) ApplyLock(lock *Lock) []string {
+	var unlocked []string
+	seen := make(map[string]bool)
+	for i := range c.Layers {
+		layer := &c.Layers[i]
+		if layer.Git == "" || layer.Commit != "" {
+			continue
+		}
+		locked := lock.Find(layer.Git)
+		if locked == nil || locked.Branch != c.LayerBranch(*layer) {
+			if !seen[layer.Git] {
+				seen[layer.Git] = true
+				unlocked = append(unlocked, layer.Name)
+			}
+			continue
+		}
+		layer.Commit = locked.Commit
+	}
+	return unlocked
+}
+
+// ApplyLockFile applies the lockfile at path, see ApplyLock. Without a lockfile nothing is
+// pinned and every git layer is reported as unlocked.
+func (c *Config) ApplyLockFile(path string) ([]string, error) {
+	lock, err := LoadLock(path)
+	if os.IsNotExist(err) {
+		lock = &Lock{Version: LockVersion}
+	} else if err != nil {
+		return nil, err
+	}
+	return c.ApplyLock(lock), nil
+}
+
+// PinCommits records resolved commits, keyed by repository URL, on the git layers
+// that have no commit yet
+func (c *Config) PinCommits(commits map[string]string) {
+	for i := range c.Layers {
+		layer := &c.Layers[i]
+		if layer.Git != "" && layer.Commit == "" {
+			layer.Commit = commits[layer.Git]
+		}
+	}
+}
+
+// Lock returns the commits the git layers are pinned to, one entry per repository
+func (c *Config) Lock() *Lock {
+	lock := &Lock{Version: LockVersion}
+	for _, layer := range c.Layers {
+		if layer.Git == "" || layer.Commit == "" || lock.Find(layer.Git) != nil {
+			continue
+		}
+		lock.Layers = append(lock.Layers, LockedLayer{Name: layer.Name, Git: layer.Git, Branch: c.LayerBranch(layer), Commit: layer.Commit})
+	}
+	return lock
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	pokyGit = "https://git.yoctoproject.org/poky"
+	oeGit   = "https://github.com/openembedded/meta-openembedded"
+	pokySHA = "0123456789abcdef0123456789abcdef01234567"
+	oeSHA   = "89abcdef0123456789abcdef0123456789abcdef"
+)
+
+func lockTestConfig() *Config {
+	return &Config{
+		YoctoSeries: "kirkstone",
+		Layers: []Layer{
+			{Name: "poky", Git: pokyGit},
+			{Name: "meta-oe", Git: oeGit, Branch: "kirkstone"},
+			{Name: "meta-python", Git: oeGit, Branch: "kirkstone"},
+			{Name: "meta-local", Path: "/srv/layers/meta-local"},
+		},
+	}
+}
+
+func TestLock_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), LockFileName)
+	lock := &Lock{Layers: []LockedLayer{{Name: "poky", Git: pokyGit, Branch: "kirkstone", Commit: pokySHA}}}
+	if err := lock.Save(path); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	loaded, err := LoadLock(path)
+	if err != nil {
+		t.Fatalf("LoadLock returned error: %v", err)
+	}
+	if loaded.Version != LockVersion || len(loaded.Layers) != 1 || loaded.Layers[0] != lock.Layers[0] {
+		t.Errorf("unexpected lock %+v", loaded)
+	}
+
+	if _, err := LoadLock(filepath.Join(t.TempDir(), LockFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected a not-exist error for a missing lockfile, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("version: 1\nlayers:\n  - name: poky\n    git: "+pokyGit+"\n    commit: main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadLock(path); err == nil || !strings.Contains(err.Error(), "commit") {
+		t.Errorf("expected a branch name to be rejected as commit, got %v", err)
+	}
+}
+
+func TestConfig_ApplyLock(t *testing.T) {
+	t.Parallel()
+
+	cfg := lockTestConfig()
+	lock := &Lock{Version: LockVersion, Layers: []LockedLayer{
+		{Name: "poky", Git: pokyGit, Branch: "kirkstone", Commit: pokySHA},
+		// Locked on another branch than configured: stale
+		{Name: "meta-oe", Git: oeGit, Branch: "dunfell", Commit: oeSHA},
+	}}
+
+	unlocked := cfg.ApplyLock(lock)
+	if cfg.Layers[0].Commit != pokySHA {
+		t.Errorf("expected poky to be pinned, got %q", cfg.Layers[0].Commit)
+	}
+	if cfg.Layers[1].Commit != "" || cfg.Layers[2].Commit != "" {
+		t.Errorf("expected the stale meta-openembedded entry to be ignored: %+v", cfg.Layers)
+	}
+	if len(unlocked) != 1 || unlocked[0] != "meta-oe" {
+		t.Errorf("expected meta-oe to be reported once, got %v", unlocked)
+	}
+}
+
+func TestConfig_ApplyLockFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), LockFileName)
+	cfg := lockTestConfig()
+	unlocked, err := cfg.ApplyLockFile(path)
+	if err != nil {
+		t.Fatalf("expected a missing lockfile to be fine, got %v", err)
+	}
+	if len(unlocked) != 2 {
+		t.Errorf("expected every repository to be unlocked, got %v", unlocked)
+	}
+
+	lock := &Lock{Layers: []LockedLayer{{Name: "poky", Git: pokyGit, Branch: "kirkstone", Commit: pokySHA}}}
+	if err := lock.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	cfg = lockTestConfig()
+	if _, err := cfg.ApplyLockFile(path); err != nil {
+		t.Fatalf("ApplyLockFile returned error: %v", err)
+	}
+	if cfg.Layers[0].Commit != pokySHA {
+		t.Errorf("expected poky to be pinned, got %q", cfg.Layers[0].Commit)
+	}
+}
+
+func TestConfig_PinCommitsAndLock(t *testing.T) {
+	t.Parallel()
+
+	cfg := lockTestConfig()
+	cfg.Layers[0].Commit = pokySHA
+	cfg.PinCommits(map[string]string{pokyGit: "ffffffffffffffffffffffffffffffffffffffff", oeGit: oeSHA})
+	if cfg.Layers[0].Commit != pokySHA {
+		t.Errorf("expected an existing pin to be kept, got %q", cfg.Layers[0].Commit)
+	}
+	if cfg.Layers[1].Commit != oeSHA || cfg.Layers[2].Commit != oeSHA {
+		t.Errorf("expected both meta-openembedded layers to be pinned: %+v", cfg.Layers)
+	}
+
+	lock := cfg.Lock()
+	if len(lock.Layers) != 2 {
+		t.Fatalf("expected one entry per repository, got %+v", lock.Layers)
+	}
+	if lock.Layers[0].Branch != "kirkstone" || lock.Layers[1].Name != "meta-oe" {
+		t.Errorf("unexpected lock entries %+v", lock.Layers)
+	}
+}
+
+func TestLayerValidation_Commit(t *testing.T) {
+	t.Parallel()
+
+	layer := Layer{Name: "poky", Git: pokyGit, Commit: pokySHA}
+	if err := layer.Validate(); err != nil {
+		t.Fatalf("expected a pinned layer to be valid, got %v", err)
+	}
+	layer.Commit = "kirkstone"
+	if err := layer.Validate(); err == nil {
+		t.Fatalf("expected validation error for a non-SHA commit")
+	}
+	layer = Layer{Name: "local", Path: "/srv/layers/local", Commit: pokySHA}
+	if err := layer.Validate(); err == nil {
+		t.Fatalf("expected validation error for a commit without git URL")
+	}
+}
@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"fmt"
+
+	smidrContainer "github.com/schererja/smidr/internal/container"
+	"github.com/schererja/smidr/internal/container/docker"
+	"github.com/schererja/smidr/internal/container/podman"
+	"github.com/schererja/smidr/pkg/logger"
+)
+
+// Supported container backends
+const (
+	Docker = "docker"
+	Podman = "podman"
+
+	// Default is used when neither the build config nor the daemon selects a backend
+	Default = Docker
+)
+
+// Names lists the supported backends
+var Names = []string{Docker, Podman}
+
+// Resolve returns the first non-empty backend name, falling back to Default
+func Resolve(names ...string) string {
+	for _, name := range names {
+		if name != "" {
+			return name
+		}
+	}
+	return Default
+}
+
+// New creates the container manager for the named backend ("" selects Default)
+func New(name string, log *logger.Logger) (smidrContainer.ContainerManager, error) {
+	// Return untyped nil on error so callers never see a non-nil interface around a nil manager
+	switch Resolve(name) {
+	case Docker:
+		dm, err := docker.NewDockerManager(log)
+		if err != nil {
+			return nil, err
+		}
+		return dm, nil
+	case Podman:
+		pm, err := podman.NewPodmanManager(log)
+		if err != nil {
+			return nil, err
+		}
+		return pm, nil
+	default:
+		return nil, fmt.Errorf("unknown container backend %q (supported: %v)", name, Names)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
@@ -25,44 +26,6 @@ import (
 	"github.com/schererja/smidr/pkg/logger"
 )
 
-// lineCallbackWriter buffers bytes until newline and invokes a callback per line while mirroring to a buffer
-type lineCallbackWriter struct {
-	buf    bytes.Buffer
-	onLine func(string)
-	mirror *bytes.Buffer
-}
-
-// writeLine delivers a line to callback and mirror
-func writeLine(w *lineCallbackWriter, line string) {
-	if w.onLine != nil {
-		w.onLine(strings.TrimRight(line, "\r"))
-	}
-	if w.mirror != nil {
-		w.mirror.WriteString(line)
-		w.mirror.WriteByte('\n')
-	}
-}
-
-// Write implements io.Writer and splits input by newlines
-func (w *lineCallbackWriter) Write(p []byte) (int, error) {
-	n := len(p)
-	for len(p) > 0 {
-		if i := bytes.IndexByte(p, '\n'); i >= 0 {
-			// write up to newline
-			w.buf.Write(p[:i])
-			line := w.buf.String()
-			writeLine(w, line)
-			w.buf.Reset()
-			p = p[i+1:]
-		} else {
-			// no newline, buffer remainder
-			w.buf.Write(p)
-			break
-		}
-	}
-	return n, nil
-}
-
 // DockerManager implements container.ContainerManager using the Docker Engine API
 // (moby/moby Go client)
 
@@ -335,6 +298,7 @@ func (d *DockerManager) CreateContainer(ctx context.Context, cfg smidrContainer.
 		Env:        cfg.Env,
 		Entrypoint: entrypoint,
 		Cmd:        cmd,
+		Labels:     cfg.Labels,
 	}, hostConfig, &network.NetworkingConfig{}, nil, cfg.Name)
 	if err != nil {
 		d.logger.Error("failed to create container", err, slog.String("name", cfg.Name), slog.String("image", cfg.Image))
@@ -418,6 +382,33 @@ func (d *DockerManager) RemoveContainer(ctx context.Context, containerID string,
 	return nil
 }
 
+func (d *DockerManager) ListContainers(ctx context.Context, labels map[string]string) ([]smidrContainer.ContainerInfo, error) {
+	args := filters.NewArgs()
+	for key, value := range labels {
+		args.Add("label", key+"="+value)
+	}
+	summaries, err := d.cli.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		d.logger.Error("failed to list containers", err)
+		return nil, err
+	}
+	infos := make([]smidrContainer.ContainerInfo, 0, len(summaries))
+	for _, c := range summaries {
+		var name string
+		if len(c.Names) > 0 {
+			// Docker reports names with a leading slash
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		infos = append(infos, smidrContainer.ContainerInfo{
+			ID:     c.ID,
+			Name:   name,
+			State:  string(c.State),
+			Labels: c.Labels,
+		})
+	}
+	return infos, nil
+}
+
 func (d *DockerManager) Exec(ctx context.Context, containerID string, cmd []string, timeout time.Duration) (smidrContainer.ExecResult, error) {
 	d.logger.Debug("executing command in container",
 		slog.String("container_id", containerID),
@@ -545,8 +536,8 @@ func (d *DockerManager) ExecStreamLines(ctx context.Context, containerID string,
 
 	// Prepare writers for stdout and stderr
 	outBytes, errBytes := new(bytes.Buffer), new(bytes.Buffer)
-	stdoutLW := &lineCallbackWriter{onLine: onStdout, mirror: outBytes}
-	stderrLW := &lineCallbackWriter{onLine: onStderr, mirror: errBytes}
+	stdoutLW := smidrContainer.NewLineWriter(onStdout, outBytes)
+	stderrLW := smidrContainer.NewLineWriter(onStderr, errBytes)
 
 	// Demultiplex Docker's multiplexed stream to our writers
 	if _, err := stdcopy.StdCopy(stdoutLW, stderrLW, resp.Reader); err != nil {
@@ -555,14 +546,8 @@ func (d *DockerManager) ExecStreamLines(ctx context.Context, containerID string,
 	}
 
 	// Flush any remaining partial lines
-	if stdoutLW.buf.Len() > 0 {
-		writeLine(stdoutLW, stdoutLW.buf.String())
-		stdoutLW.buf.Reset()
-	}
-	if stderrLW.buf.Len() > 0 {
-		writeLine(stderrLW, stderrLW.buf.String())
-		stderrLW.buf.Reset()
-	}
+	stdoutLW.Flush()
+	stderrLW.Flush()
 
 	inspect, err := d.cli.ContainerExecInspect(execCtx, execIDResp.ID)
 	if err != nil {
@@ -0,0 +1,58 @@
+package container
+
+import (
+	"bytes"
+	"strings"
+)
+
+// LineWriter buffers bytes until newline and invokes a callback per line while mirroring to a buffer.
+// Backends use it to implement ContainerManagerStreamer.
+type LineWriter struct {
+	buf    bytes.Buffer
+	onLine func(string)
+	mirror *bytes.Buffer
+}
+
+// NewLineWriter returns a LineWriter calling onLine for each line and appending it to mirror.
+// Either may be nil.
+func NewLineWriter(onLine func(string), mirror *bytes.Buffer) *LineWriter {
+	return &LineWriter{onLine: onLine, mirror: mirror}
+}
+
+// writeLine delivers a line to callback and mirror
+func (w *LineWriter) writeLine(line string) {
+	if w.onLine != nil {
+		w.onLine(strings.TrimRight(line, "\r"))
+	}
+	if w.mirror != nil {
+		w.mirror.WriteString(line)
+		w.mirror.WriteByte('\n')
+	}
+}
+
+// Write implements io.Writer and splits input by newlines
+func (w *LineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			// write up to newline
+			w.buf.Write(p[:i])
+			w.writeLine(w.buf.String())
+			w.buf.Reset()
+			p = p[i+1:]
+		} else {
+			// no newline, buffer remainder
+			w.buf.Write(p)
+			break
+		}
+	}
+	return n, nil
+}
+
+// Flush delivers a remaining partial line, if any
+func (w *LineWriter) Flush() {
+	if w.buf.Len() > 0 {
+		w.writeLine(w.buf.String())
+		w.buf.Reset()
+	}
+}
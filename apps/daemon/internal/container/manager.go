@@ -13,16 +13,34 @@ type ContainerConfig struct {
 	Cmd                  []string
 	Entrypoint           []string
 	Mounts               []Mount
-	DownloadsDir         string   // Host path to mount as /home/builder/downloads
-	SstateCacheDir       string   // Host path to mount as /home/builder/sstate-cache
-	BuildDir             string   // Host path to mount as /home/builder/build (persistent Yocto build dir)
-	WorkspaceDir         string   // Host path to mount as /home/builder/work (main workspace)
-	WorkspaceMountTarget string   // Container path where WorkspaceDir/BuildDir should be mounted (defaults to /home/builder/build if empty)
-	LayerDirs            []string // Host paths to Yocto meta-layers to inject into /home/builder/layers
-	LayerNames           []string // Names corresponding to LayerDirs for proper mounting
-	MemoryLimit          string   `yaml:"memory"`    // e.g. "2g"
-	CPUCount             int      `yaml:"cpu_count"` // Number of CPUs to allocate
-	TmpDir               string   // Host path to mount as /home/builder/tmp
+	DownloadsDir         string            // Host path to mount as /home/builder/downloads
+	SstateCacheDir       string            // Host path to mount as /home/builder/sstate-cache
+	BuildDir             string            // Host path to mount as /home/builder/build (persistent Yocto build dir)
+	WorkspaceDir         string            // Host path to mount as /home/builder/work (main workspace)
+	WorkspaceMountTarget string            // Container path where WorkspaceDir/BuildDir should be mounted (defaults to /home/builder/build if empty)
+	LayerDirs            []string          // Host paths to Yocto meta-layers to inject into /home/builder/layers
+	LayerNames           []string          // Names corresponding to LayerDirs for proper mounting
+	MemoryLimit          string            `yaml:"memory"`    // e.g. "2g"
+	CPUCount             int               `yaml:"cpu_count"` // Number of CPUs to allocate
+	TmpDir               string            // Host path to mount as /home/builder/tmp
+	Labels               map[string]string // Labels attached to the container (see LabelManaged)
+}
+
+// Labels smidr attaches to the containers it creates so they can be found again,
+// e.g. after a daemon crash left one behind
+const (
+	// LabelManaged marks a container as created by smidr
+	LabelManaged = "io.smidr.managed"
+	// LabelBuildID records the ID of the build a container runs
+	LabelBuildID = "io.smidr.build-id"
+)
+
+// ContainerInfo describes a container returned by ListContainers
+type ContainerInfo struct {
+	ID     string
+	Name   string
+	State  string // e.g. "running", "exited", "created"
+	Labels map[string]string
 }
 
 type Mount struct {
@@ -50,6 +68,8 @@ type ContainerManager interface {
 	ExecStream(ctx context.Context, containerID string, cmd []string, timeout time.Duration) (ExecResult, error)
 	ImageExists(ctx context.Context, imageName string) bool
 	CopyFromContainer(ctx context.Context, containerID, containerPath, hostPath string) error
+	// ListContainers returns all containers, running or not, carrying every given label
+	ListContainers(ctx context.Context, labels map[string]string) ([]ContainerInfo, error)
 }
 
 // ContainerManagerStreamer is an optional extension that supports line-by-line streaming callbacks.
@@ -104,6 +124,17 @@ func (c ContainerConfig) WithWorkspaceDir(dir string) ContainerConfig {
 	return c
 }
 
+// WithLabel adds a label to the container config
+func (c ContainerConfig) WithLabel(key, value string) ContainerConfig {
+	labels := make(map[string]string, len(c.Labels)+1)
+	for k, v := range c.Labels {
+		labels[k] = v
+	}
+	labels[key] = value
+	c.Labels = labels
+	return c
+}
+
 // AddLayer adds a layer directory to the container config
 func (c ContainerConfig) AddLayer(hostPath, name string) ContainerConfig {
 	c.LayerDirs = append(c.LayerDirs, hostPath)
@@ -35,6 +35,9 @@ func (d *DummyContainerManager) ImageExists(ctx context.Context, imageName strin
 func (d *DummyContainerManager) CopyFromContainer(ctx context.Context, containerID, containerPath, hostPath string) error {
 	return nil
 }
+func (d *DummyContainerManager) ListContainers(ctx context.Context, labels map[string]string) ([]ContainerInfo, error) {
+	return nil, nil
+}
 
 func TestContainerManagerInterface(t *testing.T) {
 	var cm ContainerManager = &DummyContainerManager{}
@@ -123,6 +126,18 @@ func TestContainerConfig_WithWorkspaceDir(t *testing.T) {
 	}
 }
 
+func TestContainerConfig_WithLabel(t *testing.T) {
+	base := NewContainerConfig("img", "name").WithLabel(LabelManaged, "true")
+	cfg := base.WithLabel(LabelBuildID, "build-1")
+	if cfg.Labels[LabelManaged] != "true" || cfg.Labels[LabelBuildID] != "build-1" {
+		t.Errorf("labels not set correctly: %v", cfg.Labels)
+	}
+	// Adding a label must not modify the config it was derived from
+	if _, ok := base.Labels[LabelBuildID]; ok {
+		t.Errorf("WithLabel modified the original config: %v", base.Labels)
+	}
+}
+
 func TestContainerConfig_AddLayer(t *testing.T) {
 	cfg := NewContainerConfig("img", "name").
 		AddLayer("/path/to/layer1", "layer1").
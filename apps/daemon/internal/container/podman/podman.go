@@ -0,0 +1,734 @@
+package podman
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+
+	smidrContainer "github.com/schererja/smidr/internal/container"
+	"github.com/schererja/smidr/pkg/logger"
+)
+
+const (
+	// apiPrefix is the libpod REST API path prefix; v4 is served by Podman 4.x and 5.x
+	apiPrefix = "/v4.0.0/libpod"
+
+	// builderUID and builderGID are the IDs of the builder user in the Smidr build images
+	builderUID = 1000
+	builderGID = 1000
+
+	// cpuPeriod is the CFS period used to express CPU limits as a quota
+	cpuPeriod = 100000
+)
+
+// PodmanManager implements container.ContainerManager using the Podman REST API (libpod)
+// over its unix socket. In rootless mode the invoking user is mapped to the builder user
+// inside the container, so bind-mounted directories stay owned by the host user.
+type PodmanManager struct {
+	http     *http.Client
+	logger   *logger.Logger
+	rootless bool
+	cpus     int
+}
+
+// apiError is an error response from the Podman API
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("podman API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// hostInfo is the subset of /info used by the manager
+type hostInfo struct {
+	Host struct {
+		CPUs     int `json:"cpus"`
+		Security struct {
+			Rootless bool `json:"rootless"`
+		} `json:"security"`
+	} `json:"host"`
+}
+
+// SocketPath returns the Podman API socket to use: CONTAINER_HOST if set (unix:// only),
+// otherwise the rootless socket under XDG_RUNTIME_DIR or the rootful system socket.
+func SocketPath() (string, error) {
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		if !strings.HasPrefix(host, "unix://") {
+			return "", fmt.Errorf("unsupported CONTAINER_HOST %q: only unix:// sockets are supported", host)
+		}
+		return strings.TrimPrefix(host, "unix://"), nil
+	}
+	if os.Geteuid() == 0 {
+		return "/run/podman/podman.sock", nil
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "podman", "podman.sock"), nil
+	}
+	return fmt.Sprintf("/run/user/%d/podman/podman.sock", os.Geteuid()), nil
+}
+
+// NewPodmanManager creates a new PodmanManager, returning error if the Podman API is unavailable
+func NewPodmanManager(log *logger.Logger) (*PodmanManager, error) {
+	socket, err := SocketPath()
+	if err != nil {
+		log.Error("failed to resolve Podman socket", err)
+		return nil, err
+	}
+	p, err := newPodmanManager(unixSocketClient(socket), log)
+	if err != nil {
+		log.Error("failed to connect to Podman", err, slog.String("socket", socket))
+		return nil, fmt.Errorf("podman API not available at %s: %w", socket, err)
+	}
+	return p, nil
+}
+
+// newPodmanManager creates a manager on top of an HTTP client that is connected to the Podman API
+func newPodmanManager(httpClient *http.Client, log *logger.Logger) (*PodmanManager, error) {
+	p := &PodmanManager{http: httpClient, logger: log}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var info hostInfo
+	if err := p.getJSON(ctx, "/info", nil, &info); err != nil {
+		return nil, err
+	}
+	p.rootless = info.Host.Security.Rootless
+	p.cpus = info.Host.CPUs
+	log.Debug("connected to podman", slog.Bool("rootless", p.rootless), slog.Int("cpus", p.cpus))
+	return p, nil
+}
+
+// unixSocketClient returns an HTTP client that dials the given unix socket for every request
+func unixSocketClient(socket string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+}
+
+// do sends a request to the libpod API; the host part of the URL is ignored by the unix dialer
+func (p *PodmanManager) do(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	u := "http://d" + apiPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		return nil, decodeAPIError(resp)
+	}
+	return resp, nil
+}
+
+// decodeAPIError reads the libpod error body ({"cause", "message", "response"})
+func decodeAPIError(resp *http.Response) error {
+	var body struct {
+		Message string `json:"message"`
+		Cause   string `json:"cause"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := json.Unmarshal(data, &body); err != nil || body.Message == "" {
+		body.Message = strings.TrimSpace(string(data))
+	}
+	return &apiError{StatusCode: resp.StatusCode, Message: body.Message}
+}
+
+// getJSON performs a GET request and decodes the JSON response into out
+func (p *PodmanManager) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	resp, err := p.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// call performs a request whose response body is not needed
+func (p *PodmanManager) call(ctx context.Context, method, path string, query url.Values, body any) error {
+	resp, err := p.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (p *PodmanManager) PullImage(ctx context.Context, imageName string) error {
+	p.logger.Info("pulling podman image", slog.String("image", imageName))
+	resp, err := p.do(ctx, http.MethodPost, "/images/pull", url.Values{"reference": {imageName}}, nil)
+	if err != nil {
+		p.logger.Error("failed to pull image", err, slog.String("image", imageName))
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The pull reports progress as a stream of JSON objects; failures arrive in-band
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var report struct {
+			Error string `json:"error"`
+		}
+		if err := dec.Decode(&report); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read pull progress: %w", err)
+		}
+		if report.Error != "" {
+			err := fmt.Errorf("failed to pull %s: %s", imageName, report.Error)
+			p.logger.Error("failed to pull image", err, slog.String("image", imageName))
+			return err
+		}
+	}
+}
+
+func (p *PodmanManager) ImageExists(ctx context.Context, imageName string) bool {
+	// 204 if the image exists, 404 otherwise
+	return p.call(ctx, http.MethodGet, "/images/"+url.PathEscape(imageName)+"/exists", nil, nil) == nil
+}
+
+func (p *PodmanManager) CopyFromContainer(ctx context.Context, containerID, containerPath, hostPath string) error {
+	p.logger.Debug("copying from container",
+		slog.String("container_id", containerID),
+		slog.String("container_path", containerPath),
+		slog.String("host_path", hostPath))
+
+	resp, err := p.do(ctx, http.MethodGet, "/containers/"+containerID+"/archive", url.Values{"path": {containerPath}}, nil)
+	if err != nil {
+		p.logger.Error("failed to copy from container", err,
+			slog.String("container_id", containerID),
+			slog.String("container_path", containerPath))
+		return err
+	}
+	defer resp.Body.Close()
+
+	tarReader := tar.NewReader(resp.Body)
+	if _, err := tarReader.Next(); err != nil {
+		p.logger.Error("failed to read tar header", err)
+		return err
+	}
+
+	outFile, err := os.Create(hostPath)
+	if err != nil {
+		p.logger.Error("failed to create destination file", err, slog.String("path", hostPath))
+		return err
+	}
+	defer outFile.Close()
+
+	if _, err := io.Copy(outFile, tarReader); err != nil {
+		p.logger.Error("failed to copy file content", err)
+		return err
+	}
+	return nil
+}
+
+// specMount is a mount in the libpod SpecGenerator
+type specMount struct {
+	Destination string   `json:"destination"`
+	Source      string   `json:"source"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options,omitempty"`
+}
+
+// namespace is a namespace setting in the libpod SpecGenerator
+type namespace struct {
+	NSMode string `json:"nsmode"`
+	Value  string `json:"value,omitempty"`
+}
+
+// resourceLimits is the subset of the OCI LinuxResources used for builds
+type resourceLimits struct {
+	Memory *struct {
+		Limit int64 `json:"limit"`
+	} `json:"memory,omitempty"`
+	CPU *struct {
+		Quota  int64  `json:"quota"`
+		Period uint64 `json:"period"`
+	} `json:"cpu,omitempty"`
+}
+
+// createSpec is the subset of the libpod SpecGenerator used to create build containers
+type createSpec struct {
+	Name           string            `json:"name,omitempty"`
+	Image          string            `json:"image"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Env            map[string]string `json:"env,omitempty"`
+	Entrypoint     []string          `json:"entrypoint,omitempty"`
+	Command        []string          `json:"command,omitempty"`
+	Mounts         []specMount       `json:"mounts,omitempty"`
+	UserNS         *namespace        `json:"userns,omitempty"`
+	ResourceLimits *resourceLimits   `json:"resource_limits,omitempty"`
+}
+
+func (p *PodmanManager) CreateContainer(ctx context.Context, cfg smidrContainer.ContainerConfig) (string, error) {
+	mounts, err := p.buildMounts(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	spec := createSpec{
+		Name:           cfg.Name,
+		Image:          cfg.Image,
+		Labels:         cfg.Labels,
+		Env:            envMap(cfg.Env),
+		Mounts:         mounts,
+		ResourceLimits: p.resourceLimits(cfg),
+	}
+
+	// Same entrypoint rules as the Docker backend: explicit entrypoint wins, a single-string
+	// command runs via the shell, and a multi-element command is passed to the image ENTRYPOINT
+	if len(cfg.Entrypoint) > 0 {
+		spec.Entrypoint = cfg.Entrypoint
+		spec.Command = cfg.Cmd
+	} else if len(cfg.Cmd) == 1 {
+		spec.Entrypoint = []string{"/bin/sh", "-c"}
+		spec.Command = []string{cfg.Cmd[0]}
+	} else {
+		spec.Command = cfg.Cmd
+	}
+
+	// Rootless: map the invoking host user onto the builder user so files written by BitBake
+	// into bind mounts are owned by the host user rather than a subordinate UID
+	if p.rootless {
+		spec.UserNS = &namespace{NSMode: "keep-id", Value: fmt.Sprintf("uid=%d,gid=%d", builderUID, builderGID)}
+	}
+
+	var created struct {
+		ID       string   `json:"Id"`
+		Warnings []string `json:"Warnings"`
+	}
+	resp, err := p.do(ctx, http.MethodPost, "/containers/create", nil, spec)
+	if err != nil {
+		p.logger.Error("failed to create container", err, slog.String("name", cfg.Name), slog.String("image", cfg.Image))
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("failed to decode create response: %w", err)
+	}
+	for _, warning := range created.Warnings {
+		p.logger.Warn("podman create warning", slog.String("warning", warning))
+	}
+
+	p.logger.Info("container created", slog.String("container_id", created.ID), slog.String("name", cfg.Name))
+	return created.ID, nil
+}
+
+// resourceLimits converts the memory and CPU settings, capping CPUs to what the host has
+func (p *PodmanManager) resourceLimits(cfg smidrContainer.ContainerConfig) *resourceLimits {
+	var limits resourceLimits
+
+	if cfg.MemoryLimit != "" {
+		memBytes, err := units.RAMInBytes(cfg.MemoryLimit)
+		if err != nil {
+			p.logger.Warn("could not parse memory limit", slog.String("limit", cfg.MemoryLimit), slog.Any("error", err))
+		} else {
+			limits.Memory = &struct {
+				Limit int64 `json:"limit"`
+			}{Limit: memBytes}
+			p.logger.Info("setting container memory limit", slog.String("limit", cfg.MemoryLimit), slog.Int64("bytes", memBytes))
+		}
+	}
+
+	if cfg.CPUCount > 0 {
+		cpus := cfg.CPUCount
+		if p.cpus > 0 && cpus > p.cpus {
+			p.logger.Warn("requested CPU count exceeds available CPUs",
+				slog.Int("requested", cpus),
+				slog.Int("available", p.cpus),
+				slog.Int("using", p.cpus))
+			cpus = p.cpus
+		}
+		limits.CPU = &struct {
+			Quota  int64  `json:"quota"`
+			Period uint64 `json:"period"`
+		}{Quota: int64(cpus) * cpuPeriod, Period: cpuPeriod}
+		p.logger.Info("setting container CPU count", slog.Int("cpus", cpus))
+	}
+
+	if limits.Memory == nil && limits.CPU == nil {
+		return nil
+	}
+	return &limits
+}
+
+// buildMounts creates the host directories and returns the bind mounts for a build container
+func (p *PodmanManager) buildMounts(cfg smidrContainer.ContainerConfig) ([]specMount, error) {
+	var mounts []specMount
+	bind := func(source, target string, readOnly bool) {
+		options := []string{"rbind"}
+		if readOnly {
+			options = append(options, "ro")
+		}
+		mounts = append(mounts, specMount{Destination: target, Source: source, Type: "bind", Options: options})
+	}
+
+	for _, m := range cfg.Mounts {
+		bind(m.Source, m.Target, m.ReadOnly)
+	}
+
+	if cfg.DownloadsDir != "" {
+		if err := p.prepareDir(cfg.DownloadsDir, 0755); err != nil {
+			return nil, err
+		}
+		bind(cfg.DownloadsDir, "/home/builder/downloads", false)
+	}
+
+	if cfg.SstateCacheDir != "" {
+		if err := os.MkdirAll(cfg.SstateCacheDir, 0755); err != nil {
+			p.logger.Error("failed to create sstate cache dir", err, slog.String("dir", cfg.SstateCacheDir))
+			return nil, err
+		}
+		bind(cfg.SstateCacheDir, "/home/builder/sstate-cache", false)
+	}
+
+	if cfg.BuildDir != "" {
+		if err := p.prepareDir(cfg.BuildDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := p.prepareDir(filepath.Join(cfg.BuildDir, "deploy"), 0755); err != nil {
+			return nil, err
+		}
+		workspaceTarget := cfg.WorkspaceMountTarget
+		if workspaceTarget == "" {
+			workspaceTarget = "/home/builder/build"
+		}
+		bind(cfg.BuildDir, workspaceTarget, false)
+	}
+
+	if cfg.TmpDir != "" {
+		if err := p.prepareDir(cfg.TmpDir, 01777); err != nil {
+			return nil, err
+		}
+		bind(cfg.TmpDir, "/home/builder/tmp", false)
+	}
+
+	for i, layerDir := range cfg.LayerDirs {
+		if layerDir == "" {
+			continue
+		}
+		if err := os.MkdirAll(layerDir, 0755); err != nil {
+			p.logger.Error("failed to create layer dir", err, slog.String("dir", layerDir))
+			return nil, err
+		}
+		target := "/home/builder/layers/layer-" + strconv.Itoa(i)
+		if i < len(cfg.LayerNames) && cfg.LayerNames[i] != "" {
+			target = "/home/builder/layers/" + cfg.LayerNames[i]
+		}
+		bind(layerDir, target, true)
+	}
+
+	return mounts, nil
+}
+
+// prepareDir creates a host directory that the builder user must be able to write.
+// Rootless keep-id already maps the host user to the builder; rootful Podman needs the
+// directory owned by the builder UID, falling back to world-writable permissions.
+func (p *PodmanManager) prepareDir(dir string, fallbackMode os.FileMode) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		p.logger.Error("failed to create dir", err, slog.String("dir", dir))
+		return err
+	}
+	if p.rootless {
+		return nil
+	}
+	if err := os.Chown(dir, builderUID, builderGID); err != nil {
+		if chmodErr := os.Chmod(dir, fallbackMode); chmodErr != nil {
+			p.logger.Warn("could not set writable permissions",
+				slog.String("dir", dir),
+				slog.Any("chown_error", err),
+				slog.Any("chmod_error", chmodErr))
+		}
+	}
+	return nil
+}
+
+// envMap converts KEY=VALUE pairs to the map form used by the SpecGenerator
+func envMap(env []string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(env))
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		m[key] = value
+	}
+	return m
+}
+
+func (p *PodmanManager) StartContainer(ctx context.Context, containerID string) error {
+	p.logger.Info("starting container", slog.String("container_id", containerID))
+	// 204 when started, 304 when already running
+	if err := p.call(ctx, http.MethodPost, "/containers/"+containerID+"/start", nil, nil); err != nil {
+		p.logger.Error("failed to start container", err, slog.String("container_id", containerID))
+		return err
+	}
+
+	// Match the Docker backend: provide a writable workspace and a sticky /home/builder/tmp
+	setup := []string{"sh", "-c", "mkdir -p /tmp/builder-workspace; mkdir -p /home/builder/tmp && chmod 1777 /home/builder/tmp || chmod 0777 /home/builder/tmp"}
+	if res, err := p.Exec(ctx, containerID, setup, 10*time.Second); err != nil {
+		p.logger.Warn("container setup exec error", slog.Any("error", err))
+	} else if res.ExitCode != 0 {
+		p.logger.Warn("container setup failed",
+			slog.Int("exit_code", res.ExitCode),
+			slog.String("stdout", string(res.Stdout)),
+			slog.String("stderr", string(res.Stderr)))
+	}
+
+	return nil
+}
+
+func (p *PodmanManager) StopContainer(ctx context.Context, containerID string, timeout time.Duration) error {
+	p.logger.Info("stopping container", slog.String("container_id", containerID), slog.Duration("timeout", timeout))
+	var query url.Values
+	if timeout > 0 {
+		query = url.Values{"timeout": {strconv.Itoa(int(timeout.Seconds()))}}
+	}
+	if err := p.call(ctx, http.MethodPost, "/containers/"+containerID+"/stop", query, nil); err != nil {
+		p.logger.Error("failed to stop container", err, slog.String("container_id", containerID))
+		return err
+	}
+	return nil
+}
+
+func (p *PodmanManager) RemoveContainer(ctx context.Context, containerID string, force bool) error {
+	p.logger.Debug("removing container", slog.String("container_id", containerID), slog.Bool("force", force))
+	query := url.Values{"force": {strconv.FormatBool(force)}}
+	if err := p.call(ctx, http.MethodDelete, "/containers/"+containerID, query, nil); err != nil {
+		p.logger.Error("failed to remove container", err, slog.String("container_id", containerID))
+		return err
+	}
+	return nil
+}
+
+// listedContainer is the subset of a libpod container list entry smidr uses
+type listedContainer struct {
+	ID     string            `json:"Id"`
+	Names  []string          `json:"Names"`
+	State  string            `json:"State"`
+	Labels map[string]string `json:"Labels"`
+}
+
+func (p *PodmanManager) ListContainers(ctx context.Context, labels map[string]string) ([]smidrContainer.ContainerInfo, error) {
+	query := url.Values{"all": {"true"}}
+	if len(labels) > 0 {
+		var labelFilters []string
+		for key, value := range labels {
+			labelFilters = append(labelFilters, key+"="+value)
+		}
+		sort.Strings(labelFilters)
+		encoded, err := json.Marshal(map[string][]string{"label": labelFilters})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode container filters: %w", err)
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	var listed []listedContainer
+	if err := p.getJSON(ctx, "/containers/json", query, &listed); err != nil {
+		p.logger.Error("failed to list containers", err)
+		return nil, err
+	}
+	infos := make([]smidrContainer.ContainerInfo, 0, len(listed))
+	for _, c := range listed {
+		var name string
+		if len(c.Names) > 0 {
+			name = c.Names[0]
+		}
+		infos = append(infos, smidrContainer.ContainerInfo{
+			ID:     c.ID,
+			Name:   name,
+			State:  c.State,
+			Labels: c.Labels,
+		})
+	}
+	return infos, nil
+}
+
+func (p *PodmanManager) Exec(ctx context.Context, containerID string, cmd []string, timeout time.Duration) (smidrContainer.ExecResult, error) {
+	p.logger.Debug("executing command in container",
+		slog.String("container_id", containerID),
+		slog.Any("cmd", cmd),
+		slog.Duration("timeout", timeout))
+
+	outBytes, errBytes := new(bytes.Buffer), new(bytes.Buffer)
+	exitCode, err := p.exec(ctx, containerID, cmd, timeout, outBytes, errBytes)
+	if err != nil {
+		return smidrContainer.ExecResult{}, err
+	}
+	return smidrContainer.ExecResult{Stdout: outBytes.Bytes(), Stderr: errBytes.Bytes(), ExitCode: exitCode}, nil
+}
+
+// ExecStream runs a command in the container with real-time output streaming to stdout/stderr
+func (p *PodmanManager) ExecStream(ctx context.Context, containerID string, cmd []string, timeout time.Duration) (smidrContainer.ExecResult, error) {
+	p.logger.Debug("executing command in container with streaming",
+		slog.String("container_id", containerID),
+		slog.Any("cmd", cmd),
+		slog.Duration("timeout", timeout))
+
+	outBytes, errBytes := new(bytes.Buffer), new(bytes.Buffer)
+	exitCode, err := p.exec(ctx, containerID, cmd, timeout, io.MultiWriter(os.Stdout, outBytes), io.MultiWriter(os.Stderr, errBytes))
+	if err != nil {
+		return smidrContainer.ExecResult{}, err
+	}
+	return smidrContainer.ExecResult{Stdout: outBytes.Bytes(), Stderr: errBytes.Bytes(), ExitCode: exitCode}, nil
+}
+
+// ExecStreamLines runs a command in the container and invokes callbacks for each stdout/stderr line in real-time.
+// It also collects the full output and returns it in ExecResult.
+func (p *PodmanManager) ExecStreamLines(ctx context.Context, containerID string, cmd []string, timeout time.Duration, onStdout func(string), onStderr func(string)) (smidrContainer.ExecResult, error) {
+	p.logger.Debug("executing command in container with line streaming",
+		slog.String("container_id", containerID),
+		slog.Any("cmd", cmd),
+		slog.Duration("timeout", timeout))
+
+	outBytes, errBytes := new(bytes.Buffer), new(bytes.Buffer)
+	stdoutLW := smidrContainer.NewLineWriter(onStdout, outBytes)
+	stderrLW := smidrContainer.NewLineWriter(onStderr, errBytes)
+
+	exitCode, err := p.exec(ctx, containerID, cmd, timeout, stdoutLW, stderrLW)
+	if err != nil {
+		return smidrContainer.ExecResult{}, err
+	}
+
+	// Flush any remaining partial lines
+	stdoutLW.Flush()
+	stderrLW.Flush()
+
+	return smidrContainer.ExecResult{Stdout: outBytes.Bytes(), Stderr: errBytes.Bytes(), ExitCode: exitCode}, nil
+}
+
+// exec creates an exec session, streams its demultiplexed output to stdout/stderr and
+// returns the exit code
+func (p *PodmanManager) exec(ctx context.Context, containerID string, cmd []string, timeout time.Duration, stdout, stderr io.Writer) (int, error) {
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var created struct {
+		ID string `json:"Id"`
+	}
+	createReq := map[string]any{"Cmd": cmd, "AttachStdout": true, "AttachStderr": true}
+	resp, err := p.do(execCtx, http.MethodPost, "/containers/"+containerID+"/exec", nil, createReq)
+	if err != nil {
+		p.logger.Error("failed to create exec", err, slog.String("container_id", containerID))
+		return 0, err
+	}
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode exec create response: %w", err)
+	}
+
+	resp, err = p.do(execCtx, http.MethodPost, "/exec/"+created.ID+"/start", nil, map[string]any{"Detach": false, "Tty": false})
+	if err != nil {
+		p.logger.Error("failed to start exec", err, slog.String("exec_id", created.ID))
+		return 0, err
+	}
+	err = demux(resp.Body, stdout, stderr)
+	resp.Body.Close()
+	if err != nil {
+		p.logger.Error("failed to copy exec output", err)
+		return 0, err
+	}
+
+	exitCode, err := p.execExitCode(execCtx, created.ID)
+	if err != nil {
+		p.logger.Error("failed to inspect exec", err, slog.String("exec_id", created.ID))
+		return 0, err
+	}
+
+	return exitCode, nil
+}
+
+// execExitCode inspects an exec session, waiting briefly for Podman to record its exit
+func (p *PodmanManager) execExitCode(ctx context.Context, execID string) (int, error) {
+	for {
+		var inspect struct {
+			Running  bool `json:"Running"`
+			ExitCode int  `json:"ExitCode"`
+		}
+		if err := p.getJSON(ctx, "/exec/"+execID+"/json", nil, &inspect); err != nil {
+			return 0, err
+		}
+		if !inspect.Running {
+			return inspect.ExitCode, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// demux splits the multiplexed exec stream (8-byte header: stream type, 3 reserved bytes,
+// big-endian payload size) into stdout and stderr
+func demux(r io.Reader, stdout, stderr io.Writer) error {
+	var header [8]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		var w io.Writer
+		switch header[0] {
+		case 0, 1:
+			w = stdout
+		case 2:
+			w = stderr
+		default:
+			return fmt.Errorf("unexpected stream type %d in exec output", header[0])
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.CopyN(w, r, size); err != nil {
+			return err
+		}
+	}
+}
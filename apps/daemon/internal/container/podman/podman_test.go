@@ -0,0 +1,257 @@
+package podman
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	smidrContainer "github.com/schererja/smidr/internal/container"
+	"github.com/schererja/smidr/pkg/logger"
+)
+
+// newFakePodman serves handler on a unix socket and returns a manager connected to it
+func newFakePodman(t *testing.T, rootless bool, handler http.HandlerFunc) *PodmanManager {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "podman.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on unix socket: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPrefix+"/info", func(w http.ResponseWriter, r *http.Request) {
+		var info hostInfo
+		info.Host.CPUs = 4
+		info.Host.Security.Rootless = rootless
+		json.NewEncoder(w).Encode(info)
+	})
+	mux.HandleFunc("/", handler)
+
+	server := httptest.NewUnstartedServer(mux)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	p, err := newPodmanManager(unixSocketClient(socket), logger.NewLogger())
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	return p
+}
+
+// frame encodes a multiplexed exec output frame
+func frame(stream byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+func TestPodmanManager_CreateContainerSpec(t *testing.T) {
+	var spec createSpec
+	p := newFakePodman(t, true, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != apiPrefix+"/containers/create" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+			t.Errorf("failed to decode spec: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"Id":"abc123","Warnings":[]}`))
+	})
+
+	dir := t.TempDir()
+	cfg := smidrContainer.NewContainerConfig("busybox:latest", "smidr-test").
+		WithCmd("echo ready").
+		WithLabel(smidrContainer.LabelManaged, "true").
+		WithEnv("FOO=bar").
+		WithMemoryLimit("2g").
+		WithCPUCount(8).
+		WithDownloadsDir(filepath.Join(dir, "downloads")).
+		AddLayer(filepath.Join(dir, "poky"), "poky")
+
+	id, err := p.CreateContainer(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("CreateContainer failed: %v", err)
+	}
+	if id != "abc123" {
+		t.Errorf("expected container ID abc123, got %s", id)
+	}
+
+	if spec.UserNS == nil || spec.UserNS.NSMode != "keep-id" || spec.UserNS.Value != "uid=1000,gid=1000" {
+		t.Errorf("expected keep-id user namespace for the builder user, got %+v", spec.UserNS)
+	}
+	if spec.ResourceLimits == nil || spec.ResourceLimits.Memory == nil || spec.ResourceLimits.Memory.Limit != 2<<30 {
+		t.Errorf("expected 2GiB memory limit, got %+v", spec.ResourceLimits)
+	}
+	// 8 requested CPUs are capped to the 4 the host reports
+	if spec.ResourceLimits == nil || spec.ResourceLimits.CPU == nil || spec.ResourceLimits.CPU.Quota != 4*cpuPeriod {
+		t.Errorf("expected CPU quota for 4 CPUs, got %+v", spec.ResourceLimits)
+	}
+	if strings.Join(spec.Entrypoint, " ") != "/bin/sh -c" || len(spec.Command) != 1 {
+		t.Errorf("expected shell entrypoint for single-string command, got %v %v", spec.Entrypoint, spec.Command)
+	}
+	if spec.Labels[smidrContainer.LabelManaged] != "true" {
+		t.Errorf("expected managed label, got %v", spec.Labels)
+	}
+	if spec.Env["FOO"] != "bar" {
+		t.Errorf("expected env FOO=bar, got %v", spec.Env)
+	}
+
+	mounts := map[string]specMount{}
+	for _, m := range spec.Mounts {
+		mounts[m.Destination] = m
+	}
+	if m, ok := mounts["/home/builder/downloads"]; !ok || m.Type != "bind" {
+		t.Errorf("expected downloads bind mount, got %+v", spec.Mounts)
+	}
+	if m, ok := mounts["/home/builder/layers/poky"]; !ok || !strings.Contains(strings.Join(m.Options, ","), "ro") {
+		t.Errorf("expected read-only layer mount, got %+v", spec.Mounts)
+	}
+}
+
+func TestPodmanManager_RootfulHasNoUserNamespace(t *testing.T) {
+	var spec createSpec
+	p := newFakePodman(t, false, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&spec)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"Id":"abc123"}`))
+	})
+
+	if _, err := p.CreateContainer(context.Background(), smidrContainer.NewContainerConfig("busybox", "")); err != nil {
+		t.Fatalf("CreateContainer failed: %v", err)
+	}
+	if spec.UserNS != nil {
+		t.Errorf("expected no user namespace override for rootful podman, got %+v", spec.UserNS)
+	}
+	if spec.ResourceLimits != nil {
+		t.Errorf("expected no resource limits, got %+v", spec.ResourceLimits)
+	}
+}
+
+func TestPodmanManager_ExecStreamLines(t *testing.T) {
+	p := newFakePodman(t, true, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case apiPrefix + "/containers/c1/exec":
+			var req struct{ Cmd []string }
+			json.NewDecoder(r.Body).Decode(&req)
+			if strings.Join(req.Cmd, " ") != "sh -c build" {
+				t.Errorf("unexpected exec command %v", req.Cmd)
+			}
+			w.Write([]byte(`{"Id":"e1"}`))
+		case apiPrefix + "/exec/e1/start":
+			w.Write(frame(1, "line one\nline "))
+			w.Write(frame(2, "oops\n"))
+			w.Write(frame(1, "two\npartial"))
+		case apiPrefix + "/exec/e1/json":
+			w.Write([]byte(`{"Running":false,"ExitCode":3}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	var stdout, stderr []string
+	res, err := p.ExecStreamLines(context.Background(), "c1", []string{"sh", "-c", "build"}, 5*time.Second,
+		func(line string) { stdout = append(stdout, line) },
+		func(line string) { stderr = append(stderr, line) })
+	if err != nil {
+		t.Fatalf("ExecStreamLines failed: %v", err)
+	}
+
+	if res.ExitCode != 3 {
+		t.Errorf("expected exit code 3, got %d", res.ExitCode)
+	}
+	if strings.Join(stdout, "|") != "line one|line two|partial" {
+		t.Errorf("unexpected stdout lines %q", stdout)
+	}
+	if strings.Join(stderr, "|") != "oops" {
+		t.Errorf("unexpected stderr lines %q", stderr)
+	}
+	if res.GetStdoutString() != "line one\nline two\npartial\n" {
+		t.Errorf("unexpected collected stdout %q", res.GetStdoutString())
+	}
+}
+
+func TestPodmanManager_ListContainers(t *testing.T) {
+	var query url.Values
+	p := newFakePodman(t, true, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != apiPrefix+"/containers/json" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.Query()
+		w.Write([]byte(`[{"Id":"abc123","Names":["smidr-build-1"],"State":"exited","Labels":{"io.smidr.managed":"true","io.smidr.build-id":"1"}}]`))
+	})
+
+	containers, err := p.ListContainers(context.Background(), map[string]string{
+		smidrContainer.LabelManaged: "true",
+		smidrContainer.LabelBuildID: "1",
+	})
+	if err != nil {
+		t.Fatalf("ListContainers failed: %v", err)
+	}
+	if query.Get("all") != "true" {
+		t.Errorf("expected stopped containers to be listed, got query %v", query)
+	}
+	if want := `{"label":["io.smidr.build-id=1","io.smidr.managed=true"]}`; query.Get("filters") != want {
+		t.Errorf("expected filters %s, got %s", want, query.Get("filters"))
+	}
+	if len(containers) != 1 {
+		t.Fatalf("expected 1 container, got %d", len(containers))
+	}
+	c := containers[0]
+	if c.ID != "abc123" || c.Name != "smidr-build-1" || c.State != "exited" || c.Labels[smidrContainer.LabelBuildID] != "1" {
+		t.Errorf("unexpected container info: %+v", c)
+	}
+}
+
+func TestPodmanManager_PullImageError(t *testing.T) {
+	p := newFakePodman(t, true, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"stream":"Trying to pull..."}` + "\n" + `{"error":"manifest unknown"}` + "\n"))
+	})
+
+	err := p.PullImage(context.Background(), "missing:latest")
+	if err == nil || !strings.Contains(err.Error(), "manifest unknown") {
+		t.Errorf("expected in-band pull error, got %v", err)
+	}
+}
+
+func TestPodmanManager_ImageExists(t *testing.T) {
+	p := newFakePodman(t, true, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "present") {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"cause":"image not known","message":"failed to find image missing","response":404}`))
+	})
+
+	if !p.ImageExists(context.Background(), "present:latest") {
+		t.Error("expected image to exist")
+	}
+	if p.ImageExists(context.Background(), "missing:latest") {
+		t.Error("expected image to be missing")
+	}
+}
+
+func TestSocketPath(t *testing.T) {
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/podman-test.sock")
+	if path, err := SocketPath(); err != nil || path != "/tmp/podman-test.sock" {
+		t.Errorf("expected socket from CONTAINER_HOST, got %q (%v)", path, err)
+	}
+
+	t.Setenv("CONTAINER_HOST", "ssh://user@host/run/podman/podman.sock")
+	if _, err := SocketPath(); err == nil {
+		t.Error("expected error for non-unix CONTAINER_HOST")
+	}
+}
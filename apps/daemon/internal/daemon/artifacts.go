@@ -0,0 +1,240 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/schererja/smidr/internal/db"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+const (
+	// defaultDownloadChunkSize is used when the client does not request a chunk size
+	defaultDownloadChunkSize = 1 << 20 // 1 MiB
+	// maxDownloadChunkSize keeps each message well below gRPC's default 4 MiB limit
+	maxDownloadChunkSize = 2 << 20 // 2 MiB
+)
+
+// deploySubdir is the directory inside a build's artifact store holding the copied deploy dir.
+// Artifact paths (and therefore artifact IDs) are relative to it.
+const deploySubdir = "deploy"
+
+// resolveArtifact maps an artifact ID to its build ID, deploy-relative path and absolute path on disk
+func (s *Server) resolveArtifact(id string) (string, string, string, error) {
+	if s.artifactMgr == nil {
+		return "", "", "", fmt.Errorf("artifact storage is not available on this daemon")
+	}
+	buildID, relPath, err := db.ParseArtifactID(id)
+	if err != nil {
+		return "", "", "", err
+	}
+	fullPath, err := s.artifactMgr.ResolveArtifact(buildID, filepath.Join(deploySubdir, relPath))
+	if err != nil {
+		return "", "", "", err
+	}
+	return buildID, relPath, fullPath, nil
+}
+
+// artifactSummary converts a recorded artifact to its API representation
+func artifactSummary(artifact *db.BuildArtifact) *v1.ArtifactSummary {
+	return &v1.ArtifactSummary{
+		ArtifactId:  artifact.ArtifactID,
+		Name:        filepath.Base(artifact.ArtifactPath),
+		DownloadUrl: filepath.ToSlash(artifact.ArtifactPath),
+		SizeBytes:   artifact.SizeBytes,
+		Checksum:    artifact.Checksum,
+	}
+}
+
+// GetArtifact returns details of a single artifact
+func (s *Server) GetArtifact(ctx context.Context, req *v1.GetArtifactRequest) (*v1.ArtifactSummary, error) {
+	if err := s.authorizeArtifact(ctx, req.ArtifactId); err != nil {
+		return nil, err
+	}
+
+	if s.database != nil {
+		artifact, err := s.database.GetArtifact(req.ArtifactId)
+		if err != nil {
+			return nil, err
+		}
+		return artifactSummary(artifact), nil
+	}
+
+	// Without a database fall back to the artifact store on disk
+	buildID, relPath, fullPath, err := s.resolveArtifact(req.ArtifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat artifact: %w", err)
+	}
+
+	artifact := &v1.ArtifactSummary{
+		ArtifactId:  req.ArtifactId,
+		Name:        filepath.Base(relPath),
+		DownloadUrl: filepath.ToSlash(relPath),
+		SizeBytes:   info.Size(),
+	}
+	artifact.Checksum = s.recordedChecksum(req.ArtifactId, buildID, relPath)
+	if artifact.Checksum == "" {
+		if checksum, err := s.calculateChecksum(fullPath); err == nil {
+			artifact.Checksum = checksum
+		}
+	}
+
+	return artifact, nil
+}
+
+// recordedChecksum returns the SHA-256 recorded for an artifact when it was stored,
+// from the database or else the build manifest; empty if none was recorded
+func (s *Server) recordedChecksum(artifactID, buildID, relPath string) string {
+	if s.database != nil {
+		if recorded, err := s.database.GetArtifact(artifactID); err == nil && recorded.Checksum != "" {
+			return recorded.Checksum
+		}
+	}
+	// Stored artifacts carry their checksum in the build manifest
+	if manifest, err := s.artifactMgr.LoadManifest(buildID); err == nil {
+		if entry, ok := manifest.Entry(filepath.Join(deploySubdir, relPath)); ok {
+			return entry.SHA256
+		}
+	}
+	return ""
+}
+
+// DownloadArtifact streams the content of an artifact in chunks.
+// Clients can resume an interrupted download by passing the number of bytes
+// already received as offset; the final chunk carries the SHA-256 of the
+// complete file so the client can verify the reassembled result.
+func (s *Server) DownloadArtifact(req *v1.DownloadArtifactRequest, stream v1.ArtifactService_DownloadArtifactServer) error {
+	if err := s.authorizeArtifact(stream.Context(), req.ArtifactId); err != nil {
+		return err
+	}
+
+	buildID, relPath, fullPath, err := s.resolveArtifact(req.ArtifactId)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to open artifact: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat artifact: %w", err)
+	}
+	totalSize := info.Size()
+
+	if req.Offset < 0 || req.Offset > totalSize {
+		return fmt.Errorf("offset %d out of range for artifact of %d bytes", req.Offset, totalSize)
+	}
+
+	chunkSize := defaultDownloadChunkSize
+	if req.ChunkSize > 0 {
+		chunkSize = int(req.ChunkSize)
+	}
+	if chunkSize > maxDownloadChunkSize {
+		chunkSize = maxDownloadChunkSize
+	}
+
+	s.logger.Info("Streaming artifact",
+		slog.String("buildID", buildID),
+		slog.String("artifact", relPath),
+		slog.Int64("offset", req.Offset),
+		slog.Int64("size", totalSize))
+
+	if _, err := file.Seek(req.Offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek artifact: %w", err)
+	}
+
+	offset := req.Offset
+	for {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+
+		// Allocate per chunk; gRPC may still reference a message after Send returns
+		buf := make([]byte, chunkSize)
+		n, readErr := file.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&v1.DownloadArtifactResponse{
+				Data:      buf[:n],
+				Offset:    offset,
+				TotalSize: totalSize,
+			}); err != nil {
+				return err
+			}
+			offset += int64(n)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read artifact: %w", readErr)
+		}
+	}
+
+	// The checksum covers the whole file; it was recorded when the artifact was stored.
+	// Only artifacts stored without one are hashed on demand.
+	checksum := s.recordedChecksum(req.ArtifactId, buildID, relPath)
+	if checksum == "" {
+		if checksum, err = s.calculateChecksum(fullPath); err != nil {
+			return fmt.Errorf("failed to checksum artifact: %w", err)
+		}
+	}
+
+	return stream.Send(&v1.DownloadArtifactResponse{
+		DownloadUrl: filepath.ToSlash(relPath),
+		Offset:      offset,
+		TotalSize:   totalSize,
+		Checksum:    checksum,
+		Last:        true,
+	})
+}
+
+// DeleteArtifact removes a single artifact from a finished build
+func (s *Server) DeleteArtifact(ctx context.Context, req *v1.DeleteArtifactRequest) (*v1.DeleteArtifactResponse, error) {
+	if err := s.authorizeArtifact(ctx, req.ArtifactId); err != nil {
+		return nil, err
+	}
+
+	buildID, relPath, _, err := s.resolveArtifact(req.ArtifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.buildsMutex.RLock()
+	build, exists := s.builds[buildID]
+	s.buildsMutex.RUnlock()
+	if exists && !isTerminalState(build.State) {
+		return nil, fmt.Errorf("build %s is still in progress", buildID)
+	}
+
+	if err := s.artifactMgr.DeleteArtifact(buildID, filepath.Join(deploySubdir, relPath)); err != nil {
+		return nil, fmt.Errorf("failed to delete artifact: %w", err)
+	}
+	if s.database != nil {
+		if err := s.database.DeleteArtifact(req.ArtifactId); err != nil {
+			s.logger.Warn("Failed to remove artifact record", slog.String("artifactID", req.ArtifactId), slog.String("error", err.Error()))
+		}
+	}
+
+	s.logger.Info("Deleted artifact", slog.String("buildID", buildID), slog.String("artifact", relPath))
+	return &v1.DeleteArtifactResponse{Success: true}, nil
+}
+
+// isTerminalState reports whether a build state is final
+func isTerminalState(state v1.BuildState) bool {
+	return state == v1.BuildState_BUILD_STATE_COMPLETED ||
+		state == v1.BuildState_BUILD_STATE_FAILED ||
+		state == v1.BuildState_BUILD_STATE_CANCELLED
+}
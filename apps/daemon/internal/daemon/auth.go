@@ -0,0 +1,56 @@
+package daemon
+
+import (
+	"context"
+
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizeBuild checks that the caller may access a build. Callers restricted to a
+// customer are denied unless the build's owner can be resolved, so builds that do not
+// exist are reported as not found without revealing anything about other customers.
+func (s *Server) authorizeBuild(ctx context.Context, buildID string) error {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok || p.Admin {
+		return nil
+	}
+
+	s.buildsMutex.RLock()
+	build, exists := s.builds[buildID]
+	s.buildsMutex.RUnlock()
+	if exists {
+		return auth.Authorize(ctx, build.Customer)
+	}
+
+	if s.database != nil {
+		if record, err := s.database.GetBuild(buildID); err == nil {
+			return auth.Authorize(ctx, record.Customer)
+		}
+	}
+	return status.Errorf(codes.NotFound, "build %s not found", buildID)
+}
+
+// authorizeArtifact checks that the caller may access the build an artifact belongs to
+func (s *Server) authorizeArtifact(ctx context.Context, artifactID string) error {
+	buildID, _, err := db.ParseArtifactID(artifactID)
+	if err != nil {
+		if p, ok := auth.PrincipalFromContext(ctx); ok && !p.Admin {
+			return status.Errorf(codes.NotFound, "artifact %s not found", artifactID)
+		}
+		// Unrestricted callers get the handler's validation error
+		return nil
+	}
+	return s.authorizeBuild(ctx, buildID)
+}
+
+// customerFilter returns the customer a caller is restricted to, or "" for unrestricted callers
+func customerFilter(ctx context.Context) string {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok || p.Admin {
+		return ""
+	}
+	return p.Customer
+}
@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newAuthTestServer returns a server with a live build of acme and a recorded build of globex
+func newAuthTestServer(t *testing.T) *Server {
+	t.Helper()
+	database, err := db.Open(filepath.Join(t.TempDir(), "smidr.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	if err := database.CreateBuild(&db.Build{
+		ID:          "globex-build",
+		Customer:    "globex",
+		ProjectName: "proj",
+		TargetImage: "core-image-minimal",
+		Machine:     "qemux86-64",
+		Status:      db.StatusCompleted,
+		BuildDir:    "/tmp/build",
+		DeployDir:   "/tmp/deploy",
+	}); err != nil {
+		t.Fatalf("failed to create build record: %v", err)
+	}
+
+	return &Server{
+		builds:   map[string]*BuildInfo{"acme-build": {ID: "acme-build", Customer: "acme"}},
+		database: database,
+	}
+}
+
+func TestAuthorizeBuild(t *testing.T) {
+	s := newAuthTestServer(t)
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "acme-ci", Customer: "acme"})
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ops", Admin: true})
+
+	cases := []struct {
+		name    string
+		ctx     context.Context
+		buildID string
+		want    codes.Code
+	}{
+		{"own live build", acme, "acme-build", codes.OK},
+		{"other customer's recorded build", acme, "globex-build", codes.PermissionDenied},
+		{"unknown build", acme, "missing", codes.NotFound},
+		{"admin unknown build", admin, "missing", codes.OK},
+		{"admin other customer", admin, "globex-build", codes.OK},
+		{"authentication disabled", context.Background(), "missing", codes.OK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := status.Code(s.authorizeBuild(tc.ctx, tc.buildID)); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthorizeArtifact(t *testing.T) {
+	s := newAuthTestServer(t)
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "acme-ci", Customer: "acme"})
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ops", Admin: true})
+
+	cases := []struct {
+		name       string
+		ctx        context.Context
+		artifactID string
+		want       codes.Code
+	}{
+		{"own artifact", acme, "acme-build/images/a.wic", codes.OK},
+		{"other customer's artifact", acme, "globex-build/images/a.wic", codes.PermissionDenied},
+		{"unknown build", acme, "missing/images/a.wic", codes.NotFound},
+		{"malformed ID", acme, "no-path", codes.NotFound},
+		{"empty ID", acme, "", codes.NotFound},
+		{"admin malformed ID", admin, "no-path", codes.OK},
+		{"authentication disabled", context.Background(), "no-path", codes.OK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := status.Code(s.authorizeArtifact(tc.ctx, tc.artifactID)); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
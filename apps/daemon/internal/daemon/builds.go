@@ -0,0 +1,434 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/schererja/smidr/internal/artifacts"
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/internal/source"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+// terminalStatuses are the persisted statuses of builds that may be deleted or purged
+var terminalStatuses = []db.BuildStatus{db.StatusCompleted, db.StatusFailed, db.StatusCancelled}
+
+// GetBuild returns the full record of a build, including its config snapshot and artifact totals
+func (s *Server) GetBuild(ctx context.Context, req *v1.GetBuildRequest) (*v1.BuildDetails, error) {
+	buildID := req.BuildIdentifier.GetBuildId()
+	if err := s.authorizeBuild(ctx, buildID); err != nil {
+		return nil, err
+	}
+
+	s.buildsMutex.RLock()
+	build, exists := s.builds[buildID]
+	var liveState v1.BuildState
+	if exists {
+		liveState = build.State
+	}
+	s.buildsMutex.RUnlock()
+
+	if s.database != nil {
+		if record, err := s.database.GetBuild(buildID); err == nil {
+			details := buildDetailsFromRecord(record)
+			details.ConfigSnapshot = record.ConfigSnapshot
+			if exists {
+				// The in-memory state distinguishes the phases of a running build
+				details.BuildState = liveState
+			}
+			count, size, err := s.database.ArtifactStats(buildID)
+			if err != nil {
+				return nil, err
+			}
+			details.ArtifactCount = int32(count)
+			details.TotalArtifactSizeBytes = size
+			if logs, err := s.taskLogs(buildID); err == nil {
+				details.TaskLogs = taskLogsToProto(logs)
+			}
+			if events, err := s.database.ListRecoveryEvents(buildID); err == nil {
+				details.RecoveryActions = recoveryActionsToProto(recoveryActionsFromRecords(events))
+			}
+			return details, nil
+		}
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("build %s not found", buildID)
+	}
+
+	s.buildsMutex.RLock()
+	details := buildDetailsFromInfo(build)
+	if build.Config != nil {
+		if snapshot, err := json.Marshal(build.Config); err == nil {
+			details.ConfigSnapshot = string(snapshot)
+		}
+	}
+	s.buildsMutex.RUnlock()
+
+	if s.artifactMgr != nil {
+		if metadata, err := s.artifactMgr.LoadMetadata(buildID); err == nil {
+			for _, size := range metadata.ArtifactSizes {
+				details.ArtifactCount++
+				details.TotalArtifactSizeBytes += size
+			}
+		}
+	}
+	return details, nil
+}
+
+// DeleteBuild soft-deletes a finished build and removes its artifacts.
+// The build record and logs are kept; PurgeBuilds removes them for good.
+func (s *Server) DeleteBuild(ctx context.Context, req *v1.DeleteBuildRequest) (*v1.DeleteBuildResponse, error) {
+	buildID := req.BuildIdentifier.GetBuildId()
+	if err := s.authorizeBuild(ctx, buildID); err != nil {
+		return nil, err
+	}
+
+	s.buildsMutex.RLock()
+	build, exists := s.builds[buildID]
+	inProgress := exists && !isTerminalState(build.State)
+	s.buildsMutex.RUnlock()
+
+	var record *db.Build
+	if s.database != nil {
+		record, _ = s.database.GetBuild(buildID)
+	}
+	if !exists && record == nil {
+		return &v1.DeleteBuildResponse{
+			Success: false,
+			Message: fmt.Sprintf("build %s not found", buildID),
+		}, nil
+	}
+	if inProgress || (record != nil && !exists && !isTerminalStatus(record.Status)) {
+		return &v1.DeleteBuildResponse{
+			Success: false,
+			Message: fmt.Sprintf("build %s is still in progress; cancel it first", buildID),
+		}, nil
+	}
+
+	freed, err := s.removeArtifacts(buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	if record != nil {
+		if err := s.database.DeleteBuildArtifacts(buildID); err != nil {
+			return nil, err
+		}
+		if err := s.database.SoftDeleteBuild(buildID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.buildsMutex.Lock()
+	delete(s.builds, buildID)
+	s.buildsMutex.Unlock()
+
+	s.logger.Info("Deleted build", slog.String("buildID", buildID), slog.Int64("freedBytes", freed))
+	return &v1.DeleteBuildResponse{
+		Success: true,
+		Message: fmt.Sprintf("Build deleted, freed %s", artifacts.FormatSize(freed)),
+	}, nil
+}
+
+// PurgeBuilds permanently removes finished builds created before a cutoff: their
+// records, artifacts, logs and build directories
+func (s *Server) PurgeBuilds(ctx context.Context, req *v1.PurgeBuildsRequest) (*v1.PurgeBuildsResponse, error) {
+	if req.OlderThanUnixSeconds <= 0 {
+		return nil, fmt.Errorf("older_than_unix_seconds is required")
+	}
+	customer := req.Customer
+	if restricted := customerFilter(ctx); restricted != "" {
+		if customer == "" {
+			customer = restricted
+		}
+		if err := auth.Authorize(ctx, customer); err != nil {
+			return nil, err
+		}
+	}
+	cutoff := time.Unix(req.OlderThanUnixSeconds, 0)
+
+	resp := &v1.PurgeBuildsResponse{}
+	purged := func(buildID string, freed int64) {
+		resp.PurgedBuildIds = append(resp.PurgedBuildIds, buildID)
+		resp.FreedSpaceBytes += freed
+	}
+
+	if s.database != nil {
+		records, _, err := s.database.QueryBuilds(db.BuildQuery{
+			Customer:       customer,
+			Statuses:       terminalStatuses,
+			CreatedBefore:  cutoff,
+			IncludeDeleted: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			freed, err := s.purgeBuild(record.ID, record.BuildDir)
+			if err != nil {
+				s.logger.Warn("Failed to purge build", slog.String("buildID", record.ID), slog.String("error", err.Error()))
+				continue
+			}
+			purged(record.ID, freed)
+		}
+	} else {
+		var candidates []string
+		s.buildsMutex.RLock()
+		for _, build := range s.builds {
+			if isTerminalState(build.State) && build.StartedAt.Before(cutoff) && (customer == "" || build.Customer == customer) {
+				candidates = append(candidates, build.ID)
+			}
+		}
+		s.buildsMutex.RUnlock()
+		for _, buildID := range candidates {
+			freed, err := s.purgeBuild(buildID, "")
+			if err != nil {
+				s.logger.Warn("Failed to purge build", slog.String("buildID", buildID), slog.String("error", err.Error()))
+				continue
+			}
+			purged(buildID, freed)
+		}
+	}
+
+	resp.PurgedBuildCount = int32(len(resp.PurgedBuildIds))
+	resp.Message = fmt.Sprintf("Purged %d build(s), freed %s", resp.PurgedBuildCount, artifacts.FormatSize(resp.FreedSpaceBytes))
+	s.logger.Info("Purged builds",
+		slog.Int("count", len(resp.PurgedBuildIds)),
+		slog.Int64("freedBytes", resp.FreedSpaceBytes),
+		slog.String("customer", customer),
+		slog.Time("olderThan", cutoff))
+	return resp, nil
+}
+
+// RetryBuild starts a new build from the config snapshot, target and customer of a
+// finished build. The new build records the build it retries for lineage.
+func (s *Server) RetryBuild(ctx context.Context, req *v1.RetryBuildRequest) (*v1.BuildStatusResponse, error) {
+	buildID := req.BuildIdentifier.GetBuildId()
+	if buildID == "" {
+		return nil, fmt.Errorf("build_identifier is required")
+	}
+	if err := s.authorizeBuild(ctx, buildID); err != nil {
+		return nil, err
+	}
+
+	original, err := s.retrySource(buildID)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.LoadFromSnapshot([]byte(original.ConfigSnapshot))
+	if err != nil {
+		return nil, fmt.Errorf("cannot retry build %s: %w", buildID, err)
+	}
+	resetBuildDirectories(cfg, buildID)
+	if err := s.checkLayerSources(cfg); err != nil {
+		return nil, err
+	}
+
+	target := req.Target
+	if target == "" {
+		target = original.TargetImage
+	}
+	if target, err = requestedTarget(target, "", false); err != nil {
+		return nil, err
+	}
+	configPath := original.ConfigFile
+	if configPath == "" {
+		configPath = "<snapshot>"
+	}
+
+	resp := s.startBuild(&v1.StartBuildRequest{
+		Target:            target,
+		ForceClean:        req.ForceClean,
+		ForceImageRebuild: req.ForceImageRebuild,
+		Customer:          original.Customer,
+		Priority:          req.Priority,
+	}, cfg, configPath, buildID)
+
+	s.logger.Info("Retrying build",
+		slog.String("buildID", resp.BuildIdentifier.GetBuildId()),
+		slog.String("retriedFrom", buildID),
+		slog.String("target", resp.Target))
+	return resp, nil
+}
+
+// retrySource returns the record of a finished build to retry. Builds of this
+// daemon run without a database are snapshotted from memory.
+func (s *Server) retrySource(buildID string) (*db.Build, error) {
+	s.buildsMutex.RLock()
+	build, exists := s.builds[buildID]
+	var source *db.Build
+	if exists && isTerminalState(build.State) && build.Config != nil {
+		source = &db.Build{
+			ID:          build.ID,
+			Customer:    build.Customer,
+			TargetImage: build.Target,
+			ConfigFile:  build.ConfigPath,
+		}
+		if snapshot, err := json.Marshal(build.Config); err == nil {
+			source.ConfigSnapshot = string(snapshot)
+		}
+	}
+	inProgress := exists && !isTerminalState(build.State)
+	s.buildsMutex.RUnlock()
+
+	if inProgress {
+		return nil, fmt.Errorf("build %s is still in progress; wait for it to finish or cancel it first", buildID)
+	}
+	if s.database != nil {
+		if record, err := s.database.GetBuild(buildID); err == nil {
+			if !exists && !isTerminalStatus(record.Status) {
+				return nil, fmt.Errorf("build %s is still in progress; wait for it to finish or cancel it first", buildID)
+			}
+			source = record
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("build %s not found", buildID)
+	}
+	if source.ConfigSnapshot == "" || source.ConfigSnapshot == "{}" {
+		return nil, fmt.Errorf("build %s has no config snapshot to retry from", buildID)
+	}
+	return source, nil
+}
+
+// resetBuildDirectories clears the workspace directories the runner derived from the
+// original build's ID, so the retry gets a workspace of its own. Directories set in
+// the original config are kept.
+func resetBuildDirectories(cfg *config.Config, originalID string) {
+	workspace := cfg.Directories.Build
+	if workspace == "" || filepath.Base(workspace) != originalID {
+		return
+	}
+	cfg.Directories.Build = ""
+	if isWithin(cfg.Directories.Tmp, workspace) {
+		cfg.Directories.Tmp = ""
+	}
+	if isWithin(cfg.Directories.Deploy, workspace) {
+		cfg.Directories.Deploy = ""
+	}
+}
+
+// isWithin reports whether path is dir or inside it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// purgeBuild removes everything a finished build left behind and returns the bytes freed.
+// A build directory shared with builds that still have records is kept, and so is one the
+// daemon did not create (see ownsBuildDir).
+func (s *Server) purgeBuild(buildID, buildDir string) (int64, error) {
+	s.buildsMutex.RLock()
+	build, exists := s.builds[buildID]
+	inProgress := exists && !isTerminalState(build.State)
+	s.buildsMutex.RUnlock()
+	if inProgress {
+		// The database may lag behind a build restarted under the same ID
+		return 0, fmt.Errorf("build is still in progress")
+	}
+
+	freed, err := s.removeArtifacts(buildID)
+	if err != nil {
+		return freed, err
+	}
+
+	if s.database != nil {
+		// Artifact records are removed by the foreign key cascade
+		if err := s.database.HardDeleteBuild(buildID); err != nil {
+			return freed, err
+		}
+		if buildDir != "" && filepath.IsAbs(buildDir) && s.ownsBuildDir(buildID, buildDir) {
+			inUse, err := s.database.BuildDirInUse(buildDir)
+			if err != nil {
+				return freed, err
+			}
+			if !inUse {
+				// Unregister the layer worktrees from their mirrors before they disappear
+				if err := source.RemoveCheckouts(filepath.Join(buildDir, "layers")); err != nil {
+					s.logger.Warn("Failed to remove layer checkouts", slog.String("path", buildDir), slog.String("error", err.Error()))
+				}
+				n, err := removeTree(buildDir)
+				freed += n
+				if err != nil {
+					return freed, err
+				}
+			}
+		}
+	}
+
+	if s.logDir != "" {
+		n, err := removeTree(s.logSpillPath(buildID))
+		freed += n
+		if err != nil {
+			return freed, err
+		}
+	}
+
+	s.buildsMutex.Lock()
+	delete(s.builds, buildID)
+	s.buildsMutex.Unlock()
+	return freed, nil
+}
+
+// ownsBuildDir reports whether buildDir is the workspace the daemon derived for a build:
+// the directory named after the build directly below the builds directory, symlinks
+// resolved. Configs can name any directory as directories.build, so only such workspaces
+// are removed with a build.
+func (s *Server) ownsBuildDir(buildID, buildDir string) bool {
+	s.settingsMutex.RLock()
+	buildsDir := s.defaults.BuildsDir
+	s.settingsMutex.RUnlock()
+	if buildsDir == "" {
+		// The runner's default
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		buildsDir = filepath.Join(home, ".smidr", "builds")
+	}
+
+	resolvedDir, err := filepath.EvalSymlinks(buildDir)
+	if err != nil {
+		return false
+	}
+	resolvedBuilds, err := filepath.EvalSymlinks(buildsDir)
+	if err != nil {
+		return false
+	}
+	return filepath.Dir(resolvedDir) == resolvedBuilds && filepath.Base(resolvedDir) == buildID
+}
+
+// removeArtifacts deletes the artifacts of a build and the blobs no other build shares,
+// and returns the bytes freed
+func (s *Server) removeArtifacts(buildID string) (int64, error) {
+	if s.artifactMgr == nil {
+		return 0, nil
+	}
+	return s.artifactMgr.RemoveBuild(buildID)
+}
+
+// removeTree removes a file or directory and returns the size of the files it contained
+func removeTree(path string) (int64, error) {
+	size, err := artifacts.DiskUsage(path)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return 0, fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return size, nil
+}
+
+// isTerminalStatus reports whether a persisted build status is final
+func isTerminalStatus(status db.BuildStatus) bool {
+	return status == db.StatusCompleted || status == db.StatusFailed || status == db.StatusCancelled
+}
@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/pkg/logger"
+)
+
+func TestPurgeBuildKeepsForeignBuildDirs(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "smidr.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	buildsDir := t.TempDir()
+	s := &Server{builds: map[string]*BuildInfo{}, database: database, logger: logger.NewLogger()}
+	s.SetBuildDefaults(BuildDefaults{BuildsDir: buildsDir})
+
+	outside := t.TempDir()
+	escape := filepath.Join(buildsDir, "acme-escape")
+	if err := os.Symlink(outside, escape); err != nil {
+		t.Fatal(err)
+	}
+	dirs := map[string]string{
+		"acme-owned":   filepath.Join(buildsDir, "acme-owned"),   // derived by the daemon
+		"acme-outside": outside,                                  // directories.build from the config
+		"acme-other":   filepath.Join(buildsDir, "globex-build"), // another build's workspace
+		"acme-escape":  escape,                                   // named like the build, but a symlink out
+	}
+	for id, dir := range dirs {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "keep.txt"), []byte("data"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := database.CreateBuild(&db.Build{
+			ID:          id,
+			Customer:    "acme",
+			ProjectName: "proj",
+			TargetImage: "core-image-minimal",
+			Machine:     "qemux86-64",
+			Status:      db.StatusCompleted,
+			BuildDir:    dir,
+			DeployDir:   filepath.Join(dir, "deploy"),
+		}); err != nil {
+			t.Fatalf("failed to create build record: %v", err)
+		}
+	}
+
+	for id, dir := range dirs {
+		if _, err := s.purgeBuild(id, dir); err != nil {
+			t.Fatalf("purgeBuild(%s) failed: %v", id, err)
+		}
+		if _, err := database.GetBuild(id); err == nil {
+			t.Errorf("%s: expected the record to be removed", id)
+		}
+		_, err := os.Stat(filepath.Join(dir, "keep.txt"))
+		if id == "acme-owned" {
+			if !os.IsNotExist(err) {
+				t.Errorf("expected the daemon's workspace to be removed, got %v", err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: expected %s to be kept, got %v", id, dir, err)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/schererja/smidr/internal/bitbake"
+	buildpkg "github.com/schererja/smidr/internal/build"
+	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/internal/source"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+// buildEvents records the structured events of a build for WatchBuild subscribers.
+// State and phase changes are kept in full so every watcher sees each transition;
+// task progress is coalesced to the latest value since only the current position matters.
+// Phases only move forward, so the history stays small even for long builds.
+type buildEvents struct {
+	buildID string
+
+	mu              sync.Mutex
+	history         []*v1.BuildEvent // state changes, phase changes, layer fetches and recovery steps in order
+	phase           v1.BuildPhase    // latest phase recorded in history
+	progress        *v1.BuildEvent   // latest task progress
+	progressVersion uint64
+	counts          v1.TaskProgress // running setscene/real task counts
+	closed          bool
+	changed         chan struct{} // closed and replaced on every update
+}
+
+func newBuildEvents(buildID string) *buildEvents {
+	return &buildEvents{buildID: buildID, changed: make(chan struct{})}
+}
+
+func (e *buildEvents) newEvent() *v1.BuildEvent {
+	return &v1.BuildEvent{
+		BuildIdentifier:      &v1.BuildIdentifier{BuildId: e.buildID},
+		TimestampUnixSeconds: time.Now().Unix(),
+	}
+}
+
+// notify wakes up watchers; the caller must hold e.mu
+func (e *buildEvents) notify() {
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// stateChanged records a build state transition
+func (e *buildEvents) stateChanged(previous, state v1.BuildState, message string) {
+	if previous == state {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+
+	event := e.newEvent()
+	event.Event = &v1.BuildEvent_StateChange{StateChange: &v1.BuildStateChange{
+		PreviousState: previous,
+		State:         state,
+		Message:       message,
+	}}
+	e.history = append(e.history, event)
+	e.notify()
+}
+
+// phaseChanged records that the build entered a new phase. Repeats are dropped, and
+// once the build phase is reached earlier phases are too.
+func (e *buildEvents) phaseChanged(phase v1.BuildPhase) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed || phase == e.phase ||
+		(e.phase >= v1.BuildPhase_BUILD_PHASE_BUILD && phase < e.phase) {
+		return
+	}
+	e.phase = phase
+
+	event := e.newEvent()
+	event.Event = &v1.BuildEvent_PhaseChange{PhaseChange: &v1.BuildPhaseChange{Phase: phase}}
+	e.history = append(e.history, event)
+	e.notify()
+}
+
+// recovery records a recovery step the executor took after a failed BitBake run
+func (e *buildEvents) recovery(action bitbake.RecoveryAction) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+
+	event := e.newEvent()
+	event.Event = &v1.BuildEvent_Recovery{Recovery: recoveryActionToProto(action)}
+	e.history = append(e.history, event)
+	e.notify()
+}
+
+// layerFetched records the outcome of fetching a layer repository
+func (e *buildEvents) layerFetched(fetch source.LayerFetch) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+
+	event := e.newEvent()
+	event.Event = &v1.BuildEvent_LayerFetch{LayerFetch: layerFetchToProto(fetch)}
+	e.history = append(e.history, event)
+	e.notify()
+}
+
+// taskProgress records BitBake task progress and updates the setscene/real task counts
+func (e *buildEvents) taskProgress(tp buildpkg.TaskProgress) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+
+	if tp.Setscene {
+		e.counts.SetsceneCurrent = int32(tp.Current)
+		e.counts.SetsceneTotal = int32(tp.Total)
+	} else {
+		e.counts.TaskCurrent = int32(tp.Current)
+		e.counts.TaskTotal = int32(tp.Total)
+	}
+
+	event := e.newEvent()
+	event.Event = &v1.BuildEvent_TaskProgress{TaskProgress: &v1.TaskProgress{
+		Current:         int32(tp.Current),
+		Total:           int32(tp.Total),
+		Recipe:          tp.Recipe,
+		Task:            tp.TaskName,
+		Setscene:        tp.Setscene,
+		SetsceneCurrent: e.counts.SetsceneCurrent,
+		SetsceneTotal:   e.counts.SetsceneTotal,
+		TaskCurrent:     e.counts.TaskCurrent,
+		TaskTotal:       e.counts.TaskTotal,
+	}}
+	e.progress = event
+	e.progressVersion++
+	e.notify()
+}
+
+// close marks the event stream complete; watchers return after sending what is left
+func (e *buildEvents) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	e.closed = true
+	e.notify()
+}
+
+// watch sends all events to send until the stream is closed or ctx is done
+func (e *buildEvents) watch(ctx context.Context, send func(*v1.BuildEvent) error) error {
+	sent := 0
+	var seenProgress uint64
+
+	for {
+		e.mu.Lock()
+		pending := e.history[sent:]
+		var progress *v1.BuildEvent
+		if e.progressVersion > seenProgress {
+			progress = e.progress
+			seenProgress = e.progressVersion
+		}
+		changed, closed := e.changed, e.closed
+		e.mu.Unlock()
+
+		for _, event := range pending {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+		sent += len(pending)
+		if progress != nil {
+			if err := send(progress); err != nil {
+				return err
+			}
+		}
+
+		if closed {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// toProtoPhase maps a runner phase to its API representation
+func toProtoPhase(phase buildpkg.Phase) v1.BuildPhase {
+	switch phase {
+	case buildpkg.PhaseFetch:
+		return v1.BuildPhase_BUILD_PHASE_FETCH
+	case buildpkg.PhaseParse:
+		return v1.BuildPhase_BUILD_PHASE_PARSE
+	case buildpkg.PhaseBuild:
+		return v1.BuildPhase_BUILD_PHASE_BUILD
+	case buildpkg.PhaseExtract:
+		return v1.BuildPhase_BUILD_PHASE_EXTRACT
+	default:
+		return v1.BuildPhase_BUILD_PHASE_UNSPECIFIED
+	}
+}
+
+// WatchBuild streams structured events (state, phase, task progress) of a build.
+// Watchers joining late first receive all state and phase changes so far and the
+// latest task progress. Builds only known to the database yield their final state.
+func (s *Server) WatchBuild(req *v1.WatchBuildRequest, stream v1.BuildService_WatchBuildServer) error {
+	buildID := req.BuildIdentifier.GetBuildId()
+	if err := s.authorizeBuild(stream.Context(), buildID); err != nil {
+		return err
+	}
+
+	s.buildsMutex.RLock()
+	build, exists := s.builds[buildID]
+	s.buildsMutex.RUnlock()
+
+	if exists {
+		return build.Events.watch(stream.Context(), stream.Send)
+	}
+
+	if s.database == nil {
+		return fmt.Errorf("build %s not found", buildID)
+	}
+	record, err := s.database.GetBuild(buildID)
+	if err != nil {
+		return fmt.Errorf("build %s not found", buildID)
+	}
+
+	event := &v1.BuildEvent{
+		BuildIdentifier:      &v1.BuildIdentifier{BuildId: buildID},
+		TimestampUnixSeconds: record.CreatedAt.Unix(),
+		Event: &v1.BuildEvent_StateChange{StateChange: &v1.BuildStateChange{
+			State:   dbStatusToProto(record.Status),
+			Message: record.ErrorMessage,
+		}},
+	}
+	if record.CompletedAt != nil {
+		event.TimestampUnixSeconds = record.CompletedAt.Unix()
+	}
+	return stream.Send(event)
+}
+
+// dbStatusToProto maps a persisted build status to the API build state
+func dbStatusToProto(status db.BuildStatus) v1.BuildState {
+	switch status {
+	case db.StatusQueued:
+		return v1.BuildState_BUILD_STATE_QUEUED
+	case db.StatusRunning:
+		return v1.BuildState_BUILD_STATE_BUILDING
+	case db.StatusCompleted:
+		return v1.BuildState_BUILD_STATE_COMPLETED
+	case db.StatusFailed:
+		return v1.BuildState_BUILD_STATE_FAILED
+	case db.StatusCancelled:
+		return v1.BuildState_BUILD_STATE_CANCELLED
+	default:
+		return v1.BuildState_BUILD_STATE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"testing"
+
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+func TestBuildEventsPhasesMoveForward(t *testing.T) {
+	e := newBuildEvents("acme-build")
+	for _, phase := range []v1.BuildPhase{
+		v1.BuildPhase_BUILD_PHASE_FETCH,
+		v1.BuildPhase_BUILD_PHASE_PARSE,
+		v1.BuildPhase_BUILD_PHASE_FETCH, // the pre-fetch run fetches after parsing
+		v1.BuildPhase_BUILD_PHASE_FETCH,
+		v1.BuildPhase_BUILD_PHASE_BUILD,
+		v1.BuildPhase_BUILD_PHASE_FETCH, // a late fetch task does not leave the build phase
+		v1.BuildPhase_BUILD_PHASE_PARSE,
+		v1.BuildPhase_BUILD_PHASE_BUILD,
+		v1.BuildPhase_BUILD_PHASE_EXTRACT,
+		v1.BuildPhase_BUILD_PHASE_BUILD,
+	} {
+		e.phaseChanged(phase)
+	}
+
+	want := []v1.BuildPhase{
+		v1.BuildPhase_BUILD_PHASE_FETCH,
+		v1.BuildPhase_BUILD_PHASE_PARSE,
+		v1.BuildPhase_BUILD_PHASE_FETCH,
+		v1.BuildPhase_BUILD_PHASE_BUILD,
+		v1.BuildPhase_BUILD_PHASE_EXTRACT,
+	}
+	if len(e.history) != len(want) {
+		t.Fatalf("expected %d phase changes, got %v", len(want), e.history)
+	}
+	for i, event := range e.history {
+		if got := event.GetPhaseChange().GetPhase(); got != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], got)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package daemon
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/schererja/smidr/internal/artifacts"
+	"github.com/schererja/smidr/internal/db"
+)
+
+// DefaultRetentionInterval is how often the janitor applies retention policies
+const DefaultRetentionInterval = time.Hour
+
+// RetentionConfig configures the janitor that removes old builds in the background
+type RetentionConfig struct {
+	Interval  time.Duration                        // time between runs; 0 uses DefaultRetentionInterval
+	Default   artifacts.RetentionPolicy            // applies to customers without their own policy
+	Customers map[string]artifacts.RetentionPolicy // per-customer policies
+}
+
+// Enabled reports whether any policy limits builds
+func (c RetentionConfig) Enabled() bool {
+	if c.Default.Enabled() {
+		return true
+	}
+	for _, p := range c.Customers {
+		if p.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// policyFor returns the policy of a customer
+func (c RetentionConfig) policyFor(customer string) artifacts.RetentionPolicy {
+	if p, ok := c.Customers[customer]; ok {
+		return p
+	}
+	return c.Default
+}
+
+// SetRetention sets the retention policies. On a running server the new policies apply
+// from the next run, and the janitor starts if it was not running yet.
+func (s *Server) SetRetention(cfg RetentionConfig) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultRetentionInterval
+	}
+	s.settingsMutex.Lock()
+	s.retention = cfg
+	s.settingsMutex.Unlock()
+	s.startJanitor()
+}
+
+// retentionConfig returns the current retention policies
+func (s *Server) retentionConfig() RetentionConfig {
+	s.settingsMutex.RLock()
+	defer s.settingsMutex.RUnlock()
+	return s.retention
+}
+
+// startJanitor starts the janitor if the server is serving, a policy is enabled and it is not running yet
+func (s *Server) startJanitor() {
+	s.settingsMutex.Lock()
+	defer s.settingsMutex.Unlock()
+	if !s.serving || s.stopJanitor != nil || !s.retention.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopJanitor = cancel
+	go s.runJanitor(ctx)
+}
+
+// runJanitor enforces retention right away and then on every interval until ctx is done
+func (s *Server) runJanitor(ctx context.Context) {
+	for {
+		s.enforceRetention(time.Now())
+		timer := time.NewTimer(s.retentionConfig().Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// retentionBuild is a finished build as seen by the janitor
+type retentionBuild struct {
+	artifacts.RetentionCandidate
+	customer string
+	buildDir string
+}
+
+// enforceRetention removes finished builds that fall outside their customer's policy.
+// Builds are removed like PurgeBuilds does, so records, artifacts, logs and workspaces
+// disappear together. Queued and running builds are never considered.
+func (s *Server) enforceRetention(now time.Time) {
+	retention := s.retentionConfig()
+	builds, err := s.retentionBuilds()
+	if err != nil {
+		s.logger.Warn("Retention check failed", slog.String("error", err.Error()))
+		return
+	}
+
+	byCustomer := make(map[string][]retentionBuild)
+	for _, b := range builds {
+		byCustomer[b.customer] = append(byCustomer[b.customer], b)
+	}
+
+	removed := 0
+	var freedTotal int64
+	for customer, customerBuilds := range byCustomer {
+		policy := retention.policyFor(customer)
+		if !policy.Enabled() {
+			continue
+		}
+		if policy.MaxSizeGB > 0 {
+			s.measureBuilds(customerBuilds)
+		}
+
+		candidates := make([]artifacts.RetentionCandidate, 0, len(customerBuilds))
+		buildDirs := make(map[string]string, len(customerBuilds))
+		for _, b := range customerBuilds {
+			candidates = append(candidates, b.RetentionCandidate)
+			buildDirs[b.BuildID] = b.buildDir
+		}
+
+		for _, decision := range policy.Expired(candidates, now) {
+			freed, err := s.purgeBuild(decision.BuildID, buildDirs[decision.BuildID])
+			if err != nil {
+				s.logger.Warn("Retention failed to remove build",
+					slog.String("buildID", decision.BuildID),
+					slog.String("customer", customer),
+					slog.String("error", err.Error()))
+				continue
+			}
+			removed++
+			freedTotal += freed
+			s.logger.Info("Retention removed build",
+				slog.String("buildID", decision.BuildID),
+				slog.String("customer", customer),
+				slog.String("reason", decision.Reason),
+				slog.Int64("freedBytes", freed))
+		}
+	}
+
+	if removed > 0 {
+		s.logger.Info("Retention run complete", slog.Int("removed", removed), slog.Int64("freedBytes", freedTotal))
+	}
+}
+
+// retentionBuilds lists the finished builds the janitor may remove
+func (s *Server) retentionBuilds() ([]retentionBuild, error) {
+	var builds []retentionBuild
+
+	if s.database != nil {
+		records, _, err := s.database.QueryBuilds(db.BuildQuery{
+			Statuses:       terminalStatuses,
+			IncludeDeleted: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		s.buildsMutex.RLock()
+		defer s.buildsMutex.RUnlock()
+		for _, record := range records {
+			if live, ok := s.builds[record.ID]; ok && !isTerminalState(live.State) {
+				continue
+			}
+			builds = append(builds, retentionBuild{
+				RetentionCandidate: artifacts.RetentionCandidate{BuildID: record.ID, Timestamp: record.CreatedAt},
+				customer:           record.Customer,
+				buildDir:           record.BuildDir,
+			})
+		}
+		return builds, nil
+	}
+
+	s.buildsMutex.RLock()
+	defer s.buildsMutex.RUnlock()
+	for _, build := range s.builds {
+		if !isTerminalState(build.State) {
+			continue
+		}
+		builds = append(builds, retentionBuild{
+			RetentionCandidate: artifacts.RetentionCandidate{BuildID: build.ID, Timestamp: build.StartedAt},
+			customer:           build.Customer,
+		})
+	}
+	return builds, nil
+}
+
+// measureBuilds sets the disk usage of builds: their artifacts plus their workspace.
+// A workspace shared by several builds is counted once, for the newest of them;
+// deduplicated artifact content is counted for every build that references it.
+func (s *Server) measureBuilds(builds []retentionBuild) {
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].Timestamp.After(builds[j].Timestamp)
+	})
+
+	seenDirs := make(map[string]bool)
+	for i := range builds {
+		b := &builds[i]
+		b.SizeBytes = 0
+		if s.artifactMgr != nil {
+			if size, err := s.artifactMgr.BuildUsage(b.BuildID); err == nil {
+				b.SizeBytes += size
+			}
+		}
+		if b.buildDir != "" && !seenDirs[b.buildDir] {
+			seenDirs[b.buildDir] = true
+			if size, err := artifacts.DiskUsage(b.buildDir); err == nil {
+				b.SizeBytes += size
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/schererja/smidr/internal/bitbake"
+	"github.com/schererja/smidr/internal/buildlog"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+// logFilterFromRequest converts the filter fields of a StreamBuildLogs request
+func logFilterFromRequest(req *v1.StreamBuildLogsRequest) buildlog.Filter {
+	filter := buildlog.Filter{
+		Stream:   req.Stream,
+		Level:    req.Level,
+		Tail:     int(req.Tail),
+		AfterSeq: req.AfterSequence,
+	}
+	if req.SinceUnixSeconds > 0 {
+		filter.Since = time.Unix(req.SinceUnixSeconds, 0)
+	}
+	if req.UntilUnixSeconds > 0 {
+		filter.Until = time.Unix(req.UntilUnixSeconds, 0)
+	}
+	return filter
+}
+
+// logEntryToProto converts a build log entry to its API representation
+func logEntryToProto(e buildlog.Entry) *v1.LogEntry {
+	return &v1.LogEntry{
+		TimestampUnixSeconds: e.Timestamp.Unix(),
+		Stream:               e.Stream,
+		Level:                e.Level,
+		Message:              e.Message,
+		Sequence:             e.Seq,
+	}
+}
+
+// ignoreStreamEnd treats the end of a build's log and client disconnects as a normal end of stream
+func ignoreStreamEnd(ctx context.Context, err error) error {
+	if err == io.EOF || ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// replayPersistedLogs streams the on-disk JSONL log of a build that is only known to the database,
+// e.g. a build from before a daemon restart
+func (s *Server) replayPersistedLogs(req *v1.StreamBuildLogsRequest, stream v1.LogService_StreamBuildLogsServer) error {
+	buildID := req.BuildIdentifier.BuildId
+	build, err := s.database.GetBuild(buildID)
+	if err != nil {
+		return fmt.Errorf("build %s not found", buildID)
+	}
+
+	// Prefer the daemon's own log, which has every line with sequence numbers;
+	// fall back to the BitBake output written by the runner
+	var logPath string
+	candidates := []string{build.LogFileJSONL, filepath.Join(build.BuildDir, "build-log.jsonl")}
+	if s.logDir != "" {
+		candidates = append([]string{s.logSpillPath(buildID)}, candidates...)
+	}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if _, err := os.Stat(candidate); err == nil {
+			logPath = candidate
+			break
+		}
+	}
+	if logPath == "" {
+		return fmt.Errorf("no persisted logs available for build %s", buildID)
+	}
+
+	s.logger.Debug("Replaying persisted build log", slog.String("buildID", buildID), slog.String("path", logPath))
+	return buildlog.ReplayFile(logPath, logFilterFromRequest(req), func(e buildlog.Entry) error {
+		return stream.Send(logEntryToProto(e))
+	})
+}
+
+// maxTaskLogBytes caps the content returned by GetTaskLog so a response stays below the
+// default gRPC message size; longer logs are returned from their end, where the error is
+const maxTaskLogBytes = 3 << 20
+
+// GetTaskLog returns the BitBake log of a failed task, collected into the build directory
+// when the build failed
+func (s *Server) GetTaskLog(ctx context.Context, req *v1.GetTaskLogRequest) (*v1.GetTaskLogResponse, error) {
+	buildID := req.BuildIdentifier.GetBuildId()
+	if err := s.authorizeBuild(ctx, buildID); err != nil {
+		return nil, err
+	}
+
+	task := req.Task
+	if !strings.HasPrefix(task, "do_") {
+		task = "do_" + task
+	}
+
+	logs, err := s.taskLogs(buildID)
+	if err != nil {
+		return nil, err
+	}
+	var found *bitbake.TaskLog
+	available := make([]string, 0, len(logs))
+	for i := range logs {
+		if logs[i].Recipe == req.Recipe && logs[i].Task == task {
+			found = &logs[i]
+		}
+		available = append(available, logs[i].Recipe+" "+logs[i].Task)
+	}
+	if found == nil {
+		if len(available) == 0 {
+			return nil, fmt.Errorf("no task logs were collected for build %s", buildID)
+		}
+		return nil, fmt.Errorf("no log of %s %s in build %s (available: %s)", req.Recipe, task, buildID, strings.Join(available, ", "))
+	}
+
+	limit := int64(maxTaskLogBytes)
+	if req.TailBytes > 0 && req.TailBytes < limit {
+		limit = req.TailBytes
+	}
+	content, size, err := readFileTail(found.Path, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task log: %w", err)
+	}
+	return &v1.GetTaskLogResponse{
+		Recipe:    found.Recipe,
+		Task:      found.Task,
+		Content:   content,
+		SizeBytes: size,
+		Truncated: int64(len(content)) < size,
+	}, nil
+}
+
+// taskLogs returns the task logs collected for a build
+func (s *Server) taskLogs(buildID string) ([]bitbake.TaskLog, error) {
+	if s.database != nil {
+		if _, err := s.database.GetBuild(buildID); err == nil {
+			records, err := s.database.ListTaskLogs(buildID)
+			if err != nil {
+				return nil, err
+			}
+			logs := make([]bitbake.TaskLog, 0, len(records))
+			for _, r := range records {
+				logs = append(logs, bitbake.TaskLog{Recipe: r.Recipe, Task: r.Task, Path: r.LogPath, SizeBytes: r.SizeBytes})
+			}
+			return logs, nil
+		}
+	}
+
+	s.buildsMutex.RLock()
+	defer s.buildsMutex.RUnlock()
+	build, exists := s.builds[buildID]
+	if !exists {
+		return nil, fmt.Errorf("build %s not found", buildID)
+	}
+	return build.TaskLogs, nil
+}
+
+// taskLogsToProto lists collected task logs in their API form
+func taskLogsToProto(logs []bitbake.TaskLog) []*v1.TaskLogInfo {
+	if len(logs) == 0 {
+		return nil
+	}
+	out := make([]*v1.TaskLogInfo, 0, len(logs))
+	for _, l := range logs {
+		out = append(out, &v1.TaskLogInfo{Recipe: l.Recipe, Task: l.Task, SizeBytes: l.SizeBytes})
+	}
+	return out
+}
+
+// readFileTail reads at most limit bytes from the end of a file and returns them with the file size
+func readFileTail(path string, limit int64) ([]byte, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	size := info.Size()
+	offset := int64(0)
+	if size > limit {
+		offset = size - limit
+	}
+	content := make([]byte, size-offset)
+	if _, err := f.ReadAt(content, offset); err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	return content, size, nil
+}
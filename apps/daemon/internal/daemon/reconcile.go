@@ -0,0 +1,178 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/schererja/smidr/internal/container"
+	"github.com/schererja/smidr/internal/container/backend"
+	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/internal/source"
+)
+
+// DefaultReconcileInterval is how often the reconciler looks for orphaned containers and workspaces
+const DefaultReconcileInterval = 15 * time.Minute
+
+// orphanStopTimeout is the grace period given to an orphaned container before it is killed
+const orphanStopTimeout = 10 * time.Second
+
+// startReconciler starts the reconciler if the server is serving with a database and it is not running yet
+func (s *Server) startReconciler() {
+	s.settingsMutex.Lock()
+	defer s.settingsMutex.Unlock()
+	if !s.serving || s.stopReconciler != nil || s.database == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopReconciler = cancel
+	go s.runReconciler(ctx)
+}
+
+// runReconciler reconciles right away and then on every interval until ctx is done
+func (s *Server) runReconciler(ctx context.Context) {
+	for {
+		s.reconcileContainers(ctx)
+		s.reconcileWorkspaces()
+		timer := time.NewTimer(DefaultReconcileInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// isActiveBuild reports whether the daemon is queuing or running a build
+func (s *Server) isActiveBuild(buildID string) bool {
+	s.buildsMutex.RLock()
+	defer s.buildsMutex.RUnlock()
+	build, ok := s.builds[buildID]
+	return ok && !isTerminalState(build.State)
+}
+
+// reconcileContainers stops and removes build containers left behind by a crashed daemon:
+// containers whose build has a record in the database but is no longer active. Containers
+// of builds without a record, such as those started by 'smidr build', are left alone.
+func (s *Server) reconcileContainers(ctx context.Context) {
+	s.settingsMutex.RLock()
+	defaultBackend := backend.Resolve(s.defaults.ContainerBackend)
+	s.settingsMutex.RUnlock()
+
+	// Builds may select any backend in their config, so every available backend is checked
+	for _, name := range backend.Names {
+		manager, err := backend.New(name, s.logger)
+		if err != nil {
+			if name == defaultBackend {
+				s.logger.Warn("Cannot check for orphaned containers", slog.String("backend", name), slog.String("error", err.Error()))
+			} else {
+				s.logger.Debug("Skipping orphaned container check", slog.String("backend", name), slog.String("error", err.Error()))
+			}
+			continue
+		}
+
+		listCtx, cancel := context.WithTimeout(ctx, staleContainerTimeout)
+		containers, err := manager.ListContainers(listCtx, map[string]string{container.LabelManaged: "true"})
+		cancel()
+		if err != nil {
+			s.logger.Warn("Failed to list build containers", slog.String("backend", name), slog.String("error", err.Error()))
+			continue
+		}
+		for _, c := range containers {
+			if ctx.Err() != nil {
+				return
+			}
+			s.reconcileContainer(ctx, manager, name, c)
+		}
+	}
+}
+
+// reconcileContainer removes a single build container if its build is orphaned
+func (s *Server) reconcileContainer(ctx context.Context, manager container.ContainerManager, backendName string, c container.ContainerInfo) {
+	buildID := c.Labels[container.LabelBuildID]
+	if buildID == "" || s.isActiveBuild(buildID) {
+		return
+	}
+
+	record, err := s.database.GetBuild(buildID)
+	if errors.Is(err, db.ErrBuildNotFound) {
+		s.logger.Debug("Leaving build container without a build record", slog.String("container", c.Name), slog.String("buildID", buildID))
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Failed to look up build of container", slog.String("container", c.Name), slog.String("buildID", buildID), slog.String("error", err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, staleContainerTimeout)
+	defer cancel()
+	if c.State == "running" {
+		if err := manager.StopContainer(ctx, c.ID, orphanStopTimeout); err != nil {
+			s.logger.Debug("Failed to stop orphaned container, removing it anyway", slog.String("container", c.Name), slog.String("error", err.Error()))
+		}
+	}
+	if err := manager.RemoveContainer(ctx, c.ID, true); err != nil {
+		s.logger.Warn("Failed to remove orphaned container", slog.String("container", c.Name), slog.String("error", err.Error()))
+		return
+	}
+	s.logger.Info("Removed orphaned build container",
+		slog.String("container", c.Name),
+		slog.String("backend", backendName),
+		slog.String("buildID", buildID),
+		slog.String("status", string(record.Status)))
+}
+
+// reconcileWorkspaces reports workspaces in the builds directory that belong to no build
+// record. They are only reported: the directory is shared with 'smidr build', whose
+// workspaces have no record either. Workspaces of builds that are no longer active get
+// their layer checkouts removed, which a crashed daemon could not do.
+func (s *Server) reconcileWorkspaces() {
+	s.settingsMutex.RLock()
+	buildsDir := s.defaults.BuildsDir
+	s.settingsMutex.RUnlock()
+	if buildsDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return
+		}
+		buildsDir = filepath.Join(home, ".smidr", "builds")
+	}
+
+	entries, err := os.ReadDir(buildsDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Warn("Failed to read builds directory", slog.String("dir", buildsDir), slog.String("error", err.Error()))
+		}
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || s.isActiveBuild(entry.Name()) {
+			continue
+		}
+		workspace := filepath.Join(buildsDir, entry.Name())
+		_, err := s.database.GetBuild(entry.Name())
+		if errors.Is(err, db.ErrBuildNotFound) {
+			s.logger.Warn("Workspace has no build record", slog.String("path", workspace))
+		} else if err != nil {
+			s.logger.Warn("Failed to look up build of workspace", slog.String("path", workspace), slog.String("error", err.Error()))
+		} else {
+			s.reconcileCheckouts(filepath.Join(workspace, "layers"))
+		}
+	}
+}
+
+// reconcileCheckouts removes the layer checkouts of a build that is no longer active
+func (s *Server) reconcileCheckouts(dir string) {
+	if _, err := os.Stat(dir); err != nil {
+		return
+	}
+	if err := source.RemoveCheckouts(dir); err != nil {
+		s.logger.Warn("Failed to remove orphaned layer checkouts", slog.String("path", dir), slog.String("error", err.Error()))
+		return
+	}
+	s.logger.Info("Removed orphaned layer checkouts", slog.String("path", dir))
+}
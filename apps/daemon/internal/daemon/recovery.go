@@ -0,0 +1,179 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	buildpkg "github.com/schererja/smidr/internal/build"
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/container/backend"
+	"github.com/schererja/smidr/internal/db"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+// RecoveryPolicy selects what happens on startup to builds that were queued or
+// running when the daemon stopped
+type RecoveryPolicy string
+
+const (
+	// RecoveryFail marks interrupted builds as failed; users retry them by hand
+	RecoveryFail RecoveryPolicy = "fail"
+	// RecoveryRequeue queues queued builds again and fails running ones
+	RecoveryRequeue RecoveryPolicy = "requeue"
+	// RecoveryResume also restarts running builds in their existing workspace,
+	// where sstate and finished tasks make the second run cheap
+	RecoveryResume RecoveryPolicy = "resume"
+)
+
+// RecoveryPolicies lists the supported recovery policies
+var RecoveryPolicies = []RecoveryPolicy{RecoveryFail, RecoveryRequeue, RecoveryResume}
+
+// ParseRecoveryPolicy parses a policy name; "" selects RecoveryFail
+func ParseRecoveryPolicy(name string) (RecoveryPolicy, error) {
+	if name == "" {
+		return RecoveryFail, nil
+	}
+	for _, policy := range RecoveryPolicies {
+		if string(policy) == name {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("unknown recovery policy %q (supported: %v)", name, RecoveryPolicies)
+}
+
+// staleContainerTimeout bounds the removal of a container left behind by an interrupted build
+const staleContainerTimeout = 30 * time.Second
+
+// SetRecoveryPolicy selects how builds interrupted by a daemon restart are recovered.
+// It must be called before Start.
+func (s *Server) SetRecoveryPolicy(policy RecoveryPolicy) {
+	s.recovery = policy
+}
+
+// recoverStaleBuilds applies the recovery policy to builds that were queued or running
+// when the daemon stopped. Builds that cannot be queued again are marked as failed.
+func (s *Server) recoverStaleBuilds() error {
+	builds, err := s.database.ListStaleBuilds()
+	if err != nil {
+		return fmt.Errorf("failed to list stale builds: %w", err)
+	}
+	if len(builds) == 0 {
+		s.logger.Info("No stale builds detected")
+		return nil
+	}
+
+	policy := s.recovery
+	if policy == "" {
+		policy = RecoveryFail
+	}
+	s.logger.Warn("Recovering stale builds", slog.Int("count", len(builds)), slog.String("policy", string(policy)))
+
+	// Running builds held slots before the restart, so they go back first; each group
+	// keeps its original order
+	sort.SliceStable(builds, func(i, j int) bool {
+		return builds[i].Status == db.StatusRunning && builds[j].Status != db.StatusRunning
+	})
+
+	for _, b := range builds {
+		requeue := (b.Status == db.StatusQueued && policy != RecoveryFail) ||
+			(b.Status == db.StatusRunning && policy == RecoveryResume)
+		if requeue {
+			err := s.requeueStaleBuild(b)
+			if err == nil {
+				continue
+			}
+			s.logger.Warn("Failed to queue stale build again", slog.String("buildID", b.ID), slog.String("error", err.Error()))
+		}
+		s.failStaleBuild(b)
+	}
+	return nil
+}
+
+// failStaleBuild marks an interrupted build as failed
+func (s *Server) failStaleBuild(b *db.Build) {
+	// Compute a best-effort duration
+	var dur time.Duration
+	if b.StartedAt != nil {
+		dur = time.Since(*b.StartedAt)
+	} else {
+		dur = time.Since(b.CreatedAt)
+	}
+	msg := fmt.Sprintf("daemon restarted: marking stale build as failed (run 'smidr client retry %s' to rebuild)", b.ID)
+	if err := s.database.CompleteBuild(b.ID, db.StatusFailed, 1, dur, msg); err != nil {
+		s.logger.Warn("Failed to mark stale build as failed", slog.String("buildID", b.ID), slog.String("error", err.Error()))
+		return
+	}
+	s.logger.Info("Marked stale build as failed", slog.String("buildID", b.ID), slog.String("customer", b.Customer), slog.String("target", b.TargetImage))
+}
+
+// requeueStaleBuild queues an interrupted build again under its own ID. A build that
+// was running keeps its workspace; the container it ran in is removed first because
+// the BitBake session inside cannot be reattached.
+func (s *Server) requeueStaleBuild(b *db.Build) error {
+	cfg, err := config.LoadFromSnapshot([]byte(b.ConfigSnapshot))
+	if err != nil {
+		return err
+	}
+
+	resumed := b.Status == db.StatusRunning
+	if resumed {
+		s.removeStaleContainer(cfg, b.ID)
+	}
+	if err := s.database.UpdateBuildStatus(b.ID, db.StatusQueued, ""); err != nil {
+		return err
+	}
+
+	req := &v1.StartBuildRequest{
+		Target:   b.TargetImage,
+		Customer: b.Customer,
+		Priority: int32(b.Priority),
+	}
+	buildInfo := &BuildInfo{
+		ID:          b.ID,
+		Target:      b.TargetImage,
+		StartedAt:   b.CreatedAt,
+		ConfigPath:  b.ConfigFile,
+		Config:      cfg,
+		Customer:    customerKey(b.Customer, cfg),
+		RetriedFrom: b.RetriedFrom,
+	}
+	// The log continues after the entries written before the restart, so clients can
+	// resume reading where they were
+	previous, note := v1.BuildState_BUILD_STATE_UNSPECIFIED, "🔄 Queued again after a daemon restart"
+	if resumed {
+		previous, note = v1.BuildState_BUILD_STATE_BUILDING, "🔄 Resuming after a daemon restart in the existing workspace"
+	}
+	s.enqueueBuild(buildInfo, req, previous, note)
+
+	s.logger.Info("Queued stale build again",
+		slog.String("buildID", b.ID),
+		slog.String("customer", b.Customer),
+		slog.Bool("resumed", resumed))
+	return nil
+}
+
+// removeStaleContainer removes the container an interrupted build ran in, if it still exists
+func (s *Server) removeStaleContainer(cfg *config.Config, buildID string) {
+	s.settingsMutex.RLock()
+	backendName := backend.Resolve(cfg.Container.Backend, s.defaults.ContainerBackend)
+	s.settingsMutex.RUnlock()
+
+	manager, err := backend.New(backendName, s.logger)
+	if err != nil {
+		s.logger.Warn("Cannot clean up container of interrupted build", slog.String("buildID", buildID), slog.String("error", err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), staleContainerTimeout)
+	defer cancel()
+	name := buildpkg.ContainerName(buildID)
+	// Force removal also stops a BitBake process still running inside
+	if err := manager.RemoveContainer(ctx, name, true); err != nil {
+		s.logger.Debug("No container of interrupted build removed", slog.String("container", name), slog.String("error", err.Error()))
+		return
+	}
+	s.logger.Info("Removed container of interrupted build", slog.String("buildID", buildID), slog.String("container", name))
+}
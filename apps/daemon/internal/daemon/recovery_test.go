@@ -0,0 +1,127 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/schererja/smidr/internal/buildlog"
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/internal/scheduler"
+	"github.com/schererja/smidr/pkg/logger"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+func TestResumeKeepsEarlierLog(t *testing.T) {
+	database, err := db.Open(filepath.Join(t.TempDir(), "smidr.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	cfg, err := config.LoadFromBytes([]byte(`
+name: test-project
+description: "Test description"
+base:
+  provider: poky
+  machine: qemux86-64
+  distro: poky
+layers:
+  - name: poky
+    git: https://git.yoctoproject.org/poky
+build:
+  image: core-image-minimal
+  machine: qemux86-64
+`))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	snapshot, _ := json.Marshal(cfg)
+	if err := database.CreateBuild(&db.Build{
+		ID:             "acme-resumed",
+		Customer:       "acme",
+		ProjectName:    "proj",
+		TargetImage:    "core-image-minimal",
+		Machine:        "qemux86-64",
+		Status:         db.StatusRunning,
+		BuildDir:       "/tmp/build",
+		DeployDir:      "/tmp/deploy",
+		ConfigSnapshot: string(snapshot),
+		CreatedAt:      time.Now(),
+	}); err != nil {
+		t.Fatalf("failed to create build record: %v", err)
+	}
+
+	// Another build holds the only slot, so the resumed build stays queued
+	sched := scheduler.New(scheduler.Options{MaxParallel: 1})
+	sched.Enqueue(scheduler.Request{ID: "other", Customer: "globex"})
+	s := &Server{
+		builds:    map[string]*BuildInfo{},
+		database:  database,
+		logger:    logger.NewLogger(),
+		logDir:    t.TempDir(),
+		scheduler: sched,
+		recovery:  RecoveryResume,
+		// An unknown backend skips removing the stale container
+		defaults: BuildDefaults{ContainerBackend: "none"},
+	}
+
+	// The log written before the restart
+	before, err := buildlog.NewBuffer(10, s.logSpillPath("acme-resumed"))
+	if err != nil {
+		t.Fatalf("NewBuffer failed: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		before.Append("stdout", fmt.Sprintf("line %d", i))
+	}
+	before.Close()
+
+	if err := s.recoverStaleBuilds(); err != nil {
+		t.Fatalf("recoverStaleBuilds failed: %v", err)
+	}
+	s.buildsMutex.RLock()
+	build, ok := s.builds["acme-resumed"]
+	s.buildsMutex.RUnlock()
+	if !ok {
+		t.Fatal("expected the build to be queued again")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	reader := build.Logs.NewReader(0)
+	for i := 1; i <= 4; i++ {
+		entry, err := reader.Next(ctx)
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		if entry.Seq != uint64(i) {
+			t.Errorf("expected seq %d, got %d", i, entry.Seq)
+		}
+		if i <= 3 && entry.Message != fmt.Sprintf("line %d", i) {
+			t.Errorf("expected the earlier line %d, got %q", i, entry.Message)
+		}
+	}
+
+	build.Events.mu.Lock()
+	history := build.Events.history
+	build.Events.mu.Unlock()
+	if len(history) == 0 || history[0].GetStateChange().GetPreviousState() != v1.BuildState_BUILD_STATE_BUILDING {
+		t.Errorf("expected the resume to be reported as a transition from building, got %v", history)
+	}
+
+	// Let the executor finish before the database is closed
+	build.cancel()
+	for {
+		if _, err := reader.Next(ctx); err != nil {
+			if err != io.EOF {
+				t.Errorf("expected the log to end after cancelling, got %v", err)
+			}
+			break
+		}
+	}
+}
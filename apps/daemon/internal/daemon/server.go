@@ -3,23 +3,35 @@ package daemon
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/google/uuid"
 	"github.com/schererja/smidr/internal/artifacts"
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/bitbake"
 	buildpkg "github.com/schererja/smidr/internal/build"
+	"github.com/schererja/smidr/internal/buildlog"
 	"github.com/schererja/smidr/internal/config"
 	"github.com/schererja/smidr/internal/db"
+	"github.com/schererja/smidr/internal/scheduler"
+	"github.com/schererja/smidr/internal/source"
 	"github.com/schererja/smidr/pkg/logger"
 	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // Server implements the Smidr gRPC service
@@ -28,34 +40,62 @@ type Server struct {
 	v1.UnimplementedBuildServiceServer
 	v1.UnimplementedLogServiceServer
 
-	address        string
-	grpcServer     *grpc.Server
-	builds         map[string]*BuildInfo
-	buildsMutex    sync.RWMutex
-	artifactMgr    *artifacts.ArtifactManager
-	buildSemaphore chan struct{}            // global semaphore to limit concurrent builds based on available resources
-	customerQueues map[string]chan struct{} // per-customer queues for serializing builds within the same customer
-	queuesMutex    sync.RWMutex             // protects customerQueues map
-	logger         *logger.Logger           // structured logger
-	database       *db.DB                   // optional database for build persistence
+	address     string
+	grpcServer  *grpc.Server
+	builds      map[string]*BuildInfo
+	buildsMutex sync.RWMutex
+	artifactMgr *artifacts.ArtifactManager
+	scheduler   *scheduler.Scheduler // admits queued builds under the global limit, fair across customers
+	logger      *logger.Logger       // structured logger
+	database    *db.DB               // optional database for build persistence
+	logDir      string               // directory for per-build log spill files
+	tlsConfig   *tls.Config          // serves TLS (mTLS when it requires client certificates) when set
+	tokens      *auth.TokenStore     // requires per-customer bearer tokens when set
+	recovery    RecoveryPolicy       // applied on startup to builds interrupted by a restart
+
+	// Settings that can change while serving (config reload) are guarded by settingsMutex
+	settingsMutex  sync.RWMutex
+	logBufferLines int             // number of log lines kept in memory per build
+	defaults       BuildDefaults   // applied to new builds whose config leaves a setting empty
+	retention      RetentionConfig // policies enforced by the background janitor
+	serving        bool
+	stopJanitor    context.CancelFunc
+	stopReconciler context.CancelFunc
+}
+
+// BuildDefaults are applied to builds whose config leaves the corresponding setting empty
+type BuildDefaults struct {
+	ContainerBackend string                     // container backend for configs without container.backend
+	Image            string                     // builder image for configs without container.base_image
+	DownloadsDir     string                     // shared downloads cache for configs without directories.downloads
+	SStateDir        string                     // shared sstate cache for configs without directories.sstate
+	BuildsDir        string                     // parent of per-build workspaces for configs without directories.build
+	GitCredentials   *source.GitCredentialStore // credentials for private layer repositories, per customer
+	FileURLRoots     []string                   // directories file:// layer URLs may point into; none allows no file URLs
 }
 
 // BuildInfo holds information about an active or completed build
 type BuildInfo struct {
-	ID             string
-	Target         string
-	State          v1.BuildState
-	ExitCode       int32
-	ErrorMsg       string
-	StartedAt      time.Time
-	CompletedAt    time.Time
-	ConfigPath     string
-	Config         *config.Config
-	LogBuffer      []*v1.LogEntry
-	LogMutex       sync.RWMutex
-	LogSubscribers map[chan *v1.LogEntry]bool
-	cancel         context.CancelFunc
-	ArtifactPaths  []string
+	ID            string
+	Target        string
+	State         v1.BuildState
+	ExitCode      int32
+	ErrorMsg      string
+	StartedAt     time.Time
+	CompletedAt   time.Time
+	ConfigPath    string
+	Config        *config.Config
+	Logs          *buildlog.Buffer         // bounded in-memory log, spilled to disk
+	Events        *buildEvents             // state, phase and task progress for WatchBuild
+	Customer      string                   // customer key used for fair scheduling
+	RetriedFrom   string                   // ID of the build this build re-runs, if any
+	Diagnostics   []bitbake.Diagnostic     // failures recognised in the BitBake output
+	TaskLogs      []bitbake.TaskLog        // logs of failed tasks collected into the build directory
+	Recovery      []bitbake.RecoveryAction // recovery steps taken by the executor
+	LayerFetches  []source.LayerFetch      // how each layer repository was fetched
+	ticket        *scheduler.Ticket
+	cancel        context.CancelFunc
+	ArtifactPaths []string
 }
 
 // LogWriter implements bitbake.BuildLogWriter for streaming logs
@@ -65,26 +105,8 @@ type LogWriter struct {
 }
 
 func (lw *LogWriter) WriteLog(stream, content string) {
-	logLine := &v1.LogEntry{
-		TimestampUnixSeconds: time.Now().UnixNano(),
-		Stream:               stream,
-		Message:              content,
-	}
-
-	lw.buildInfo.LogMutex.Lock()
-	defer lw.buildInfo.LogMutex.Unlock()
-
-	// Add to buffer
-	lw.buildInfo.LogBuffer = append(lw.buildInfo.LogBuffer, logLine)
-
-	// Send to all subscribers
-	for ch := range lw.buildInfo.LogSubscribers {
-		select {
-		case ch <- logLine:
-		default:
-			// Don't block if subscriber is slow
-		}
-	}
+	// Subscribers read from the buffer at their own pace, so nothing is dropped for slow clients
+	lw.buildInfo.Logs.Append(stream, content)
 
 	// Also log to structured logger if available
 	if lw.buildLogger != nil {
@@ -106,19 +128,105 @@ func NewServer(address string, log *logger.Logger, database *db.DB) *Server {
 		artifactMgr = nil
 	}
 
+	logDir := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		logDir = filepath.Join(home, ".smidr", "logs")
+	}
+
 	return &Server{
 		address:        address,
 		builds:         make(map[string]*BuildInfo),
 		artifactMgr:    artifactMgr,
-		customerQueues: make(map[string]chan struct{}),
+		scheduler:      scheduler.New(scheduler.Options{}),
 		logger:         log,
 		database:       database,
+		logDir:         logDir,
+		logBufferLines: buildlog.DefaultBufferLines,
+	}
+}
+
+// SetScheduler replaces the build scheduler, e.g. to apply a concurrency limit or customer weights.
+// It must be called before the server accepts builds.
+func (s *Server) SetScheduler(sched *scheduler.Scheduler) {
+	s.scheduler = sched
+}
+
+// SetContainerBackend sets the container backend used for builds whose config does not select one
+func (s *Server) SetContainerBackend(name string) {
+	s.settingsMutex.Lock()
+	defer s.settingsMutex.Unlock()
+	s.defaults.ContainerBackend = name
+}
+
+// SetBuildDefaults sets the defaults for builds started from now on
+func (s *Server) SetBuildDefaults(defaults BuildDefaults) {
+	s.settingsMutex.Lock()
+	defer s.settingsMutex.Unlock()
+	s.defaults = defaults
+}
+
+// SetArtifactDir stores artifacts below dir instead of ~/.smidr/artifacts.
+// It must be called before Start.
+func (s *Server) SetArtifactDir(dir string) error {
+	artifactMgr, err := artifacts.NewArtifactManager(dir)
+	if err != nil {
+		return err
+	}
+	s.artifactMgr = artifactMgr
+	return nil
+}
+
+// SetLogDir keeps per-build log files below dir instead of ~/.smidr/logs.
+// It must be called before Start.
+func (s *Server) SetLogDir(dir string) {
+	s.logDir = dir
+}
+
+// SetTLSConfig makes the server accept only TLS connections
+func (s *Server) SetTLSConfig(cfg *tls.Config) {
+	s.tlsConfig = cfg
+}
+
+// SetTokenStore requires every RPC to carry a bearer token from the store.
+// Tokens scoped to a customer only see and start builds of that customer.
+func (s *Server) SetTokenStore(tokens *auth.TokenStore) {
+	s.tokens = tokens
+}
+
+// SetLogBufferLines sets how many log lines per build are kept in memory; older lines are read from disk
+func (s *Server) SetLogBufferLines(lines int) {
+	if lines > 0 {
+		s.settingsMutex.Lock()
+		s.logBufferLines = lines
+		s.settingsMutex.Unlock()
 	}
 }
 
+// newLogBuffer creates the log buffer for a build, spilling to the daemon log directory when possible
+func (s *Server) newLogBuffer(buildID string) *buildlog.Buffer {
+	s.settingsMutex.RLock()
+	lines := s.logBufferLines
+	s.settingsMutex.RUnlock()
+
+	if s.logDir != "" {
+		buf, err := buildlog.NewBuffer(lines, s.logSpillPath(buildID))
+		if err == nil {
+			return buf
+		}
+		s.logger.Warn("Failed to create log spill file, keeping logs in memory only", slog.String("buildID", buildID), slog.String("error", err.Error()))
+	}
+	buf, _ := buildlog.NewBuffer(lines, "")
+	return buf
+}
+
+// logSpillPath returns where the daemon persists the complete log of a build
+func (s *Server) logSpillPath(buildID string) string {
+	return filepath.Join(s.logDir, buildID+".jsonl")
+}
+
 // Start starts the gRPC server
 func (s *Server) Start() error {
-	// Attempt recovery of stale builds if DB is available
+	// Recover builds interrupted by the last shutdown if DB is available
 	if s.database != nil {
 		if err := s.recoverStaleBuilds(); err != nil {
 			s.logger.Warn("Stale build recovery encountered errors", slog.String("error", err.Error()))
@@ -130,11 +238,47 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
 	}
 
-	s.grpcServer = grpc.NewServer()
+	// Reclaim blobs left behind by builds removed or interrupted while the daemon was down
+	if s.artifactMgr != nil {
+		go func() {
+			removed, freed, err := s.artifactMgr.CollectGarbage()
+			if err != nil {
+				s.logger.Warn("Artifact garbage collection failed", slog.String("error", err.Error()))
+				return
+			}
+			if removed > 0 || freed > 0 {
+				s.logger.Info("Collected unreferenced artifact blobs", slog.Int("blobs", removed), slog.Int64("freedBytes", freed))
+			}
+		}()
+	}
+
+	s.settingsMutex.Lock()
+	s.serving = true
+	s.settingsMutex.Unlock()
+	s.startJanitor()
+	// Runs after recovery so requeued builds count as active and keep their containers
+	s.startReconciler()
+
+	var opts []grpc.ServerOption
+	if s.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+	if s.tokens != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.tokens.UnaryInterceptor()),
+			grpc.ChainStreamInterceptor(s.tokens.StreamInterceptor()),
+		)
+	}
+
+	s.grpcServer = grpc.NewServer(opts...)
 	v1.RegisterArtifactServiceServer(s.grpcServer, s)
 	v1.RegisterBuildServiceServer(s.grpcServer, s)
 	v1.RegisterLogServiceServer(s.grpcServer, s)
-	s.logger.Info("Smidr daemon listening", slog.String("address", s.address))
+	s.logger.Info("Smidr daemon listening",
+		slog.String("address", s.address),
+		slog.Bool("tls", s.tlsConfig != nil),
+		slog.Bool("mtls", s.tlsConfig != nil && s.tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert),
+		slog.Bool("tokenAuth", s.tokens != nil))
 
 	if err := s.grpcServer.Serve(lis); err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -143,63 +287,27 @@ func (s *Server) Start() error {
 	return nil
 }
 
-// getOrCreateCustomerQueue returns a semaphore channel for the given customer.
-// Each customer gets their own queue to allow parallel builds across customers
-// while serializing builds within the same customer to prevent BitBake deadlocks.
-func (s *Server) getOrCreateCustomerQueue(customer string) chan struct{} {
-	s.queuesMutex.Lock()
-	defer s.queuesMutex.Unlock()
-
-	if queue, exists := s.customerQueues[customer]; exists {
-		return queue
-	}
-
-	// Create new queue with capacity 1 (one build at a time per customer)
-	queue := make(chan struct{}, 1)
-	queue <- struct{}{} // initialize with one token
-	s.customerQueues[customer] = queue
-	return queue
-}
-
 // generateShortID creates a short unique identifier (first 8 chars of UUID)
 func generateShortID() string {
 	return uuid.New().String()[:8]
 }
 
-// recoverStaleBuilds marks any previously running builds as failed so users can rebuild
-func (s *Server) recoverStaleBuilds() error {
-	builds, err := s.database.ListStaleBuilds()
-	if err != nil {
-		return fmt.Errorf("failed to list stale builds: %w", err)
-	}
-	if len(builds) == 0 {
-		s.logger.Info("No stale builds detected")
-		return nil
-	}
-
-	s.logger.Warn("Recovering stale builds", slog.Int("count", len(builds)))
-	for _, b := range builds {
-		// Compute a best-effort duration
-		var dur time.Duration
-		if b.StartedAt != nil {
-			dur = time.Since(*b.StartedAt)
-		} else {
-			dur = time.Since(b.CreatedAt)
-		}
-		msg := "daemon restarted: marking stale build as failed (re-run to rebuild)"
-		if err := s.database.CompleteBuild(b.ID, db.StatusFailed, 1, dur, msg); err != nil {
-			s.logger.Warn("Failed to mark stale build as failed", slog.String("buildID", b.ID), slog.String("error", err.Error()))
-			continue
-		}
-		s.logger.Info("Marked stale build as failed", slog.String("buildID", b.ID), slog.String("customer", b.Customer), slog.String("target", b.TargetImage))
-	}
-	return nil
-}
-
 // Stop gracefully stops the gRPC server and cancels all running builds
 func (s *Server) Stop() {
 	s.logger.Info("Stopping daemon...")
 
+	s.settingsMutex.Lock()
+	s.serving = false
+	if s.stopJanitor != nil {
+		s.stopJanitor()
+		s.stopJanitor = nil
+	}
+	if s.stopReconciler != nil {
+		s.stopReconciler()
+		s.stopReconciler = nil
+	}
+	s.settingsMutex.Unlock()
+
 	// Cancel all running builds
 	s.buildsMutex.Lock()
 	s.logger.Info("Cancelling active builds", slog.Int("count", len(s.builds)))
@@ -233,16 +341,44 @@ func (s *Server) StartBuild(ctx context.Context, req *v1.StartBuildRequest) (*v1
 	if req.Config == "" {
 		return nil, fmt.Errorf("config is required (path or inline YAML/JSON content)")
 	}
+	target, err := requestedTarget(req.Target, req.Task, req.ForceTask)
+	if err != nil {
+		return nil, err
+	}
+	req.Target = target
 
-	// Load configuration: treat req.Config as path if it exists; otherwise as inline content
+	// Tokens scoped to a customer build for that customer only
+	if customer := customerFilter(ctx); customer != "" {
+		if req.Customer == "" {
+			req.Customer = customer
+		}
+		if err := auth.Authorize(ctx, req.Customer); err != nil {
+			return nil, err
+		}
+	}
+
+	// Load configuration: treat req.Config as path if it exists; otherwise as inline content.
+	// Only unrestricted callers may name files on the daemon host, so tokens scoped to a
+	// customer cannot read them through parse errors.
 	var (
 		cfg             *config.Config
-		err             error
 		configPathLabel string
+		hostFile        bool
 	)
-	if st, statErr := os.Stat(req.Config); statErr == nil && !st.IsDir() {
+	if p, ok := auth.PrincipalFromContext(ctx); !ok || p.Admin {
+		st, statErr := os.Stat(req.Config)
+		hostFile = statErr == nil && !st.IsDir()
+	}
+	if hostFile {
 		cfg, err = config.Load(req.Config)
 		configPathLabel = req.Config
+		if err == nil {
+			// Check out the commits of the smidr.lock next to the config
+			var unlocked []string
+			if unlocked, err = cfg.ApplyLockFile(config.LockPath(req.Config)); err == nil && len(unlocked) > 0 {
+				s.logger.Warn("Layers not pinned by smidr.lock, building their branch heads", slog.String("config", req.Config), slog.String("layers", strings.Join(unlocked, ", ")))
+			}
+		}
 	} else {
 		cfg, err = config.LoadFromBytes([]byte(req.Config))
 		configPathLabel = "<inline>"
@@ -250,7 +386,38 @@ func (s *Server) StartBuild(ctx context.Context, req *v1.StartBuildRequest) (*v1
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := s.checkLayerSources(cfg); err != nil {
+		return nil, err
+	}
+
+	return s.startBuild(req, cfg, configPathLabel, ""), nil
+}
 
+// checkLayerSources rejects configs whose layers are fetched from the daemon host's
+// filesystem outside of the allowed roots
+func (s *Server) checkLayerSources(cfg *config.Config) error {
+	s.settingsMutex.RLock()
+	roots := s.defaults.FileURLRoots
+	s.settingsMutex.RUnlock()
+	if err := cfg.CheckFileURLs(roots); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	return nil
+}
+
+// requestedTarget validates the targets and task of a request and returns them in the
+// command line form builds store, e.g. "core-image-minimal -c populate_sdk"
+func requestedTarget(target, task string, force bool) (string, error) {
+	inv, err := bitbake.NewInvocation(target, task, force)
+	if err != nil {
+		return "", fmt.Errorf("invalid build target: %w", err)
+	}
+	return inv.String(), nil
+}
+
+// startBuild queues a build for a loaded config and starts its executor.
+// retriedFrom links the build to the build it re-runs.
+func (s *Server) startBuild(req *v1.StartBuildRequest, cfg *config.Config, configPathLabel, retriedFrom string) *v1.BuildStatusResponse {
 	// If target wasn't provided, default to config's build.image for reproducibility and DB persistence
 	if req.Target == "" && cfg != nil && cfg.Build.Image != "" {
 		req.Target = cfg.Build.Image
@@ -263,48 +430,123 @@ func (s *Server) StartBuild(ctx context.Context, req *v1.StartBuildRequest) (*v1
 	}
 	buildID := fmt.Sprintf("%s-%s", buildIDPrefix, generateShortID())
 
-	buildCtx, cancel := context.WithCancel(context.Background())
-
-	s.buildsMutex.Lock()
 	buildInfo := &BuildInfo{
-		ID:             buildID,
-		Target:         req.Target,
-		State:          v1.BuildState_BUILD_STATE_QUEUED,
-		StartedAt:      time.Now(),
-		ConfigPath:     configPathLabel,
-		Config:         cfg,
-		LogBuffer:      make([]*v1.LogEntry, 0),
-		LogSubscribers: make(map[chan *v1.LogEntry]bool),
-		cancel:         cancel,
-	}
-	s.builds[buildID] = buildInfo
-	s.buildsMutex.Unlock()
+		ID:          buildID,
+		Target:      req.Target,
+		StartedAt:   time.Now(),
+		ConfigPath:  configPathLabel,
+		Config:      cfg,
+		Customer:    customerKey(req.Customer, cfg),
+		RetriedFrom: retriedFrom,
+	}
+	// Record the build while it waits so a daemon restart can queue it again
+	if s.database != nil {
+		if err := s.database.CreateBuild(queuedRecord(buildInfo, req)); err != nil {
+			s.logger.Warn("Failed to record queued build", slog.String("buildID", buildID), slog.String("error", err.Error()))
+		}
+	}
+	s.enqueueBuild(buildInfo, req, v1.BuildState_BUILD_STATE_UNSPECIFIED, "")
 
-	// Start the build in a goroutine
-	go s.executeBuild(buildCtx, buildInfo, req)
 	buildIdentifier := v1.BuildIdentifier{BuildId: buildID}
 	return &v1.BuildStatusResponse{
 		BuildIdentifier: &buildIdentifier,
-		State:           buildInfo.State,
+		State:           v1.BuildState_BUILD_STATE_QUEUED,
 		Customer:        req.Customer,
 		Target:          req.Target,
 		Timestamps: &v1.TimeStampRange{
 			StartTimeUnixSeconds: buildInfo.StartedAt.Unix(),
 		},
-		ConfigPath: buildInfo.ConfigPath,
-	}, nil
+		ConfigPath:    buildInfo.ConfigPath,
+		QueuePosition: int32(s.scheduler.Position(buildID)),
+		RetriedFrom:   retriedFrom,
+	}
 }
 
-// executeBuild runs the actual build process
-func (s *Server) executeBuild(ctx context.Context, buildInfo *BuildInfo, req *v1.StartBuildRequest) {
-	// Determine the customer key for queuing (use customer name or config name as fallback)
-	customerKey := req.Customer
-	if customerKey == "" {
-		customerKey = buildInfo.Config.Name
+// enqueueBuild hands a build to the scheduler and starts its executor, which waits
+// for a slot. previous is the state the build was in before, e.g. BUILDING for a build
+// resumed after a restart; note, if set, is logged and describes the transition. The log
+// of a build queued again continues after its earlier entries.
+func (s *Server) enqueueBuild(buildInfo *BuildInfo, req *v1.StartBuildRequest, previous v1.BuildState, note string) {
+	buildCtx, cancel := context.WithCancel(context.Background())
+	buildInfo.State = v1.BuildState_BUILD_STATE_QUEUED
+	buildInfo.Logs = s.newLogBuffer(buildInfo.ID)
+	buildInfo.Events = newBuildEvents(buildInfo.ID)
+	buildInfo.cancel = cancel
+	buildInfo.Events.stateChanged(previous, buildInfo.State, note)
+	if note != "" {
+		buildInfo.Logs.Append("stdout", note)
+	}
+
+	s.buildsMutex.Lock()
+	buildInfo.ticket = s.scheduler.Enqueue(s.schedulerRequest(buildInfo, req))
+	s.builds[buildInfo.ID] = buildInfo
+	s.buildsMutex.Unlock()
+
+	go s.executeBuild(buildCtx, buildInfo, req)
+}
+
+// queuedRecord describes a build that has not started yet for the database.
+// The runner fills in directories and log files once the build starts.
+func queuedRecord(buildInfo *BuildInfo, req *v1.StartBuildRequest) *db.Build {
+	record := &db.Build{
+		ID:          buildInfo.ID,
+		Customer:    req.Customer,
+		TargetImage: buildInfo.Target,
+		Status:      db.StatusQueued,
+		ConfigFile:  buildInfo.ConfigPath,
+		User:        os.Getenv("USER"),
+		CreatedAt:   buildInfo.StartedAt,
+		RetriedFrom: buildInfo.RetriedFrom,
+		Priority:    int(req.Priority),
+	}
+	if buildInfo.Config != nil {
+		record.ProjectName = buildInfo.Config.Name
+		record.Machine = buildInfo.Config.Base.Machine
+		record.BuildDir = buildInfo.Config.Directories.Build
+		if snapshot, err := json.Marshal(buildInfo.Config); err == nil {
+			record.ConfigSnapshot = string(snapshot)
+		}
 	}
-	if customerKey == "" {
-		customerKey = "default"
+	record.Host, _ = os.Hostname()
+	return record
+}
+
+// customerKey returns the key builds are queued under: the customer name, else the config name
+func customerKey(customer string, cfg *config.Config) string {
+	if customer != "" {
+		return customer
+	}
+	if cfg != nil && cfg.Name != "" {
+		return cfg.Name
 	}
+	return "default"
+}
+
+// schedulerRequest describes the resources a build will use, taken from its container settings
+func (s *Server) schedulerRequest(buildInfo *BuildInfo, req *v1.StartBuildRequest) scheduler.Request {
+	sreq := scheduler.Request{
+		ID:       buildInfo.ID,
+		Customer: buildInfo.Customer,
+		Priority: int(req.Priority),
+	}
+	if buildInfo.Config != nil {
+		sreq.CPUs = buildInfo.Config.Container.CPUCount
+		if mem := buildInfo.Config.Container.Memory; mem != "" {
+			if memBytes, err := units.RAMInBytes(mem); err == nil {
+				sreq.MemoryBytes = memBytes
+			}
+		}
+	}
+	return sreq
+}
+
+// executeBuild runs the actual build process
+func (s *Server) executeBuild(ctx context.Context, buildInfo *BuildInfo, req *v1.StartBuildRequest) {
+	// Every exit path writes a final log line; closing the log afterwards ends follow streams
+	defer buildInfo.Logs.Close()
+	defer buildInfo.Events.close()
+
+	customerKey := buildInfo.Customer
 
 	// Create a build-specific logger with context
 	buildLogger := s.logger.With(
@@ -319,22 +561,17 @@ func (s *Server) executeBuild(ctx context.Context, buildInfo *BuildInfo, req *v1
 		buildLogger: buildLogger,
 	}
 
-	// Get or create a queue for this customer
-	queue := s.getOrCreateCustomerQueue(customerKey)
-
-	// Acquire the customer's build queue token to serialize builds per customer
-	logWriter.WriteLog("stdout", fmt.Sprintf("⏳ Waiting for build slot for customer '%s'...", customerKey))
-	select {
-	case <-queue:
-		// Got the token, proceed with build
-		defer func() {
-			queue <- struct{}{} // return token when done
-		}()
-		logWriter.WriteLog("stdout", "Build slot acquired, proceeding...")
-	case <-ctx.Done():
-		s.failBuild(buildInfo.ID, "Build cancelled while waiting for queue slot")
+	// Wait for the scheduler to admit the build; the slot is returned when the build ends
+	if position := s.scheduler.Position(buildInfo.ID); position > 0 {
+		logWriter.WriteLog("stdout", fmt.Sprintf("⏳ Waiting for build slot for customer '%s' (queue position %d)...", customerKey, position))
+	}
+	if err := buildInfo.ticket.Wait(ctx); err != nil {
+		s.recordQueuedCancellation(buildInfo, req)
+		s.markCancelled(buildInfo, logWriter, "cancelled while waiting for queue slot")
 		return
 	}
+	defer buildInfo.ticket.Release()
+	logWriter.WriteLog("stdout", "Build slot acquired, proceeding...")
 
 	// Update state to preparing
 	s.updateBuildState(buildInfo.ID, v1.BuildState_BUILD_STATE_PREPARING)
@@ -342,19 +579,32 @@ func (s *Server) executeBuild(ctx context.Context, buildInfo *BuildInfo, req *v1
 	logWriter.WriteLog("stdout", "Starting build process...")
 	logWriter.WriteLog("stdout", fmt.Sprintf("Config: %s", buildInfo.ConfigPath))
 	logWriter.WriteLog("stdout", fmt.Sprintf("Target: %s", req.Target))
+	if buildInfo.RetriedFrom != "" {
+		logWriter.WriteLog("stdout", fmt.Sprintf("Retry of: %s", buildInfo.RetriedFrom))
+	}
 
 	// Build options for runner
+	s.settingsMutex.RLock()
+	defaults := s.defaults
+	s.settingsMutex.RUnlock()
 	opts := buildpkg.BuildOptions{
-		BuildID:    buildInfo.ID,
-		Target:     req.Target,
-		Customer:   req.Customer,
-		ForceClean: req.ForceClean,
-		ForceImage: req.ForceImageRebuild,
-		ConfigPath: buildInfo.ConfigPath,
+		BuildID:          buildInfo.ID,
+		Target:           req.Target,
+		Customer:         req.Customer,
+		ForceClean:       req.ForceClean,
+		ForceImage:       req.ForceImageRebuild,
+		ConfigPath:       buildInfo.ConfigPath,
+		ContainerBackend: defaults.ContainerBackend,
+		DefaultImage:     defaults.Image,
+		DownloadsDir:     defaults.DownloadsDir,
+		SStateDir:        defaults.SStateDir,
+		BuildsDir:        defaults.BuildsDir,
+		GitCredentials:   defaults.GitCredentials.ForCustomer(customerKey),
+		RetriedFrom:      buildInfo.RetriedFrom,
 	}
 
 	// Bridge for runner logs -> gRPC stream subscribers
-	sink := &runnerLogSink{lw: logWriter}
+	sink := &runnerLogSink{lw: logWriter, events: buildInfo.Events, server: s, build: buildInfo}
 
 	// Mark as BUILDING
 	s.updateBuildState(buildInfo.ID, v1.BuildState_BUILD_STATE_BUILDING)
@@ -362,23 +612,44 @@ func (s *Server) executeBuild(ctx context.Context, buildInfo *BuildInfo, req *v1
 	// Use runner to execute build with DB persistence if available
 	runner := buildpkg.NewRunner(logWriter.buildLogger, s.database)
 	result, err := runner.Run(ctx, buildInfo.Config, opts, sink)
+	if ctx.Err() != nil {
+		// The runner has already stopped BitBake and persisted the cancelled status
+		s.markCancelled(buildInfo, logWriter, "cancelled by request")
+		return
+	}
+
+	// Status handlers read the build concurrently, so results are published under the lock
+	s.buildsMutex.Lock()
+	if result != nil {
+		buildInfo.Diagnostics = result.Diagnostics
+		buildInfo.TaskLogs = result.TaskLogs
+		buildInfo.Recovery = result.Recovery
+	}
 	if err != nil {
-		// Failed
 		buildInfo.ExitCode = 1
 		buildInfo.ErrorMsg = err.Error()
-		buildInfo.CompletedAt = time.Now()
+		if len(buildInfo.Diagnostics) > 0 {
+			buildInfo.ErrorMsg += ": " + buildInfo.Diagnostics[0].Summary()
+		}
+	} else {
+		buildInfo.ExitCode = int32(result.ExitCode)
+	}
+	buildInfo.CompletedAt = time.Now()
+	s.buildsMutex.Unlock()
+
+	if err != nil {
+		// Failed
 		s.updateBuildState(buildInfo.ID, v1.BuildState_BUILD_STATE_FAILED)
 		logWriter.WriteLog("stderr", fmt.Sprintf("Build failed: %v", err))
 		return
 	}
 
 	// Success or non-zero exit
-	buildInfo.ExitCode = int32(result.ExitCode)
-	buildInfo.CompletedAt = time.Now()
 	if result.Success {
 		// Extract artifacts if artifact manager is available and build succeeded
 		if s.artifactMgr != nil {
 			s.updateBuildState(buildInfo.ID, v1.BuildState_BUILD_STATE_EXTRACTING_ARTIFACTS)
+			buildInfo.Events.phaseChanged(v1.BuildPhase_BUILD_PHASE_EXTRACT)
 			logWriter.WriteLog("stdout", "Extracting artifacts...")
 
 			if err := s.extractArtifacts(ctx, buildInfo, result, logWriter); err != nil {
@@ -397,8 +668,15 @@ func (s *Server) executeBuild(ctx context.Context, buildInfo *BuildInfo, req *v1
 	}
 }
 
-// runnerLogSink adapts daemon LogWriter to the Runner LogSink interface
-type runnerLogSink struct{ lw *LogWriter }
+// runnerLogSink adapts daemon LogWriter to the Runner LogSink interface.
+// It also implements buildpkg.ProgressSink, buildpkg.RecoverySink and buildpkg.LayerFetchSink
+// to feed WatchBuild events.
+type runnerLogSink struct {
+	lw     *LogWriter
+	events *buildEvents
+	server *Server
+	build  *BuildInfo
+}
 
 func (s *runnerLogSink) Write(stream string, line string) {
 	if s == nil || s.lw == nil {
@@ -407,6 +685,41 @@ func (s *runnerLogSink) Write(stream string, line string) {
 	s.lw.WriteLog(stream, line)
 }
 
+func (s *runnerLogSink) PhaseChanged(phase buildpkg.Phase) {
+	if s == nil || s.events == nil {
+		return
+	}
+	s.events.phaseChanged(toProtoPhase(phase))
+}
+
+func (s *runnerLogSink) TaskProgress(progress buildpkg.TaskProgress) {
+	if s == nil || s.events == nil {
+		return
+	}
+	s.events.taskProgress(progress)
+}
+
+func (s *runnerLogSink) Recovery(action bitbake.RecoveryAction) {
+	if s == nil || s.events == nil {
+		return
+	}
+	s.events.recovery(action)
+}
+
+func (s *runnerLogSink) LayerFetched(fetch source.LayerFetch) {
+	if s == nil {
+		return
+	}
+	if s.server != nil && s.build != nil {
+		s.server.buildsMutex.Lock()
+		s.build.LayerFetches = append(s.build.LayerFetches, fetch)
+		s.server.buildsMutex.Unlock()
+	}
+	if s.events != nil {
+		s.events.layerFetched(fetch)
+	}
+}
+
 // extractArtifacts extracts build artifacts from the build result
 func (s *Server) extractArtifacts(ctx context.Context, buildInfo *BuildInfo, result *buildpkg.BuildResult, logWriter *LogWriter) error {
 	// Get current user for metadata
@@ -448,12 +761,21 @@ func (s *Server) extractArtifacts(ctx context.Context, buildInfo *BuildInfo, res
 		return fmt.Errorf("failed to create artifact directory: %w", err)
 	}
 
-	// Copy deploy directory contents to artifact storage
-	logWriter.WriteLog("stdout", fmt.Sprintf("Copying artifacts from %s to %s", deployPath, artifactPath))
+	// Store deploy directory contents in the deduplicating blob store
+	logWriter.WriteLog("stdout", fmt.Sprintf("Storing artifacts from %s in %s", deployPath, artifactPath))
 
-	if err := s.copyDirectory(deployPath, filepath.Join(artifactPath, "deploy"), &metadata); err != nil {
-		return fmt.Errorf("failed to copy deploy directory: %w", err)
+	manifest, newBytes, err := s.artifactMgr.StoreDirectory(buildInfo.ID, deployPath, deploySubdir, result.ArtifactChecksums)
+	if err != nil {
+		return fmt.Errorf("failed to store deploy directory: %w", err)
 	}
+	var totalBytes int64
+	for _, entry := range manifest.Entries {
+		// Symlinks are recorded with size 0; their content is tracked at the target path
+		metadata.ArtifactSizes[entry.Path] = entry.Size
+		totalBytes += entry.Size
+	}
+	logWriter.WriteLog("stdout", fmt.Sprintf("Stored %d artifact files (%s), %s new after deduplication",
+		len(manifest.Entries), artifacts.FormatSize(totalBytes), artifacts.FormatSize(newBytes)))
 
 	// Save metadata
 	if err := s.artifactMgr.SaveMetadata(metadata); err != nil {
@@ -465,99 +787,64 @@ func (s *Server) extractArtifacts(ctx context.Context, buildInfo *BuildInfo, res
 	if err != nil {
 		logWriter.WriteLog("stderr", fmt.Sprintf("Failed to list artifacts: %v", err))
 	} else {
+		s.buildsMutex.Lock()
 		buildInfo.ArtifactPaths = artifacts
+		s.buildsMutex.Unlock()
 	}
 
 	return nil
 }
 
-// copyDirectory recursively copies a directory and calculates file sizes
-func (s *Server) copyDirectory(src, dst string, metadata *artifacts.BuildMetadata) error {
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Calculate relative path from source
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
-			return err
-		}
-
-		dstPath := filepath.Join(dst, relPath)
-
-		// Preserve symlinks to avoid dereferencing directory links (e.g., deploy/licenses/*-<machine>)
-		if info.Mode()&os.ModeSymlink != 0 {
-			// Read the symlink target and recreate the symlink at destination
-			target, err := os.Readlink(path)
-			if err != nil {
-				return err
-			}
-			// Ensure destination parent exists
-			if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
-				return err
-			}
-			// Best-effort remove an existing file/dir at dstPath before creating the symlink
-			_ = os.RemoveAll(dstPath)
-			if err := os.Symlink(target, dstPath); err != nil {
-				return err
-			}
-			// Record size as 0 for symlinks in metadata (content tracked at target path if also copied)
-			if metadata != nil {
-				artifactRelPath := filepath.Join("deploy", relPath)
-				metadata.ArtifactSizes[artifactRelPath] = 0
-			}
-			return nil
-		}
-
-		if info.IsDir() {
-			// Create directory
-			return os.MkdirAll(dstPath, info.Mode())
-		} else {
-			// Copy file
-			if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
-				return err
-			}
-
-			srcFile, err := os.Open(path)
-			if err != nil {
-				return err
-			}
-			defer srcFile.Close()
-
-			dstFile, err := os.Create(dstPath)
-			if err != nil {
-				return err
-			}
-			defer dstFile.Close()
+// markCancelled moves a build to CANCELLED and writes the final log line.
+// The state is updated before the log line so followers see a terminal state when it arrives.
+func (s *Server) markCancelled(buildInfo *BuildInfo, logWriter *LogWriter, reason string) {
+	s.buildsMutex.Lock()
+	previous := buildInfo.State
+	buildInfo.State = v1.BuildState_BUILD_STATE_CANCELLED
+	buildInfo.ErrorMsg = "Build " + reason
+	buildInfo.CompletedAt = time.Now()
+	s.buildsMutex.Unlock()
 
-			_, err = io.Copy(dstFile, srcFile)
-			if err != nil {
-				return err
-			}
+	buildInfo.Events.stateChanged(previous, buildInfo.State, buildInfo.ErrorMsg)
 
-			// Calculate checksum and store size
-			srcFile.Seek(0, 0)
-			hash := sha256.New()
-			io.Copy(hash, srcFile)
+	logWriter.WriteLog("stderr", fmt.Sprintf("🛑 Build %s", reason))
+}
 
-			// Store in metadata
-			artifactRelPath := filepath.Join("deploy", relPath)
-			metadata.ArtifactSizes[artifactRelPath] = info.Size()
-		}
+// recordQueuedCancellation marks a build that was cancelled before the runner started it
+func (s *Server) recordQueuedCancellation(buildInfo *BuildInfo, req *v1.StartBuildRequest) {
+	if s.database == nil {
+		return
+	}
 
-		return nil
-	})
+	// The record usually exists since the build was queued; create it if that failed
+	record := queuedRecord(buildInfo, req)
+	record.Status = db.StatusCancelled
+	if err := s.database.CreateBuild(record); err != nil {
+		s.logger.Warn("Failed to record cancelled build", slog.String("buildID", buildInfo.ID), slog.String("error", err.Error()))
+		return
+	}
+	if err := s.database.CompleteBuild(buildInfo.ID, db.StatusCancelled, 0, 0, "cancelled while queued"); err != nil {
+		s.logger.Warn("Failed to record cancelled build", slog.String("buildID", buildInfo.ID), slog.String("error", err.Error()))
+	}
 }
 
-// updateBuildState updates the state of a build
+// updateBuildState updates the state of a build and notifies WatchBuild subscribers
 func (s *Server) updateBuildState(buildID string, state v1.BuildState) {
 	s.buildsMutex.Lock()
-	defer s.buildsMutex.Unlock()
-
-	if build, exists := s.builds[buildID]; exists {
-		build.State = state
+	build, exists := s.builds[buildID]
+	if !exists {
+		s.buildsMutex.Unlock()
+		return
 	}
+	previous := build.State
+	build.State = state
+	message := ""
+	if state == v1.BuildState_BUILD_STATE_FAILED {
+		message = build.ErrorMsg
+	}
+	s.buildsMutex.Unlock()
+
+	build.Events.stateChanged(previous, state, message)
 }
 
 // failBuild marks a build as failed
@@ -566,10 +853,12 @@ func (s *Server) failBuild(buildID string, errorMsg string) {
 	defer s.buildsMutex.Unlock()
 
 	if build, exists := s.builds[buildID]; exists {
+		previous := build.State
 		build.State = v1.BuildState_BUILD_STATE_FAILED
 		build.ErrorMsg = errorMsg
 		build.CompletedAt = time.Now()
 		build.ExitCode = 1
+		build.Events.stateChanged(previous, build.State, errorMsg)
 
 		logWriter := &LogWriter{buildInfo: build}
 		logWriter.WriteLog("stderr", fmt.Sprintf("Build failed: %s", errorMsg))
@@ -578,10 +867,13 @@ func (s *Server) failBuild(buildID string, errorMsg string) {
 
 // GetBuildStatus retrieves the status of a build
 func (s *Server) GetBuildStatus(ctx context.Context, req *v1.BuildStatusRequest) (*v1.BuildStatusResponse, error) {
+	if err := s.authorizeBuild(ctx, req.BuildIdentifier.GetBuildId()); err != nil {
+		return nil, err
+	}
+
 	s.buildsMutex.RLock()
+	defer s.buildsMutex.RUnlock()
 	build, exists := s.builds[req.BuildIdentifier.BuildId]
-	s.buildsMutex.RUnlock()
-
 	if !exists {
 		return nil, fmt.Errorf("build %s not found", req.BuildIdentifier.BuildId)
 	}
@@ -594,7 +886,10 @@ func (s *Server) GetBuildStatus(ctx context.Context, req *v1.BuildStatusRequest)
 		Timestamps: &v1.TimeStampRange{
 			StartTimeUnixSeconds: build.StartedAt.Unix(),
 		},
-		ConfigPath: build.ConfigPath,
+		ConfigPath:    build.ConfigPath,
+		Customer:      build.Customer,
+		QueuePosition: int32(s.scheduler.Position(build.ID)),
+		RetriedFrom:   build.RetriedFrom,
 	}
 
 	if build.ErrorMsg != "" {
@@ -608,92 +903,118 @@ func (s *Server) GetBuildStatus(ctx context.Context, req *v1.BuildStatusRequest)
 	return status, nil
 }
 
-// StreamLogs streams build logs to the client
-func (s *Server) StreamLogs(req *v1.StreamBuildLogsRequest, stream v1.LogService_StreamBuildLogsServer) error {
+// StreamBuildLogs streams build logs to the client
+func (s *Server) StreamBuildLogs(req *v1.StreamBuildLogsRequest, stream v1.LogService_StreamBuildLogsServer) error {
+	if err := s.authorizeBuild(stream.Context(), req.BuildIdentifier.GetBuildId()); err != nil {
+		return err
+	}
+
 	s.buildsMutex.RLock()
 	build, exists := s.builds[req.BuildIdentifier.BuildId]
 	s.buildsMutex.RUnlock()
 
 	if !exists {
+		// Builds from before a daemon restart are only known to the database
+		if s.database != nil {
+			return s.replayPersistedLogs(req, stream)
+		}
 		return fmt.Errorf("build %s not found", req.BuildIdentifier.BuildId)
 	}
 
-	// Send existing logs
-	build.LogMutex.RLock()
-	for _, logLine := range build.LogBuffer {
-		if err := stream.Send(logLine); err != nil {
-			build.LogMutex.RUnlock()
+	filter := logFilterFromRequest(req)
+	ctx := stream.Context()
+	reader := build.Logs.NewReader(req.AfterSequence)
+
+	// Send existing logs; with a tail only the last matching entries are kept
+	end := build.Logs.LastSeq()
+	var history []buildlog.Entry
+	for seq := req.AfterSequence; seq < end; {
+		entry, err := reader.Next(ctx)
+		if err != nil {
+			return ignoreStreamEnd(ctx, err)
+		}
+		seq = entry.Seq
+		if !filter.Match(entry) {
+			continue
+		}
+		if filter.Tail > 0 {
+			history = append(history, entry)
+			if len(history) > filter.Tail {
+				history = history[1:]
+			}
+			continue
+		}
+		if err := stream.Send(logEntryToProto(entry)); err != nil {
+			return err
+		}
+	}
+	for _, entry := range history {
+		if err := stream.Send(logEntryToProto(entry)); err != nil {
 			return err
 		}
 	}
-	build.LogMutex.RUnlock()
-
-	// If follow=true, continue streaming new logs
-	if req.Follow {
-		// Create a channel for this subscriber
-		logChan := make(chan *v1.LogEntry, 100)
-
-		build.LogMutex.Lock()
-		build.LogSubscribers[logChan] = true
-		build.LogMutex.Unlock()
-
-		// Clean up subscriber when done
-		defer func() {
-			build.LogMutex.Lock()
-			delete(build.LogSubscribers, logChan)
-			build.LogMutex.Unlock()
-			close(logChan)
-		}()
 
-		// Stream logs until build completes or client disconnects
-		for {
-			select {
-			case logLine := <-logChan:
-				if err := stream.Send(logLine); err != nil {
-					return err
-				}
-			case <-stream.Context().Done():
-				return nil
-			}
+	if !req.Follow {
+		return nil
+	}
 
-			// Check if build is complete
-			build.LogMutex.RLock()
-			isComplete := build.State == v1.BuildState_BUILD_STATE_COMPLETED ||
-				build.State == v1.BuildState_BUILD_STATE_FAILED ||
-				build.State == v1.BuildState_BUILD_STATE_CANCELLED
-			build.LogMutex.RUnlock()
-
-			if isComplete {
-				// Drain any remaining logs
-				for {
-					select {
-					case logLine := <-logChan:
-						if err := stream.Send(logLine); err != nil {
-							return err
-						}
-					default:
-						return nil
-					}
-				}
-			}
+	// Stream new logs until the build's log is closed or the client disconnects
+	for {
+		entry, err := reader.Next(ctx)
+		if err != nil {
+			return ignoreStreamEnd(ctx, err)
+		}
+		if !filter.Match(entry) {
+			continue
+		}
+		if err := stream.Send(logEntryToProto(entry)); err != nil {
+			return err
 		}
 	}
-
-	return nil
 }
 
 // ListArtifacts lists all artifacts from a completed build
 func (s *Server) ListArtifacts(ctx context.Context, req *v1.ListArtifactsRequest) (*v1.ListArtifactsResponse, error) {
+	buildID := req.BuildIdentifier.BuildId
+	if err := s.authorizeBuild(ctx, buildID); err != nil {
+		return nil, err
+	}
+
+	// Artifacts recorded in the database survive daemon restarts and carry precomputed checksums
+	if s.database != nil {
+		build, err := s.database.GetBuild(buildID)
+		if err != nil {
+			return nil, fmt.Errorf("build %s not found", buildID)
+		}
+		if build.Status != db.StatusCompleted {
+			return nil, fmt.Errorf("build %s is not completed", buildID)
+		}
+
+		records, err := s.database.ListArtifacts(buildID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts: %w", err)
+		}
+
+		protoArtifacts := make([]*v1.ArtifactSummary, 0, len(records))
+		for _, record := range records {
+			protoArtifacts = append(protoArtifacts, artifactSummary(record))
+		}
+		return &v1.ListArtifactsResponse{
+			BuildIdentifier: req.BuildIdentifier,
+			Artifacts:       protoArtifacts,
+		}, nil
+	}
+
 	s.buildsMutex.RLock()
-	build, exists := s.builds[req.BuildIdentifier.BuildId]
+	build, exists := s.builds[buildID]
 	s.buildsMutex.RUnlock()
 
 	if !exists {
-		return nil, fmt.Errorf("build %s not found", req.BuildIdentifier.BuildId)
+		return nil, fmt.Errorf("build %s not found", buildID)
 	}
 
 	if build.State != v1.BuildState_BUILD_STATE_COMPLETED {
-		return nil, fmt.Errorf("build %s is not completed", req.BuildIdentifier.BuildId)
+		return nil, fmt.Errorf("build %s is not completed", buildID)
 	}
 
 	// Return empty list if artifact manager is not available
@@ -705,34 +1026,32 @@ func (s *Server) ListArtifacts(ctx context.Context, req *v1.ListArtifactsRequest
 	}
 
 	// Get artifacts from artifact manager
-	artifactFiles, err := s.artifactMgr.ListArtifacts(req.BuildIdentifier.BuildId)
+	artifactFiles, err := s.artifactMgr.ListArtifacts(buildID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list artifacts: %w", err)
 	}
 
 	// Load metadata to get file sizes
-	metadata, err := s.artifactMgr.LoadMetadata(req.BuildIdentifier.BuildId)
+	metadata, err := s.artifactMgr.LoadMetadata(buildID)
 	if err != nil {
 		// If we can't load metadata, just return files without sizes
 		metadata = &artifacts.BuildMetadata{ArtifactSizes: make(map[string]int64)}
 	}
 
-	// Convert to protobuf artifacts
+	// Convert to protobuf artifacts. Checksums are only available for recorded
+	// artifacts; downloads still report the checksum of the streamed content.
 	var protoArtifacts []*v1.ArtifactSummary
 	for _, artifactFile := range artifactFiles {
-		artifact := &v1.ArtifactSummary{
-			Name:        filepath.Base(artifactFile),
-			DownloadUrl: artifactFile,
-			SizeBytes:   metadata.ArtifactSizes[artifactFile],
-		}
-
-		// Calculate checksum if file exists
-		fullPath := filepath.Join(s.artifactMgr.GetArtifactPath(req.BuildIdentifier.BuildId), artifactFile)
-		if checksum, err := s.calculateChecksum(fullPath); err == nil {
-			artifact.Checksum = checksum
+		relPath, err := filepath.Rel(deploySubdir, artifactFile)
+		if err != nil || strings.HasPrefix(relPath, "..") {
+			continue
 		}
-
-		protoArtifacts = append(protoArtifacts, artifact)
+		protoArtifacts = append(protoArtifacts, &v1.ArtifactSummary{
+			ArtifactId:  db.ArtifactID(buildID, relPath),
+			Name:        filepath.Base(relPath),
+			DownloadUrl: filepath.ToSlash(relPath),
+			SizeBytes:   metadata.ArtifactSizes[artifactFile],
+		})
 	}
 
 	return &v1.ListArtifactsResponse{
@@ -759,6 +1078,10 @@ func (s *Server) calculateChecksum(filePath string) (string, error) {
 
 // CancelBuild cancels a running build
 func (s *Server) CancelBuild(ctx context.Context, req *v1.CancelBuildRequest) (*v1.CancelBuildResponse, error) {
+	if err := s.authorizeBuild(ctx, req.BuildIdentifier.GetBuildId()); err != nil {
+		return nil, err
+	}
+
 	s.buildsMutex.Lock()
 	defer s.buildsMutex.Unlock()
 
@@ -770,173 +1093,381 @@ func (s *Server) CancelBuild(ctx context.Context, req *v1.CancelBuildRequest) (*
 		}, nil
 	}
 
-	if build.State != v1.BuildState_BUILD_STATE_BUILDING {
+	switch build.State {
+	case v1.BuildState_BUILD_STATE_QUEUED,
+		v1.BuildState_BUILD_STATE_PREPARING,
+		v1.BuildState_BUILD_STATE_BUILDING:
+	default:
 		return &v1.CancelBuildResponse{
 			Success: false,
 			Message: fmt.Sprintf("build %s is not in a cancellable state", req.BuildIdentifier.BuildId),
 		}, nil
 	}
 
-	// TODO: Actually cancel the build
+	// Cancelling the build context interrupts BitBake in the container; executeBuild
+	// moves the build to CANCELLED once the runner has shut everything down.
 	if build.cancel != nil {
 		build.cancel()
 	}
 
-	build.State = v1.BuildState_BUILD_STATE_CANCELLED
-	build.CompletedAt = time.Now()
+	s.logger.Info("Cancelling build", slog.String("buildID", build.ID), slog.String("state", build.State.String()))
+	logWriter := &LogWriter{buildInfo: build}
+	logWriter.WriteLog("stderr", "Cancellation requested, stopping build...")
 
 	return &v1.CancelBuildResponse{
 		Success: true,
-		Message: "Build cancelled successfully",
+		Message: "Build cancellation requested",
 	}, nil
 }
 
-// ListBuilds lists all builds
+// ListBuilds lists builds newest first. With a database, filtering and keyset
+// pagination run as SQL queries; otherwise the builds of this daemon run are filtered in memory.
 func (s *Server) ListBuilds(ctx context.Context, req *v1.ListBuildsRequest) (*v1.ListBuildsResponse, error) {
-	// If a database is available, return persisted builds (includes historical and running)
+	query, err := buildQueryFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	if s.database != nil {
-		// Fetch without limit to allow filtering by state, then apply limit
-		dbBuilds, err := s.database.ListBuilds("", false, 0)
+		records, next, err := s.database.QueryBuilds(query)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list builds from database: %w", err)
 		}
+		total, err := s.database.CountBuilds(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count builds: %w", err)
+		}
 
-		// Helper to map db.BuildStatus -> proto BuildState
-		toProtoState := func(st db.BuildStatus) v1.BuildState {
-			switch st {
-			case db.StatusQueued:
-				return v1.BuildState_BUILD_STATE_QUEUED
-			case db.StatusRunning:
-				return v1.BuildState_BUILD_STATE_BUILDING
-			case db.StatusCompleted:
-				return v1.BuildState_BUILD_STATE_COMPLETED
-			case db.StatusFailed:
-				return v1.BuildState_BUILD_STATE_FAILED
-			case db.StatusCancelled:
-				return v1.BuildState_BUILD_STATE_CANCELLED
-			default:
-				return v1.BuildState_BUILD_STATE_UNSPECIFIED
-			}
+		builds := make([]*v1.BuildDetails, 0, len(records))
+		for _, record := range records {
+			builds = append(builds, buildDetailsFromRecord(record))
 		}
+		resp := &v1.ListBuildsResponse{Builds: builds, TotalBuilds: int32(total)}
+		if next != nil {
+			resp.NextPageToken = next.Token()
+		}
+		return resp, nil
+	}
 
-		// Optional state filter set from request
-		filterByState := func(state v1.BuildState) bool {
-			if len(req.StateFilter) == 0 {
-				return true
-			}
-			for _, s := range req.StateFilter {
-				if s == state {
-					return true
-				}
-			}
-			return false
+	return s.listActiveBuilds(req, query), nil
+}
+
+// buildQueryFromRequest translates ListBuilds filters into a database query.
+// Callers restricted to one customer only see that customer's builds.
+func buildQueryFromRequest(ctx context.Context, req *v1.ListBuildsRequest) (db.BuildQuery, error) {
+	if req.PageSize < 0 {
+		return db.BuildQuery{}, fmt.Errorf("page_size must not be negative")
+	}
+
+	query := db.BuildQuery{
+		Customer:       req.Customer,
+		IncludeDeleted: req.IncludeDeleted,
+		Limit:          int(req.PageSize),
+	}
+	if restricted := customerFilter(ctx); restricted != "" {
+		if query.Customer == "" {
+			query.Customer = restricted
+		}
+		if err := auth.Authorize(ctx, query.Customer); err != nil {
+			return db.BuildQuery{}, err
 		}
+	}
 
-		builds := make([]*v1.BuildDetails, 0, len(dbBuilds))
-		for _, b := range dbBuilds {
-			protoState := toProtoState(b.Status)
-			if !filterByState(protoState) {
-				continue
-			}
+	for _, state := range req.StateFilter {
+		status, ok := protoStateToDB(state)
+		if ok && !slices.Contains(query.Statuses, status) {
+			query.Statuses = append(query.Statuses, status)
+		}
+	}
 
-			bd := &v1.BuildDetails{
-				BuildIdentifier:   &v1.BuildIdentifier{BuildId: b.ID},
-				TargetImage:       b.TargetImage,
-				BuildState:        protoState,
-				ConfigFile:        b.ConfigFile,
-				Customer:          b.Customer,
-				ProjectName:       b.ProjectName,
-				Machine:           b.Machine,
-				BuildDirectory:    b.BuildDir,
-				DownloadDirectory: b.DeployDir,
-				User:              b.User,
-				Host:              b.Host,
-				ErrorMessage:      b.ErrorMessage,
-				Deleted:           b.Deleted,
-				Timestamps:        &v1.TimeStampRange{},
-			}
-			if b.ExitCode != nil {
-				bd.ExitCode = int32(*b.ExitCode)
-			}
-			if !b.CreatedAt.IsZero() {
-				bd.CreatedAt = b.CreatedAt.Unix()
-			}
-			if b.StartedAt != nil {
-				bd.Timestamps.StartTimeUnixSeconds = b.StartedAt.Unix()
-			}
-			if b.CompletedAt != nil {
-				bd.Timestamps.EndTimeUnixSeconds = b.CompletedAt.Unix()
-				if b.StartedAt != nil {
-					bd.DurationSeconds = int32(b.CompletedAt.Sub(*b.StartedAt).Seconds())
-				}
-			}
+	if tr := req.TimeRange; tr != nil {
+		if tr.StartTimeUnixSeconds > 0 {
+			query.CreatedAfter = time.Unix(tr.StartTimeUnixSeconds, 0)
+		}
+		if tr.EndTimeUnixSeconds > 0 {
+			query.CreatedBefore = time.Unix(tr.EndTimeUnixSeconds, 0)
+		}
+	}
 
-			if b.DeletedAt != nil {
-				bd.DeletedAt = b.DeletedAt.Unix()
-			}
+	if req.PageToken != "" {
+		cursor, err := db.ParseBuildCursor(req.PageToken)
+		if err != nil {
+			return db.BuildQuery{}, err
+		}
+		query.After = cursor
+	}
+	return query, nil
+}
 
-			builds = append(builds, bd)
+// protoStateToDB maps an API build state to the persisted status; the database
+// does not distinguish the phases of a running build
+func protoStateToDB(state v1.BuildState) (db.BuildStatus, bool) {
+	switch state {
+	case v1.BuildState_BUILD_STATE_QUEUED:
+		return db.StatusQueued, true
+	case v1.BuildState_BUILD_STATE_PREPARING,
+		v1.BuildState_BUILD_STATE_BUILDING,
+		v1.BuildState_BUILD_STATE_EXTRACTING_ARTIFACTS:
+		return db.StatusRunning, true
+	case v1.BuildState_BUILD_STATE_COMPLETED:
+		return db.StatusCompleted, true
+	case v1.BuildState_BUILD_STATE_FAILED:
+		return db.StatusFailed, true
+	case v1.BuildState_BUILD_STATE_CANCELLED:
+		return db.StatusCancelled, true
+	default:
+		return "", false
+	}
+}
 
-			// Apply limit if requested
-			if req.PageSize > 0 && len(builds) >= int(req.PageSize) {
-				break
-			}
+// buildDetailsFromRecord converts a persisted build to its API representation
+func buildDetailsFromRecord(b *db.Build) *v1.BuildDetails {
+	bd := &v1.BuildDetails{
+		BuildIdentifier:   &v1.BuildIdentifier{BuildId: b.ID},
+		TargetImage:       b.TargetImage,
+		BuildState:        dbStatusToProto(b.Status),
+		ConfigFile:        b.ConfigFile,
+		Customer:          b.Customer,
+		ProjectName:       b.ProjectName,
+		Machine:           b.Machine,
+		BuildDirectory:    b.BuildDir,
+		DownloadDirectory: b.DeployDir,
+		LogFilePlain:      b.LogFilePlain,
+		LogFileJsonl:      b.LogFileJSONL,
+		User:              b.User,
+		Host:              b.Host,
+		ErrorMessage:      b.ErrorMessage,
+		Deleted:           b.Deleted,
+		RetriedFrom:       b.RetriedFrom,
+		Timestamps:        &v1.TimeStampRange{},
+	}
+	if b.Diagnostics != "" {
+		var diagnostics []bitbake.Diagnostic
+		if err := json.Unmarshal([]byte(b.Diagnostics), &diagnostics); err == nil {
+			bd.Diagnostics = diagnosticsToProto(diagnostics)
+		}
+	}
+	if b.LayerCommits != "" {
+		var layers []config.LockedLayer
+		if err := json.Unmarshal([]byte(b.LayerCommits), &layers); err == nil {
+			bd.LayerCommits = layerCommitsToProto(layers)
+		}
+	}
+	if b.LayerFetches != "" {
+		var fetches []source.LayerFetch
+		if err := json.Unmarshal([]byte(b.LayerFetches), &fetches); err == nil {
+			bd.LayerFetches = layerFetchesToProto(fetches)
+		}
+	}
+	if b.ExitCode != nil {
+		bd.ExitCode = int32(*b.ExitCode)
+	}
+	if !b.CreatedAt.IsZero() {
+		bd.CreatedAt = b.CreatedAt.Unix()
+	}
+	if b.StartedAt != nil {
+		bd.Timestamps.StartTimeUnixSeconds = b.StartedAt.Unix()
+	}
+	if b.CompletedAt != nil {
+		bd.Timestamps.EndTimeUnixSeconds = b.CompletedAt.Unix()
+		if b.StartedAt != nil {
+			bd.DurationSeconds = int32(b.CompletedAt.Sub(*b.StartedAt).Seconds())
 		}
+	}
+	if b.DeletedAt != nil {
+		bd.DeletedAt = b.DeletedAt.Unix()
+	}
+	return bd
+}
+
+// diagnosticsToProto converts diagnosed build failures to their API form
+func diagnosticsToProto(diagnostics []bitbake.Diagnostic) []*v1.BuildDiagnostic {
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	out := make([]*v1.BuildDiagnostic, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		out = append(out, &v1.BuildDiagnostic{
+			Kind:    string(d.Kind),
+			Recipe:  d.Recipe,
+			Task:    d.Task,
+			Url:     d.URL,
+			File:    d.File,
+			Line:    int32(d.Line),
+			Layer:   d.Layer,
+			LogFile: d.LogFile,
+			Message: d.Message,
+			Hint:    d.Hint,
+		})
+	}
+	return out
+}
+
+// recoveryActionToProto converts a recovery step to its API form
+func recoveryActionToProto(action bitbake.RecoveryAction) *v1.RecoveryAction {
+	return &v1.RecoveryAction{
+		Strategy:             string(action.Strategy),
+		Recipe:               action.Recipe,
+		Attempt:              int32(action.Attempt),
+		Succeeded:            action.Succeeded,
+		Detail:               action.Detail,
+		StartedAtUnixSeconds: action.StartedAt.Unix(),
+		DurationMs:           action.Duration.Milliseconds(),
+	}
+}
 
-		return &v1.ListBuildsResponse{Builds: builds}, nil
+// recoveryActionsToProto converts the recovery steps of a build to their API form
+func recoveryActionsToProto(actions []bitbake.RecoveryAction) []*v1.RecoveryAction {
+	if len(actions) == 0 {
+		return nil
+	}
+	out := make([]*v1.RecoveryAction, 0, len(actions))
+	for _, action := range actions {
+		out = append(out, recoveryActionToProto(action))
+	}
+	return out
+}
+
+// layerCommitsToProto converts the commits a build's layers were checked out at
+func layerCommitsToProto(layers []config.LockedLayer) []*v1.LayerCommit {
+	out := make([]*v1.LayerCommit, 0, len(layers))
+	for _, layer := range layers {
+		out = append(out, &v1.LayerCommit{Name: layer.Name, Git: layer.Git, Branch: layer.Branch, Commit: layer.Commit})
+	}
+	return out
+}
+
+// layerFetchToProto converts the fetch outcome of a layer to its API form
+func layerFetchToProto(fetch source.LayerFetch) *v1.LayerFetch {
+	return &v1.LayerFetch{
+		Name:       fetch.Name,
+		Git:        fetch.Git,
+		Status:     string(fetch.Status),
+		Url:        fetch.URL,
+		Ref:        fetch.Ref,
+		Commit:     fetch.Commit,
+		Attempts:   int32(fetch.Attempts),
+		DurationMs: fetch.Duration.Milliseconds(),
+		Error:      fetch.Error,
+	}
+}
+
+// layerFetchesToProto converts the layer fetches of a build to their API form
+func layerFetchesToProto(fetches []source.LayerFetch) []*v1.LayerFetch {
+	if len(fetches) == 0 {
+		return nil
 	}
+	out := make([]*v1.LayerFetch, 0, len(fetches))
+	for _, fetch := range fetches {
+		out = append(out, layerFetchToProto(fetch))
+	}
+	return out
+}
+
+// recoveryActionsFromRecords converts stored recovery events back to recovery steps
+func recoveryActionsFromRecords(events []*db.RecoveryEvent) []bitbake.RecoveryAction {
+	actions := make([]bitbake.RecoveryAction, 0, len(events))
+	for _, event := range events {
+		actions = append(actions, bitbake.RecoveryAction{
+			Strategy:  bitbake.RecoveryStrategy(event.Strategy),
+			Recipe:    event.Recipe,
+			Attempt:   event.Attempt,
+			Succeeded: event.Succeeded,
+			Detail:    event.Detail,
+			StartedAt: event.StartedAt,
+			Duration:  event.Duration,
+		})
+	}
+	return actions
+}
 
-	// Fallback to in-memory builds when DB is not enabled
+// listActiveBuilds lists the builds of this daemon run when no database is configured.
+// It applies the same filters and cursor order as the database query.
+func (s *Server) listActiveBuilds(req *v1.ListBuildsRequest, query db.BuildQuery) *v1.ListBuildsResponse {
 	s.buildsMutex.RLock()
 	defer s.buildsMutex.RUnlock()
 
-	builds := make([]*v1.BuildDetails, 0)
+	matched := make([]*BuildInfo, 0, len(s.builds))
 	for _, build := range s.builds {
-		// Filter by state if requested
-		if len(req.StateFilter) > 0 {
-			matched := false
-			for _, state := range req.StateFilter {
-				if build.State == state {
-					matched = true
-					break
-				}
-			}
-			if !matched {
-				continue
-			}
+		if query.Customer != "" && build.Customer != query.Customer {
+			continue
 		}
-
-		details := &v1.BuildDetails{
-			BuildIdentifier: &v1.BuildIdentifier{BuildId: build.ID},
-			TargetImage:     build.Target,
-			BuildState:      build.State,
-			ExitCode:        build.ExitCode,
-			ConfigFile:      build.ConfigPath,
-			Timestamps:      &v1.TimeStampRange{},
+		if len(req.StateFilter) > 0 && !slices.Contains(req.StateFilter, build.State) {
+			continue
 		}
-
-		if !build.StartedAt.IsZero() {
-			details.Timestamps.StartTimeUnixSeconds = build.StartedAt.Unix()
+		if !query.CreatedAfter.IsZero() && build.StartedAt.Before(query.CreatedAfter) {
+			continue
 		}
-
-		if build.ErrorMsg != "" {
-			details.ErrorMessage = build.ErrorMsg
+		if !query.CreatedBefore.IsZero() && !build.StartedAt.Before(query.CreatedBefore) {
+			continue
 		}
+		matched = append(matched, build)
+	}
 
-		if !build.CompletedAt.IsZero() {
-			details.Timestamps.EndTimeUnixSeconds = build.CompletedAt.Unix()
-			if !build.StartedAt.IsZero() {
-				details.DurationSeconds = int32(build.CompletedAt.Sub(build.StartedAt).Seconds())
-			}
+	// Newest first, ties broken by ID like the database cursor
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].StartedAt.Equal(matched[j].StartedAt) {
+			return matched[i].StartedAt.After(matched[j].StartedAt)
 		}
+		return matched[i].ID > matched[j].ID
+	})
+	resp := &v1.ListBuildsResponse{TotalBuilds: int32(len(matched))}
+
+	if after := query.After; after != nil {
+		start := sort.Search(len(matched), func(i int) bool {
+			b := matched[i]
+			return b.StartedAt.Before(after.CreatedAt) || (b.StartedAt.Equal(after.CreatedAt) && b.ID < after.ID)
+		})
+		matched = matched[start:]
+	}
+	if query.Limit > 0 && len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+		last := matched[len(matched)-1]
+		resp.NextPageToken = (&db.BuildCursor{CreatedAt: last.StartedAt, ID: last.ID}).Token()
+	}
 
-		builds = append(builds, details)
+	resp.Builds = make([]*v1.BuildDetails, 0, len(matched))
+	for _, build := range matched {
+		resp.Builds = append(resp.Builds, buildDetailsFromInfo(build))
+	}
+	return resp
+}
 
-		// Limit results if requested
-		if req.PageSize > 0 && len(builds) >= int(req.PageSize) {
-			break
-		}
+// buildDetailsFromInfo converts an in-memory build; the caller must hold buildsMutex
+func buildDetailsFromInfo(build *BuildInfo) *v1.BuildDetails {
+	details := &v1.BuildDetails{
+		BuildIdentifier: &v1.BuildIdentifier{BuildId: build.ID},
+		TargetImage:     build.Target,
+		BuildState:      build.State,
+		ExitCode:        build.ExitCode,
+		ConfigFile:      build.ConfigPath,
+		Customer:        build.Customer,
+		CreatedAt:       build.StartedAt.Unix(),
+		RetriedFrom:     build.RetriedFrom,
+		Diagnostics:     diagnosticsToProto(build.Diagnostics),
+		TaskLogs:        taskLogsToProto(build.TaskLogs),
+		RecoveryActions: recoveryActionsToProto(build.Recovery),
+		LayerFetches:    layerFetchesToProto(build.LayerFetches),
+		Timestamps:      &v1.TimeStampRange{},
+	}
+	if build.Config != nil {
+		details.ProjectName = build.Config.Name
+		details.Machine = build.Config.Base.Machine
+	}
+
+	if !build.StartedAt.IsZero() {
+		details.Timestamps.StartTimeUnixSeconds = build.StartedAt.Unix()
 	}
 
-	return &v1.ListBuildsResponse{Builds: builds}, nil
+	if build.ErrorMsg != "" {
+		details.ErrorMessage = build.ErrorMsg
+	}
+
+	if !build.CompletedAt.IsZero() {
+		details.Timestamps.EndTimeUnixSeconds = build.CompletedAt.Unix()
+		if !build.StartedAt.IsZero() {
+			details.DurationSeconds = int32(build.CompletedAt.Sub(build.StartedAt).Seconds())
+		}
+	}
+	return details
 }
@@ -0,0 +1,98 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/schererja/smidr/internal/auth"
+	"github.com/schererja/smidr/internal/config"
+	"github.com/schererja/smidr/pkg/logger"
+	v1 "github.com/schererja/smidr/pkg/smidr-sdk/v1"
+)
+
+func TestStartBuildRejectsFileURLs(t *testing.T) {
+	s := &Server{builds: map[string]*BuildInfo{}, logger: logger.NewLogger()}
+	root := t.TempDir()
+	cfg := `
+name: test-project
+description: "Test description"
+base:
+  provider: poky
+  machine: qemux86-64
+  distro: poky
+layers:
+  - name: meta-private
+    git: file://` + root + `/meta-private.git
+build:
+  image: core-image-minimal
+  machine: qemux86-64
+`
+
+	// Without allowed roots remote builds cannot read repositories on the daemon host
+	_, err := s.StartBuild(context.Background(), &v1.StartBuildRequest{Config: cfg})
+	if err == nil || !strings.Contains(err.Error(), "file git URLs are not allowed") {
+		t.Fatalf("expected the file URL to be rejected, got %v", err)
+	}
+	if len(s.builds) != 0 {
+		t.Errorf("expected no build to be queued, got %v", s.builds)
+	}
+
+	s.SetBuildDefaults(BuildDefaults{FileURLRoots: []string{root}})
+	loaded, err := config.LoadFromBytes([]byte(cfg))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := s.checkLayerSources(loaded); err != nil {
+		t.Errorf("expected a file URL below an allowed root to pass, got %v", err)
+	}
+}
+
+func TestStartBuildReadsHostConfigOnlyForAdmins(t *testing.T) {
+	s := &Server{builds: map[string]*BuildInfo{}, logger: logger.NewLogger()}
+	// A config on the daemon host that the layer check rejects, so no build is started
+	path := filepath.Join(t.TempDir(), "smidr.yaml")
+	cfg := `
+name: test-project
+description: "Test description"
+base:
+  provider: poky
+  machine: qemux86-64
+  distro: poky
+layers:
+  - name: meta-private
+    git: file:///srv/git/meta-private.git
+build:
+  image: core-image-minimal
+  machine: qemux86-64
+`
+	if err := os.WriteFile(path, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	acme := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "acme-ci", Customer: "acme"})
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "ops", Admin: true})
+	for _, tt := range []struct {
+		name      string
+		ctx       context.Context
+		loadsFile bool
+	}{
+		{"customer token", acme, false},
+		{"admin token", admin, true},
+		{"no auth", context.Background(), true},
+	} {
+		_, err := s.StartBuild(tt.ctx, &v1.StartBuildRequest{Config: path})
+		loaded := err != nil && strings.Contains(err.Error(), "file git URLs are not allowed")
+		if loaded != tt.loadsFile {
+			t.Errorf("%s: expected the host file to be loaded: %v, got %v", tt.name, tt.loadsFile, err)
+		}
+		if !tt.loadsFile && (err == nil || !strings.Contains(err.Error(), "failed to load config")) {
+			t.Errorf("%s: expected the path to be parsed as inline config, got %v", tt.name, err)
+		}
+	}
+	if len(s.builds) != 0 {
+		t.Errorf("expected no build to be queued, got %v", s.builds)
+	}
+}
@@ -3,7 +3,11 @@ package db
 import (
 	"database/sql"
 	"embed"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -12,6 +16,9 @@ import (
 //go:embed schema.sql
 var schemaFS embed.FS
 
+// ErrBuildNotFound is returned by GetBuild when no record has the requested ID
+var ErrBuildNotFound = errors.New("build not found")
+
 // DB wraps the SQLite database connection
 type DB struct {
 	conn *sql.DB
@@ -53,11 +60,17 @@ type Build struct {
 	Deleted         bool
 	DeletedAt       *time.Time
 	ErrorMessage    string
+	RetriedFrom     string // ID of the build this build is a retry of
+	Priority        int    // scheduling priority
+	Diagnostics     string // JSON array of the failures diagnosed in the build output
+	LayerCommits    string // JSON array of the commits the git layers were checked out at
+	LayerFetches    string // JSON array of how each layer repository was fetched
 }
 
 // BuildArtifact represents a file produced by a build
 type BuildArtifact struct {
 	ID           int64
+	ArtifactID   string // stable public identifier, see ArtifactID
 	BuildID      string
 	ArtifactPath string
 	ArtifactType string
@@ -66,6 +79,31 @@ type BuildArtifact struct {
 	CreatedAt    time.Time
 }
 
+// TaskLog is a BitBake task log collected into the build directory after a task failed
+type TaskLog struct {
+	ID        int64
+	BuildID   string
+	Recipe    string // recipe name without version, e.g. "busybox"
+	Task      string // e.g. "do_compile"
+	LogPath   string // host path of the collected log
+	SizeBytes int64
+	CreatedAt time.Time
+}
+
+// RecoveryEvent is a self-heal step the executor took during a build, e.g. a cleansstate
+// of the failed recipe followed by a retry
+type RecoveryEvent struct {
+	ID        int64
+	BuildID   string
+	Strategy  string // "package_index", "pseudo_cleanup", "cleansstate" or "retry"
+	Recipe    string
+	Attempt   int
+	Succeeded bool
+	Detail    string
+	StartedAt time.Time
+	Duration  time.Duration
+}
+
 // Open opens or creates the SQLite database at the given path
 func Open(dbPath string) (*DB, error) {
 	conn, err := sql.Open("sqlite3", dbPath)
@@ -97,6 +135,31 @@ func Open(dbPath string) (*DB, error) {
 	return db, nil
 }
 
+// columnMigrations lists columns added after the initial schema. CREATE TABLE IF NOT EXISTS
+// does not alter existing tables, so these are added to older databases on open.
+var columnMigrations = []struct {
+	table      string
+	column     string
+	definition string
+}{
+	{"build_artifacts", "artifact_id", "TEXT"},
+	{"builds", "retried_from", "TEXT"},
+	{"builds", "priority", "INTEGER NOT NULL DEFAULT 0"},
+	{"builds", "diagnostics", "TEXT"},
+	{"builds", "layer_commits", "TEXT"},
+	{"builds", "layer_fetches", "TEXT"},
+}
+
+// postMigrations run after all columns exist (e.g. indexes on migrated columns)
+var postMigrations = []string{
+	// Rows recorded before artifact IDs existed: keep the newest row per artifact
+	// and derive its ID (see ArtifactID) so the unique index can be built
+	`DELETE FROM build_artifacts WHERE artifact_id IS NULL AND id NOT IN (
+		SELECT MAX(id) FROM build_artifacts WHERE artifact_id IS NULL GROUP BY build_id, artifact_path)`,
+	`UPDATE build_artifacts SET artifact_id = build_id || '/' || artifact_path WHERE artifact_id IS NULL`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_artifacts_artifact_id ON build_artifacts(artifact_id)`,
+}
+
 // migrate applies the schema to the database
 func (db *DB) migrate() error {
 	schema, err := schemaFS.ReadFile("schema.sql")
@@ -108,6 +171,85 @@ func (db *DB) migrate() error {
 		return fmt.Errorf("failed to execute schema: %w", err)
 	}
 
+	for _, m := range columnMigrations {
+		if err := db.ensureColumn(m.table, m.column, m.definition); err != nil {
+			return err
+		}
+	}
+
+	for _, stmt := range postMigrations {
+		if _, err := db.conn.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to apply migration %q: %w", stmt, err)
+		}
+	}
+
+	return db.normalizeCreatedAt()
+}
+
+// normalizeCreatedAt rewrites build timestamps stored before CreateBuild converted them
+// to UTC. Filters and page cursors compare created_at as text, which only orders
+// correctly when every row uses the same offset.
+func (db *DB) normalizeCreatedAt() error {
+	rows, err := db.conn.Query(`SELECT id, created_at FROM builds WHERE created_at NOT LIKE '%+00:00'`)
+	if err != nil {
+		return fmt.Errorf("failed to list build timestamps: %w", err)
+	}
+	defer rows.Close()
+
+	legacy := map[string]time.Time{}
+	for rows.Next() {
+		var id string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			return fmt.Errorf("failed to scan build timestamp: %w", err)
+		}
+		legacy[id] = createdAt
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list build timestamps: %w", err)
+	}
+	rows.Close()
+
+	for id, createdAt := range legacy {
+		if _, err := db.conn.Exec(`UPDATE builds SET created_at = ? WHERE id = ?`, createdAt.UTC(), id); err != nil {
+			return fmt.Errorf("failed to normalize timestamp of build %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ensureColumn adds a column to a table if it does not exist yet
+func (db *DB) ensureColumn(table, column, definition string) error {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return fmt.Errorf("failed to scan table info for %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	rows.Close()
+
+	if _, err := db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
 	return nil
 }
 
@@ -121,19 +263,40 @@ func (db *DB) Path() string {
 	return db.path
 }
 
-// CreateBuild inserts a new build record
+// CreateBuild inserts a new build record. created_at is stored in UTC so that
+// range filters and page cursors compare consistently. Creating a build that was
+// recorded while queued updates its record but keeps its creation time, lineage
+// and priority.
 func (db *DB) CreateBuild(build *Build) error {
 	query := `
 		INSERT INTO builds (
 			id, customer, project_name, target_image, machine, status,
 			build_dir, deploy_dir, log_file_plain, log_file_jsonl,
-			config_file, config_snapshot, user, host, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			config_file, config_snapshot, user, host, created_at, retried_from, priority
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			customer = excluded.customer,
+			project_name = excluded.project_name,
+			target_image = excluded.target_image,
+			machine = excluded.machine,
+			status = excluded.status,
+			build_dir = excluded.build_dir,
+			deploy_dir = excluded.deploy_dir,
+			log_file_plain = excluded.log_file_plain,
+			log_file_jsonl = excluded.log_file_jsonl,
+			config_file = excluded.config_file,
+			config_snapshot = excluded.config_snapshot,
+			user = excluded.user,
+			host = excluded.host
 	`
+	var retriedFrom sql.NullString
+	if build.RetriedFrom != "" {
+		retriedFrom = sql.NullString{String: build.RetriedFrom, Valid: true}
+	}
 	_, err := db.conn.Exec(query,
 		build.ID, build.Customer, build.ProjectName, build.TargetImage, build.Machine, build.Status,
 		build.BuildDir, build.DeployDir, build.LogFilePlain, build.LogFileJSONL,
-		build.ConfigFile, build.ConfigSnapshot, build.User, build.Host, build.CreatedAt,
+		build.ConfigFile, build.ConfigSnapshot, build.User, build.Host, build.CreatedAt.UTC(), retriedFrom, build.Priority,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create build: %w", err)
@@ -182,88 +345,208 @@ func (db *DB) GetBuild(buildID string) (*Build, error) {
 			build_dir, deploy_dir, log_file_plain, log_file_jsonl,
 			config_file, config_snapshot, user, host,
 			created_at, started_at, completed_at, duration_seconds,
-			deleted, deleted_at, error_message
+			deleted, deleted_at, error_message, retried_from, diagnostics, layer_commits, layer_fetches
 		FROM builds WHERE id = ?
 	`
 	build := &Build{}
-	var errorMessage sql.NullString
-	var configSnapshot sql.NullString
+	var errorMessage, configSnapshot, retriedFrom, diagnostics, layerCommits, layerFetches sql.NullString
 	err := db.conn.QueryRow(query, buildID).Scan(
 		&build.ID, &build.Customer, &build.ProjectName, &build.TargetImage, &build.Machine,
 		&build.Status, &build.ExitCode, &build.BuildDir, &build.DeployDir,
 		&build.LogFilePlain, &build.LogFileJSONL, &build.ConfigFile, &configSnapshot,
 		&build.User, &build.Host, &build.CreatedAt, &build.StartedAt, &build.CompletedAt,
-		&build.DurationSeconds, &build.Deleted, &build.DeletedAt, &errorMessage,
+		&build.DurationSeconds, &build.Deleted, &build.DeletedAt, &errorMessage, &retriedFrom, &diagnostics, &layerCommits, &layerFetches,
 	)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("build not found: %s", buildID)
+		return nil, fmt.Errorf("%w: %s", ErrBuildNotFound, buildID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get build: %w", err)
 	}
-	if errorMessage.Valid {
-		build.ErrorMessage = errorMessage.String
+	build.ErrorMessage = errorMessage.String
+	build.ConfigSnapshot = configSnapshot.String
+	build.RetriedFrom = retriedFrom.String
+	build.Diagnostics = diagnostics.String
+	build.LayerCommits = layerCommits.String
+	build.LayerFetches = layerFetches.String
+	return build, nil
+}
+
+// SetBuildDiagnostics stores the failures diagnosed in a build's output as a JSON array
+func (db *DB) SetBuildDiagnostics(buildID string, diagnostics string) error {
+	_, err := db.conn.Exec(`UPDATE builds SET diagnostics = ? WHERE id = ?`, diagnostics, buildID)
+	if err != nil {
+		return fmt.Errorf("failed to store build diagnostics: %w", err)
 	}
-	if configSnapshot.Valid {
-		build.ConfigSnapshot = configSnapshot.String
+	return nil
+}
+
+// SetLayerCommits stores the commits a build's layers resolved to, as a JSON array, together
+// with the config snapshot pinned to those commits so the build can be reproduced
+func (db *DB) SetLayerCommits(buildID string, layerCommits string, configSnapshot string) error {
+	_, err := db.conn.Exec(`UPDATE builds SET layer_commits = ?, config_snapshot = ? WHERE id = ?`, layerCommits, configSnapshot, buildID)
+	if err != nil {
+		return fmt.Errorf("failed to store layer commits: %w", err)
 	}
-	return build, nil
+	return nil
+}
+
+// SetLayerFetches stores how a build's layer repositories were fetched, as a JSON array
+func (db *DB) SetLayerFetches(buildID string, layerFetches string) error {
+	_, err := db.conn.Exec(`UPDATE builds SET layer_fetches = ? WHERE id = ?`, layerFetches, buildID)
+	if err != nil {
+		return fmt.Errorf("failed to store layer fetches: %w", err)
+	}
+	return nil
 }
 
 // ListBuilds retrieves builds with optional filters
 func (db *DB) ListBuilds(customer string, includeDeleted bool, limit int) ([]*Build, error) {
-	query := `
-		SELECT id, customer, project_name, target_image, machine, status, exit_code,
-			build_dir, deploy_dir, log_file_plain, log_file_jsonl,
-			config_file, user, host,
-			created_at, started_at, completed_at, duration_seconds,
-			deleted, deleted_at, error_message
-		FROM builds
-		WHERE 1=1
-	`
+	builds, _, err := db.QueryBuilds(BuildQuery{
+		Customer:       customer,
+		IncludeDeleted: includeDeleted,
+		Limit:          limit,
+	})
+	return builds, err
+}
+
+// BuildQuery selects builds for QueryBuilds and CountBuilds. Zero values do not filter.
+type BuildQuery struct {
+	Customer       string
+	Statuses       []BuildStatus
+	CreatedAfter   time.Time // inclusive lower bound on created_at
+	CreatedBefore  time.Time // exclusive upper bound on created_at
+	IncludeDeleted bool
+
+	After *BuildCursor // continue after this build; ignored by CountBuilds
+	Limit int          // maximum builds per page; 0 returns all
+}
+
+// BuildCursor marks the last build of a page. Builds are ordered newest first
+// by (created_at, id), so pages stay stable while new builds are added.
+type BuildCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Token encodes the cursor as an opaque page token
+func (c *BuildCursor) Token() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseBuildCursor decodes a page token created by BuildCursor.Token
+func ParseBuildCursor(token string) (*BuildCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	return &BuildCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// where renders the filter of a query as a WHERE clause with its arguments
+func (q BuildQuery) where(withCursor bool) (string, []interface{}) {
+	clause := " WHERE 1=1"
 	args := []interface{}{}
 
-	if customer != "" {
-		query += " AND customer = ?"
-		args = append(args, customer)
+	if q.Customer != "" {
+		clause += " AND customer = ?"
+		args = append(args, q.Customer)
+	}
+	if len(q.Statuses) > 0 {
+		clause += " AND status IN (?" + strings.Repeat(", ?", len(q.Statuses)-1) + ")"
+		for _, status := range q.Statuses {
+			args = append(args, status)
+		}
+	}
+	if !q.CreatedAfter.IsZero() {
+		clause += " AND created_at >= ?"
+		args = append(args, q.CreatedAfter.UTC())
+	}
+	if !q.CreatedBefore.IsZero() {
+		clause += " AND created_at < ?"
+		args = append(args, q.CreatedBefore.UTC())
 	}
-	if !includeDeleted {
-		query += " AND deleted = 0"
+	if !q.IncludeDeleted {
+		clause += " AND deleted = 0"
 	}
+	if withCursor && q.After != nil {
+		createdAt := q.After.CreatedAt.UTC()
+		clause += " AND (created_at < ? OR (created_at = ? AND id < ?))"
+		args = append(args, createdAt, createdAt, q.After.ID)
+	}
+	return clause, args
+}
 
-	query += " ORDER BY created_at DESC"
-	if limit > 0 {
+// QueryBuilds returns one page of builds matching the query, newest first.
+// The returned cursor continues with the next page; it is nil on the last page.
+func (db *DB) QueryBuilds(q BuildQuery) ([]*Build, *BuildCursor, error) {
+	where, args := q.where(true)
+	query := `
+		SELECT id, customer, project_name, target_image, machine, status, exit_code,
+			build_dir, deploy_dir, log_file_plain, log_file_jsonl,
+			config_file, user, host,
+			created_at, started_at, completed_at, duration_seconds,
+			deleted, deleted_at, error_message, retried_from
+		FROM builds` + where + " ORDER BY created_at DESC, id DESC"
+	if q.Limit > 0 {
+		// Fetch one extra row to learn whether another page follows
 		query += " LIMIT ?"
-		args = append(args, limit)
+		args = append(args, q.Limit+1)
 	}
 
 	rows, err := db.conn.Query(query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list builds: %w", err)
+		return nil, nil, fmt.Errorf("failed to list builds: %w", err)
 	}
 	defer rows.Close()
 
 	builds := []*Build{}
 	for rows.Next() {
 		build := &Build{}
-		var errorMessage sql.NullString
+		var errorMessage, retriedFrom sql.NullString
 		err := rows.Scan(
 			&build.ID, &build.Customer, &build.ProjectName, &build.TargetImage, &build.Machine,
 			&build.Status, &build.ExitCode, &build.BuildDir, &build.DeployDir,
 			&build.LogFilePlain, &build.LogFileJSONL, &build.ConfigFile,
 			&build.User, &build.Host, &build.CreatedAt, &build.StartedAt, &build.CompletedAt,
-			&build.DurationSeconds, &build.Deleted, &build.DeletedAt, &errorMessage,
+			&build.DurationSeconds, &build.Deleted, &build.DeletedAt, &errorMessage, &retriedFrom,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan build: %w", err)
-		}
-		if errorMessage.Valid {
-			build.ErrorMessage = errorMessage.String
+			return nil, nil, fmt.Errorf("failed to scan build: %w", err)
 		}
+		build.ErrorMessage = errorMessage.String
+		build.RetriedFrom = retriedFrom.String
 		builds = append(builds, build)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to list builds: %w", err)
+	}
 
-	return builds, nil
+	if q.Limit > 0 && len(builds) > q.Limit {
+		builds = builds[:q.Limit]
+		last := builds[len(builds)-1]
+		return builds, &BuildCursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+	}
+	return builds, nil, nil
+}
+
+// CountBuilds returns how many builds match the query across all pages
+func (db *DB) CountBuilds(q BuildQuery) (int, error) {
+	where, args := q.where(false)
+	var count int
+	if err := db.conn.QueryRow("SELECT COUNT(*) FROM builds"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count builds: %w", err)
+	}
+	return count, nil
 }
 
 // SoftDeleteBuild marks a build as deleted
@@ -286,12 +569,15 @@ func (db *DB) HardDeleteBuild(buildID string) error {
 	return nil
 }
 
-// ListStaleBuilds retrieves builds that were running when daemon stopped
+// ListStaleBuilds retrieves builds that were queued or running when the daemon
+// stopped, oldest first, with what is needed to queue them again
 func (db *DB) ListStaleBuilds() ([]*Build, error) {
 	query := `
 		SELECT id, customer, project_name, target_image, machine, status,
-			build_dir, deploy_dir, created_at, started_at
+			build_dir, deploy_dir, config_file, config_snapshot, created_at, started_at,
+			retried_from, priority
 		FROM stale_builds
+		ORDER BY created_at, id
 	`
 	rows, err := db.conn.Query(query)
 	if err != nil {
@@ -302,43 +588,89 @@ func (db *DB) ListStaleBuilds() ([]*Build, error) {
 	builds := []*Build{}
 	for rows.Next() {
 		build := &Build{}
+		var configFile, configSnapshot, retriedFrom sql.NullString
 		err := rows.Scan(
 			&build.ID, &build.Customer, &build.ProjectName, &build.TargetImage, &build.Machine,
-			&build.Status, &build.BuildDir, &build.DeployDir, &build.CreatedAt, &build.StartedAt,
+			&build.Status, &build.BuildDir, &build.DeployDir, &configFile, &configSnapshot,
+			&build.CreatedAt, &build.StartedAt, &retriedFrom, &build.Priority,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stale build: %w", err)
 		}
+		build.ConfigFile = configFile.String
+		build.ConfigSnapshot = configSnapshot.String
+		build.RetriedFrom = retriedFrom.String
 		builds = append(builds, build)
 	}
 
 	return builds, nil
 }
 
-// AddArtifact records a build artifact
+// ArtifactID returns the stable public identifier for an artifact of a build.
+// Format: "<build-id>/<path relative to deploy dir>" using forward slashes.
+func ArtifactID(buildID, artifactPath string) string {
+	return buildID + "/" + filepath.ToSlash(artifactPath)
+}
+
+// ParseArtifactID splits an artifact identifier into build ID and deploy-relative path
+func ParseArtifactID(id string) (string, string, error) {
+	buildID, artifactPath, ok := strings.Cut(id, "/")
+	if !ok || buildID == "" || artifactPath == "" {
+		return "", "", fmt.Errorf("invalid artifact id %q (expected <build-id>/<path>)", id)
+	}
+	return buildID, filepath.FromSlash(artifactPath), nil
+}
+
+// AddArtifact records a build artifact. Recording the same artifact twice updates the existing row.
 func (db *DB) AddArtifact(artifact *BuildArtifact) error {
+	if artifact.ArtifactID == "" {
+		artifact.ArtifactID = ArtifactID(artifact.BuildID, artifact.ArtifactPath)
+	}
 	query := `
-		INSERT INTO build_artifacts (build_id, artifact_path, artifact_type, size_bytes, checksum, created_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO build_artifacts (artifact_id, build_id, artifact_path, artifact_type, size_bytes, checksum, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(artifact_id) DO UPDATE SET
+			artifact_type = excluded.artifact_type,
+			size_bytes = excluded.size_bytes,
+			checksum = excluded.checksum,
+			created_at = excluded.created_at
 	`
-	result, err := db.conn.Exec(query,
-		artifact.BuildID, artifact.ArtifactPath, artifact.ArtifactType,
+	_, err := db.conn.Exec(query,
+		artifact.ArtifactID, artifact.BuildID, artifact.ArtifactPath, artifact.ArtifactType,
 		artifact.SizeBytes, artifact.Checksum, artifact.CreatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to add artifact: %w", err)
 	}
-	id, _ := result.LastInsertId()
-	artifact.ID = id
+	// LastInsertId is not reliable for upserts, so read the row ID back
+	if err := db.conn.QueryRow(`SELECT id FROM build_artifacts WHERE artifact_id = ?`, artifact.ArtifactID).Scan(&artifact.ID); err != nil {
+		return fmt.Errorf("failed to read artifact id: %w", err)
+	}
 	return nil
 }
 
+// GetArtifact retrieves a single artifact by its stable ID
+func (db *DB) GetArtifact(artifactID string) (*BuildArtifact, error) {
+	query := `
+		SELECT id, artifact_id, build_id, artifact_path, artifact_type, size_bytes, checksum, created_at
+		FROM build_artifacts WHERE artifact_id = ?
+	`
+	artifact, err := scanArtifact(db.conn.QueryRow(query, artifactID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("artifact not found: %s", artifactID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact: %w", err)
+	}
+	return artifact, nil
+}
+
 // ListArtifacts retrieves all artifacts for a build
 func (db *DB) ListArtifacts(buildID string) ([]*BuildArtifact, error) {
 	query := `
-		SELECT id, build_id, artifact_path, artifact_type, size_bytes, checksum, created_at
+		SELECT id, artifact_id, build_id, artifact_path, artifact_type, size_bytes, checksum, created_at
 		FROM build_artifacts WHERE build_id = ?
-		ORDER BY created_at DESC
+		ORDER BY artifact_path
 	`
 	rows, err := db.conn.Query(query, buildID)
 	if err != nil {
@@ -348,11 +680,7 @@ func (db *DB) ListArtifacts(buildID string) ([]*BuildArtifact, error) {
 
 	artifacts := []*BuildArtifact{}
 	for rows.Next() {
-		artifact := &BuildArtifact{}
-		err := rows.Scan(
-			&artifact.ID, &artifact.BuildID, &artifact.ArtifactPath, &artifact.ArtifactType,
-			&artifact.SizeBytes, &artifact.Checksum, &artifact.CreatedAt,
-		)
+		artifact, err := scanArtifact(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan artifact: %w", err)
 		}
@@ -361,3 +689,149 @@ func (db *DB) ListArtifacts(buildID string) ([]*BuildArtifact, error) {
 
 	return artifacts, nil
 }
+
+// DeleteArtifact removes a single artifact record
+func (db *DB) DeleteArtifact(artifactID string) error {
+	_, err := db.conn.Exec(`DELETE FROM build_artifacts WHERE artifact_id = ?`, artifactID)
+	if err != nil {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+	return nil
+}
+
+// DeleteBuildArtifacts removes all artifact records of a build
+func (db *DB) DeleteBuildArtifacts(buildID string) error {
+	_, err := db.conn.Exec(`DELETE FROM build_artifacts WHERE build_id = ?`, buildID)
+	if err != nil {
+		return fmt.Errorf("failed to delete artifacts: %w", err)
+	}
+	return nil
+}
+
+// AddTaskLog records a collected task log. Recording the same task of a build twice
+// replaces the earlier log.
+func (db *DB) AddTaskLog(log *TaskLog) error {
+	query := `
+		INSERT INTO build_task_logs (build_id, recipe, task, log_path, size_bytes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(build_id, recipe, task) DO UPDATE SET
+			log_path = excluded.log_path,
+			size_bytes = excluded.size_bytes,
+			created_at = excluded.created_at
+	`
+	if _, err := db.conn.Exec(query, log.BuildID, log.Recipe, log.Task, log.LogPath, log.SizeBytes, log.CreatedAt); err != nil {
+		return fmt.Errorf("failed to add task log: %w", err)
+	}
+	return nil
+}
+
+// ListTaskLogs returns the task logs collected for a build, in the order they were recorded
+func (db *DB) ListTaskLogs(buildID string) ([]*TaskLog, error) {
+	query := `
+		SELECT id, build_id, recipe, task, log_path, COALESCE(size_bytes, 0), created_at
+		FROM build_task_logs WHERE build_id = ? ORDER BY id
+	`
+	rows, err := db.conn.Query(query, buildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task logs: %w", err)
+	}
+	defer rows.Close()
+
+	logs := []*TaskLog{}
+	for rows.Next() {
+		log := &TaskLog{}
+		if err := rows.Scan(&log.ID, &log.BuildID, &log.Recipe, &log.Task, &log.LogPath, &log.SizeBytes, &log.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task log: %w", err)
+		}
+		logs = append(logs, log)
+	}
+	return logs, rows.Err()
+}
+
+// AddRecoveryEvent records a recovery step of a build
+func (db *DB) AddRecoveryEvent(event *RecoveryEvent) error {
+	query := `
+		INSERT INTO build_recovery_events (build_id, strategy, recipe, attempt, succeeded, detail, started_at, duration_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	if _, err := db.conn.Exec(query, event.BuildID, event.Strategy, event.Recipe, event.Attempt, event.Succeeded, event.Detail,
+		event.StartedAt, event.Duration.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to add recovery event: %w", err)
+	}
+	return nil
+}
+
+// ListRecoveryEvents returns the recovery steps of a build in the order they were taken
+func (db *DB) ListRecoveryEvents(buildID string) ([]*RecoveryEvent, error) {
+	query := `
+		SELECT id, build_id, strategy, COALESCE(recipe, ''), attempt, succeeded, COALESCE(detail, ''), started_at, COALESCE(duration_ms, 0)
+		FROM build_recovery_events WHERE build_id = ? ORDER BY id
+	`
+	rows, err := db.conn.Query(query, buildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recovery events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*RecoveryEvent{}
+	for rows.Next() {
+		event := &RecoveryEvent{}
+		var durationMs int64
+		if err := rows.Scan(&event.ID, &event.BuildID, &event.Strategy, &event.Recipe, &event.Attempt, &event.Succeeded,
+			&event.Detail, &event.StartedAt, &durationMs); err != nil {
+			return nil, fmt.Errorf("failed to scan recovery event: %w", err)
+		}
+		event.Duration = time.Duration(durationMs) * time.Millisecond
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// ArtifactStats returns the number and total size of the recorded artifacts of a build
+func (db *DB) ArtifactStats(buildID string) (int, int64, error) {
+	var count int
+	var totalBytes int64
+	query := `SELECT COUNT(*), COALESCE(SUM(size_bytes), 0) FROM build_artifacts WHERE build_id = ?`
+	if err := db.conn.QueryRow(query, buildID).Scan(&count, &totalBytes); err != nil {
+		return 0, 0, fmt.Errorf("failed to get artifact stats: %w", err)
+	}
+	return count, totalBytes, nil
+}
+
+// BuildDirInUse reports whether any build record still references a build directory,
+// e.g. because several builds share a configured directories.build
+func (db *DB) BuildDirInUse(dir string) (bool, error) {
+	var count int
+	if err := db.conn.QueryRow(`SELECT COUNT(*) FROM builds WHERE build_dir = ?`, dir).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check build directory usage: %w", err)
+	}
+	return count > 0, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanArtifact reads a build_artifacts row, tolerating NULLs in columns added by migrations
+func scanArtifact(row rowScanner) (*BuildArtifact, error) {
+	artifact := &BuildArtifact{}
+	var artifactID, artifactType, checksum sql.NullString
+	var sizeBytes sql.NullInt64
+	err := row.Scan(
+		&artifact.ID, &artifactID, &artifact.BuildID, &artifact.ArtifactPath, &artifactType,
+		&sizeBytes, &checksum, &artifact.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	artifact.ArtifactID = artifactID.String
+	if artifact.ArtifactID == "" {
+		// Rows recorded before stable IDs existed get their ID derived on read
+		artifact.ArtifactID = ArtifactID(artifact.BuildID, artifact.ArtifactPath)
+	}
+	artifact.ArtifactType = artifactType.String
+	artifact.SizeBytes = sizeBytes.Int64
+	artifact.Checksum = checksum.String
+	return artifact, nil
+}
@@ -1,6 +1,9 @@
 package db
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -75,6 +78,10 @@ func TestCreateAndGetBuild(t *testing.T) {
 	if retrieved.Status != StatusQueued {
 		t.Errorf("expected status %s, got %s", StatusQueued, retrieved.Status)
 	}
+
+	if _, err := db.GetBuild("missing-build"); !errors.Is(err, ErrBuildNotFound) {
+		t.Errorf("expected ErrBuildNotFound for a missing build, got %v", err)
+	}
 }
 
 func TestUpdateBuildStatus(t *testing.T) {
@@ -239,6 +246,182 @@ func TestListBuilds(t *testing.T) {
 	}
 }
 
+func TestQueryBuildsPagination(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	// Two builds share a timestamp so the id tiebreaker is exercised
+	base := time.Now().Add(-time.Hour)
+	for i, created := range []time.Time{base, base, base.Add(time.Minute), base.Add(2 * time.Minute), base.Add(3 * time.Minute)} {
+		b := &Build{
+			ID: fmt.Sprintf("build-%d", i), Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m",
+			Status: StatusCompleted, BuildDir: "/tmp", DeployDir: "/tmp/d", CreatedAt: created,
+		}
+		if err := db.CreateBuild(b); err != nil {
+			t.Fatalf("failed to create build: %v", err)
+		}
+	}
+
+	var seen []string
+	var cursor *BuildCursor
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		q := BuildQuery{Limit: 2}
+		if cursor != nil {
+			// Round-trip through the page token like the daemon does
+			parsed, err := ParseBuildCursor(cursor.Token())
+			if err != nil {
+				t.Fatalf("failed to parse page token: %v", err)
+			}
+			q.After = parsed
+		}
+		page, next, err := db.QueryBuilds(q)
+		if err != nil {
+			t.Fatalf("failed to query builds: %v", err)
+		}
+		for _, b := range page {
+			seen = append(seen, b.ID)
+		}
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+
+	want := []string{"build-4", "build-3", "build-2", "build-1", "build-0"}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Errorf("expected pages %v, got %v", want, seen)
+	}
+}
+
+func TestQueryBuildsFilters(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	builds := []*Build{
+		{ID: "old", Customer: "acme", Status: StatusCompleted, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "failed", Customer: "acme", Status: StatusFailed, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "recent", Customer: "acme", Status: StatusCompleted, CreatedAt: now.Add(-time.Hour)},
+		{ID: "other", Customer: "globex", Status: StatusCompleted, CreatedAt: now.Add(-time.Hour)},
+		{ID: "deleted", Customer: "acme", Status: StatusCompleted, CreatedAt: now.Add(-time.Hour)},
+	}
+	for _, b := range builds {
+		b.ProjectName, b.TargetImage, b.Machine, b.BuildDir, b.DeployDir = "p", "img", "m", "/tmp", "/tmp/d"
+		if err := db.CreateBuild(b); err != nil {
+			t.Fatalf("failed to create build: %v", err)
+		}
+	}
+	if err := db.SoftDeleteBuild("deleted"); err != nil {
+		t.Fatalf("failed to delete build: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query BuildQuery
+		want  []string
+	}{
+		{"customer", BuildQuery{Customer: "acme"}, []string{"recent", "failed", "old"}},
+		{"status", BuildQuery{Customer: "acme", Statuses: []BuildStatus{StatusFailed}}, []string{"failed"}},
+		{"since", BuildQuery{Customer: "acme", CreatedAfter: now.Add(-24 * time.Hour)}, []string{"recent", "failed"}},
+		{"before", BuildQuery{CreatedBefore: now.Add(-24 * time.Hour)}, []string{"old"}},
+		{"include deleted", BuildQuery{Customer: "acme", IncludeDeleted: true, CreatedAfter: now.Add(-90 * time.Minute)}, []string{"recent", "deleted"}},
+	}
+	for _, tt := range tests {
+		got, _, err := db.QueryBuilds(tt.query)
+		if err != nil {
+			t.Fatalf("%s: failed to query builds: %v", tt.name, err)
+		}
+		var ids []string
+		for _, b := range got {
+			ids = append(ids, b.ID)
+		}
+		// Builds created at the same instant are ordered by id, descending
+		if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, ids)
+		}
+
+		count, err := db.CountBuilds(tt.query)
+		if err != nil {
+			t.Fatalf("%s: failed to count builds: %v", tt.name, err)
+		}
+		if count != len(tt.want) {
+			t.Errorf("%s: expected count %d, got %d", tt.name, len(tt.want), count)
+		}
+	}
+}
+
+func TestMigrateNormalizesLegacyCreatedAt(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, id := range []string{"legacy", "new"} {
+		b := &Build{
+			ID: id, Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m",
+			Status: StatusCompleted, BuildDir: "/tmp", DeployDir: "/tmp/d", CreatedAt: base,
+		}
+		if err := db.CreateBuild(b); err != nil {
+			t.Fatalf("failed to create build: %v", err)
+		}
+	}
+	// Older releases stored the daemon's local offset: 08:30 UTC sorts after 09:00 UTC as text
+	if _, err := db.conn.Exec(`UPDATE builds SET created_at = '2025-03-01 10:30:00+02:00' WHERE id = 'legacy'`); err != nil {
+		t.Fatalf("failed to store legacy timestamp: %v", err)
+	}
+	db.Close()
+
+	db, err = Open(dbPath)
+	if err != nil {
+		t.Fatalf("failed to reopen database: %v", err)
+	}
+	defer db.Close()
+
+	legacy, err := db.GetBuild("legacy")
+	if err != nil {
+		t.Fatalf("failed to get legacy build: %v", err)
+	}
+	if !legacy.CreatedAt.Equal(base.Add(-30 * time.Minute)) {
+		t.Errorf("expected the legacy creation time to be kept, got %v", legacy.CreatedAt)
+	}
+
+	page, next, err := db.QueryBuilds(BuildQuery{Limit: 1})
+	if err != nil {
+		t.Fatalf("failed to query builds: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != "new" {
+		t.Fatalf("expected the newer build first, got %v", page)
+	}
+	page, _, err = db.QueryBuilds(BuildQuery{Limit: 1, After: next})
+	if err != nil {
+		t.Fatalf("failed to query builds: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != "legacy" {
+		t.Errorf("expected the legacy build on the second page, got %v", page)
+	}
+
+	recent, _, err := db.QueryBuilds(BuildQuery{CreatedAfter: base.Add(-15 * time.Minute)})
+	if err != nil {
+		t.Fatalf("failed to query builds: %v", err)
+	}
+	if len(recent) != 1 || recent[0].ID != "new" {
+		t.Errorf("expected only the newer build after 08:45 UTC, got %v", recent)
+	}
+}
+
+func TestParseBuildCursorInvalid(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm8tc2VwYXJhdG9y", "eHwx"} {
+		if _, err := ParseBuildCursor(token); err == nil {
+			t.Errorf("expected error for token %q", token)
+		}
+	}
+}
+
 func TestSoftDelete(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -351,6 +534,349 @@ func TestArtifacts(t *testing.T) {
 	if len(retrieved) != 2 {
 		t.Errorf("expected 2 artifacts, got %d", len(retrieved))
 	}
+
+	// Artifacts get stable IDs derived from build ID and path
+	wantID := "build-with-artifacts/images/core-image-minimal.wic"
+	if artifacts[0].ArtifactID != wantID {
+		t.Errorf("expected artifact ID %s, got %s", wantID, artifacts[0].ArtifactID)
+	}
+
+	got, err := db.GetArtifact(wantID)
+	if err != nil {
+		t.Fatalf("failed to get artifact: %v", err)
+	}
+	if got.Checksum != "abc123" || got.SizeBytes != 1024000 {
+		t.Errorf("unexpected artifact: %+v", got)
+	}
+
+	// Recording the same artifact again updates it instead of duplicating it
+	err = db.AddArtifact(&BuildArtifact{
+		BuildID:      "build-with-artifacts",
+		ArtifactPath: "images/core-image-minimal.wic",
+		ArtifactType: "image",
+		SizeBytes:    2048000,
+		Checksum:     "fff999",
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("failed to re-add artifact: %v", err)
+	}
+
+	retrieved, _ = db.ListArtifacts("build-with-artifacts")
+	if len(retrieved) != 2 {
+		t.Errorf("expected 2 artifacts after upsert, got %d", len(retrieved))
+	}
+
+	got, _ = db.GetArtifact(wantID)
+	if got == nil || got.Checksum != "fff999" {
+		t.Errorf("expected updated checksum fff999, got %+v", got)
+	}
+
+	if err := db.DeleteArtifact(wantID); err != nil {
+		t.Fatalf("failed to delete artifact: %v", err)
+	}
+	if _, err := db.GetArtifact(wantID); err == nil {
+		t.Error("expected error getting deleted artifact")
+	}
+}
+
+func TestArtifactIDRoundTrip(t *testing.T) {
+	id := ArtifactID("build-1", filepath.Join("images", "qemux86-64", "core.wic"))
+	if id != "build-1/images/qemux86-64/core.wic" {
+		t.Errorf("unexpected artifact ID %s", id)
+	}
+
+	buildID, path, err := ParseArtifactID(id)
+	if err != nil {
+		t.Fatalf("failed to parse artifact ID: %v", err)
+	}
+	if buildID != "build-1" || path != filepath.Join("images", "qemux86-64", "core.wic") {
+		t.Errorf("unexpected parse result %s %s", buildID, path)
+	}
+
+	for _, bad := range []string{"", "build-1", "/images/core.wic", "build-1/"} {
+		if _, _, err := ParseArtifactID(bad); err == nil {
+			t.Errorf("expected error parsing %q", bad)
+		}
+	}
+}
+
+func TestMigrateAddsArtifactID(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "old.db")
+
+	// Simulate a database created before artifact IDs existed
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open raw database: %v", err)
+	}
+	_, err = conn.Exec(`
+		CREATE TABLE build_artifacts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			build_id TEXT NOT NULL,
+			artifact_path TEXT NOT NULL,
+			artifact_type TEXT,
+			size_bytes INTEGER,
+			checksum TEXT,
+			created_at DATETIME NOT NULL
+		);
+		INSERT INTO build_artifacts (build_id, artifact_path, created_at) VALUES ('old-build', 'images/old.wic', CURRENT_TIMESTAMP);
+	`)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("failed to create old schema: %v", err)
+	}
+
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open and migrate database: %v", err)
+	}
+	defer db.Close()
+
+	retrieved, err := db.ListArtifacts("old-build")
+	if err != nil {
+		t.Fatalf("failed to list artifacts: %v", err)
+	}
+	if len(retrieved) != 1 || retrieved[0].ArtifactID != "old-build/images/old.wic" {
+		t.Errorf("expected derived artifact ID for legacy row, got %+v", retrieved)
+	}
+}
+
+func TestMigrateBaselineSchemaBackfillsArtifactID(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "baseline.db")
+
+	// Create a database with the schema the first release shipped, including an
+	// artifact that was recorded twice
+	schema, err := os.ReadFile(filepath.Join("testdata", "schema_baseline.sql"))
+	if err != nil {
+		t.Fatalf("failed to read baseline schema: %v", err)
+	}
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open raw database: %v", err)
+	}
+	_, err = conn.Exec(string(schema) + `
+		INSERT INTO builds (id, customer, project_name, target_image, machine, status, build_dir, deploy_dir)
+		VALUES ('old-build', 'acme', 'proj', 'core-image-minimal', 'qemux86-64', 'completed', '/b', '/d');
+		INSERT INTO build_artifacts (build_id, artifact_path, checksum) VALUES ('old-build', 'images/old.wic', 'stale');
+		INSERT INTO build_artifacts (build_id, artifact_path, checksum) VALUES ('old-build', 'images/old.wic', 'fresh');
+		INSERT INTO build_artifacts (build_id, artifact_path, checksum) VALUES ('old-build', 'images/old.manifest', 'm');
+	`)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("failed to create baseline database: %v", err)
+	}
+
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open and migrate database: %v", err)
+	}
+	defer db.Close()
+
+	// Lookups by ID go through the artifact_id column, so they only work once it is backfilled
+	artifact, err := db.GetArtifact("old-build/images/old.wic")
+	if err != nil {
+		t.Fatalf("failed to get backfilled artifact: %v", err)
+	}
+	if artifact.Checksum != "fresh" {
+		t.Errorf("expected newest duplicate to be kept, got checksum %q", artifact.Checksum)
+	}
+	if _, err := db.GetArtifact("old-build/images/old.manifest"); err != nil {
+		t.Errorf("failed to get backfilled artifact: %v", err)
+	}
+
+	// Recording the artifact again updates the legacy row instead of adding one
+	if err := db.AddArtifact(&BuildArtifact{BuildID: "old-build", ArtifactPath: "images/old.wic", Checksum: "new"}); err != nil {
+		t.Fatalf("failed to re-record artifact: %v", err)
+	}
+	artifacts, err := db.ListArtifacts("old-build")
+	if err != nil {
+		t.Fatalf("failed to list artifacts: %v", err)
+	}
+	if len(artifacts) != 2 {
+		t.Errorf("expected 2 artifacts after migration, got %d", len(artifacts))
+	}
+
+	// Reopening an already migrated database is a no-op
+	reopened, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("failed to reopen migrated database: %v", err)
+	}
+	reopened.Close()
+}
+
+func TestRetriedFrom(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	for _, build := range []*Build{
+		{ID: "original", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: StatusFailed, BuildDir: "/b/original", DeployDir: "/d", CreatedAt: now.Add(-time.Minute)},
+		{ID: "retry", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: StatusQueued, BuildDir: "/b/retry", DeployDir: "/d", CreatedAt: now, RetriedFrom: "original"},
+	} {
+		if err := db.CreateBuild(build); err != nil {
+			t.Fatalf("failed to create build: %v", err)
+		}
+	}
+
+	retry, err := db.GetBuild("retry")
+	if err != nil {
+		t.Fatalf("failed to get build: %v", err)
+	}
+	if retry.RetriedFrom != "original" {
+		t.Errorf("expected retry to link to original, got %q", retry.RetriedFrom)
+	}
+
+	builds, _, err := db.QueryBuilds(BuildQuery{Customer: "acme"})
+	if err != nil {
+		t.Fatalf("failed to query builds: %v", err)
+	}
+	if len(builds) != 2 || builds[0].RetriedFrom != "original" || builds[1].RetriedFrom != "" {
+		t.Errorf("unexpected lineage in listing: %+v", builds)
+	}
+}
+
+func TestSetBuildDiagnostics(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	build := &Build{ID: "diag", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: StatusRunning, BuildDir: "/b/diag", DeployDir: "/d", CreatedAt: time.Now()}
+	if err := db.CreateBuild(build); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+	if retrieved, _ := db.GetBuild("diag"); retrieved.Diagnostics != "" {
+		t.Errorf("expected no diagnostics on a new build, got %q", retrieved.Diagnostics)
+	}
+
+	diagnostics := `[{"kind":"disk_full","message":"No space left on device","hint":"free space"}]`
+	if err := db.SetBuildDiagnostics("diag", diagnostics); err != nil {
+		t.Fatalf("failed to set diagnostics: %v", err)
+	}
+	retrieved, err := db.GetBuild("diag")
+	if err != nil {
+		t.Fatalf("failed to get build: %v", err)
+	}
+	if retrieved.Diagnostics != diagnostics {
+		t.Errorf("expected diagnostics %q, got %q", diagnostics, retrieved.Diagnostics)
+	}
+}
+
+func TestSetLayerCommits(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	build := &Build{ID: "pinned", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: StatusRunning, BuildDir: "/b/pinned", DeployDir: "/d", ConfigSnapshot: `{"layers":[{"name":"poky"}]}`, CreatedAt: time.Now()}
+	if err := db.CreateBuild(build); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+
+	commits := `[{"name":"poky","git":"https://git.yoctoproject.org/poky","commit":"0123456789abcdef0123456789abcdef01234567"}]`
+	snapshot := `{"layers":[{"name":"poky","commit":"0123456789abcdef0123456789abcdef01234567"}]}`
+	if err := db.SetLayerCommits("pinned", commits, snapshot); err != nil {
+		t.Fatalf("failed to set layer commits: %v", err)
+	}
+	retrieved, err := db.GetBuild("pinned")
+	if err != nil {
+		t.Fatalf("failed to get build: %v", err)
+	}
+	if retrieved.LayerCommits != commits || retrieved.ConfigSnapshot != snapshot {
+		t.Errorf("expected pinned commits and snapshot, got %q and %q", retrieved.LayerCommits, retrieved.ConfigSnapshot)
+	}
+}
+
+func TestSetLayerFetches(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	build := &Build{ID: "fetched", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: StatusRunning, BuildDir: "/b/fetched", DeployDir: "/d", CreatedAt: time.Now()}
+	if err := db.CreateBuild(build); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+
+	fetches := `[{"name":"poky","git":"https://git.yoctoproject.org/poky","status":"failed","attempts":3,"duration":1500000000,"error":"git clone failed"}]`
+	if err := db.SetLayerFetches("fetched", fetches); err != nil {
+		t.Fatalf("failed to set layer fetches: %v", err)
+	}
+	retrieved, err := db.GetBuild("fetched")
+	if err != nil {
+		t.Fatalf("failed to get build: %v", err)
+	}
+	if retrieved.LayerFetches != fetches {
+		t.Errorf("expected layer fetches %q, got %q", fetches, retrieved.LayerFetches)
+	}
+}
+
+func TestTaskLogs(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	build := &Build{ID: "failed", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: StatusFailed, BuildDir: "/b/failed", DeployDir: "/d", CreatedAt: time.Now()}
+	if err := db.CreateBuild(build); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+	for _, log := range []*TaskLog{
+		{BuildID: "failed", Recipe: "busybox", Task: "do_compile", LogPath: "/b/failed/task-logs/busybox/do_compile.log", SizeBytes: 10, CreatedAt: time.Now()},
+		{BuildID: "failed", Recipe: "zlib", Task: "do_fetch", LogPath: "/b/failed/task-logs/zlib/do_fetch.log", SizeBytes: 20, CreatedAt: time.Now()},
+		// Collected again: replaces the first log
+		{BuildID: "failed", Recipe: "busybox", Task: "do_compile", LogPath: "/b/failed/task-logs/busybox/do_compile.log", SizeBytes: 30, CreatedAt: time.Now()},
+	} {
+		if err := db.AddTaskLog(log); err != nil {
+			t.Fatalf("failed to add task log: %v", err)
+		}
+	}
+
+	logs, err := db.ListTaskLogs("failed")
+	if err != nil {
+		t.Fatalf("failed to list task logs: %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 task logs, got %d", len(logs))
+	}
+	if logs[0].Recipe != "busybox" || logs[0].Task != "do_compile" || logs[0].SizeBytes != 30 {
+		t.Errorf("expected the busybox log to be updated, got %+v", logs[0])
+	}
+
+	if err := db.HardDeleteBuild("failed"); err != nil {
+		t.Fatalf("failed to delete build: %v", err)
+	}
+	if logs, _ := db.ListTaskLogs("failed"); len(logs) != 0 {
+		t.Errorf("expected task logs to be deleted with their build, got %d", len(logs))
+	}
+}
+
+func TestRecoveryEvents(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	build := &Build{ID: "healed", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m", Status: StatusCompleted, BuildDir: "/b/healed", DeployDir: "/d", CreatedAt: time.Now()}
+	if err := db.CreateBuild(build); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+	for _, event := range []*RecoveryEvent{
+		{BuildID: "healed", Strategy: "cleansstate", Recipe: "zlib", Attempt: 1, Succeeded: true, StartedAt: time.Now(), Duration: 90 * time.Second},
+		{BuildID: "healed", Strategy: "retry", Recipe: "zlib", Attempt: 1, Succeeded: true, StartedAt: time.Now(), Duration: time.Hour},
+	} {
+		if err := db.AddRecoveryEvent(event); err != nil {
+			t.Fatalf("failed to add recovery event: %v", err)
+		}
+	}
+
+	events, err := db.ListRecoveryEvents("healed")
+	if err != nil {
+		t.Fatalf("failed to list recovery events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 recovery events, got %d", len(events))
+	}
+	if events[0].Strategy != "cleansstate" || events[0].Recipe != "zlib" || !events[0].Succeeded || events[0].Duration != 90*time.Second {
+		t.Errorf("unexpected first event %+v", events[0])
+	}
+
+	if err := db.HardDeleteBuild("healed"); err != nil {
+		t.Fatalf("failed to delete build: %v", err)
+	}
+	if events, _ := db.ListRecoveryEvents("healed"); len(events) != 0 {
+		t.Errorf("expected recovery events to be deleted with their build, got %d", len(events))
+	}
 }
 
 func TestListStaleBuilds(t *testing.T) {
@@ -388,3 +914,118 @@ func TestListStaleBuilds(t *testing.T) {
 		t.Errorf("expected stale-build, got %s", staleBuilds[0].ID)
 	}
 }
+
+func TestListStaleBuildsOrderAndSnapshot(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	for i, id := range []string{"second", "first", "third"} {
+		offset := map[string]time.Duration{"first": -3 * time.Minute, "second": -2 * time.Minute, "third": -time.Minute}[id]
+		build := &Build{
+			ID: id, Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m",
+			Status: StatusQueued, BuildDir: "", DeployDir: "", ConfigFile: "smidr.yaml",
+			ConfigSnapshot: fmt.Sprintf(`{"Name":"build-%d"}`, i), CreatedAt: now.Add(offset), Priority: i,
+		}
+		if err := db.CreateBuild(build); err != nil {
+			t.Fatalf("failed to create build: %v", err)
+		}
+	}
+
+	staleBuilds, err := db.ListStaleBuilds()
+	if err != nil {
+		t.Fatalf("failed to list stale builds: %v", err)
+	}
+	var ids []string
+	for _, b := range staleBuilds {
+		ids = append(ids, b.ID)
+	}
+	if fmt.Sprint(ids) != "[first second third]" {
+		t.Errorf("expected stale builds oldest first, got %v", ids)
+	}
+	if first := staleBuilds[0]; first.ConfigSnapshot != `{"Name":"build-1"}` || first.ConfigFile != "smidr.yaml" || first.Priority != 1 {
+		t.Errorf("expected snapshot, config file and priority, got %+v", first)
+	}
+}
+
+func TestCreateBuildUpdatesQueuedRecord(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	queuedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	queued := &Build{
+		ID: "build-1", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m",
+		Status: StatusQueued, CreatedAt: queuedAt, RetriedFrom: "build-0", Priority: 5,
+	}
+	if err := db.CreateBuild(queued); err != nil {
+		t.Fatalf("failed to record queued build: %v", err)
+	}
+
+	// The runner records the build again once it starts
+	started := &Build{
+		ID: "build-1", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m",
+		Status: StatusQueued, BuildDir: "/tmp/builds/build-1", DeployDir: "/tmp/builds/build-1/deploy",
+		ConfigSnapshot: `{"Name":"p"}`, CreatedAt: time.Now(),
+	}
+	if err := db.CreateBuild(started); err != nil {
+		t.Fatalf("failed to update queued build: %v", err)
+	}
+
+	got, err := db.GetBuild("build-1")
+	if err != nil {
+		t.Fatalf("failed to get build: %v", err)
+	}
+	if got.BuildDir != started.BuildDir || got.ConfigSnapshot != started.ConfigSnapshot {
+		t.Errorf("expected runner fields to be updated, got %+v", got)
+	}
+	if !got.CreatedAt.Equal(queuedAt) || got.RetriedFrom != "build-0" {
+		t.Errorf("expected creation time and lineage to be kept, got created_at=%v retried_from=%q", got.CreatedAt, got.RetriedFrom)
+	}
+}
+
+func TestArtifactStatsAndDelete(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	b := &Build{
+		ID: "build-1", Customer: "acme", ProjectName: "p", TargetImage: "img", Machine: "m",
+		Status: StatusCompleted, BuildDir: "/tmp/shared", DeployDir: "/tmp/shared/deploy", CreatedAt: time.Now(),
+	}
+	if err := db.CreateBuild(b); err != nil {
+		t.Fatalf("failed to create build: %v", err)
+	}
+	for _, a := range []*BuildArtifact{
+		{BuildID: "build-1", ArtifactPath: "a.wic", SizeBytes: 100, CreatedAt: time.Now()},
+		{BuildID: "build-1", ArtifactPath: "b.ext4", SizeBytes: 50, CreatedAt: time.Now()},
+	} {
+		if err := db.AddArtifact(a); err != nil {
+			t.Fatalf("failed to add artifact: %v", err)
+		}
+	}
+
+	count, size, err := db.ArtifactStats("build-1")
+	if err != nil {
+		t.Fatalf("failed to get artifact stats: %v", err)
+	}
+	if count != 2 || size != 150 {
+		t.Errorf("expected 2 artifacts of 150 bytes, got %d of %d", count, size)
+	}
+
+	if err := db.DeleteBuildArtifacts("build-1"); err != nil {
+		t.Fatalf("failed to delete artifacts: %v", err)
+	}
+	if count, size, _ := db.ArtifactStats("build-1"); count != 0 || size != 0 {
+		t.Errorf("expected no artifacts after delete, got %d of %d", count, size)
+	}
+
+	inUse, err := db.BuildDirInUse("/tmp/shared")
+	if err != nil || !inUse {
+		t.Errorf("expected build dir in use, got %v (%v)", inUse, err)
+	}
+	if err := db.HardDeleteBuild("build-1"); err != nil {
+		t.Fatalf("failed to hard delete build: %v", err)
+	}
+	if inUse, _ := db.BuildDirInUse("/tmp/shared"); inUse {
+		t.Error("expected build dir to be unused after hard delete")
+	}
+}
@@ -21,6 +21,11 @@ CREATE TABLE IF NOT EXISTS builds (
     config_snapshot TEXT,                   -- JSON snapshot of config at build time
     user TEXT,                              -- Username who initiated build
     host TEXT,                              -- Hostname where build ran
+    retried_from TEXT,                      -- ID of the build this build retries (NULL for new builds)
+    priority INTEGER NOT NULL DEFAULT 0,    -- Scheduling priority, kept to requeue after a restart
+    diagnostics TEXT,                       -- JSON array of failures diagnosed in the build output
+    layer_commits TEXT,                     -- JSON array of the commits the git layers were checked out at
+    layer_fetches TEXT,                     -- JSON array of how each layer repository was fetched
 
     -- Timing
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -44,6 +49,7 @@ CREATE INDEX IF NOT EXISTS idx_builds_deleted ON builds(deleted);
 CREATE TABLE IF NOT EXISTS build_artifacts (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     build_id TEXT NOT NULL,                 -- FK to builds.id
+    artifact_id TEXT,                       -- Stable public ID (<build-id>/<artifact_path>)
     artifact_path TEXT NOT NULL,            -- Relative path within deploy_dir
     artifact_type TEXT,                     -- wic, tar.gz, manifest, etc.
     size_bytes INTEGER,
@@ -63,7 +69,39 @@ CREATE INDEX IF NOT EXISTS idx_artifacts_build_id ON build_artifacts(build_id);C
     FOREIGN KEY (build_id) REFERENCES builds(id) ON DELETE CASCADE
 );
 
-CREATE INDEX IF NOT EXISTS idx_metrics_build_id ON build_metrics(build_id);-- View for active (non-deleted) builds
+CREATE INDEX IF NOT EXISTS idx_metrics_build_id ON build_metrics(build_id);
+
+CREATE TABLE IF NOT EXISTS build_task_logs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    build_id TEXT NOT NULL,                 -- FK to builds.id
+    recipe TEXT NOT NULL,                   -- Recipe name without version, e.g. busybox
+    task TEXT NOT NULL,                     -- Task name, e.g. do_compile
+    log_path TEXT NOT NULL,                 -- Host path of the log collected from the BitBake workdir
+    size_bytes INTEGER,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (build_id) REFERENCES builds(id) ON DELETE CASCADE,
+    UNIQUE (build_id, recipe, task)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_logs_build_id ON build_task_logs(build_id);
+
+CREATE TABLE IF NOT EXISTS build_recovery_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    build_id TEXT NOT NULL,                 -- FK to builds.id
+    strategy TEXT NOT NULL,                 -- package_index, pseudo_cleanup, cleansstate or retry
+    recipe TEXT,                            -- Recipe the step was applied to, if any
+    attempt INTEGER NOT NULL DEFAULT 0,     -- Retry the step belongs to, starting at 1
+    succeeded BOOLEAN NOT NULL DEFAULT 0,
+    detail TEXT,
+    started_at DATETIME NOT NULL,
+    duration_ms INTEGER,
+
+    FOREIGN KEY (build_id) REFERENCES builds(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_recovery_events_build_id ON build_recovery_events(build_id);
+-- View for active (non-deleted) builds
 CREATE VIEW IF NOT EXISTS active_builds AS
 SELECT * FROM builds WHERE deleted = 0;
 
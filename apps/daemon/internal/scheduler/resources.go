@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Defaults assumed for builds that do not set container.cpu_count/memory.
+// A Yocto image build comfortably uses about this much.
+const (
+	DefaultBuildCPUs   = 4
+	DefaultBuildMemory = 8 << 30 // 8 GiB
+)
+
+// Resources describes CPU and memory capacity; zero memory means unknown/unlimited
+type Resources struct {
+	CPUs        int
+	MemoryBytes int64
+}
+
+// HostResources detects the CPUs and physical memory of the host
+func HostResources() Resources {
+	return Resources{CPUs: runtime.NumCPU(), MemoryBytes: hostMemory()}
+}
+
+// hostMemory reads MemTotal from /proc/meminfo, returning 0 where unavailable
+func hostMemory() int64 {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0
+			}
+			return kb * 1024
+		}
+	}
+	return 0
+}
+
+// DeriveMaxParallel returns how many default-sized builds fit on the host (at least 1)
+func DeriveMaxParallel(host Resources) int {
+	limit := host.CPUs / DefaultBuildCPUs
+	if host.MemoryBytes > 0 {
+		if byMemory := int(host.MemoryBytes / DefaultBuildMemory); byMemory < limit {
+			limit = byMemory
+		}
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
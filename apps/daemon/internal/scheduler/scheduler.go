@@ -0,0 +1,409 @@
+// Package scheduler admits queued builds under a global concurrency and resource limit,
+// sharing build slots fairly between customers.
+//
+// Builds are picked by priority first. Among builds of equal priority, the customer that
+// has received the least service relative to its weight goes next (weighted fair queuing),
+// and ties are broken by submission order. The selected build waits until the host has
+// room for it, so large builds are not starved by a stream of small ones.
+package scheduler
+
+import (
+	"context"
+	"sync"
+)
+
+// Request describes a build waiting for a slot
+type Request struct {
+	ID          string
+	Customer    string
+	Priority    int   // higher runs first
+	CPUs        int   // CPUs the build uses; 0 assumes DefaultBuildCPUs
+	MemoryBytes int64 // memory the build uses; 0 assumes DefaultBuildMemory
+}
+
+// Options configures a Scheduler. Zero values select the defaults.
+type Options struct {
+	MaxParallel int       // maximum concurrent builds; 0 derives it from Host
+	PerCustomer int       // maximum concurrent builds per customer; 0 means 1
+	Host        Resources // capacity shared by all builds; zero detects the host
+}
+
+// Scheduler hands out build slots. It is safe for concurrent use.
+type Scheduler struct {
+	mu          sync.Mutex
+	host        Resources
+	maxParallel int
+	perCustomer int
+
+	running    int
+	usedCPUs   int
+	usedMemory int64
+
+	seq       uint64
+	customers map[string]*customerState
+	queued    map[string]*Ticket // by build ID
+	active    []*Ticket          // admitted and not yet released, in admission order
+	weights   map[string]float64
+}
+
+// customerState tracks the queue and received service of one customer
+type customerState struct {
+	weight  float64
+	vtime   float64   // builds started, divided by weight
+	running int       // admitted and not yet released
+	queue   []*Ticket // by priority (desc), then submission order
+}
+
+// Ticket is a build's place in the scheduler
+type Ticket struct {
+	s        *Scheduler
+	req      Request
+	customer *customerState
+	seq      uint64
+	ready    chan struct{} // closed when admitted
+
+	admitted bool
+	released bool
+}
+
+// New creates a scheduler
+func New(opts Options) *Scheduler {
+	host := opts.Host
+	if host.CPUs <= 0 {
+		host = HostResources()
+	}
+	maxParallel := opts.MaxParallel
+	if maxParallel <= 0 {
+		maxParallel = DeriveMaxParallel(host)
+	}
+	perCustomer := opts.PerCustomer
+	if perCustomer <= 0 {
+		perCustomer = 1
+	}
+
+	return &Scheduler{
+		host:        host,
+		maxParallel: maxParallel,
+		perCustomer: perCustomer,
+		customers:   make(map[string]*customerState),
+		queued:      make(map[string]*Ticket),
+		weights:     make(map[string]float64),
+	}
+}
+
+// MaxParallel returns the global limit of concurrent builds
+func (s *Scheduler) MaxParallel() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxParallel
+}
+
+// SetMaxParallel changes the global limit; 0 derives it from Host.
+// Lowering the limit lets running builds finish; queued builds wait until there is room.
+func (s *Scheduler) SetMaxParallel(maxParallel int) {
+	if maxParallel <= 0 {
+		maxParallel = DeriveMaxParallel(s.host)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxParallel = maxParallel
+	s.dispatch()
+}
+
+// Host returns the capacity shared by all builds
+func (s *Scheduler) Host() Resources {
+	return s.host
+}
+
+// SetWeight sets a customer's share of build slots relative to others (default 1)
+func (s *Scheduler) SetWeight(customer string, weight float64) {
+	if weight <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.weights[customer] = weight
+	if cs, ok := s.customers[customer]; ok {
+		cs.weight = weight
+	}
+}
+
+// Enqueue adds a build to the queue and admits it right away if there is room
+func (s *Scheduler) Enqueue(req Request) *Ticket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req = s.normalize(req)
+	cs := s.customer(req.Customer)
+
+	// A customer that was idle joins at the current virtual time instead of
+	// redeeming credit for the time it had nothing queued
+	if len(cs.queue) == 0 && cs.running == 0 {
+		if vtime, ok := s.virtualTime(); ok && vtime > cs.vtime {
+			cs.vtime = vtime
+		}
+	}
+
+	s.seq++
+	t := &Ticket{s: s, req: req, customer: cs, seq: s.seq, ready: make(chan struct{})}
+
+	i := len(cs.queue)
+	for i > 0 && cs.queue[i-1].req.Priority < req.Priority {
+		i--
+	}
+	cs.queue = append(cs.queue, nil)
+	copy(cs.queue[i+1:], cs.queue[i:])
+	cs.queue[i] = t
+	s.queued[req.ID] = t
+
+	s.dispatch()
+	return t
+}
+
+// Position returns the 1-based place of a queued build in the expected start order,
+// or 0 if it is not queued
+func (s *Scheduler) Position(buildID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target, ok := s.queued[buildID]
+	if !ok {
+		return 0
+	}
+
+	// Replay dispatch on a copy, counting slots but not resources. Running builds are
+	// assumed to finish in the order they started, freeing a slot whenever the global
+	// limit is reached or every customer with queued builds is at its own limit.
+	type simState struct {
+		vtime   float64
+		running int
+		next    int
+	}
+	sims := make(map[*customerState]*simState, len(s.customers))
+	for _, cs := range s.customers {
+		sims[cs] = &simState{vtime: cs.vtime, running: cs.running}
+	}
+	active := make([]*customerState, 0, len(s.active))
+	for _, t := range s.active {
+		active = append(active, t.customer)
+	}
+	finish := func() bool {
+		if len(active) == 0 {
+			return false
+		}
+		sims[active[0]].running--
+		active = active[1:]
+		return true
+	}
+
+	for position := 1; ; {
+		if len(active) >= s.maxParallel {
+			finish()
+			continue
+		}
+		var next *customerState
+		pending := false
+		for cs, sim := range sims {
+			if sim.next >= len(cs.queue) {
+				continue
+			}
+			pending = true
+			if sim.running >= s.perCustomer {
+				continue
+			}
+			if next == nil || before(cs.queue[sim.next], sim.vtime, next.queue[sims[next].next], sims[next].vtime) {
+				next = cs
+			}
+		}
+		if !pending {
+			return 0
+		}
+		if next == nil {
+			if !finish() {
+				return 0
+			}
+			continue
+		}
+		sim := sims[next]
+		if next.queue[sim.next] == target {
+			return position
+		}
+		sim.next++
+		sim.running++
+		sim.vtime += 1 / next.weight
+		active = append(active, next)
+		position++
+	}
+}
+
+// Queued returns the number of builds waiting for a slot
+func (s *Scheduler) Queued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queued)
+}
+
+// Wait blocks until the build is admitted or ctx is done. A cancelled build leaves the queue.
+func (t *Ticket) Wait(ctx context.Context) error {
+	select {
+	case <-t.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s := t.s
+	s.mu.Lock()
+	if t.admitted {
+		// Admitted concurrently with the cancellation; give the slot back
+		s.mu.Unlock()
+		t.Release()
+		return ctx.Err()
+	}
+	cs := t.customer
+	for i, queued := range cs.queue {
+		if queued == t {
+			cs.queue = append(cs.queue[:i], cs.queue[i+1:]...)
+			break
+		}
+	}
+	delete(s.queued, t.req.ID)
+	// The removed build may have been the one waiting for room
+	s.dispatch()
+	s.mu.Unlock()
+	return ctx.Err()
+}
+
+// Release returns the slot of an admitted build; it is safe to call more than once
+func (t *Ticket) Release() {
+	s := t.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !t.admitted || t.released {
+		return
+	}
+	t.released = true
+	for i, active := range s.active {
+		if active == t {
+			s.active = append(s.active[:i], s.active[i+1:]...)
+			break
+		}
+	}
+	s.running--
+	s.usedCPUs -= t.req.CPUs
+	s.usedMemory -= t.req.MemoryBytes
+	t.customer.running--
+	s.dispatch()
+}
+
+// normalize applies default sizes and caps requests to the host so every build can run
+func (s *Scheduler) normalize(req Request) Request {
+	if req.CPUs <= 0 {
+		req.CPUs = DefaultBuildCPUs
+	}
+	if req.CPUs > s.host.CPUs {
+		req.CPUs = s.host.CPUs
+	}
+	if req.MemoryBytes <= 0 {
+		req.MemoryBytes = DefaultBuildMemory
+	}
+	if s.host.MemoryBytes > 0 && req.MemoryBytes > s.host.MemoryBytes {
+		req.MemoryBytes = s.host.MemoryBytes
+	}
+	return req
+}
+
+// customer returns the state of a customer, creating it if needed; the caller must hold s.mu
+func (s *Scheduler) customer(name string) *customerState {
+	cs, ok := s.customers[name]
+	if !ok {
+		weight := s.weights[name]
+		if weight <= 0 {
+			weight = 1
+		}
+		cs = &customerState{weight: weight}
+		s.customers[name] = cs
+	}
+	return cs
+}
+
+// virtualTime returns the lowest start time of the last build of customers with queued
+// or running builds, i.e. the virtual time up to which every active customer was served
+func (s *Scheduler) virtualTime() (float64, bool) {
+	var min float64
+	found := false
+	for _, cs := range s.customers {
+		if len(cs.queue) == 0 && cs.running == 0 {
+			continue
+		}
+		start := cs.vtime
+		if cs.running > 0 {
+			start -= 1 / cs.weight
+		}
+		if !found || start < min {
+			min, found = start, true
+		}
+	}
+	return min, found
+}
+
+// before reports whether ticket a (of a customer at virtual time va) goes before b
+func before(a *Ticket, va float64, b *Ticket, vb float64) bool {
+	if a.req.Priority != b.req.Priority {
+		return a.req.Priority > b.req.Priority
+	}
+	if va != vb {
+		return va < vb
+	}
+	return a.seq < b.seq
+}
+
+// dispatch admits builds in order while the next one fits; the caller must hold s.mu
+func (s *Scheduler) dispatch() {
+	for {
+		var next *customerState
+		for _, cs := range s.customers {
+			if len(cs.queue) == 0 || cs.running >= s.perCustomer {
+				continue
+			}
+			if next == nil || before(cs.queue[0], cs.vtime, next.queue[0], next.vtime) {
+				next = cs
+			}
+		}
+		if next == nil {
+			return
+		}
+
+		t := next.queue[0]
+		if !s.fits(t.req) {
+			return
+		}
+
+		next.queue = next.queue[1:]
+		delete(s.queued, t.req.ID)
+		s.running++
+		s.usedCPUs += t.req.CPUs
+		s.usedMemory += t.req.MemoryBytes
+		next.running++
+		next.vtime += 1 / next.weight
+		t.admitted = true
+		s.active = append(s.active, t)
+		close(t.ready)
+	}
+}
+
+// fits reports whether a build can start now
+func (s *Scheduler) fits(req Request) bool {
+	if s.running >= s.maxParallel {
+		return false
+	}
+	if s.usedCPUs+req.CPUs > s.host.CPUs {
+		return false
+	}
+	if s.host.MemoryBytes > 0 && s.usedMemory+req.MemoryBytes > s.host.MemoryBytes {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,236 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testHost has room for exactly two default-sized builds
+var testHost = Resources{CPUs: 8, MemoryBytes: 16 << 30}
+
+func admitted(t *Ticket) bool {
+	select {
+	case <-t.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestDeriveMaxParallel(t *testing.T) {
+	if got := DeriveMaxParallel(Resources{CPUs: 32, MemoryBytes: 64 << 30}); got != 8 {
+		t.Errorf("expected 8 builds for 32 CPUs/64GiB, got %d", got)
+	}
+	// Memory is the tighter limit
+	if got := DeriveMaxParallel(Resources{CPUs: 32, MemoryBytes: 16 << 30}); got != 2 {
+		t.Errorf("expected 2 builds for 32 CPUs/16GiB, got %d", got)
+	}
+	if got := DeriveMaxParallel(Resources{CPUs: 2}); got != 1 {
+		t.Errorf("expected at least 1 build, got %d", got)
+	}
+}
+
+func TestSchedulerGlobalLimit(t *testing.T) {
+	s := New(Options{Host: testHost, PerCustomer: 10})
+	if s.MaxParallel() != 2 {
+		t.Fatalf("expected derived limit 2, got %d", s.MaxParallel())
+	}
+
+	a := s.Enqueue(Request{ID: "a", Customer: "acme"})
+	b := s.Enqueue(Request{ID: "b", Customer: "acme"})
+	c := s.Enqueue(Request{ID: "c", Customer: "acme"})
+	if !admitted(a) || !admitted(b) {
+		t.Fatal("expected the first two builds to start")
+	}
+	if admitted(c) {
+		t.Fatal("expected the third build to wait for a slot")
+	}
+	if s.Position("c") != 1 {
+		t.Errorf("expected c at position 1, got %d", s.Position("c"))
+	}
+
+	a.Release()
+	a.Release() // releasing twice must not free a second slot
+	if !admitted(c) {
+		t.Fatal("expected c to start after a finished")
+	}
+	if s.Position("c") != 0 {
+		t.Errorf("expected no queue position for a running build, got %d", s.Position("c"))
+	}
+
+	d := s.Enqueue(Request{ID: "d", Customer: "acme"})
+	if admitted(d) {
+		t.Fatal("expected d to wait; double release freed an extra slot")
+	}
+}
+
+func TestSchedulerSetMaxParallel(t *testing.T) {
+	s := New(Options{Host: Resources{CPUs: 32, MemoryBytes: 64 << 30}, MaxParallel: 1, PerCustomer: 10})
+
+	a := s.Enqueue(Request{ID: "a", Customer: "acme"})
+	b := s.Enqueue(Request{ID: "b", Customer: "acme"})
+	if !admitted(a) || admitted(b) {
+		t.Fatal("expected only the first build to start under a limit of 1")
+	}
+
+	s.SetMaxParallel(2)
+	if !admitted(b) {
+		t.Fatal("expected raising the limit to start the queued build")
+	}
+
+	s.SetMaxParallel(1)
+	a.Release()
+	c := s.Enqueue(Request{ID: "c", Customer: "acme"})
+	if admitted(c) {
+		t.Fatal("expected c to wait while b uses the only slot")
+	}
+}
+
+func TestSchedulerResourceLimits(t *testing.T) {
+	s := New(Options{Host: testHost, MaxParallel: 10, PerCustomer: 10})
+
+	big := s.Enqueue(Request{ID: "big", Customer: "a", CPUs: 6})
+	small := s.Enqueue(Request{ID: "small", Customer: "b", CPUs: 4})
+	if !admitted(big) {
+		t.Fatal("expected big build to start")
+	}
+	if admitted(small) {
+		t.Fatal("expected small build to wait for CPUs")
+	}
+
+	big.Release()
+	if !admitted(small) {
+		t.Fatal("expected small build to start once CPUs are free")
+	}
+
+	// Requests larger than the host are capped so they can still run alone
+	small.Release()
+	huge := s.Enqueue(Request{ID: "huge", Customer: "c", CPUs: 64, MemoryBytes: 1 << 40})
+	if !admitted(huge) {
+		t.Fatal("expected oversized build to run on an idle host")
+	}
+}
+
+func TestSchedulerFairAcrossCustomers(t *testing.T) {
+	s := New(Options{Host: testHost, MaxParallel: 1, PerCustomer: 1})
+
+	first := s.Enqueue(Request{ID: "a1", Customer: "a"})
+	s.Enqueue(Request{ID: "a2", Customer: "a"})
+	s.Enqueue(Request{ID: "a3", Customer: "a"})
+	s.Enqueue(Request{ID: "b1", Customer: "b"})
+	if !admitted(first) {
+		t.Fatal("expected a1 to start")
+	}
+
+	// b has not been served yet, so it goes before a's backlog
+	if pos := s.Position("b1"); pos != 1 {
+		t.Errorf("expected b1 at position 1, got %d", pos)
+	}
+	if pos := s.Position("a2"); pos != 2 {
+		t.Errorf("expected a2 at position 2, got %d", pos)
+	}
+	if pos := s.Position("a3"); pos != 3 {
+		t.Errorf("expected a3 at position 3, got %d", pos)
+	}
+}
+
+func TestSchedulerWeights(t *testing.T) {
+	s := New(Options{Host: testHost, MaxParallel: 1, PerCustomer: 1})
+	s.SetWeight("gold", 2)
+
+	running := s.Enqueue(Request{ID: "x", Customer: "third"})
+	for _, id := range []string{"g1", "g2", "g3"} {
+		s.Enqueue(Request{ID: id, Customer: "gold"})
+	}
+	for _, id := range []string{"o1", "o2"} {
+		s.Enqueue(Request{ID: id, Customer: "other"})
+	}
+	if !admitted(running) {
+		t.Fatal("expected x to start")
+	}
+
+	// gold gets two slots for every one of other's
+	want := map[string]int{"g1": 1, "o1": 2, "g2": 3, "g3": 4, "o2": 5}
+	for id, pos := range want {
+		if got := s.Position(id); got != pos {
+			t.Errorf("expected %s at position %d, got %d", id, pos, got)
+		}
+	}
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	s := New(Options{Host: testHost, MaxParallel: 1, PerCustomer: 10})
+
+	running := s.Enqueue(Request{ID: "r", Customer: "a"})
+	low := s.Enqueue(Request{ID: "low", Customer: "a"})
+	high := s.Enqueue(Request{ID: "high", Customer: "b", Priority: 10})
+	if s.Position("high") != 1 || s.Position("low") != 2 {
+		t.Errorf("expected high priority first, got high=%d low=%d", s.Position("high"), s.Position("low"))
+	}
+
+	running.Release()
+	if !admitted(high) || admitted(low) {
+		t.Fatal("expected the high priority build to start next")
+	}
+}
+
+func TestSchedulerPerCustomerLimit(t *testing.T) {
+	s := New(Options{Host: testHost, MaxParallel: 2})
+
+	a1 := s.Enqueue(Request{ID: "a1", Customer: "a"})
+	a2 := s.Enqueue(Request{ID: "a2", Customer: "a"})
+	b1 := s.Enqueue(Request{ID: "b1", Customer: "b"})
+	if !admitted(a1) || admitted(a2) || !admitted(b1) {
+		t.Fatal("expected one build per customer to run")
+	}
+	a1.Release()
+	if !admitted(a2) {
+		t.Fatal("expected a2 to start after a1")
+	}
+}
+
+func TestTicketWaitCancel(t *testing.T) {
+	s := New(Options{Host: testHost, MaxParallel: 1})
+
+	running := s.Enqueue(Request{ID: "r", Customer: "a"})
+	waiting := s.Enqueue(Request{ID: "w", Customer: "b"})
+	next := s.Enqueue(Request{ID: "n", Customer: "c"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := waiting.Wait(ctx); err == nil {
+		t.Fatal("expected Wait to fail on cancellation")
+	}
+	if s.Position("w") != 0 || s.Queued() != 1 {
+		t.Errorf("expected cancelled build to leave the queue (queued=%d)", s.Queued())
+	}
+
+	running.Release()
+	if err := next.Wait(context.Background()); err != nil {
+		t.Fatalf("expected n to start: %v", err)
+	}
+}
+
+func TestSchedulerPositionPerCustomerLimit(t *testing.T) {
+	s := New(Options{Host: testHost, MaxParallel: 2, PerCustomer: 1})
+
+	b1 := s.Enqueue(Request{ID: "b1", Customer: "b"})
+	s.Enqueue(Request{ID: "a1", Customer: "a"})
+	a2 := s.Enqueue(Request{ID: "a2", Customer: "a", Priority: 10})
+	c1 := s.Enqueue(Request{ID: "c1", Customer: "c"})
+
+	// The next free slot is b1's while a is still at its limit, so c1 starts
+	// before a2 despite a2's priority
+	if pos := s.Position("c1"); pos != 1 {
+		t.Errorf("expected c1 at position 1, got %d", pos)
+	}
+	if pos := s.Position("a2"); pos != 2 {
+		t.Errorf("expected a2 at position 2, got %d", pos)
+	}
+
+	b1.Release()
+	if !admitted(c1) || admitted(a2) {
+		t.Fatal("expected c1 to take the free slot while a is at its limit")
+	}
+}
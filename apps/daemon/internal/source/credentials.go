@@ -0,0 +1,257 @@
+package source
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/schererja/smidr/internal/config"
+	"go.yaml.in/yaml/v3"
+)
+
+// credentialHelper answers git's credential requests from the environment of the git
+// process, so https secrets never appear in URLs, command lines or the mirror's config
+const credentialHelper = `!f() { test "$1" = get && printf 'username=%s\npassword=%s\n' "$SMIDR_GIT_USERNAME" "$SMIDR_GIT_PASSWORD"; }; f`
+
+// GitCredential authenticates fetches of the repositories whose URL starts with URL
+type GitCredential struct {
+	URL          string `yaml:"url"`           // repository URL prefix, e.g. git@github.com:acme/ or https://github.com/acme/
+	Customer     string `yaml:"customer"`      // only builds of this customer use it; empty means every customer
+	SSHAuthSock  string `yaml:"ssh_auth_sock"` // ssh-agent socket holding the key
+	SSHKey       string `yaml:"ssh_key"`       // private key file, e.g. a deploy key
+	KnownHosts   string `yaml:"known_hosts"`   // known_hosts file; host keys are then checked strictly
+	Username     string `yaml:"username"`      // user name for https
+	PasswordFile string `yaml:"password_file"` // file holding the https password or token, read on every fetch
+	PasswordEnv  string `yaml:"password_env"`  // daemon environment variable holding the https password or token
+}
+
+// GitCredentials are the credentials available to one build
+type GitCredentials []GitCredential
+
+// GitCredentialStore holds the git credentials of all customers
+type GitCredentialStore struct {
+	entries []GitCredential
+}
+
+// LoadGitCredentials reads a git credentials file:
+//
+//	credentials:
+//	  - url: git@github.com:acme/
+//	    customer: acme
+//	    ssh_key: ~/.smidr/keys/acme-deploy
+//	    known_hosts: ~/.smidr/known_hosts
+//	  - url: ssh://git@git.example.com/
+//	    ssh_auth_sock: /run/smidr/ssh-agent.sock
+//	  - url: https://git.example.com/bsp/
+//	    customer: acme
+//	    username: acme-ci
+//	    password_file: ~/.smidr/secrets/acme-git-token
+func LoadGitCredentials(path string) (*GitCredentialStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git credentials file: %w", err)
+	}
+
+	var file struct {
+		Credentials []GitCredential `yaml:"credentials"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse git credentials file: %w", err)
+	}
+
+	store := &GitCredentialStore{}
+	for i, entry := range file.Credentials {
+		if entry.URL == "" {
+			return nil, fmt.Errorf("credentials[%d]: url is required", i)
+		}
+		if prefix, ok := parseGitURL(entry.URL); !ok || prefix.host == "" {
+			return nil, fmt.Errorf("credentials[%d]: url must name a host, e.g. https://git.example.com/ or git@github.com:", i)
+		}
+		if entry.SSHAuthSock == "" && entry.SSHKey == "" && entry.Username == "" {
+			return nil, fmt.Errorf("credentials[%d]: one of ssh_auth_sock, ssh_key or username is required", i)
+		}
+		hasPassword := entry.PasswordFile != "" || entry.PasswordEnv != ""
+		if entry.Username != "" && !hasPassword {
+			return nil, fmt.Errorf("credentials[%d]: username requires password_file or password_env", i)
+		}
+		if hasPassword && entry.Username == "" {
+			return nil, fmt.Errorf("credentials[%d]: password_file and password_env require username", i)
+		}
+		if entry.PasswordFile != "" && entry.PasswordEnv != "" {
+			return nil, fmt.Errorf("credentials[%d]: password_file and password_env are mutually exclusive", i)
+		}
+
+		entry.SSHAuthSock = config.ExpandHome(entry.SSHAuthSock)
+		entry.SSHKey = config.ExpandHome(entry.SSHKey)
+		entry.KnownHosts = config.ExpandHome(entry.KnownHosts)
+		entry.PasswordFile = config.ExpandHome(entry.PasswordFile)
+		for _, file := range []string{entry.SSHKey, entry.KnownHosts, entry.PasswordFile} {
+			if file == "" {
+				continue
+			}
+			if _, err := os.Stat(file); err != nil {
+				return nil, fmt.Errorf("credentials[%d]: %w", i, err)
+			}
+		}
+		store.entries = append(store.entries, entry)
+	}
+	if len(store.entries) == 0 {
+		return nil, fmt.Errorf("git credentials file %s defines no credentials", path)
+	}
+	return store, nil
+}
+
+// ForCustomer returns the credentials a customer's builds may use: its own entries ahead of
+// the shared ones. A nil store has none.
+func (s *GitCredentialStore) ForCustomer(customer string) GitCredentials {
+	if s == nil {
+		return nil
+	}
+	var own, shared GitCredentials
+	for _, entry := range s.entries {
+		switch entry.Customer {
+		case customer:
+			own = append(own, entry)
+		case "":
+			shared = append(shared, entry)
+		}
+	}
+	return append(own, shared...)
+}
+
+// lookup returns the credential with the longest URL prefix matching gitURL. The scheme,
+// user and host must be equal and the prefix must end at a path boundary, so a credential
+// for https://git.example.com never reaches https://git.example.com.evil.io.
+func (c GitCredentials) lookup(gitURL string) *GitCredential {
+	target, ok := parseGitURL(gitURL)
+	if !ok {
+		return nil
+	}
+	var match *GitCredential
+	for i := range c {
+		prefix, ok := parseGitURL(c[i].URL)
+		if !ok || !prefix.matches(target) {
+			continue
+		}
+		if match == nil || len(c[i].URL) > len(match.URL) {
+			match = &c[i]
+		}
+	}
+	return match
+}
+
+// gitURL is a repository URL split for credential matching
+type gitURL struct {
+	scheme string // URL scheme, or "scp" for the [user@]host:path form
+	user   string
+	host   string // lower case, including the port if any
+	path   string
+}
+
+// parseGitURL splits a URL in one of the forms layers accept
+func parseGitURL(raw string) (gitURL, bool) {
+	if !strings.Contains(raw, "://") {
+		// scp-like: [user@]host:path
+		hostPart, path, ok := strings.Cut(raw, ":")
+		if !ok || strings.Contains(hostPart, "/") {
+			return gitURL{}, false
+		}
+		user, host, hasUser := strings.Cut(hostPart, "@")
+		if !hasUser {
+			user, host = "", hostPart
+		}
+		return gitURL{scheme: "scp", user: user, host: strings.ToLower(host), path: path}, true
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Opaque != "" {
+		return gitURL{}, false
+	}
+	return gitURL{scheme: strings.ToLower(u.Scheme), user: u.User.Username(), host: strings.ToLower(u.Host), path: u.Path}, true
+}
+
+// matches reports whether p, a credential's URL prefix, covers target. A prefix without a
+// user covers every user; its path only covers whole path segments unless it ends in "/".
+func (p gitURL) matches(target gitURL) bool {
+	if p.scheme != target.scheme || p.host != target.host || (p.user != "" && p.user != target.user) {
+		return false
+	}
+	if p.path == "" || strings.HasSuffix(p.path, "/") {
+		return strings.HasPrefix(target.path, p.path)
+	}
+	return target.path == p.path || strings.HasPrefix(target.path, p.path+"/")
+}
+
+// identity names the credentials used to fetch from urls; empty when none of them has one.
+// Repositories fetched with different credentials must not share a mirror: a customer
+// would otherwise be served what only another customer's key may read.
+func (c GitCredentials) identity(urls []string) string {
+	var b strings.Builder
+	for _, url := range urls {
+		if cred := c.lookup(url); cred != nil {
+			fmt.Fprintf(&b, "%s\x00%s\x00%s\x00%s\x00%s\x00%s\x00%s\n",
+				cred.Customer, cred.URL, cred.SSHAuthSock, cred.SSHKey, cred.Username, cred.PasswordFile, cred.PasswordEnv)
+		}
+	}
+	return b.String()
+}
+
+// gitEnv returns the environment for git commands that talk to gitURL's remote. Git never
+// prompts, since nobody could answer; with a matching credential it authenticates with it.
+func (c GitCredentials) gitEnv(gitURL string) ([]string, error) {
+	env := []string{"GIT_TERMINAL_PROMPT=0"}
+	cred := c.lookup(gitURL)
+	if cred == nil {
+		return env, nil
+	}
+
+	if cred.SSHAuthSock != "" {
+		env = append(env, "SSH_AUTH_SOCK="+cred.SSHAuthSock)
+	}
+	if cred.SSHKey != "" || cred.KnownHosts != "" {
+		ssh := "ssh -o BatchMode=yes"
+		if cred.SSHKey != "" {
+			ssh += " -i " + shellQuote(cred.SSHKey) + " -o IdentitiesOnly=yes"
+		}
+		if cred.KnownHosts != "" {
+			ssh += " -o UserKnownHostsFile=" + shellQuote(cred.KnownHosts) + " -o StrictHostKeyChecking=yes"
+		}
+		env = append(env, "GIT_SSH_COMMAND="+ssh)
+	}
+	if cred.Username != "" {
+		password, err := cred.password()
+		if err != nil {
+			return nil, err
+		}
+		env = append(env,
+			// An empty helper first drops the helpers configured on the host
+			"GIT_CONFIG_COUNT=2",
+			"GIT_CONFIG_KEY_0=credential.helper", "GIT_CONFIG_VALUE_0=",
+			"GIT_CONFIG_KEY_1=credential.helper", "GIT_CONFIG_VALUE_1="+credentialHelper,
+			"SMIDR_GIT_USERNAME="+cred.Username,
+			"SMIDR_GIT_PASSWORD="+password,
+		)
+	}
+	return env, nil
+}
+
+// password reads the https password or token of a credential
+func (c *GitCredential) password() (string, error) {
+	if c.PasswordEnv != "" {
+		password, ok := os.LookupEnv(c.PasswordEnv)
+		if !ok {
+			return "", fmt.Errorf("git credential for %s: environment variable %s is not set", c.URL, c.PasswordEnv)
+		}
+		return password, nil
+	}
+	data, err := os.ReadFile(c.PasswordFile)
+	if err != nil {
+		return "", fmt.Errorf("git credential for %s: %w", c.URL, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// shellQuote quotes s for the shell git runs GIT_SSH_COMMAND with
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,152 @@
+package source
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "git-credentials.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadGitCredentials(t *testing.T) {
+	dir := t.TempDir()
+	key := filepath.Join(dir, "acme-deploy")
+	token := filepath.Join(dir, "acme-token")
+	for _, file := range []string{key, token} {
+		if err := os.WriteFile(file, []byte("secret\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store, err := LoadGitCredentials(writeCredentialsFile(t, `credentials:
+  - url: "git@github.com:"
+    ssh_auth_sock: /run/smidr/agent.sock
+  - url: git@github.com:acme/
+    customer: acme
+    ssh_key: `+key+`
+  - url: https://git.example.com/bsp/
+    customer: acme
+    username: acme-ci
+    password_file: `+token+`
+`))
+	if err != nil {
+		t.Fatalf("LoadGitCredentials returned error: %v", err)
+	}
+
+	acme := store.ForCustomer("acme")
+	if len(acme) != 3 || acme[0].Customer != "acme" || acme[2].Customer != "" {
+		t.Fatalf("expected acme's credentials ahead of the shared one, got %+v", acme)
+	}
+	if cred := acme.lookup("git@github.com:acme/meta-private.git"); cred == nil || cred.SSHKey != key {
+		t.Errorf("expected the deploy key for acme's repository, got %+v", cred)
+	}
+	if cred := acme.lookup("git@github.com:other/meta-other.git"); cred == nil || cred.SSHAuthSock == "" {
+		t.Errorf("expected the shared agent for other repositories, got %+v", cred)
+	}
+
+	other := store.ForCustomer("globex")
+	if len(other) != 1 || other.lookup("https://git.example.com/bsp/meta-acme.git") != nil {
+		t.Errorf("expected other customers to see only the shared credential, got %+v", other)
+	}
+	if (*GitCredentialStore)(nil).ForCustomer("acme") != nil {
+		t.Error("expected a nil store to have no credentials")
+	}
+
+	for name, content := range map[string]string{
+		"no url":        "credentials:\n  - ssh_key: " + key + "\n",
+		"no secret":     "credentials:\n  - url: https://git.example.com/\n",
+		"no password":   "credentials:\n  - url: https://git.example.com/\n    username: ci\n",
+		"missing key":   "credentials:\n  - url: \"git@github.com:\"\n    ssh_key: " + filepath.Join(dir, "missing") + "\n",
+		"empty":         "credentials: []\n",
+		"no host":       "credentials:\n  - url: file:///srv/git/\n    ssh_key: " + key + "\n",
+		"both password": "credentials:\n  - url: https://git.example.com/\n    username: ci\n    password_file: " + token + "\n    password_env: TOKEN\n",
+	} {
+		if _, err := LoadGitCredentials(writeCredentialsFile(t, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestGitCredentials_Env(t *testing.T) {
+	t.Setenv("ACME_GIT_TOKEN", "s3cr3t")
+	credentials := GitCredentials{
+		{URL: "git@github.com:acme/", SSHKey: "/keys/acme's key", KnownHosts: "/keys/known_hosts"},
+		{URL: "https://git.example.com/", Username: "acme-ci", PasswordEnv: "ACME_GIT_TOKEN"},
+	}
+
+	env, err := credentials.gitEnv("https://github.com/openembedded/meta-openembedded")
+	if err != nil || len(env) != 1 || env[0] != "GIT_TERMINAL_PROMPT=0" {
+		t.Errorf("expected only prompts to be disabled without a credential, got %v (%v)", env, err)
+	}
+
+	env, err = credentials.gitEnv("git@github.com:acme/meta-private.git")
+	if err != nil {
+		t.Fatalf("gitEnv returned error: %v", err)
+	}
+	want := `GIT_SSH_COMMAND=ssh -o BatchMode=yes -i '/keys/acme'\''s key' -o IdentitiesOnly=yes -o UserKnownHostsFile='/keys/known_hosts' -o StrictHostKeyChecking=yes`
+	if !slices.Contains(env, want) {
+		t.Errorf("expected %q in %v", want, env)
+	}
+
+	// The helper hands the token to git; it never is part of the URL or the arguments
+	env, err = credentials.gitEnv("https://git.example.com/bsp/meta-acme.git")
+	if err != nil {
+		t.Fatalf("gitEnv returned error: %v", err)
+	}
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=git.example.com\n\n")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git credential fill failed: %v", err)
+	}
+	if !strings.Contains(string(out), "username=acme-ci\n") || !strings.Contains(string(out), "password=s3cr3t\n") {
+		t.Errorf("expected the configured credential from the helper, got %q", out)
+	}
+
+	credentials[1].PasswordEnv = "SMIDR_TEST_UNSET_TOKEN"
+	if _, err := credentials.gitEnv("https://git.example.com/bsp/meta-acme.git"); err == nil {
+		t.Error("expected an unset password variable to fail")
+	}
+}
+
+func TestGitCredentials_LookupBoundaries(t *testing.T) {
+	credentials := GitCredentials{
+		{URL: "https://git.example.com", Username: "ci", PasswordEnv: "TOKEN"},
+		{URL: "https://github.com/acme", Username: "acme-ci", PasswordEnv: "ACME_TOKEN"},
+		{URL: "git@github.com:acme/", SSHKey: "/keys/acme"},
+	}
+	matches := map[string]string{
+		"https://git.example.com/bsp/meta-acme.git":    "https://git.example.com",
+		"https://GIT.example.com/bsp/meta-acme.git":    "https://git.example.com",
+		"https://github.com/acme/meta-private.git":     "https://github.com/acme",
+		"git@github.com:acme/meta-private.git":         "git@github.com:acme/",
+		"https://git.example.com.evil.io/x.git":        "",
+		"https://git.example.com:8443/bsp/meta.git":    "",
+		"https://git.example.com@evil.io/bsp/x.git":    "",
+		"http://git.example.com/bsp/meta-acme.git":     "",
+		"https://github.com/acme-other/meta.git":       "",
+		"git@github.com:acme-other/meta.git":           "",
+		"git@github.com.evil.io:acme/meta-private.git": "",
+	}
+	for gitURL, want := range matches {
+		cred := credentials.lookup(gitURL)
+		got := ""
+		if cred != nil {
+			got = cred.URL
+		}
+		if got != want {
+			t.Errorf("lookup(%q) = %q, want %q", gitURL, got, want)
+		}
+	}
+}
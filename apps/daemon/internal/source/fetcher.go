@@ -1,11 +1,15 @@
 package source
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -14,20 +18,123 @@ import (
 	"github.com/schererja/smidr/pkg/logger"
 )
 
+// mirrorLockTimeout bounds the wait for another build cloning or updating the same mirror
+const mirrorLockTimeout = 30 * time.Minute
+
 // Fetcher is responsible for fetching source code from various repositories.
 type Fetcher struct {
 	layersDir    string
 	downloadsDir string
 	logger       *logger.Logger
+	credentials  GitCredentials // used to clone and fetch private repositories
 	mu           sync.Mutex
 }
 
+// FetchStatus says how a layer's repository was obtained
+type FetchStatus string
+
+const (
+	FetchCloned  FetchStatus = "cloned"  // the mirror was created
+	FetchUpdated FetchStatus = "updated" // the existing mirror was fetched
+	FetchCached  FetchStatus = "cached"  // the pinned commit was mirrored already; no network access
+	FetchStale   FetchStatus = "stale"   // updating failed; the branch is used as last mirrored
+	FetchFailed  FetchStatus = "failed"
+)
+
 type FetchResult struct {
 	LayerName string
-	Path      string
+	Git       string
+	Path      string // bare mirror of the repository
 	Success   bool
 	Error     error
-	Cached    bool // Meaning already cloned
+	Cached    bool   // Meaning already cloned
+	Commit    string // Commit the layer resolved to: the pinned commit or the branch head
+	Status    FetchStatus
+	Ref       string        // branch ref the commit was resolved from, e.g. refs/heads/kirkstone; empty when pinned
+	URL       string        // URL the repository was fetched from: the git URL or one of its mirrors
+	Attempts  int           // clone and fetch attempts over all URLs
+	Duration  time.Duration // including the wait for other builds using the mirror
+}
+
+// LayerFetch is the outcome of fetching one layer repository, as reported with a build
+type LayerFetch struct {
+	Name     string        `json:"name"`
+	Git      string        `json:"git"`
+	Status   FetchStatus   `json:"status"`
+	URL      string        `json:"url,omitempty"`
+	Ref      string        `json:"ref,omitempty"`
+	Commit   string        `json:"commit,omitempty"`
+	Attempts int           `json:"attempts,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Report returns the outcome of a fetch for reporting
+func (r FetchResult) Report() LayerFetch {
+	fetch := LayerFetch{
+		Name:     r.LayerName,
+		Git:      r.Git,
+		Status:   r.Status,
+		URL:      r.URL,
+		Ref:      r.Ref,
+		Commit:   r.Commit,
+		Attempts: r.Attempts,
+		Duration: r.Duration,
+	}
+	if r.Error != nil {
+		fetch.Error = r.Error.Error()
+	}
+	return fetch
+}
+
+// Summary returns a one-line description, e.g.
+// "poky: cloned refs/heads/kirkstone at 0123456789ab in 12.3s"
+func (l LayerFetch) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", l.Name, l.Status)
+	if l.Ref != "" {
+		b.WriteString(" " + l.Ref)
+	}
+	if l.Commit != "" {
+		fmt.Fprintf(&b, " at %.12s", l.Commit)
+	}
+	if l.URL != "" && l.URL != l.Git {
+		b.WriteString(" from mirror " + l.URL)
+	}
+	fmt.Fprintf(&b, " in %s", l.Duration.Round(100*time.Millisecond))
+	if l.Attempts > 1 {
+		fmt.Fprintf(&b, " (%d attempts)", l.Attempts)
+	}
+	if l.Error != "" {
+		b.WriteString(": " + l.Error)
+	}
+	return b.String()
+}
+
+// FetchError reports the layers that could not be fetched
+type FetchError struct {
+	Failures []FetchResult
+}
+
+func (e *FetchError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", failure.LayerName, failure.Error))
+	}
+	noun := "layers"
+	if len(e.Failures) == 1 {
+		noun = "layer"
+	}
+	return fmt.Sprintf("failed to fetch %d %s: %s", len(e.Failures), noun, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed layers
+func (e *FetchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Error)
+	}
+	return errs
 }
 
 // NewFetcher creates a new Fetcher instance
@@ -39,25 +146,32 @@ func NewFetcher(layersDir string, downloadsDir string, logger *logger.Logger) *F
 	}
 }
 
+// SetCredentials sets the credentials used to clone and fetch repositories
+func (f *Fetcher) SetCredentials(credentials GitCredentials) {
+	f.credentials = credentials
+}
+
 // ----------------------------------------------------------------
 // Public methods
 // ----------------------------------------------------------------
-// FetchLayers fetches all required layers for a configuration
-func (f *Fetcher) FetchLayers(cfg *config.Config) ([]FetchResult, error) {
+// FetchLayers fetches all required layers for a configuration. The results are in config
+// order; if any layer failed, the error is a *FetchError listing them. Cancelling ctx stops
+// the clones and fetches in progress and the waits between retries.
+func (f *Fetcher) FetchLayers(ctx context.Context, cfg *config.Config) ([]FetchResult, error) {
 	if err := os.MkdirAll(f.layersDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create layers directory: %w", err)
 	}
 
 	// Collect unique source URLs and their config.Layer (first occurrence wins)
 	sourceMap := make(map[string]config.Layer)
+	order := make(map[string]int)
 	for _, layer := range cfg.Layers {
 		if layer.Git != "" {
 			if _, exists := sourceMap[layer.Git]; !exists {
 				// If no branch is set, use yocto_series as default branch
-				if layer.Branch == "" && cfg.YoctoSeries != "" {
-					layer.Branch = cfg.YoctoSeries
-				}
+				layer.Branch = cfg.LayerBranch(layer)
 				sourceMap[layer.Git] = layer
+				order[layer.Git] = len(order)
 			}
 		}
 	}
@@ -71,7 +185,7 @@ func (f *Fetcher) FetchLayers(cfg *config.Config) ([]FetchResult, error) {
 		wg.Add(1)
 		go func(l config.Layer) {
 			defer wg.Done()
-			result := f.fetchGitLayerTo(l, f.layersDir)
+			result := f.fetchGitLayerTo(ctx, l, f.layersDir, cfg.Fetch)
 			resultsChan <- result
 		}(layer)
 	}
@@ -85,21 +199,69 @@ func (f *Fetcher) FetchLayers(cfg *config.Config) ([]FetchResult, error) {
 	// Collect results
 	for result := range resultsChan {
 		results = append(results, result)
-		if result.Success {
-			if result.Cached {
-				f.logger.Info("Layer %s already cached at", slog.String("layerName", result.LayerName), slog.String("path", result.Path))
-				_ = writeCacheMeta(filepath.Join(result.Path, ".smidr_meta.json"))
-			} else {
-				f.logger.Info("Successfully fetched layer", slog.String("layerName", result.LayerName), slog.String("path", result.Path))
-				_ = writeCacheMeta(filepath.Join(result.Path, ".smidr_meta.json"))
-			}
-		} else {
-			f.logger.Error("Failed to fetch layer", result.Error, slog.String("layerName", result.LayerName))
+	}
+	slices.SortFunc(results, func(a, b FetchResult) int { return order[a.Git] - order[b.Git] })
+
+	fetchErr := &FetchError{}
+	for _, result := range results {
+		if !result.Success {
+			f.logger.Error("Failed to fetch layer", result.Error, slog.String("layerName", result.LayerName), slog.Int("attempts", result.Attempts))
+			fetchErr.Failures = append(fetchErr.Failures, result)
+			continue
 		}
+		f.logger.Info("Fetched layer",
+			slog.String("layerName", result.LayerName),
+			slog.String("status", string(result.Status)),
+			slog.String("commit", result.Commit),
+			slog.String("path", result.Path),
+			slog.Duration("duration", result.Duration))
+		_ = writeCacheMeta(filepath.Join(result.Path, ".smidr_meta.json"))
+	}
+	if len(fetchErr.Failures) > 0 {
+		return results, fetchErr
 	}
 	return results, nil
 }
 
+// Commits returns the commit each successfully fetched repository is at, keyed by git URL
+func Commits(results []FetchResult) map[string]string {
+	commits := make(map[string]string, len(results))
+	for _, result := range results {
+		if result.Success && result.Commit != "" {
+			commits[result.Git] = result.Commit
+		}
+	}
+	return commits
+}
+
+// ResolveRemoteCommit returns the commit a branch of a remote repository points to.
+// A branch that does not exist falls back to the first "<branch>-" prefixed
+// branch (e.g. kirkstone-6.x.y); an empty branch resolves the remote HEAD.
+func ResolveRemoteCommit(gitURL, branch string) (string, error) {
+	args := []string{"ls-remote", "--heads", gitURL}
+	if branch == "" {
+		args = []string{"ls-remote", gitURL, "HEAD"}
+	}
+	var stdout, stderr strings.Builder
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git ls-remote failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	if branch == "" {
+		if fields := strings.Fields(stdout.String()); len(fields) > 0 {
+			return fields[0], nil
+		}
+		return "", fmt.Errorf("no HEAD in %s", gitURL)
+	}
+	if commit, _, ok := findBranch(stdout.String(), branch); ok {
+		return commit, nil
+	}
+	return "", fmt.Errorf("branch %q not found in %s", branch, gitURL)
+}
+
 // EvictOldCache removes cached repos not accessed within the given TTL
 func (f *Fetcher) EvictOldCache(ttl time.Duration) error {
 	entries, err := os.ReadDir(f.layersDir)
@@ -158,7 +320,7 @@ func (f *Fetcher) GetCacheSize() (int64, error) {
 // Internal methods
 // ---------------------------------------------------------------
 // fetchBaseLayer fetches a standard base layer (poky, meta-openembedded, etc.)
-func (f *Fetcher) fetchBaseLayer(layerName string, cfg *config.Config) FetchResult {
+func (f *Fetcher) fetchBaseLayer(ctx context.Context, layerName string, cfg *config.Config) FetchResult {
 	repo := getBaseLayerRepository(layerName)
 	if repo == "" {
 		return FetchResult{
@@ -177,21 +339,32 @@ func (f *Fetcher) fetchBaseLayer(layerName string, cfg *config.Config) FetchResu
 		Branch: branch,
 	}
 
-	return f.fetchGitLayerTo(layer, f.layersDir)
+	return f.fetchGitLayerTo(ctx, layer, f.layersDir, cfg.Fetch)
 }
 
-// fetchGitLayer clones or updates a git repository
-// fetchGitLayer is now deprecated; use fetchGitLayerTo with layersDir instead.
-
-// fetchGitLayerTo clones or updates a git repository into a specified baseDir
-func (f *Fetcher) fetchGitLayerTo(layer config.Layer, baseDir string) FetchResult {
-	layerPath := filepath.Join(baseDir, layer.Name)
+// fetchGitLayerTo clones or updates the bare mirror of a git repository in baseDir and
+// resolves the commit the layer is built from. The mirror is shared by all builds fetching
+// with the same credentials; each build checks the commit out into its own worktree (see
+// Checkout). Clones and fetches fall back to the layer's mirrors and are retried as the
+// fetch policy says.
+func (f *Fetcher) fetchGitLayerTo(ctx context.Context, layer config.Layer, baseDir string, policy config.FetchConfig) (result FetchResult) {
+	start := time.Now()
+	identity := f.credentials.identity(layerURLs(layer))
+	mirrorPath := filepath.Join(baseDir, mirrorName(layer, identity))
+	result = FetchResult{LayerName: layer.Name, Git: layer.Git, Path: mirrorPath, Status: FetchFailed}
+	defer func() {
+		result.Duration = time.Since(start)
+		if !result.Success {
+			result.Status = FetchFailed
+		}
+	}()
 
 	// Acquire per-repo lock to avoid concurrent clones/updates across processes
-	lockFile := filepath.Join(baseDir, layer.Name+".lock")
-	locked, lockErr := acquireLock(lockFile, 10*time.Second)
+	lockFile := mirrorPath + ".lock"
+	locked, lockErr := acquireLock(ctx, lockFile, mirrorLockTimeout)
 	if lockErr != nil {
-		return FetchResult{LayerName: layer.Name, Path: layerPath, Success: false, Error: fmt.Errorf("failed to acquire lock: %w", lockErr)}
+		result.Error = fmt.Errorf("failed to acquire lock: %w", lockErr)
+		return result
 	}
 	defer func() {
 		if locked {
@@ -199,105 +372,251 @@ func (f *Fetcher) fetchGitLayerTo(layer config.Layer, baseDir string) FetchResul
 		}
 	}()
 
-	// Check if already exists
-	if f.isGitRepository(layerPath) {
-		f.logger.Debug("Layer %s already exists at %s, checking status...", slog.String("name", layer.Name), slog.String("path", layerPath))
-		if err := f.updateGitRepository(layerPath, layer.Branch); err != nil {
-			f.logger.Error("Failed to update layer", err, slog.String("name", layer.Name))
+	if isMirror(mirrorPath) {
+		f.logger.Debug("Layer mirror already exists, checking status...", slog.String("name", layer.Name), slog.String("path", mirrorPath))
+		result.Cached = true
+		result.Status = FetchCached
+		// A pinned commit that is already mirrored needs no network access
+		if layer.Commit == "" || !hasCommit(mirrorPath, layer.Commit) {
+			err := f.withRetries(ctx, layer, policy, &result, func(url string, env []string) error {
+				if url == layer.Git {
+					_, err := remoteGit(ctx, mirrorPath, env, "fetch", "origin")
+					return err
+				}
+				_, err := remoteGit(ctx, mirrorPath, env, "fetch", "--", url, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+				return err
+			})
+			switch {
+			case err == nil:
+				result.Status = FetchUpdated
+			case ctx.Err() != nil || layer.Commit != "" || identity != "":
+				// A private repository is only served while the credential still grants access
+				result.Error = err
+				return result
+			default:
+				// Unpinned public layers fall back to the branch as last mirrored
+				f.logger.Error("Failed to update layer mirror", err, slog.String("name", layer.Name))
+				result.Status = FetchStale
+			}
 		}
-		return FetchResult{LayerName: layer.Name, Path: layerPath, Success: true, Cached: true}
+	} else {
+		f.logger.Info("Cloning layer mirror", slog.String("name", layer.Name), slog.String("url", strings.TrimSuffix(layer.Git, ".git")), slog.String("path", mirrorPath))
+		err := f.withRetries(ctx, layer, policy, &result, func(url string, env []string) error {
+			_ = os.RemoveAll(mirrorPath) // leftover of an interrupted or failed clone
+			if _, err := remoteGit(ctx, "", env, "clone", "--mirror", "--", url, mirrorPath); err != nil {
+				return err
+			}
+			if url != layer.Git {
+				// Updates try the git URL first again
+				return runGit(mirrorPath, "remote", "set-url", "origin", layer.Git)
+			}
+			return nil
+		})
+		if err != nil {
+			_ = os.RemoveAll(mirrorPath)
+			result.Error = err
+			return result
+		}
+		result.Status = FetchCloned
 	}
 
-	// Clone the repository
-	branch := layer.Branch // Restore original branch handling
-
-	cleanURL := strings.TrimSuffix(layer.Git, ".git")
-	f.logger.Info("Cloning layer", slog.String("name", layer.Name), slog.String("branchURL", cleanURL), slog.String("branch", branch), slog.String("path", baseDir))
-	useShallow := !strings.Contains(layer.Git, "git.toradex.com")
-
-	var cmd *exec.Cmd
-	var stderr strings.Builder
-	tryBranch := branch // Restore original branch handling
-	cloneSucceeded := false
-	cloneErr := error(nil)
-	// Try initial branch
-	if useShallow {
-		cmd = exec.Command("git", "clone", "--branch", tryBranch, "--depth", "1", layer.Git, layerPath)
+	if layer.Commit != "" {
+		if !hasCommit(mirrorPath, layer.Commit) {
+			// Not reachable from any branch (e.g. force-pushed away); servers may still serve it by SHA
+			err := f.withRetries(ctx, layer, policy, &result, func(url string, env []string) error {
+				_, err := remoteGit(ctx, mirrorPath, env, "fetch", "--", url, layer.Commit+":refs/smidr/pinned/"+layer.Commit)
+				return err
+			})
+			if err != nil {
+				result.Error = fmt.Errorf("commit %s not found: %w", layer.Commit, err)
+				return result
+			}
+		}
+		commit, err := gitOutput(mirrorPath, "rev-parse", layer.Commit+"^{commit}")
+		if err != nil {
+			result.Error = fmt.Errorf("commit %s not found: %w", layer.Commit, err)
+			return result
+		}
+		result.Commit = commit
 	} else {
-		cmd = exec.Command("git", "clone", "--branch", tryBranch, layer.Git, layerPath)
+		commit, ref, err := resolveMirrorBranch(mirrorPath, layer.Branch)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		result.Commit, result.Ref = commit, ref
 	}
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err == nil {
-		cloneSucceeded = true
-	} else {
-		// If failed, try to find a branch with the correct prefix
-		errorMsg := stderr.String()
-		if errorMsg == "" {
-			errorMsg = err.Error()
+	result.Success = true
+	return result
+}
+
+// withRetries runs op against the layer's git URL and then each of its mirrors until one
+// succeeds, for up to 1+retries rounds with a growing backoff in between. The URL that
+// worked is recorded in result. Once ctx is done no further attempt is made.
+func (f *Fetcher) withRetries(ctx context.Context, layer config.Layer, policy config.FetchConfig, result *FetchResult, op func(url string, env []string) error) error {
+	urls := layerURLs(layer)
+	var lastErr error
+	for round := 0; round <= policy.Retries(); round++ {
+		if round > 0 {
+			backoff := policy.RetryBackoff(round)
+			f.logger.Warn("Retrying layer fetch", slog.String("name", layer.Name), slog.Int("retry", round), slog.Duration("backoff", backoff))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
 		}
-		f.logger.Debug("Git clone failed for %s: %s", slog.String("name", layer.Name), slog.String("error", errorMsg))
-		// Always try prefix match if the branch matches yocto_series (even if set by default logic)
-		// (Assume yocto_series is used as default branch if not set by user)
-		// Use the value of 'branch' as the prefix for matching
-		if branch != "master" && branch != "main" && branch != "" {
-			// List remote branches
-			lsRemoteCmd := exec.Command("git", "ls-remote", "--heads", layer.Git)
-			var lsOut strings.Builder
-			lsRemoteCmd.Stdout = &lsOut
-			_ = lsRemoteCmd.Run()
-			lines := strings.Split(lsOut.String(), "\n")
-			var bestMatch string
-			for _, line := range lines {
-				parts := strings.Fields(line)
-				if len(parts) == 2 {
-					ref := parts[1]
-					if strings.HasPrefix(ref, "refs/heads/"+branch+"-") {
-						bestMatch = strings.TrimPrefix(ref, "refs/heads/")
-						break // take first match
-					}
-				}
+		for _, url := range urls {
+			if err := ctx.Err(); err != nil {
+				return err
 			}
-			if bestMatch != "" {
-				f.logger.Info("Retrying clone for %s with branch %s (prefix match)", slog.String("name", layer.Name), slog.String("branch", bestMatch))
-				if useShallow {
-					cmd = exec.Command("git", "clone", "--branch", bestMatch, "--depth", "1", layer.Git, layerPath)
-				} else {
-					cmd = exec.Command("git", "clone", "--branch", bestMatch, layer.Git, layerPath)
-				}
-				stderr.Reset()
-				cmd.Stderr = &stderr
-				if err := cmd.Run(); err == nil {
-					cloneSucceeded = true
-				} else {
-					errorMsg = stderr.String()
-					if errorMsg == "" {
-						errorMsg = err.Error()
-					}
-					cloneErr = fmt.Errorf("git clone failed (prefix match): %s", errorMsg)
-				}
-			} else {
-				cloneErr = fmt.Errorf("git clone failed: %s", errorMsg)
+			env, err := f.credentials.gitEnv(url)
+			if err != nil {
+				// A misconfigured credential does not heal by retrying
+				return err
+			}
+			result.Attempts++
+			if lastErr = op(url, env); lastErr == nil {
+				result.URL = url
+				return nil
 			}
-		} else {
-			cloneErr = fmt.Errorf("git clone failed: %s", errorMsg)
+			f.logger.Warn("Layer fetch attempt failed", slog.String("name", layer.Name), slog.String("url", url), slog.String("error", lastErr.Error()))
+		}
+	}
+	if result.Attempts > 1 {
+		return fmt.Errorf("%d attempts failed, last: %w", result.Attempts, lastErr)
+	}
+	return lastErr
+}
+
+// layerURLs returns the URLs a layer's repository can be fetched from, in order: its git
+// URL, its configured mirrors and, for well-known layers, alternative forms of their URL
+func layerURLs(layer config.Layer) []string {
+	candidates := slices.Clone(layer.Mirrors)
+	if sameRepository(layer.Git, getBaseLayerRepository(layer.Name)) {
+		candidates = append(candidates, getAlternativeRepository(layer.Name)...)
+	}
+	urls := []string{layer.Git}
+	for _, url := range candidates {
+		if !slices.Contains(urls, url) {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// sameRepository reports whether two URLs name the same repository, ignoring a trailing
+// slash or .git
+func sameRepository(a, b string) bool {
+	normalize := func(url string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	}
+	return a != "" && b != "" && normalize(a) == normalize(b)
+}
+
+// mirrorName returns the directory name of a repository's mirror. The URL hash keeps forks
+// that use the same layer name apart; the credential identity, if any, keeps the mirrors
+// fetched with different credentials apart.
+func mirrorName(layer config.Layer, identity string) string {
+	key := layer.Git
+	if identity != "" {
+		key += "\n" + identity
+	}
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%s-%s.git", layer.Name, hex.EncodeToString(sum[:])[:8])
+}
+
+// isMirror checks if a directory is a bare repository
+func isMirror(path string) bool {
+	out, err := gitOutput(path, "rev-parse", "--is-bare-repository")
+	return err == nil && out == "true"
+}
+
+// hasCommit reports whether a repository contains a commit
+func hasCommit(gitDir, commit string) bool {
+	return exec.Command("git", "--git-dir", gitDir, "cat-file", "-e", commit+"^{commit}").Run() == nil
+}
+
+// resolveMirrorBranch returns the commit a branch of a mirror points to, and the ref it was
+// found as. Like ResolveRemoteCommit, a missing branch falls back to the first "<branch>-"
+// prefixed branch and an empty branch resolves HEAD.
+func resolveMirrorBranch(mirrorPath, branch string) (string, string, error) {
+	if branch == "" {
+		commit, err := gitOutput(mirrorPath, "rev-parse", "HEAD")
+		if err != nil {
+			return "", "", fmt.Errorf("failed to resolve HEAD: %w", err)
 		}
+		return commit, "HEAD", nil
+	}
+	refs, err := gitOutput(mirrorPath, "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads/")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list branches: %w", err)
 	}
+	if commit, ref, ok := findBranch(refs, branch); ok {
+		return commit, ref, nil
+	}
+	return "", "", fmt.Errorf("branch %q not found", branch)
+}
 
-	if !cloneSucceeded {
-		return FetchResult{LayerName: layer.Name, Path: layerPath, Success: false, Error: cloneErr}
+// findBranch looks a branch up in a "<commit> <ref>" listing as printed by git ls-remote
+// and for-each-ref, falling back to the first branch named "<branch>-..." (e.g. kirkstone-6.x.y)
+func findBranch(listing, branch string) (string, string, bool) {
+	ref := "refs/heads/" + branch
+	var prefixCommit, prefixRef string
+	for _, line := range strings.Split(listing, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			continue
+		}
+		if parts[1] == ref {
+			return parts[0], ref, true
+		}
+		if prefixRef == "" && strings.HasPrefix(parts[1], ref+"-") {
+			prefixCommit, prefixRef = parts[0], parts[1]
+		}
 	}
-	return FetchResult{LayerName: layer.Name, Path: layerPath, Success: true, Cached: false}
+	return prefixCommit, prefixRef, prefixRef != ""
 }
 
-// isGitRepository checks if a directory is a git repository
-func (f *Fetcher) isGitRepository(path string) bool {
-	gitDir := filepath.Join(path, ".git")
-	info, err := os.Stat(gitDir)
-	return err == nil && info.IsDir()
+// runGit runs a git command against a bare repository
+func runGit(gitDir string, args ...string) error {
+	_, err := gitOutput(gitDir, args...)
+	return err
 }
 
-// acquireLock attempts to create a lockfile atomically. It will retry until timeout.
-func acquireLock(path string, timeout time.Duration) (bool, error) {
+// gitOutput runs a git command against a repository and returns its trimmed output
+func gitOutput(gitDir string, args ...string) (string, error) {
+	return remoteGit(context.Background(), gitDir, nil, args...)
+}
+
+// remoteGit runs a git command with env added to the daemon's environment, for commands
+// that talk to a remote (see GitCredentials.gitEnv). An empty gitDir runs it outside of
+// a repository; cancelling ctx kills it.
+func remoteGit(ctx context.Context, gitDir string, env []string, args ...string) (string, error) {
+	var stdout, stderr strings.Builder
+	command := args[0]
+	if gitDir != "" {
+		args = append([]string{"--git-dir", gitDir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s failed: %s", command, msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// acquireLock attempts to create a lockfile atomically. It will retry until timeout or
+// until ctx is done.
+func acquireLock(ctx context.Context, path string, timeout time.Duration) (bool, error) {
 	// Ensure parent directory exists so OpenFile with O_CREATE doesn't fail with ENOENT
 	if dir := filepath.Dir(path); dir != "" {
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -323,7 +642,11 @@ func acquireLock(path string, timeout time.Duration) (bool, error) {
 			return false, fmt.Errorf("timed out waiting for lock %s", path)
 		}
 
-		time.Sleep(100 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
 	}
 }
 
@@ -332,49 +655,6 @@ func releaseLock(path string) error {
 	return os.Remove(path)
 }
 
-// updateGitRepository updates an existing git repository
-func (f *Fetcher) updateGitRepository(path string, branch string) error {
-	// Fetch latest changes
-	fetchCmd := exec.Command("git", "-C", path, "fetch", "origin")
-	if err := fetchCmd.Run(); err != nil {
-		return fmt.Errorf("git fetch failed: %w", err)
-	}
-
-	// Checkout the desired branch if specified
-	if branch != "" { // Restore original branch handling
-		checkoutCmd := exec.Command("git", "-C", path, "checkout", branch)
-		if err := checkoutCmd.Run(); err != nil {
-			return fmt.Errorf("git checkout failed: %w", err)
-		}
-	}
-
-	// Pull latest changes
-	pullCmd := exec.Command("git", "-C", path, "pull", "--ff-only")
-	if err := pullCmd.Run(); err != nil {
-		return fmt.Errorf("git pull failed: %w", err)
-	}
-
-	return nil
-}
-
-func (f *Fetcher) fetchLayer(layerName string, cfg *config.Config) FetchResult {
-	repo := getBaseLayerRepository(layerName)
-	if repo == "" {
-		return FetchResult{
-			LayerName: layerName,
-			Success:   false,
-			Error:     fmt.Errorf("unknown base layer:  %s", layerName),
-		}
-	}
-	return FetchResult{
-		LayerName: layerName,
-	}
-}
-
-func (f *Fetcher) getBaseLayerRepo(layerName string) string {
-	return ""
-}
-
 // ---------------------------------------------------------------
 // Helper functions for base layers
 // ---------------------------------------------------------------
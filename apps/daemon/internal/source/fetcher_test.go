@@ -1,7 +1,9 @@
 package source
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,10 +20,11 @@ func TestFetcher_fetchBaseLayer(t *testing.T) {
 	tmpDir := t.TempDir()
 	logger := logger.NewLogger()
 	fetcher := NewFetcher(tmpDir, tmpDir, logger)
-	cfg := &config.Config{Base: config.BaseConfig{Version: "6.0.0"}}
+	noRetries := 0
+	cfg := &config.Config{Base: config.BaseConfig{Version: "6.0.0"}, Fetch: config.FetchConfig{MaxRetries: &noRetries}}
 
 	t.Run("unknown layer returns error", func(t *testing.T) {
-		result := fetcher.fetchBaseLayer("not-a-real-layer", cfg)
+		result := fetcher.fetchBaseLayer(context.Background(), "not-a-real-layer", cfg)
 		if result.Success {
 			t.Errorf("Expected failure for unknown layer, got success")
 		}
@@ -31,7 +34,7 @@ func TestFetcher_fetchBaseLayer(t *testing.T) {
 	})
 
 	t.Run("known layer returns result", func(t *testing.T) {
-		result := fetcher.fetchBaseLayer("poky", cfg)
+		result := fetcher.fetchBaseLayer(context.Background(), "poky", cfg)
 		if result.LayerName != "poky" {
 			t.Errorf("Expected LayerName 'poky', got %q", result.LayerName)
 		}
@@ -42,33 +45,6 @@ func TestFetcher_fetchBaseLayer(t *testing.T) {
 	})
 }
 
-func TestFetcher_fetchLayer(t *testing.T) {
-	tmpDir := t.TempDir()
-	logger := logger.NewLogger()
-	fetcher := NewFetcher(tmpDir, tmpDir, logger)
-	cfg := &config.Config{}
-
-	t.Run("unknown layer returns error", func(t *testing.T) {
-		result := fetcher.fetchLayer("not-a-real-layer", cfg)
-		if result.Success {
-			t.Errorf("Expected failure for unknown layer, got success")
-		}
-		if result.Error == nil {
-			t.Errorf("Expected error for unknown layer, got nil")
-		}
-	})
-
-	t.Run("known layer returns valid result", func(t *testing.T) {
-		result := fetcher.fetchLayer("poky", cfg)
-		if result.LayerName != "poky" {
-			t.Errorf("Expected LayerName 'poky', got %q", result.LayerName)
-		}
-		if result.Error != nil {
-			t.Errorf("Unexpected error for known layer: %v", result.Error)
-		}
-	})
-}
-
 func TestNewFetcher(t *testing.T) {
 	logger := logger.NewLogger()
 
@@ -86,36 +62,6 @@ func TestNewFetcher(t *testing.T) {
 	}
 }
 
-func TestIsGitRepository(t *testing.T) {
-	logger := logger.NewLogger()
-	tmpDir := t.TempDir()
-	fetcher := NewFetcher(tmpDir, tmpDir, logger)
-
-	t.Run("directory with .git", func(t *testing.T) {
-		gitDir := filepath.Join(tmpDir, "repo-with-git")
-		os.MkdirAll(filepath.Join(gitDir, ".git"), 0755)
-
-		if !fetcher.isGitRepository(gitDir) {
-			t.Error("Should detect .git directory")
-		}
-	})
-
-	t.Run("directory without .git", func(t *testing.T) {
-		noGitDir := filepath.Join(tmpDir, "repo-without-git")
-		os.MkdirAll(noGitDir, 0755)
-
-		if fetcher.isGitRepository(noGitDir) {
-			t.Error("Should not detect git in directory without .git")
-		}
-	})
-
-	t.Run("non-existent directory", func(t *testing.T) {
-		if fetcher.isGitRepository("/nonexistent/path") {
-			t.Error("Should return false for non-existent directory")
-		}
-	})
-}
-
 func TestGetRequiredBaseLayers(t *testing.T) {
 	tests := []struct {
 		provider      string
@@ -330,7 +276,7 @@ func TestFetchGitLayer_MockGit(t *testing.T) {
 		cfg := &config.Config{
 			Layers: []config.Layer{layer},
 		}
-		results, err := fetcher.FetchLayers(cfg)
+		results, err := fetcher.FetchLayers(context.Background(), cfg)
 		if err != nil {
 			t.Errorf("FetchLayers failed: %v", err)
 		}
@@ -347,6 +293,355 @@ func TestFetchGitLayer_MockGit(t *testing.T) {
 	})
 }
 
+// commitTestRepo creates a repository on branch main with two commits and returns
+// its path and the commits, oldest first
+func commitTestRepo(t *testing.T) (string, []string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available, skipping test")
+	}
+	repo := filepath.Join(t.TempDir(), "source-repo")
+	git := func(args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Skipf("git %v failed: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	if err := exec.Command("git", "init", "-b", "main", repo).Run(); err != nil {
+		t.Skipf("Failed to init git repo: %v", err)
+	}
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test User")
+
+	var commits []string
+	for _, content := range []string{"first", "second"} {
+		if err := os.WriteFile(filepath.Join(repo, "test.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "test.txt")
+		git("commit", "-m", content)
+		commits = append(commits, git("rev-parse", "HEAD"))
+	}
+	return repo, commits
+}
+
+func TestFetchLayers_PinnedCommit(t *testing.T) {
+	repo, commits := commitTestRepo(t)
+	sourcesDir := filepath.Join(t.TempDir(), "sources")
+	fetcher := NewFetcher(sourcesDir, sourcesDir, logger.NewLogger())
+
+	// Unpinned: the branch head is checked out and reported
+	cfg := &config.Config{Layers: []config.Layer{{Name: "test-layer", Git: repo, Branch: "main"}}}
+	results, err := fetcher.FetchLayers(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("FetchLayers failed: %v", err)
+	}
+	if got := Commits(results)[repo]; got != commits[1] {
+		t.Fatalf("expected head commit %s, got %s", commits[1], got)
+	}
+
+	// Pinned: the locked commit is used instead of the branch head
+	cfg.Layers[0].Commit = commits[0]
+	results, err = fetcher.FetchLayers(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("FetchLayers failed: %v", err)
+	}
+	if got := Commits(results)[repo]; got != commits[0] {
+		t.Errorf("expected pinned commit %s, got %s", commits[0], got)
+	}
+	ws, err := fetcher.Checkout(results, filepath.Join(t.TempDir(), "layers"))
+	if err != nil {
+		t.Fatalf("Checkout failed: %v", err)
+	}
+	defer ws.Release()
+	content, _ := os.ReadFile(filepath.Join(ws.Dir, "test-layer", "test.txt"))
+	if string(content) != "first" {
+		t.Errorf("expected the pinned tree to be checked out, got %q", content)
+	}
+
+	// A commit the repository does not have fails the fetch
+	noRetries := 0
+	cfg.Fetch.MaxRetries = &noRetries
+	cfg.Layers[0].Commit = "0123456789abcdef0123456789abcdef01234567"
+	if _, err := fetcher.FetchLayers(context.Background(), cfg); err == nil {
+		t.Error("expected an unknown pinned commit to fail")
+	}
+}
+
+func TestFetchLayers_FileURL(t *testing.T) {
+	repo, commits := commitTestRepo(t)
+	sourcesDir := filepath.Join(t.TempDir(), "sources")
+	fetcher := NewFetcher(sourcesDir, sourcesDir, logger.NewLogger())
+	fetcher.SetCredentials(GitCredentials{{URL: "git@github.com:acme/", SSHAuthSock: "/nonexistent.sock"}})
+
+	git := "file://" + repo
+	cfg := &config.Config{Layers: []config.Layer{{Name: "test-layer", Git: git, Branch: "main"}}}
+	if err := cfg.Layers[0].Validate(); err != nil {
+		t.Fatalf("expected a file URL to be a valid layer: %v", err)
+	}
+	results, err := fetcher.FetchLayers(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("FetchLayers failed: %v", err)
+	}
+	if got := Commits(results)[git]; got != commits[1] {
+		t.Errorf("expected head commit %s, got %s", commits[1], got)
+	}
+}
+
+func TestFetchLayers_CredentialMirrors(t *testing.T) {
+	repo, commits := commitTestRepo(t)
+	sourcesDir := filepath.Join(t.TempDir(), "sources")
+	git := "file://" + repo
+	noRetries := 0
+	cfg := &config.Config{
+		Layers: []config.Layer{{Name: "private-layer", Git: git, Branch: "main"}},
+		Fetch:  config.FetchConfig{MaxRetries: &noRetries},
+	}
+
+	fetch := func(credentials GitCredentials) FetchResult {
+		t.Helper()
+		fetcher := NewFetcher(sourcesDir, sourcesDir, logger.NewLogger())
+		fetcher.SetCredentials(credentials)
+		results, _ := fetcher.FetchLayers(context.Background(), cfg)
+		if len(results) != 1 {
+			t.Fatalf("expected one result, got %+v", results)
+		}
+		return results[0]
+	}
+	acme := GitCredentials{{URL: "file://", Customer: "acme", SSHAuthSock: "/run/smidr/acme.sock"}}
+	globex := GitCredentials{{URL: "file://", Customer: "globex", SSHAuthSock: "/run/smidr/globex.sock"}}
+
+	// Each credential gets its own mirror, apart from the one fetched without credentials
+	paths := make(map[string]bool)
+	for _, credentials := range []GitCredentials{acme, globex, nil} {
+		result := fetch(credentials)
+		if result.Status != FetchCloned || result.Commit != commits[1] {
+			t.Fatalf("expected a fresh clone, got %+v", result)
+		}
+		paths[result.Path] = true
+	}
+	if len(paths) != 3 {
+		t.Errorf("expected three mirrors, got %v", paths)
+	}
+	if again := fetch(acme); again.Status != FetchUpdated {
+		t.Errorf("expected acme's mirror to be reused, got %+v", again)
+	}
+
+	// Once the repository is unreachable, only the public mirror may be used as it is
+	if err := os.Rename(repo, repo+".gone"); err != nil {
+		t.Fatal(err)
+	}
+	if result := fetch(acme); result.Success {
+		t.Errorf("expected a failed update with credentials not to fall back to the mirror, got %+v", result)
+	}
+	if result := fetch(nil); !result.Success || result.Status != FetchStale {
+		t.Errorf("expected the public mirror to be used as last mirrored, got %+v", result)
+	}
+}
+
+func TestFetchLayers_MirrorFallback(t *testing.T) {
+	repo, commits := commitTestRepo(t)
+	sourcesDir := filepath.Join(t.TempDir(), "sources")
+	fetcher := NewFetcher(sourcesDir, sourcesDir, logger.NewLogger())
+
+	primary := "file://" + filepath.Join(t.TempDir(), "unreachable.git")
+	retries := 1
+	cfg := &config.Config{
+		Layers: []config.Layer{{Name: "test-layer", Git: primary, Branch: "main", Mirrors: []string{repo}}},
+		Fetch:  config.FetchConfig{MaxRetries: &retries, Backoff: time.Millisecond},
+	}
+	results, err := fetcher.FetchLayers(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("FetchLayers failed: %v", err)
+	}
+	result := results[0]
+	if result.Status != FetchCloned || result.URL != repo || result.Attempts != 2 || result.Commit != commits[1] {
+		t.Errorf("expected a clone from the mirror after one failed attempt, got %+v", result)
+	}
+	if result.Ref != "refs/heads/main" || result.Duration <= 0 {
+		t.Errorf("expected the resolved ref and duration to be reported, got %+v", result)
+	}
+	// The mirror keeps the git URL as origin, so updates try it first
+	if origin, _ := gitOutput(result.Path, "remote", "get-url", "origin"); origin != primary {
+		t.Errorf("expected origin %s, got %s", primary, origin)
+	}
+
+	results, err = fetcher.FetchLayers(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("FetchLayers failed: %v", err)
+	}
+	if results[0].Status != FetchUpdated || results[0].URL != repo || !results[0].Cached {
+		t.Errorf("expected the mirror to be updated from the mirror URL, got %+v", results[0])
+	}
+}
+
+func TestFetchLayers_FetchError(t *testing.T) {
+	repo, _ := commitTestRepo(t)
+	sourcesDir := filepath.Join(t.TempDir(), "sources")
+	fetcher := NewFetcher(sourcesDir, sourcesDir, logger.NewLogger())
+
+	missing := filepath.Join(t.TempDir(), "missing.git")
+	retries := 2
+	cfg := &config.Config{
+		Layers: []config.Layer{
+			{Name: "broken", Git: missing, Branch: "main"},
+			{Name: "good", Git: repo, Branch: "main"},
+		},
+		Fetch: config.FetchConfig{MaxRetries: &retries, Backoff: time.Millisecond},
+	}
+	results, err := fetcher.FetchLayers(context.Background(), cfg)
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) {
+		t.Fatalf("expected a *FetchError, got %v", err)
+	}
+	if len(fetchErr.Failures) != 1 || fetchErr.Failures[0].LayerName != "broken" {
+		t.Fatalf("expected only the broken layer to fail, got %+v", fetchErr.Failures)
+	}
+	if !strings.Contains(err.Error(), "failed to fetch 1 layer: broken: 3 attempts failed") {
+		t.Errorf("unexpected error message %q", err)
+	}
+	if len(results) != 2 || results[0].LayerName != "broken" || results[1].LayerName != "good" {
+		t.Fatalf("expected results in config order, got %+v", results)
+	}
+	if results[0].Status != FetchFailed || results[0].Attempts != 3 || !results[1].Success {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestFetchLayers_Cancelled(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available, skipping test")
+	}
+	sourcesDir := filepath.Join(t.TempDir(), "sources")
+	fetcher := NewFetcher(sourcesDir, sourcesDir, logger.NewLogger())
+
+	missing := filepath.Join(t.TempDir(), "missing.git")
+	retries := 5
+	cfg := &config.Config{
+		Layers: []config.Layer{{Name: "broken", Git: missing, Branch: "main"}},
+		Fetch:  config.FetchConfig{MaxRetries: &retries, Backoff: time.Hour},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	// Cancelling ends the wait for the next retry instead of sleeping through the backoff
+	start := time.Now()
+	results, err := fetcher.FetchLayers(ctx, cfg)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the fetch to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the fetch to stop promptly, took %s", elapsed)
+	}
+	if len(results) != 1 || results[0].Attempts != 1 {
+		t.Errorf("expected no attempt after cancellation, got %+v", results)
+	}
+}
+
+func TestLayerURLs(t *testing.T) {
+	mirror := "https://mirror.example.com/meta-toradex-nxp.git"
+	layer := config.Layer{Name: "meta-toradex-nxp", Git: "https://git.toradex.com/meta-toradex-nxp.git", Mirrors: []string{mirror}}
+	urls := layerURLs(layer)
+	if len(urls) != 4 || urls[0] != layer.Git || urls[1] != mirror {
+		t.Errorf("expected the git URL, the mirror and the known alternatives, got %v", urls)
+	}
+
+	// A fork named like a well-known layer never falls back to upstream
+	fork := config.Layer{Name: "meta-toradex-nxp", Git: "https://github.com/acme/meta-toradex-nxp.git"}
+	if urls := layerURLs(fork); len(urls) != 1 {
+		t.Errorf("expected only the fork's URL, got %v", urls)
+	}
+}
+
+func TestCheckout_PerBuildWorktrees(t *testing.T) {
+	repo, commits := commitTestRepo(t)
+	// A second branch with its own content, like kirkstone next to scarthgap
+	for _, args := range [][]string{
+		{"checkout", "-q", "-b", "kirkstone", commits[0]},
+		{"commit", "-q", "--allow-empty", "-m", "kirkstone"},
+		{"checkout", "-q", "main"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+	sourcesDir := filepath.Join(t.TempDir(), "sources")
+	fetcher := NewFetcher(sourcesDir, sourcesDir, logger.NewLogger())
+
+	checkout := func(branch, dir string) *Workspace {
+		cfg := &config.Config{Layers: []config.Layer{{Name: "poky", Git: repo, Branch: branch}}}
+		results, err := fetcher.FetchLayers(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("FetchLayers failed: %v", err)
+		}
+		ws, err := fetcher.Checkout(results, dir)
+		if err != nil {
+			t.Fatalf("Checkout failed: %v", err)
+		}
+		return ws
+	}
+	scarthgap := checkout("main", filepath.Join(t.TempDir(), "build-a", "layers"))
+	kirkstone := checkout("kirkstone", filepath.Join(t.TempDir(), "build-b", "layers"))
+
+	// Both builds see their own branch, from one shared bare mirror
+	for ws, want := range map[*Workspace]string{scarthgap: "second", kirkstone: "first"} {
+		path, ok := ws.RepoPath(repo)
+		if !ok {
+			t.Fatalf("expected a checkout of %s in %s", repo, ws.Dir)
+		}
+		if content, _ := os.ReadFile(filepath.Join(path, "test.txt")); string(content) != want {
+			t.Errorf("expected %q in %s, got %q", want, path, content)
+		}
+	}
+	entries, _ := os.ReadDir(sourcesDir)
+	mirrors := 0
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".git") {
+			mirrors++
+		}
+	}
+	if mirrors != 1 {
+		t.Errorf("expected one shared mirror, got %v", entries)
+	}
+
+	// Releasing one build leaves the other alone and unregisters the worktree
+	if err := scarthgap.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, err := os.Stat(scarthgap.Dir); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed, got %v", scarthgap.Dir, err)
+	}
+	path, _ := kirkstone.RepoPath(repo)
+	if _, err := os.Stat(filepath.Join(path, "test.txt")); err != nil {
+		t.Errorf("expected the other checkout to remain: %v", err)
+	}
+	if err := RemoveCheckouts(kirkstone.Dir); err != nil {
+		t.Fatalf("RemoveCheckouts failed: %v", err)
+	}
+	mirror := filepath.Join(sourcesDir, mirrorName(config.Layer{Name: "poky", Git: repo}, ""))
+	if out, _ := gitOutput(mirror, "worktree", "list"); strings.Count(out, "\n") != 0 {
+		t.Errorf("expected only the mirror itself to be listed, got %q", out)
+	}
+}
+
+func TestResolveRemoteCommit(t *testing.T) {
+	repo, commits := commitTestRepo(t)
+
+	for _, branch := range []string{"main", ""} {
+		got, err := ResolveRemoteCommit(repo, branch)
+		if err != nil {
+			t.Fatalf("ResolveRemoteCommit(%q) failed: %v", branch, err)
+		}
+		if got != commits[1] {
+			t.Errorf("ResolveRemoteCommit(%q) = %s, want %s", branch, got, commits[1])
+		}
+	}
+	if _, err := ResolveRemoteCommit(repo, "kirkstone"); err == nil {
+		t.Error("expected an unknown branch to fail")
+	}
+}
+
 func TestCleanCache(t *testing.T) {
 	tmpDir := t.TempDir()
 	sourcesDir := filepath.Join(tmpDir, "sources")
@@ -422,7 +717,7 @@ func TestFetchLayers_EmptyConfig(t *testing.T) {
 	}
 
 	// With the current implementation, empty layers means no fetch operations
-	results, err := fetcher.FetchLayers(cfg)
+	results, err := fetcher.FetchLayers(context.Background(), cfg)
 
 	if err != nil {
 		t.Errorf("FetchLayers should not fail with empty config: %v", err)
@@ -498,7 +793,7 @@ func TestPerRepoLocking(t *testing.T) {
 	// Acquire lock in background (simulate another process)
 	acquired := make(chan struct{})
 	go func() {
-		ok, err := acquireLock(lockFile, 2*time.Second)
+		ok, err := acquireLock(context.Background(), lockFile, 2*time.Second)
 		if err != nil || !ok {
 			t.Logf("background acquire failed: %v", err)
 			close(acquired)
@@ -516,7 +811,7 @@ func TestPerRepoLocking(t *testing.T) {
 
 	// Now attempt to acquire lock with a short timeout; should either wait and then succeed after release
 	start := time.Now()
-	ok, err := acquireLock(lockFile, 3*time.Second)
+	ok, err := acquireLock(context.Background(), lockFile, 3*time.Second)
 	elapsed := time.Since(start)
 	if err != nil {
 		t.Fatalf("failed to acquire lock: %v", err)
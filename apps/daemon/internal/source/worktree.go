@@ -0,0 +1,136 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Workspace holds the layer checkouts of one build: a detached worktree of each fetched
+// repository at its resolved commit, so concurrent builds of different branches or commits
+// never share a checkout. Release it when the build is done.
+type Workspace struct {
+	Dir   string            // parent of the checkouts, one directory per repository
+	repos map[string]string // git URL -> checkout path
+}
+
+// Checkout materializes a worktree for every successful fetch result in dir/<layer name>
+func (f *Fetcher) Checkout(results []FetchResult, dir string) (*Workspace, error) {
+	ws := &Workspace{Dir: dir, repos: make(map[string]string)}
+	// Worktrees left behind by a build that did not release them (e.g. after a crash)
+	if err := RemoveCheckouts(dir); err != nil {
+		return nil, fmt.Errorf("failed to remove stale layer checkouts: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create layer checkout directory: %w", err)
+	}
+
+	for _, result := range results {
+		if !result.Success || result.Commit == "" {
+			continue
+		}
+		path := filepath.Join(dir, result.LayerName)
+		if err := addWorktree(result.Path, path, result.Commit); err != nil {
+			_ = ws.Release()
+			return nil, fmt.Errorf("failed to check out layer %s: %w", result.LayerName, err)
+		}
+		f.logger.Debug("Checked out layer", slog.String("name", result.LayerName), slog.String("commit", result.Commit), slog.String("path", path))
+		ws.repos[result.Git] = path
+	}
+	return ws, nil
+}
+
+// RepoPath returns the checkout of a repository
+func (w *Workspace) RepoPath(git string) (string, bool) {
+	path, ok := w.repos[git]
+	return path, ok
+}
+
+// Path returns the checkout named after a layer, i.e. the first layer of its repository
+func (w *Workspace) Path(name string) (string, bool) {
+	path := filepath.Join(w.Dir, name)
+	for _, p := range w.repos {
+		if p == path {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// Release removes the checkouts of the workspace
+func (w *Workspace) Release() error {
+	return RemoveCheckouts(w.Dir)
+}
+
+// RemoveCheckouts removes the layer worktrees in dir and unregisters them from their
+// mirrors, then dir itself. A missing dir is not an error.
+func RemoveCheckouts(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if mirror, ok := worktreeMirror(path); ok {
+			if err := removeWorktree(mirror, path); err == nil {
+				continue
+			}
+		}
+		// Not a worktree, or its mirror is gone: the mirror prunes it on its next checkout
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return os.Remove(dir)
+}
+
+// addWorktree checks commit out of a mirror into path as a detached worktree
+func addWorktree(mirror, path, commit string) error {
+	lockFile := mirror + ".lock"
+	if _, err := acquireLock(context.Background(), lockFile, mirrorLockTimeout); err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer func() { _ = releaseLock(lockFile) }()
+
+	// Forget worktrees whose directory was deleted with their build workspace
+	_ = runGit(mirror, "worktree", "prune")
+	return runGit(mirror, "worktree", "add", "--detach", path, commit)
+}
+
+// removeWorktree removes a worktree and its registration in the mirror
+func removeWorktree(mirror, path string) error {
+	lockFile := mirror + ".lock"
+	if _, err := acquireLock(context.Background(), lockFile, mirrorLockTimeout); err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer func() { _ = releaseLock(lockFile) }()
+
+	return runGit(mirror, "worktree", "remove", "--force", path)
+}
+
+// worktreeMirror returns the repository a worktree belongs to. A worktree's .git file
+// points to <mirror>/worktrees/<name>.
+func worktreeMirror(path string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(path, ".git"))
+	if err != nil {
+		return "", false
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok || filepath.Base(filepath.Dir(gitDir)) != "worktrees" {
+		return "", false
+	}
+	mirror := filepath.Dir(filepath.Dir(gitDir))
+	return mirror, isMirror(mirror)
+}
@@ -166,8 +166,12 @@ func (x *GetArtifactRequest) GetArtifactId() string {
 
 // DownloadArtifactRequest is used to request the download of an artifact.
 type DownloadArtifactRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ArtifactId    string                 `protobuf:"bytes,1,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ArtifactId string                 `protobuf:"bytes,1,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	// Byte offset to start streaming from, used to resume partial downloads.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Preferred chunk size in bytes (0 = server default).
+	ChunkSize     int32 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,10 +213,35 @@ func (x *DownloadArtifactRequest) GetArtifactId() string {
 	return ""
 }
 
-// DownloadArtifactResponse provides the download URL for a requested artifact.
+func (x *DownloadArtifactRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadArtifactRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+// DownloadArtifactResponse carries one chunk of artifact content.
+// The final chunk has last set and carries the SHA-256 of the whole file.
 type DownloadArtifactResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DownloadUrl   string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	DownloadUrl string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	// Raw artifact bytes for this chunk.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Byte offset of data within the artifact.
+	Offset int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Total size of the artifact in bytes.
+	TotalSize int64 `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// Hex-encoded SHA-256 of the complete artifact (set on the last chunk).
+	Checksum string `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// True on the final chunk of the stream.
+	Last          bool `protobuf:"varint,6,opt,name=last,proto3" json:"last,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -254,6 +283,41 @@ func (x *DownloadArtifactResponse) GetDownloadUrl() string {
 	return ""
 }
 
+func (x *DownloadArtifactResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadArtifactResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DownloadArtifactResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *DownloadArtifactResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *DownloadArtifactResponse) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
 // DeleteArtifactRequest is used to request deletion of a specific artifact.
 type DeleteArtifactRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -441,12 +505,21 @@ const file_artifacts_proto_rawDesc = "" +
 	"\x10build_identifier\x18\x02 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\"5\n" +
 	"\x12GetArtifactRequest\x12\x1f\n" +
 	"\vartifact_id\x18\x01 \x01(\tR\n" +
-	"artifactId\":\n" +
+	"artifactId\"q\n" +
 	"\x17DownloadArtifactRequest\x12\x1f\n" +
 	"\vartifact_id\x18\x01 \x01(\tR\n" +
-	"artifactId\"=\n" +
+	"artifactId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x03 \x01(\x05R\tchunkSize\"\xb8\x01\n" +
 	"\x18DownloadArtifactResponse\x12!\n" +
-	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\"8\n" +
+	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x04 \x01(\x03R\ttotalSize\x12\x1a\n" +
+	"\bchecksum\x18\x05 \x01(\tR\bchecksum\x12\x12\n" +
+	"\x04last\x18\x06 \x01(\bR\x04last\"8\n" +
 	"\x15DeleteArtifactRequest\x12\x1f\n" +
 	"\vartifact_id\x18\x01 \x01(\tR\n" +
 	"artifactId\"2\n" +
@@ -460,11 +533,11 @@ const file_artifacts_proto_rawDesc = "" +
 	"\fdownload_url\x18\x04 \x01(\tR\vdownloadUrl\x12\x1d\n" +
 	"\n" +
 	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x1a\n" +
-	"\bchecksum\x18\x06 \x01(\tR\bchecksum2\xdb\x02\n" +
+	"\bchecksum\x18\x06 \x01(\tR\bchecksum2\xdd\x02\n" +
 	"\x0fArtifactService\x12P\n" +
 	"\rListArtifacts\x12\x1e.smidr.v1.ListArtifactsRequest\x1a\x1f.smidr.v1.ListArtifactsResponse\x12F\n" +
-	"\vGetArtifact\x12\x1c.smidr.v1.GetArtifactRequest\x1a\x19.smidr.v1.ArtifactSummary\x12Y\n" +
-	"\x10DownloadArtifact\x12!.smidr.v1.DownloadArtifactRequest\x1a\".smidr.v1.DownloadArtifactResponse\x12S\n" +
+	"\vGetArtifact\x12\x1c.smidr.v1.GetArtifactRequest\x1a\x19.smidr.v1.ArtifactSummary\x12[\n" +
+	"\x10DownloadArtifact\x12!.smidr.v1.DownloadArtifactRequest\x1a\".smidr.v1.DownloadArtifactResponse0\x01\x12S\n" +
 	"\x0eDeleteArtifact\x12\x1f.smidr.v1.DeleteArtifactRequest\x1a .smidr.v1.DeleteArtifactResponseB\x99\x01\n" +
 	"\fcom.smidr.v1B\x0eArtifactsProtoP\x01Z8github.com/schererja/smidr/sdks/pkg/smidr-sdk/v1;smidrv1\xa2\x02\x03SXX\xaa\x02\bSmidr.V1\xca\x02\bSmidr\\V1\xe2\x02\x14Smidr\\V1\\GPBMetadata\xea\x02\tSmidr::V1b\x06proto3"
 
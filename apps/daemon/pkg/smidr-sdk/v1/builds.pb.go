@@ -21,12 +21,75 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BuildPhase is a coarse stage of the build pipeline.
+type BuildPhase int32
+
+const (
+	BuildPhase_BUILD_PHASE_UNSPECIFIED BuildPhase = 0
+	// Fetching layers and downloading sources
+	BuildPhase_BUILD_PHASE_FETCH BuildPhase = 1
+	// BitBake is parsing recipes
+	BuildPhase_BUILD_PHASE_PARSE BuildPhase = 2
+	// BitBake is executing tasks
+	BuildPhase_BUILD_PHASE_BUILD BuildPhase = 3
+	// Artifacts are being copied into the artifact store
+	BuildPhase_BUILD_PHASE_EXTRACT BuildPhase = 4
+)
+
+// Enum value maps for BuildPhase.
+var (
+	BuildPhase_name = map[int32]string{
+		0: "BUILD_PHASE_UNSPECIFIED",
+		1: "BUILD_PHASE_FETCH",
+		2: "BUILD_PHASE_PARSE",
+		3: "BUILD_PHASE_BUILD",
+		4: "BUILD_PHASE_EXTRACT",
+	}
+	BuildPhase_value = map[string]int32{
+		"BUILD_PHASE_UNSPECIFIED": 0,
+		"BUILD_PHASE_FETCH":       1,
+		"BUILD_PHASE_PARSE":       2,
+		"BUILD_PHASE_BUILD":       3,
+		"BUILD_PHASE_EXTRACT":     4,
+	}
+)
+
+func (x BuildPhase) Enum() *BuildPhase {
+	p := new(BuildPhase)
+	*p = x
+	return p
+}
+
+func (x BuildPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BuildPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_builds_proto_enumTypes[0].Descriptor()
+}
+
+func (BuildPhase) Type() protoreflect.EnumType {
+	return &file_builds_proto_enumTypes[0]
+}
+
+func (x BuildPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BuildPhase.Descriptor instead.
+func (BuildPhase) EnumDescriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{0}
+}
+
 // StartBuildRequest is used to initiate a new build, specifying configuration.
 type StartBuildRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Build configuration in YAML format or path to config file.
 	Config string `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
-	// Build target (e.g., core-image-minimal).
+	// Build targets in bitbake command line form: one or more space separated
+	// targets (images or recipes), optionally followed by "-c <task>" and "-f",
+	// e.g. "core-image-minimal meta-toolchain" or "busybox -c compile -f".
+	// Empty builds the config's build.image.
 	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 	// Force a clean rebuild
 	ForceClean bool `protobuf:"varint,3,opt,name=force_clean,json=forceClean,proto3" json:"force_clean,omitempty"`
@@ -35,7 +98,13 @@ type StartBuildRequest struct {
 	// Additional Environmental Variables
 	EnvironmentVariables map[string]string `protobuf:"bytes,5,rep,name=environment_variables,json=environmentVariables,proto3" json:"environment_variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Optional customer identifier for customer-specific builds
-	Customer      string `protobuf:"bytes,6,opt,name=customer,proto3" json:"customer,omitempty"`
+	Customer string `protobuf:"bytes,6,opt,name=customer,proto3" json:"customer,omitempty"`
+	// Scheduling priority; queued builds with a higher priority start first (default 0)
+	Priority int32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Run only this task of the targets (bitbake -c), e.g. populate_sdk; overrides a task in target
+	Task string `protobuf:"bytes,8,opt,name=task,proto3" json:"task,omitempty"`
+	// Run the task even if its stamp is valid (bitbake -f)
+	ForceTask     bool `protobuf:"varint,9,opt,name=force_task,json=forceTask,proto3" json:"force_task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -112,6 +181,27 @@ func (x *StartBuildRequest) GetCustomer() string {
 	return ""
 }
 
+func (x *StartBuildRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *StartBuildRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *StartBuildRequest) GetForceTask() bool {
+	if x != nil {
+		return x.ForceTask
+	}
+	return false
+}
+
 // BuildStatusResponse provides the current status of a build.
 type BuildStatusResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -124,8 +214,12 @@ type BuildStatusResponse struct {
 	ConfigPath      string                 `protobuf:"bytes,7,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
 	Customer        string                 `protobuf:"bytes,8,opt,name=customer,proto3" json:"customer,omitempty"`
 	Deleted         bool                   `protobuf:"varint,9,opt,name=deleted,proto3" json:"deleted,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// 1-based position in the build queue while QUEUED (estimated start order), 0 otherwise
+	QueuePosition int32 `protobuf:"varint,10,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	// ID of the build this build is a retry of, empty for new builds
+	RetriedFrom   string `protobuf:"bytes,11,opt,name=retried_from,json=retriedFrom,proto3" json:"retried_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BuildStatusResponse) Reset() {
@@ -221,6 +315,20 @@ func (x *BuildStatusResponse) GetDeleted() bool {
 	return false
 }
 
+func (x *BuildStatusResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *BuildStatusResponse) GetRetriedFrom() string {
+	if x != nil {
+		return x.RetriedFrom
+	}
+	return ""
+}
+
 // BuildStatusRequest is used to query the status of a specific build.
 type BuildStatusRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -298,8 +406,20 @@ type BuildDetails struct {
 	// Artifacts summary
 	ArtifactCount          int32 `protobuf:"varint,22,opt,name=artifact_count,json=artifactCount,proto3" json:"artifact_count,omitempty"`
 	TotalArtifactSizeBytes int64 `protobuf:"varint,23,opt,name=total_artifact_size_bytes,json=totalArtifactSizeBytes,proto3" json:"total_artifact_size_bytes,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// Lineage: ID of the build this build is a retry of
+	RetriedFrom string `protobuf:"bytes,24,opt,name=retried_from,json=retriedFrom,proto3" json:"retried_from,omitempty"`
+	// Failures recognised in the BitBake output of a failed build
+	Diagnostics []*BuildDiagnostic `protobuf:"bytes,25,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// Logs of failed tasks, retrievable with LogService.GetTaskLog
+	TaskLogs []*TaskLogInfo `protobuf:"bytes,26,rep,name=task_logs,json=taskLogs,proto3" json:"task_logs,omitempty"`
+	// Recovery steps the executor took, also when a retry made the build succeed
+	RecoveryActions []*RecoveryAction `protobuf:"bytes,27,rep,name=recovery_actions,json=recoveryActions,proto3" json:"recovery_actions,omitempty"`
+	// Commits the git layers were checked out at, as recorded in smidr.lock
+	LayerCommits []*LayerCommit `protobuf:"bytes,28,rep,name=layer_commits,json=layerCommits,proto3" json:"layer_commits,omitempty"`
+	// How each layer repository was fetched before the build started
+	LayerFetches  []*LayerFetch `protobuf:"bytes,29,rep,name=layer_fetches,json=layerFetches,proto3" json:"layer_fetches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BuildDetails) Reset() {
@@ -493,121 +613,73 @@ func (x *BuildDetails) GetTotalArtifactSizeBytes() int64 {
 	return 0
 }
 
-// ListBuildsRequest is used to request a list of builds with optional filters.
-type ListBuildsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Optional filter by build states
-	StateFilter []BuildState `protobuf:"varint,1,rep,packed,name=state_filter,json=stateFilter,proto3,enum=smidr.v1.BuildState" json:"state_filter,omitempty"`
-	// Optional time range filter
-	TimeRange *TimeStampRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
-	// Pagination parameters
-	PageSize  int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	// Optional customer identifier filter
-	Customer string `protobuf:"bytes,5,opt,name=customer,proto3" json:"customer,omitempty"`
-	// Include deleted builds in the response
-	IncludeDeleted bool `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
-}
-
-func (x *ListBuildsRequest) Reset() {
-	*x = ListBuildsRequest{}
-	mi := &file_builds_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListBuildsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListBuildsRequest) ProtoMessage() {}
-
-func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[4]
+func (x *BuildDetails) GetRetriedFrom() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.RetriedFrom
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListBuildsRequest.ProtoReflect.Descriptor instead.
-func (*ListBuildsRequest) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{4}
+	return ""
 }
 
-func (x *ListBuildsRequest) GetStateFilter() []BuildState {
+func (x *BuildDetails) GetDiagnostics() []*BuildDiagnostic {
 	if x != nil {
-		return x.StateFilter
+		return x.Diagnostics
 	}
 	return nil
 }
 
-func (x *ListBuildsRequest) GetTimeRange() *TimeStampRange {
+func (x *BuildDetails) GetTaskLogs() []*TaskLogInfo {
 	if x != nil {
-		return x.TimeRange
+		return x.TaskLogs
 	}
 	return nil
 }
 
-func (x *ListBuildsRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *ListBuildsRequest) GetPageToken() string {
+func (x *BuildDetails) GetRecoveryActions() []*RecoveryAction {
 	if x != nil {
-		return x.PageToken
+		return x.RecoveryActions
 	}
-	return ""
+	return nil
 }
 
-func (x *ListBuildsRequest) GetCustomer() string {
+func (x *BuildDetails) GetLayerCommits() []*LayerCommit {
 	if x != nil {
-		return x.Customer
+		return x.LayerCommits
 	}
-	return ""
+	return nil
 }
 
-func (x *ListBuildsRequest) GetIncludeDeleted() bool {
+func (x *BuildDetails) GetLayerFetches() []*LayerFetch {
 	if x != nil {
-		return x.IncludeDeleted
+		return x.LayerFetches
 	}
-	return false
+	return nil
 }
 
-// ListBuildsResponse provides a list of builds matching the request criteria.
-type ListBuildsResponse struct {
+// TaskLogInfo names a task log collected from a failed build
+type TaskLogInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Builds        []*BuildDetails        `protobuf:"bytes,1,rep,name=builds,proto3" json:"builds,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalBuilds   int32                  `protobuf:"varint,3,opt,name=total_builds,json=totalBuilds,proto3" json:"total_builds,omitempty"`
+	Recipe        string                 `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Task          string                 `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBuildsResponse) Reset() {
-	*x = ListBuildsResponse{}
-	mi := &file_builds_proto_msgTypes[5]
+func (x *TaskLogInfo) Reset() {
+	*x = TaskLogInfo{}
+	mi := &file_builds_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBuildsResponse) String() string {
+func (x *TaskLogInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBuildsResponse) ProtoMessage() {}
+func (*TaskLogInfo) ProtoMessage() {}
 
-func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[5]
+func (x *TaskLogInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -618,55 +690,66 @@ func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBuildsResponse.ProtoReflect.Descriptor instead.
-func (*ListBuildsResponse) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use TaskLogInfo.ProtoReflect.Descriptor instead.
+func (*TaskLogInfo) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ListBuildsResponse) GetBuilds() []*BuildDetails {
+func (x *TaskLogInfo) GetRecipe() string {
 	if x != nil {
-		return x.Builds
+		return x.Recipe
 	}
-	return nil
+	return ""
 }
 
-func (x *ListBuildsResponse) GetNextPageToken() string {
+func (x *TaskLogInfo) GetTask() string {
 	if x != nil {
-		return x.NextPageToken
+		return x.Task
 	}
 	return ""
 }
 
-func (x *ListBuildsResponse) GetTotalBuilds() int32 {
+func (x *TaskLogInfo) GetSizeBytes() int64 {
 	if x != nil {
-		return x.TotalBuilds
+		return x.SizeBytes
 	}
 	return 0
 }
 
-// CancelBuildRequest is used to request the cancellation of a specific build.
-type CancelBuildRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// BuildDiagnostic is a structured build failure with a remediation hint
+type BuildDiagnostic struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fetch, checksum_mismatch, parse_error, missing_layer_dependency,
+	// layerseries_compat, pseudo_abort, oom_killed, disk_full or task_failed
+	Kind          string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Recipe        string `protobuf:"bytes,2,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Task          string `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	Url           string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	File          string `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`
+	Line          int32  `protobuf:"varint,6,opt,name=line,proto3" json:"line,omitempty"`
+	Layer         string `protobuf:"bytes,7,opt,name=layer,proto3" json:"layer,omitempty"`
+	LogFile       string `protobuf:"bytes,8,opt,name=log_file,json=logFile,proto3" json:"log_file,omitempty"`
+	Message       string `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	Hint          string `protobuf:"bytes,10,opt,name=hint,proto3" json:"hint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelBuildRequest) Reset() {
-	*x = CancelBuildRequest{}
-	mi := &file_builds_proto_msgTypes[6]
+func (x *BuildDiagnostic) Reset() {
+	*x = BuildDiagnostic{}
+	mi := &file_builds_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelBuildRequest) String() string {
+func (x *BuildDiagnostic) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelBuildRequest) ProtoMessage() {}
+func (*BuildDiagnostic) ProtoMessage() {}
 
-func (x *CancelBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[6]
+func (x *BuildDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -677,94 +760,112 @@ func (x *CancelBuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelBuildRequest.ProtoReflect.Descriptor instead.
-func (*CancelBuildRequest) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use BuildDiagnostic.ProtoReflect.Descriptor instead.
+func (*BuildDiagnostic) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CancelBuildRequest) GetBuildIdentifier() *BuildIdentifier {
+func (x *BuildDiagnostic) GetKind() string {
 	if x != nil {
-		return x.BuildIdentifier
+		return x.Kind
 	}
-	return nil
+	return ""
 }
 
-// CancelBuildResponse provides the result of a cancellation request.
-type CancelBuildResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BuildDiagnostic) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
 }
 
-func (x *CancelBuildResponse) Reset() {
-	*x = CancelBuildResponse{}
-	mi := &file_builds_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BuildDiagnostic) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
 }
 
-func (x *CancelBuildResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BuildDiagnostic) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
 }
 
-func (*CancelBuildResponse) ProtoMessage() {}
+func (x *BuildDiagnostic) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
 
-func (x *CancelBuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[7]
+func (x *BuildDiagnostic) GetLine() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Line
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CancelBuildResponse.ProtoReflect.Descriptor instead.
-func (*CancelBuildResponse) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{7}
+func (x *BuildDiagnostic) GetLayer() string {
+	if x != nil {
+		return x.Layer
+	}
+	return ""
 }
 
-func (x *CancelBuildResponse) GetSuccess() bool {
+func (x *BuildDiagnostic) GetLogFile() string {
 	if x != nil {
-		return x.Success
+		return x.LogFile
 	}
-	return false
+	return ""
 }
 
-func (x *CancelBuildResponse) GetMessage() string {
+func (x *BuildDiagnostic) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// GetBuildRequest is used to request detailed information about a build.
-type GetBuildRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *BuildDiagnostic) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
 }
 
-func (x *GetBuildRequest) Reset() {
-	*x = GetBuildRequest{}
-	mi := &file_builds_proto_msgTypes[8]
+// RecoveryAction is a self-heal step taken after a failed BitBake run (see build.recovery)
+type RecoveryAction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// package_index, pseudo_cleanup, cleansstate or retry
+	Strategy string `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	Recipe   string `protobuf:"bytes,2,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	// Retry the step belongs to, starting at 1; 0 for steps outside the retry loop
+	Attempt              int32  `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Succeeded            bool   `protobuf:"varint,4,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Detail               string `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	StartedAtUnixSeconds int64  `protobuf:"varint,6,opt,name=started_at_unix_seconds,json=startedAtUnixSeconds,proto3" json:"started_at_unix_seconds,omitempty"`
+	DurationMs           int64  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RecoveryAction) Reset() {
+	*x = RecoveryAction{}
+	mi := &file_builds_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBuildRequest) String() string {
+func (x *RecoveryAction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBuildRequest) ProtoMessage() {}
+func (*RecoveryAction) ProtoMessage() {}
 
-func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[8]
+func (x *RecoveryAction) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -775,41 +876,834 @@ func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
-func (*GetBuildRequest) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RecoveryAction.ProtoReflect.Descriptor instead.
+func (*RecoveryAction) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetBuildRequest) GetBuildIdentifier() *BuildIdentifier {
+func (x *RecoveryAction) GetStrategy() string {
 	if x != nil {
-		return x.BuildIdentifier
+		return x.Strategy
 	}
-	return nil
+	return ""
 }
 
-// DeleteBuildRequest is used to request the deletion of a specific build.
-type DeleteBuildRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *RecoveryAction) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *RecoveryAction) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *RecoveryAction) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *RecoveryAction) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *RecoveryAction) GetStartedAtUnixSeconds() int64 {
+	if x != nil {
+		return x.StartedAtUnixSeconds
+	}
+	return 0
+}
+
+func (x *RecoveryAction) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// LayerCommit is the commit a layer repository was built from
+type LayerCommit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Git           string                 `protobuf:"bytes,2,opt,name=git,proto3" json:"git,omitempty"`
+	Branch        string                 `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	Commit        string                 `protobuf:"bytes,4,opt,name=commit,proto3" json:"commit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LayerCommit) Reset() {
+	*x = LayerCommit{}
+	mi := &file_builds_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LayerCommit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayerCommit) ProtoMessage() {}
+
+func (x *LayerCommit) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayerCommit.ProtoReflect.Descriptor instead.
+func (*LayerCommit) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LayerCommit) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LayerCommit) GetGit() string {
+	if x != nil {
+		return x.Git
+	}
+	return ""
+}
+
+func (x *LayerCommit) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *LayerCommit) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+// LayerFetch is the outcome of fetching a layer repository (see fetch in smidr.yaml)
+type LayerFetch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Git   string                 `protobuf:"bytes,2,opt,name=git,proto3" json:"git,omitempty"`
+	// cloned, updated, cached, stale or failed
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// URL the fetch succeeded from; differs from git when a mirror was used
+	Url           string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Ref           string `protobuf:"bytes,5,opt,name=ref,proto3" json:"ref,omitempty"`
+	Commit        string `protobuf:"bytes,6,opt,name=commit,proto3" json:"commit,omitempty"`
+	Attempts      int32  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	DurationMs    int64  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error         string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LayerFetch) Reset() {
+	*x = LayerFetch{}
+	mi := &file_builds_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LayerFetch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayerFetch) ProtoMessage() {}
+
+func (x *LayerFetch) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayerFetch.ProtoReflect.Descriptor instead.
+func (*LayerFetch) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LayerFetch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LayerFetch) GetGit() string {
+	if x != nil {
+		return x.Git
+	}
+	return ""
+}
+
+func (x *LayerFetch) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LayerFetch) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LayerFetch) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *LayerFetch) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *LayerFetch) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *LayerFetch) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *LayerFetch) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ListBuildsRequest is used to request a list of builds with optional filters.
+type ListBuildsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional filter by build states
+	StateFilter []BuildState `protobuf:"varint,1,rep,packed,name=state_filter,json=stateFilter,proto3,enum=smidr.v1.BuildState" json:"state_filter,omitempty"`
+	// Optional time range filter
+	TimeRange *TimeStampRange `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	// Pagination parameters
+	PageSize  int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Optional customer identifier filter
+	Customer string `protobuf:"bytes,5,opt,name=customer,proto3" json:"customer,omitempty"`
+	// Include deleted builds in the response
+	IncludeDeleted bool `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListBuildsRequest) Reset() {
+	*x = ListBuildsRequest{}
+	mi := &file_builds_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildsRequest) ProtoMessage() {}
+
+func (x *ListBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildsRequest.ProtoReflect.Descriptor instead.
+func (*ListBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListBuildsRequest) GetStateFilter() []BuildState {
+	if x != nil {
+		return x.StateFilter
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetTimeRange() *TimeStampRange {
+	if x != nil {
+		return x.TimeRange
+	}
+	return nil
+}
+
+func (x *ListBuildsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBuildsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetCustomer() string {
+	if x != nil {
+		return x.Customer
+	}
+	return ""
+}
+
+func (x *ListBuildsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+// ListBuildsResponse provides a list of builds matching the request criteria.
+type ListBuildsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Builds        []*BuildDetails        `protobuf:"bytes,1,rep,name=builds,proto3" json:"builds,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalBuilds   int32                  `protobuf:"varint,3,opt,name=total_builds,json=totalBuilds,proto3" json:"total_builds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBuildsResponse) Reset() {
+	*x = ListBuildsResponse{}
+	mi := &file_builds_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBuildsResponse) ProtoMessage() {}
+
+func (x *ListBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBuildsResponse.ProtoReflect.Descriptor instead.
+func (*ListBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListBuildsResponse) GetBuilds() []*BuildDetails {
+	if x != nil {
+		return x.Builds
+	}
+	return nil
+}
+
+func (x *ListBuildsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListBuildsResponse) GetTotalBuilds() int32 {
+	if x != nil {
+		return x.TotalBuilds
+	}
+	return 0
+}
+
+// CancelBuildRequest is used to request the cancellation of a specific build.
+type CancelBuildRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelBuildRequest) Reset() {
+	*x = CancelBuildRequest{}
+	mi := &file_builds_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildRequest) ProtoMessage() {}
+
+func (x *CancelBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBuildRequest.ProtoReflect.Descriptor instead.
+func (*CancelBuildRequest) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelBuildRequest) GetBuildIdentifier() *BuildIdentifier {
+	if x != nil {
+		return x.BuildIdentifier
+	}
+	return nil
+}
+
+// CancelBuildResponse provides the result of a cancellation request.
+type CancelBuildResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBuildResponse) Reset() {
+	*x = CancelBuildResponse{}
+	mi := &file_builds_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildResponse) ProtoMessage() {}
+
+func (x *CancelBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBuildResponse.ProtoReflect.Descriptor instead.
+func (*CancelBuildResponse) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelBuildResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelBuildResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetBuildRequest is used to request detailed information about a build.
+type GetBuildRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetBuildRequest) Reset() {
+	*x = GetBuildRequest{}
+	mi := &file_builds_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildRequest) ProtoMessage() {}
+
+func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildRequest) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetBuildRequest) GetBuildIdentifier() *BuildIdentifier {
+	if x != nil {
+		return x.BuildIdentifier
+	}
+	return nil
+}
+
+// DeleteBuildRequest is used to request the deletion of a specific build.
+type DeleteBuildRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DeleteBuildRequest) Reset() {
 	*x = DeleteBuildRequest{}
-	mi := &file_builds_proto_msgTypes[9]
+	mi := &file_builds_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildRequest) ProtoMessage() {}
+
+func (x *DeleteBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBuildRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBuildRequest) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteBuildRequest) GetBuildIdentifier() *BuildIdentifier {
+	if x != nil {
+		return x.BuildIdentifier
+	}
+	return nil
+}
+
+// DeleteBuildResponse provides the result of a deletion request.
+type DeleteBuildResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBuildResponse) Reset() {
+	*x = DeleteBuildResponse{}
+	mi := &file_builds_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBuildResponse) ProtoMessage() {}
+
+func (x *DeleteBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBuildResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBuildResponse) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteBuildResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteBuildResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// PurgeBuildsRequest is used to request the purging of old builds.
+type PurgeBuildsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Builds older than this timestamp will be purged
+	OlderThanUnixSeconds int64 `protobuf:"varint,1,opt,name=older_than_unix_seconds,json=olderThanUnixSeconds,proto3" json:"older_than_unix_seconds,omitempty"`
+	// Optional customer identifier filter
+	Customer      string `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeBuildsRequest) Reset() {
+	*x = PurgeBuildsRequest{}
+	mi := &file_builds_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeBuildsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeBuildsRequest) ProtoMessage() {}
+
+func (x *PurgeBuildsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeBuildsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeBuildsRequest) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PurgeBuildsRequest) GetOlderThanUnixSeconds() int64 {
+	if x != nil {
+		return x.OlderThanUnixSeconds
+	}
+	return 0
+}
+
+func (x *PurgeBuildsRequest) GetCustomer() string {
+	if x != nil {
+		return x.Customer
+	}
+	return ""
+}
+
+// PurgeBuildsResponse provides the result of a purge request.
+type PurgeBuildsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PurgedBuildCount int32                  `protobuf:"varint,1,opt,name=purged_build_count,json=purgedBuildCount,proto3" json:"purged_build_count,omitempty"`
+	PurgedBuildIds   []string               `protobuf:"bytes,2,rep,name=purged_build_ids,json=purgedBuildIds,proto3" json:"purged_build_ids,omitempty"`
+	FreedSpaceBytes  int64                  `protobuf:"varint,3,opt,name=freed_space_bytes,json=freedSpaceBytes,proto3" json:"freed_space_bytes,omitempty"`
+	Message          string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PurgeBuildsResponse) Reset() {
+	*x = PurgeBuildsResponse{}
+	mi := &file_builds_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeBuildsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeBuildsResponse) ProtoMessage() {}
+
+func (x *PurgeBuildsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeBuildsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeBuildsResponse) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PurgeBuildsResponse) GetPurgedBuildCount() int32 {
+	if x != nil {
+		return x.PurgedBuildCount
+	}
+	return 0
+}
+
+func (x *PurgeBuildsResponse) GetPurgedBuildIds() []string {
+	if x != nil {
+		return x.PurgedBuildIds
+	}
+	return nil
+}
+
+func (x *PurgeBuildsResponse) GetFreedSpaceBytes() int64 {
+	if x != nil {
+		return x.FreedSpaceBytes
+	}
+	return 0
+}
+
+func (x *PurgeBuildsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// WatchBuildRequest is used to subscribe to structured events of a build.
+type WatchBuildRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchBuildRequest) Reset() {
+	*x = WatchBuildRequest{}
+	mi := &file_builds_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBuildRequest) ProtoMessage() {}
+
+func (x *WatchBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBuildRequest.ProtoReflect.Descriptor instead.
+func (*WatchBuildRequest) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchBuildRequest) GetBuildIdentifier() *BuildIdentifier {
+	if x != nil {
+		return x.BuildIdentifier
+	}
+	return nil
+}
+
+// RetryBuildRequest starts a new build from the config snapshot, target and
+// customer of a finished build.
+type RetryBuildRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Build to retry
+	BuildIdentifier *BuildIdentifier `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
+	// Optional target override; defaults to the original build's target
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Force a clean rebuild
+	ForceClean bool `protobuf:"varint,3,opt,name=force_clean,json=forceClean,proto3" json:"force_clean,omitempty"`
+	// Force image rebuild only
+	ForceImageRebuild bool `protobuf:"varint,4,opt,name=force_image_rebuild,json=forceImageRebuild,proto3" json:"force_image_rebuild,omitempty"`
+	// Scheduling priority of the new build (default 0)
+	Priority      int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryBuildRequest) Reset() {
+	*x = RetryBuildRequest{}
+	mi := &file_builds_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBuildRequest) String() string {
+func (x *RetryBuildRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBuildRequest) ProtoMessage() {}
+func (*RetryBuildRequest) ProtoMessage() {}
 
-func (x *DeleteBuildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[9]
+func (x *RetryBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -820,42 +1714,78 @@ func (x *DeleteBuildRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBuildRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBuildRequest) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RetryBuildRequest.ProtoReflect.Descriptor instead.
+func (*RetryBuildRequest) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DeleteBuildRequest) GetBuildIdentifier() *BuildIdentifier {
+func (x *RetryBuildRequest) GetBuildIdentifier() *BuildIdentifier {
 	if x != nil {
 		return x.BuildIdentifier
 	}
 	return nil
 }
 
-// DeleteBuildResponse provides the result of a deletion request.
-type DeleteBuildResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+func (x *RetryBuildRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *RetryBuildRequest) GetForceClean() bool {
+	if x != nil {
+		return x.ForceClean
+	}
+	return false
+}
+
+func (x *RetryBuildRequest) GetForceImageRebuild() bool {
+	if x != nil {
+		return x.ForceImageRebuild
+	}
+	return false
+}
+
+func (x *RetryBuildRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// BuildEvent is a single structured event emitted while a build runs.
+type BuildEvent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	BuildIdentifier      *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
+	TimestampUnixSeconds int64                  `protobuf:"varint,2,opt,name=timestamp_unix_seconds,json=timestampUnixSeconds,proto3" json:"timestamp_unix_seconds,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*BuildEvent_StateChange
+	//	*BuildEvent_PhaseChange
+	//	*BuildEvent_TaskProgress
+	//	*BuildEvent_Recovery
+	//	*BuildEvent_LayerFetch
+	Event         isBuildEvent_Event `protobuf_oneof:"event"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteBuildResponse) Reset() {
-	*x = DeleteBuildResponse{}
-	mi := &file_builds_proto_msgTypes[10]
+func (x *BuildEvent) Reset() {
+	*x = BuildEvent{}
+	mi := &file_builds_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBuildResponse) String() string {
+func (x *BuildEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBuildResponse) ProtoMessage() {}
+func (*BuildEvent) ProtoMessage() {}
 
-func (x *DeleteBuildResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[10]
+func (x *BuildEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -866,51 +1796,137 @@ func (x *DeleteBuildResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBuildResponse.ProtoReflect.Descriptor instead.
-func (*DeleteBuildResponse) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use BuildEvent.ProtoReflect.Descriptor instead.
+func (*BuildEvent) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *DeleteBuildResponse) GetSuccess() bool {
+func (x *BuildEvent) GetBuildIdentifier() *BuildIdentifier {
 	if x != nil {
-		return x.Success
+		return x.BuildIdentifier
 	}
-	return false
+	return nil
 }
 
-func (x *DeleteBuildResponse) GetMessage() string {
+func (x *BuildEvent) GetTimestampUnixSeconds() int64 {
 	if x != nil {
-		return x.Message
+		return x.TimestampUnixSeconds
 	}
-	return ""
+	return 0
 }
 
-// PurgeBuildsRequest is used to request the purging of old builds.
-type PurgeBuildsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Builds older than this timestamp will be purged
-	OlderThanUnixSeconds int64 `protobuf:"varint,1,opt,name=older_than_unix_seconds,json=olderThanUnixSeconds,proto3" json:"older_than_unix_seconds,omitempty"`
-	// Optional customer identifier filter
-	Customer      string `protobuf:"bytes,2,opt,name=customer,proto3" json:"customer,omitempty"`
+func (x *BuildEvent) GetEvent() isBuildEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetStateChange() *BuildStateChange {
+	if x != nil {
+		if x, ok := x.Event.(*BuildEvent_StateChange); ok {
+			return x.StateChange
+		}
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetPhaseChange() *BuildPhaseChange {
+	if x != nil {
+		if x, ok := x.Event.(*BuildEvent_PhaseChange); ok {
+			return x.PhaseChange
+		}
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetTaskProgress() *TaskProgress {
+	if x != nil {
+		if x, ok := x.Event.(*BuildEvent_TaskProgress); ok {
+			return x.TaskProgress
+		}
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetRecovery() *RecoveryAction {
+	if x != nil {
+		if x, ok := x.Event.(*BuildEvent_Recovery); ok {
+			return x.Recovery
+		}
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetLayerFetch() *LayerFetch {
+	if x != nil {
+		if x, ok := x.Event.(*BuildEvent_LayerFetch); ok {
+			return x.LayerFetch
+		}
+	}
+	return nil
+}
+
+type isBuildEvent_Event interface {
+	isBuildEvent_Event()
+}
+
+type BuildEvent_StateChange struct {
+	StateChange *BuildStateChange `protobuf:"bytes,3,opt,name=state_change,json=stateChange,proto3,oneof"`
+}
+
+type BuildEvent_PhaseChange struct {
+	PhaseChange *BuildPhaseChange `protobuf:"bytes,4,opt,name=phase_change,json=phaseChange,proto3,oneof"`
+}
+
+type BuildEvent_TaskProgress struct {
+	TaskProgress *TaskProgress `protobuf:"bytes,5,opt,name=task_progress,json=taskProgress,proto3,oneof"`
+}
+
+type BuildEvent_Recovery struct {
+	Recovery *RecoveryAction `protobuf:"bytes,6,opt,name=recovery,proto3,oneof"`
+}
+
+type BuildEvent_LayerFetch struct {
+	LayerFetch *LayerFetch `protobuf:"bytes,7,opt,name=layer_fetch,json=layerFetch,proto3,oneof"`
+}
+
+func (*BuildEvent_StateChange) isBuildEvent_Event() {}
+
+func (*BuildEvent_PhaseChange) isBuildEvent_Event() {}
+
+func (*BuildEvent_TaskProgress) isBuildEvent_Event() {}
+
+func (*BuildEvent_Recovery) isBuildEvent_Event() {}
+
+func (*BuildEvent_LayerFetch) isBuildEvent_Event() {}
+
+// BuildStateChange reports a transition of the build state.
+type BuildStateChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PreviousState BuildState             `protobuf:"varint,1,opt,name=previous_state,json=previousState,proto3,enum=smidr.v1.BuildState" json:"previous_state,omitempty"`
+	State         BuildState             `protobuf:"varint,2,opt,name=state,proto3,enum=smidr.v1.BuildState" json:"state,omitempty"`
+	// Optional human-readable reason (e.g., error or cancellation message)
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PurgeBuildsRequest) Reset() {
-	*x = PurgeBuildsRequest{}
-	mi := &file_builds_proto_msgTypes[11]
+func (x *BuildStateChange) Reset() {
+	*x = BuildStateChange{}
+	mi := &file_builds_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PurgeBuildsRequest) String() string {
+func (x *BuildStateChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PurgeBuildsRequest) ProtoMessage() {}
+func (*BuildStateChange) ProtoMessage() {}
 
-func (x *PurgeBuildsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[11]
+func (x *BuildStateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -921,51 +1937,55 @@ func (x *PurgeBuildsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PurgeBuildsRequest.ProtoReflect.Descriptor instead.
-func (*PurgeBuildsRequest) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use BuildStateChange.ProtoReflect.Descriptor instead.
+func (*BuildStateChange) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *PurgeBuildsRequest) GetOlderThanUnixSeconds() int64 {
+func (x *BuildStateChange) GetPreviousState() BuildState {
 	if x != nil {
-		return x.OlderThanUnixSeconds
+		return x.PreviousState
 	}
-	return 0
+	return BuildState_BUILD_STATE_UNSPECIFIED
 }
 
-func (x *PurgeBuildsRequest) GetCustomer() string {
+func (x *BuildStateChange) GetState() BuildState {
 	if x != nil {
-		return x.Customer
+		return x.State
+	}
+	return BuildState_BUILD_STATE_UNSPECIFIED
+}
+
+func (x *BuildStateChange) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-// PurgeBuildsResponse provides the result of a purge request.
-type PurgeBuildsResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	PurgedBuildCount int32                  `protobuf:"varint,1,opt,name=purged_build_count,json=purgedBuildCount,proto3" json:"purged_build_count,omitempty"`
-	PurgedBuildIds   []string               `protobuf:"bytes,2,rep,name=purged_build_ids,json=purgedBuildIds,proto3" json:"purged_build_ids,omitempty"`
-	FreedSpaceBytes  int64                  `protobuf:"varint,3,opt,name=freed_space_bytes,json=freedSpaceBytes,proto3" json:"freed_space_bytes,omitempty"`
-	Message          string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// BuildPhaseChange reports that the build entered a new phase.
+type BuildPhaseChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         BuildPhase             `protobuf:"varint,1,opt,name=phase,proto3,enum=smidr.v1.BuildPhase" json:"phase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PurgeBuildsResponse) Reset() {
-	*x = PurgeBuildsResponse{}
-	mi := &file_builds_proto_msgTypes[12]
+func (x *BuildPhaseChange) Reset() {
+	*x = BuildPhaseChange{}
+	mi := &file_builds_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PurgeBuildsResponse) String() string {
+func (x *BuildPhaseChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PurgeBuildsResponse) ProtoMessage() {}
+func (*BuildPhaseChange) ProtoMessage() {}
 
-func (x *PurgeBuildsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_builds_proto_msgTypes[12]
+func (x *BuildPhaseChange) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -976,44 +1996,136 @@ func (x *PurgeBuildsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PurgeBuildsResponse.ProtoReflect.Descriptor instead.
-func (*PurgeBuildsResponse) Descriptor() ([]byte, []int) {
-	return file_builds_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use BuildPhaseChange.ProtoReflect.Descriptor instead.
+func (*BuildPhaseChange) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *PurgeBuildsResponse) GetPurgedBuildCount() int32 {
+func (x *BuildPhaseChange) GetPhase() BuildPhase {
 	if x != nil {
-		return x.PurgedBuildCount
+		return x.Phase
 	}
-	return 0
+	return BuildPhase_BUILD_PHASE_UNSPECIFIED
 }
 
-func (x *PurgeBuildsResponse) GetPurgedBuildIds() []string {
+// TaskProgress reports BitBake task execution progress ("Running task N of M").
+type TaskProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of this task within its kind (setscene or real tasks)
+	Current int32 `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`
+	Total   int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// Recipe name (e.g., busybox) and task name (e.g., do_compile)
+	Recipe string `protobuf:"bytes,3,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Task   string `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+	// True for setscene tasks restored from sstate
+	Setscene bool `protobuf:"varint,5,opt,name=setscene,proto3" json:"setscene,omitempty"`
+	// Latest counts seen for each kind of task
+	SetsceneCurrent int32 `protobuf:"varint,6,opt,name=setscene_current,json=setsceneCurrent,proto3" json:"setscene_current,omitempty"`
+	SetsceneTotal   int32 `protobuf:"varint,7,opt,name=setscene_total,json=setsceneTotal,proto3" json:"setscene_total,omitempty"`
+	TaskCurrent     int32 `protobuf:"varint,8,opt,name=task_current,json=taskCurrent,proto3" json:"task_current,omitempty"`
+	TaskTotal       int32 `protobuf:"varint,9,opt,name=task_total,json=taskTotal,proto3" json:"task_total,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	mi := &file_builds_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProgress) ProtoMessage() {}
+
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_builds_proto_msgTypes[23]
 	if x != nil {
-		return x.PurgedBuildIds
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *PurgeBuildsResponse) GetFreedSpaceBytes() int64 {
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_builds_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TaskProgress) GetCurrent() int32 {
 	if x != nil {
-		return x.FreedSpaceBytes
+		return x.Current
 	}
 	return 0
 }
 
-func (x *PurgeBuildsResponse) GetMessage() string {
+func (x *TaskProgress) GetTotal() int32 {
 	if x != nil {
-		return x.Message
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *TaskProgress) GetTask() string {
+	if x != nil {
+		return x.Task
 	}
 	return ""
 }
 
+func (x *TaskProgress) GetSetscene() bool {
+	if x != nil {
+		return x.Setscene
+	}
+	return false
+}
+
+func (x *TaskProgress) GetSetsceneCurrent() int32 {
+	if x != nil {
+		return x.SetsceneCurrent
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetSetsceneTotal() int32 {
+	if x != nil {
+		return x.SetsceneTotal
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetTaskCurrent() int32 {
+	if x != nil {
+		return x.TaskCurrent
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetTaskTotal() int32 {
+	if x != nil {
+		return x.TaskTotal
+	}
+	return 0
+}
+
 var File_builds_proto protoreflect.FileDescriptor
 
 const file_builds_proto_rawDesc = "" +
 	"\n" +
-	"\fbuilds.proto\x12\bsmidr.v1\x1a\fcommon.proto\"\xe5\x02\n" +
+	"\fbuilds.proto\x12\bsmidr.v1\x1a\fcommon.proto\"\xb4\x03\n" +
 	"\x11StartBuildRequest\x12\x16\n" +
 	"\x06config\x18\x01 \x01(\tR\x06config\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06target\x12\x1f\n" +
@@ -1021,10 +2133,14 @@ const file_builds_proto_rawDesc = "" +
 	"forceClean\x12.\n" +
 	"\x13force_image_rebuild\x18\x04 \x01(\bR\x11forceImageRebuild\x12j\n" +
 	"\x15environment_variables\x18\x05 \x03(\v25.smidr.v1.StartBuildRequest.EnvironmentVariablesEntryR\x14environmentVariables\x12\x1a\n" +
-	"\bcustomer\x18\x06 \x01(\tR\bcustomer\x1aG\n" +
+	"\bcustomer\x18\x06 \x01(\tR\bcustomer\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\x05R\bpriority\x12\x12\n" +
+	"\x04task\x18\b \x01(\tR\x04task\x12\x1d\n" +
+	"\n" +
+	"force_task\x18\t \x01(\bR\tforceTask\x1aG\n" +
 	"\x19EnvironmentVariablesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf2\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbc\x03\n" +
 	"\x13BuildStatusResponse\x12D\n" +
 	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06target\x12*\n" +
@@ -1037,9 +2153,12 @@ const file_builds_proto_rawDesc = "" +
 	"\vconfig_path\x18\a \x01(\tR\n" +
 	"configPath\x12\x1a\n" +
 	"\bcustomer\x18\b \x01(\tR\bcustomer\x12\x18\n" +
-	"\adeleted\x18\t \x01(\bR\adeleted\"Z\n" +
+	"\adeleted\x18\t \x01(\bR\adeleted\x12%\n" +
+	"\x0equeue_position\x18\n" +
+	" \x01(\x05R\rqueuePosition\x12!\n" +
+	"\fretried_from\x18\v \x01(\tR\vretriedFrom\"Z\n" +
 	"\x12BuildStatusRequest\x12D\n" +
-	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\"\xfe\x06\n" +
+	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\"\xce\t\n" +
 	"\fBuildDetails\x12D\n" +
 	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\x12\x1a\n" +
 	"\bcustomer\x18\x02 \x01(\tR\bcustomer\x12!\n" +
@@ -1070,7 +2189,56 @@ const file_builds_proto_rawDesc = "" +
 	"deleted_at\x18\x14 \x01(\x03R\tdeletedAt\x12#\n" +
 	"\rerror_message\x18\x15 \x01(\tR\ferrorMessage\x12%\n" +
 	"\x0eartifact_count\x18\x16 \x01(\x05R\rartifactCount\x129\n" +
-	"\x19total_artifact_size_bytes\x18\x17 \x01(\x03R\x16totalArtifactSizeBytes\"\x86\x02\n" +
+	"\x19total_artifact_size_bytes\x18\x17 \x01(\x03R\x16totalArtifactSizeBytes\x12!\n" +
+	"\fretried_from\x18\x18 \x01(\tR\vretriedFrom\x12;\n" +
+	"\vdiagnostics\x18\x19 \x03(\v2\x19.smidr.v1.BuildDiagnosticR\vdiagnostics\x122\n" +
+	"\ttask_logs\x18\x1a \x03(\v2\x15.smidr.v1.TaskLogInfoR\btaskLogs\x12C\n" +
+	"\x10recovery_actions\x18\x1b \x03(\v2\x18.smidr.v1.RecoveryActionR\x0frecoveryActions\x12:\n" +
+	"\rlayer_commits\x18\x1c \x03(\v2\x15.smidr.v1.LayerCommitR\flayerCommits\x129\n" +
+	"\rlayer_fetches\x18\x1d \x03(\v2\x14.smidr.v1.LayerFetchR\flayerFetches\"X\n" +
+	"\vTaskLogInfo\x12\x16\n" +
+	"\x06recipe\x18\x01 \x01(\tR\x06recipe\x12\x12\n" +
+	"\x04task\x18\x02 \x01(\tR\x04task\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\"\xea\x01\n" +
+	"\x0fBuildDiagnostic\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06recipe\x18\x02 \x01(\tR\x06recipe\x12\x12\n" +
+	"\x04task\x18\x03 \x01(\tR\x04task\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x12\n" +
+	"\x04file\x18\x05 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x06 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05layer\x18\a \x01(\tR\x05layer\x12\x19\n" +
+	"\blog_file\x18\b \x01(\tR\alogFile\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\x12\x12\n" +
+	"\x04hint\x18\n" +
+	" \x01(\tR\x04hint\"\xec\x01\n" +
+	"\x0eRecoveryAction\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\x12\x16\n" +
+	"\x06recipe\x18\x02 \x01(\tR\x06recipe\x12\x18\n" +
+	"\aattempt\x18\x03 \x01(\x05R\aattempt\x12\x1c\n" +
+	"\tsucceeded\x18\x04 \x01(\bR\tsucceeded\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\x125\n" +
+	"\x17started_at_unix_seconds\x18\x06 \x01(\x03R\x14startedAtUnixSeconds\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\"c\n" +
+	"\vLayerCommit\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03git\x18\x02 \x01(\tR\x03git\x12\x16\n" +
+	"\x06branch\x18\x03 \x01(\tR\x06branch\x12\x16\n" +
+	"\x06commit\x18\x04 \x01(\tR\x06commit\"\xd9\x01\n" +
+	"\n" +
+	"LayerFetch\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03git\x18\x02 \x01(\tR\x03git\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x10\n" +
+	"\x03ref\x18\x05 \x01(\tR\x03ref\x12\x16\n" +
+	"\x06commit\x18\x06 \x01(\tR\x06commit\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\x86\x02\n" +
 	"\x11ListBuildsRequest\x127\n" +
 	"\fstate_filter\x18\x01 \x03(\x0e2\x14.smidr.v1.BuildStateR\vstateFilter\x127\n" +
 	"\n" +
@@ -1103,7 +2271,51 @@ const file_builds_proto_rawDesc = "" +
 	"\x12purged_build_count\x18\x01 \x01(\x05R\x10purgedBuildCount\x12(\n" +
 	"\x10purged_build_ids\x18\x02 \x03(\tR\x0epurgedBuildIds\x12*\n" +
 	"\x11freed_space_bytes\x18\x03 \x01(\x03R\x0ffreedSpaceBytes\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage2\x93\x04\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"Y\n" +
+	"\x11WatchBuildRequest\x12D\n" +
+	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\"\xde\x01\n" +
+	"\x11RetryBuildRequest\x12D\n" +
+	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x1f\n" +
+	"\vforce_clean\x18\x03 \x01(\bR\n" +
+	"forceClean\x12.\n" +
+	"\x13force_image_rebuild\x18\x04 \x01(\bR\x11forceImageRebuild\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\"\xc3\x03\n" +
+	"\n" +
+	"BuildEvent\x12D\n" +
+	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\x124\n" +
+	"\x16timestamp_unix_seconds\x18\x02 \x01(\x03R\x14timestampUnixSeconds\x12?\n" +
+	"\fstate_change\x18\x03 \x01(\v2\x1a.smidr.v1.BuildStateChangeH\x00R\vstateChange\x12?\n" +
+	"\fphase_change\x18\x04 \x01(\v2\x1a.smidr.v1.BuildPhaseChangeH\x00R\vphaseChange\x12=\n" +
+	"\rtask_progress\x18\x05 \x01(\v2\x16.smidr.v1.TaskProgressH\x00R\ftaskProgress\x126\n" +
+	"\brecovery\x18\x06 \x01(\v2\x18.smidr.v1.RecoveryActionH\x00R\brecovery\x127\n" +
+	"\vlayer_fetch\x18\a \x01(\v2\x14.smidr.v1.LayerFetchH\x00R\n" +
+	"layerFetchB\a\n" +
+	"\x05event\"\x95\x01\n" +
+	"\x10BuildStateChange\x12;\n" +
+	"\x0eprevious_state\x18\x01 \x01(\x0e2\x14.smidr.v1.BuildStateR\rpreviousState\x12*\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x14.smidr.v1.BuildStateR\x05state\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\">\n" +
+	"\x10BuildPhaseChange\x12*\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\x14.smidr.v1.BuildPhaseR\x05phase\"\x9a\x02\n" +
+	"\fTaskProgress\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\x05R\acurrent\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x16\n" +
+	"\x06recipe\x18\x03 \x01(\tR\x06recipe\x12\x12\n" +
+	"\x04task\x18\x04 \x01(\tR\x04task\x12\x1a\n" +
+	"\bsetscene\x18\x05 \x01(\bR\bsetscene\x12)\n" +
+	"\x10setscene_current\x18\x06 \x01(\x05R\x0fsetsceneCurrent\x12%\n" +
+	"\x0esetscene_total\x18\a \x01(\x05R\rsetsceneTotal\x12!\n" +
+	"\ftask_current\x18\b \x01(\x05R\vtaskCurrent\x12\x1d\n" +
+	"\n" +
+	"task_total\x18\t \x01(\x05R\ttaskTotal*\x87\x01\n" +
+	"\n" +
+	"BuildPhase\x12\x1b\n" +
+	"\x17BUILD_PHASE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11BUILD_PHASE_FETCH\x10\x01\x12\x15\n" +
+	"\x11BUILD_PHASE_PARSE\x10\x02\x12\x15\n" +
+	"\x11BUILD_PHASE_BUILD\x10\x03\x12\x17\n" +
+	"\x13BUILD_PHASE_EXTRACT\x10\x042\xa0\x05\n" +
 	"\fBuildService\x12H\n" +
 	"\n" +
 	"StartBuild\x12\x1b.smidr.v1.StartBuildRequest\x1a\x1d.smidr.v1.BuildStatusResponse\x12M\n" +
@@ -1113,7 +2325,11 @@ const file_builds_proto_rawDesc = "" +
 	"\vCancelBuild\x12\x1c.smidr.v1.CancelBuildRequest\x1a\x1d.smidr.v1.CancelBuildResponse\x12=\n" +
 	"\bGetBuild\x12\x19.smidr.v1.GetBuildRequest\x1a\x16.smidr.v1.BuildDetails\x12J\n" +
 	"\vDeleteBuild\x12\x1c.smidr.v1.DeleteBuildRequest\x1a\x1d.smidr.v1.DeleteBuildResponse\x12J\n" +
-	"\vPurgeBuilds\x12\x1c.smidr.v1.PurgeBuildsRequest\x1a\x1d.smidr.v1.PurgeBuildsResponseB\x96\x01\n" +
+	"\vPurgeBuilds\x12\x1c.smidr.v1.PurgeBuildsRequest\x1a\x1d.smidr.v1.PurgeBuildsResponse\x12A\n" +
+	"\n" +
+	"WatchBuild\x12\x1b.smidr.v1.WatchBuildRequest\x1a\x14.smidr.v1.BuildEvent0\x01\x12H\n" +
+	"\n" +
+	"RetryBuild\x12\x1b.smidr.v1.RetryBuildRequest\x1a\x1d.smidr.v1.BuildStatusResponseB\x96\x01\n" +
 	"\fcom.smidr.v1B\vBuildsProtoP\x01Z8github.com/schererja/smidr/sdks/pkg/smidr-sdk/v1;smidrv1\xa2\x02\x03SXX\xaa\x02\bSmidr.V1\xca\x02\bSmidr\\V1\xe2\x02\x14Smidr\\V1\\GPBMetadata\xea\x02\tSmidr::V1b\x06proto3"
 
 var (
@@ -1128,60 +2344,93 @@ func file_builds_proto_rawDescGZIP() []byte {
 	return file_builds_proto_rawDescData
 }
 
-var file_builds_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_builds_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_builds_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_builds_proto_goTypes = []any{
-	(*StartBuildRequest)(nil),   // 0: smidr.v1.StartBuildRequest
-	(*BuildStatusResponse)(nil), // 1: smidr.v1.BuildStatusResponse
-	(*BuildStatusRequest)(nil),  // 2: smidr.v1.BuildStatusRequest
-	(*BuildDetails)(nil),        // 3: smidr.v1.BuildDetails
-	(*ListBuildsRequest)(nil),   // 4: smidr.v1.ListBuildsRequest
-	(*ListBuildsResponse)(nil),  // 5: smidr.v1.ListBuildsResponse
-	(*CancelBuildRequest)(nil),  // 6: smidr.v1.CancelBuildRequest
-	(*CancelBuildResponse)(nil), // 7: smidr.v1.CancelBuildResponse
-	(*GetBuildRequest)(nil),     // 8: smidr.v1.GetBuildRequest
-	(*DeleteBuildRequest)(nil),  // 9: smidr.v1.DeleteBuildRequest
-	(*DeleteBuildResponse)(nil), // 10: smidr.v1.DeleteBuildResponse
-	(*PurgeBuildsRequest)(nil),  // 11: smidr.v1.PurgeBuildsRequest
-	(*PurgeBuildsResponse)(nil), // 12: smidr.v1.PurgeBuildsResponse
-	nil,                         // 13: smidr.v1.StartBuildRequest.EnvironmentVariablesEntry
-	(*BuildIdentifier)(nil),     // 14: smidr.v1.BuildIdentifier
-	(BuildState)(0),             // 15: smidr.v1.BuildState
-	(*TimeStampRange)(nil),      // 16: smidr.v1.TimeStampRange
+	(BuildPhase)(0),             // 0: smidr.v1.BuildPhase
+	(*StartBuildRequest)(nil),   // 1: smidr.v1.StartBuildRequest
+	(*BuildStatusResponse)(nil), // 2: smidr.v1.BuildStatusResponse
+	(*BuildStatusRequest)(nil),  // 3: smidr.v1.BuildStatusRequest
+	(*BuildDetails)(nil),        // 4: smidr.v1.BuildDetails
+	(*TaskLogInfo)(nil),         // 5: smidr.v1.TaskLogInfo
+	(*BuildDiagnostic)(nil),     // 6: smidr.v1.BuildDiagnostic
+	(*RecoveryAction)(nil),      // 7: smidr.v1.RecoveryAction
+	(*LayerCommit)(nil),         // 8: smidr.v1.LayerCommit
+	(*LayerFetch)(nil),          // 9: smidr.v1.LayerFetch
+	(*ListBuildsRequest)(nil),   // 10: smidr.v1.ListBuildsRequest
+	(*ListBuildsResponse)(nil),  // 11: smidr.v1.ListBuildsResponse
+	(*CancelBuildRequest)(nil),  // 12: smidr.v1.CancelBuildRequest
+	(*CancelBuildResponse)(nil), // 13: smidr.v1.CancelBuildResponse
+	(*GetBuildRequest)(nil),     // 14: smidr.v1.GetBuildRequest
+	(*DeleteBuildRequest)(nil),  // 15: smidr.v1.DeleteBuildRequest
+	(*DeleteBuildResponse)(nil), // 16: smidr.v1.DeleteBuildResponse
+	(*PurgeBuildsRequest)(nil),  // 17: smidr.v1.PurgeBuildsRequest
+	(*PurgeBuildsResponse)(nil), // 18: smidr.v1.PurgeBuildsResponse
+	(*WatchBuildRequest)(nil),   // 19: smidr.v1.WatchBuildRequest
+	(*RetryBuildRequest)(nil),   // 20: smidr.v1.RetryBuildRequest
+	(*BuildEvent)(nil),          // 21: smidr.v1.BuildEvent
+	(*BuildStateChange)(nil),    // 22: smidr.v1.BuildStateChange
+	(*BuildPhaseChange)(nil),    // 23: smidr.v1.BuildPhaseChange
+	(*TaskProgress)(nil),        // 24: smidr.v1.TaskProgress
+	nil,                         // 25: smidr.v1.StartBuildRequest.EnvironmentVariablesEntry
+	(*BuildIdentifier)(nil),     // 26: smidr.v1.BuildIdentifier
+	(BuildState)(0),             // 27: smidr.v1.BuildState
+	(*TimeStampRange)(nil),      // 28: smidr.v1.TimeStampRange
 }
 var file_builds_proto_depIdxs = []int32{
-	13, // 0: smidr.v1.StartBuildRequest.environment_variables:type_name -> smidr.v1.StartBuildRequest.EnvironmentVariablesEntry
-	14, // 1: smidr.v1.BuildStatusResponse.build_identifier:type_name -> smidr.v1.BuildIdentifier
-	15, // 2: smidr.v1.BuildStatusResponse.state:type_name -> smidr.v1.BuildState
-	16, // 3: smidr.v1.BuildStatusResponse.timestamps:type_name -> smidr.v1.TimeStampRange
-	14, // 4: smidr.v1.BuildStatusRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
-	14, // 5: smidr.v1.BuildDetails.build_identifier:type_name -> smidr.v1.BuildIdentifier
-	15, // 6: smidr.v1.BuildDetails.build_state:type_name -> smidr.v1.BuildState
-	16, // 7: smidr.v1.BuildDetails.timestamps:type_name -> smidr.v1.TimeStampRange
-	15, // 8: smidr.v1.ListBuildsRequest.state_filter:type_name -> smidr.v1.BuildState
-	16, // 9: smidr.v1.ListBuildsRequest.time_range:type_name -> smidr.v1.TimeStampRange
-	3,  // 10: smidr.v1.ListBuildsResponse.builds:type_name -> smidr.v1.BuildDetails
-	14, // 11: smidr.v1.CancelBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
-	14, // 12: smidr.v1.GetBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
-	14, // 13: smidr.v1.DeleteBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
-	0,  // 14: smidr.v1.BuildService.StartBuild:input_type -> smidr.v1.StartBuildRequest
-	2,  // 15: smidr.v1.BuildService.GetBuildStatus:input_type -> smidr.v1.BuildStatusRequest
-	4,  // 16: smidr.v1.BuildService.ListBuilds:input_type -> smidr.v1.ListBuildsRequest
-	6,  // 17: smidr.v1.BuildService.CancelBuild:input_type -> smidr.v1.CancelBuildRequest
-	8,  // 18: smidr.v1.BuildService.GetBuild:input_type -> smidr.v1.GetBuildRequest
-	9,  // 19: smidr.v1.BuildService.DeleteBuild:input_type -> smidr.v1.DeleteBuildRequest
-	11, // 20: smidr.v1.BuildService.PurgeBuilds:input_type -> smidr.v1.PurgeBuildsRequest
-	1,  // 21: smidr.v1.BuildService.StartBuild:output_type -> smidr.v1.BuildStatusResponse
-	1,  // 22: smidr.v1.BuildService.GetBuildStatus:output_type -> smidr.v1.BuildStatusResponse
-	5,  // 23: smidr.v1.BuildService.ListBuilds:output_type -> smidr.v1.ListBuildsResponse
-	7,  // 24: smidr.v1.BuildService.CancelBuild:output_type -> smidr.v1.CancelBuildResponse
-	3,  // 25: smidr.v1.BuildService.GetBuild:output_type -> smidr.v1.BuildDetails
-	10, // 26: smidr.v1.BuildService.DeleteBuild:output_type -> smidr.v1.DeleteBuildResponse
-	12, // 27: smidr.v1.BuildService.PurgeBuilds:output_type -> smidr.v1.PurgeBuildsResponse
-	21, // [21:28] is the sub-list for method output_type
-	14, // [14:21] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	25, // 0: smidr.v1.StartBuildRequest.environment_variables:type_name -> smidr.v1.StartBuildRequest.EnvironmentVariablesEntry
+	26, // 1: smidr.v1.BuildStatusResponse.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	27, // 2: smidr.v1.BuildStatusResponse.state:type_name -> smidr.v1.BuildState
+	28, // 3: smidr.v1.BuildStatusResponse.timestamps:type_name -> smidr.v1.TimeStampRange
+	26, // 4: smidr.v1.BuildStatusRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	26, // 5: smidr.v1.BuildDetails.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	27, // 6: smidr.v1.BuildDetails.build_state:type_name -> smidr.v1.BuildState
+	28, // 7: smidr.v1.BuildDetails.timestamps:type_name -> smidr.v1.TimeStampRange
+	6,  // 8: smidr.v1.BuildDetails.diagnostics:type_name -> smidr.v1.BuildDiagnostic
+	5,  // 9: smidr.v1.BuildDetails.task_logs:type_name -> smidr.v1.TaskLogInfo
+	7,  // 10: smidr.v1.BuildDetails.recovery_actions:type_name -> smidr.v1.RecoveryAction
+	8,  // 11: smidr.v1.BuildDetails.layer_commits:type_name -> smidr.v1.LayerCommit
+	9,  // 12: smidr.v1.BuildDetails.layer_fetches:type_name -> smidr.v1.LayerFetch
+	27, // 13: smidr.v1.ListBuildsRequest.state_filter:type_name -> smidr.v1.BuildState
+	28, // 14: smidr.v1.ListBuildsRequest.time_range:type_name -> smidr.v1.TimeStampRange
+	4,  // 15: smidr.v1.ListBuildsResponse.builds:type_name -> smidr.v1.BuildDetails
+	26, // 16: smidr.v1.CancelBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	26, // 17: smidr.v1.GetBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	26, // 18: smidr.v1.DeleteBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	26, // 19: smidr.v1.WatchBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	26, // 20: smidr.v1.RetryBuildRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	26, // 21: smidr.v1.BuildEvent.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	22, // 22: smidr.v1.BuildEvent.state_change:type_name -> smidr.v1.BuildStateChange
+	23, // 23: smidr.v1.BuildEvent.phase_change:type_name -> smidr.v1.BuildPhaseChange
+	24, // 24: smidr.v1.BuildEvent.task_progress:type_name -> smidr.v1.TaskProgress
+	7,  // 25: smidr.v1.BuildEvent.recovery:type_name -> smidr.v1.RecoveryAction
+	9,  // 26: smidr.v1.BuildEvent.layer_fetch:type_name -> smidr.v1.LayerFetch
+	27, // 27: smidr.v1.BuildStateChange.previous_state:type_name -> smidr.v1.BuildState
+	27, // 28: smidr.v1.BuildStateChange.state:type_name -> smidr.v1.BuildState
+	0,  // 29: smidr.v1.BuildPhaseChange.phase:type_name -> smidr.v1.BuildPhase
+	1,  // 30: smidr.v1.BuildService.StartBuild:input_type -> smidr.v1.StartBuildRequest
+	3,  // 31: smidr.v1.BuildService.GetBuildStatus:input_type -> smidr.v1.BuildStatusRequest
+	10, // 32: smidr.v1.BuildService.ListBuilds:input_type -> smidr.v1.ListBuildsRequest
+	12, // 33: smidr.v1.BuildService.CancelBuild:input_type -> smidr.v1.CancelBuildRequest
+	14, // 34: smidr.v1.BuildService.GetBuild:input_type -> smidr.v1.GetBuildRequest
+	15, // 35: smidr.v1.BuildService.DeleteBuild:input_type -> smidr.v1.DeleteBuildRequest
+	17, // 36: smidr.v1.BuildService.PurgeBuilds:input_type -> smidr.v1.PurgeBuildsRequest
+	19, // 37: smidr.v1.BuildService.WatchBuild:input_type -> smidr.v1.WatchBuildRequest
+	20, // 38: smidr.v1.BuildService.RetryBuild:input_type -> smidr.v1.RetryBuildRequest
+	2,  // 39: smidr.v1.BuildService.StartBuild:output_type -> smidr.v1.BuildStatusResponse
+	2,  // 40: smidr.v1.BuildService.GetBuildStatus:output_type -> smidr.v1.BuildStatusResponse
+	11, // 41: smidr.v1.BuildService.ListBuilds:output_type -> smidr.v1.ListBuildsResponse
+	13, // 42: smidr.v1.BuildService.CancelBuild:output_type -> smidr.v1.CancelBuildResponse
+	4,  // 43: smidr.v1.BuildService.GetBuild:output_type -> smidr.v1.BuildDetails
+	16, // 44: smidr.v1.BuildService.DeleteBuild:output_type -> smidr.v1.DeleteBuildResponse
+	18, // 45: smidr.v1.BuildService.PurgeBuilds:output_type -> smidr.v1.PurgeBuildsResponse
+	21, // 46: smidr.v1.BuildService.WatchBuild:output_type -> smidr.v1.BuildEvent
+	2,  // 47: smidr.v1.BuildService.RetryBuild:output_type -> smidr.v1.BuildStatusResponse
+	39, // [39:48] is the sub-list for method output_type
+	30, // [30:39] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_builds_proto_init() }
@@ -1190,18 +2439,26 @@ func file_builds_proto_init() {
 		return
 	}
 	file_common_proto_init()
+	file_builds_proto_msgTypes[20].OneofWrappers = []any{
+		(*BuildEvent_StateChange)(nil),
+		(*BuildEvent_PhaseChange)(nil),
+		(*BuildEvent_TaskProgress)(nil),
+		(*BuildEvent_Recovery)(nil),
+		(*BuildEvent_LayerFetch)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_builds_proto_rawDesc), len(file_builds_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      1,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_builds_proto_goTypes,
 		DependencyIndexes: file_builds_proto_depIdxs,
+		EnumInfos:         file_builds_proto_enumTypes,
 		MessageInfos:      file_builds_proto_msgTypes,
 	}.Build()
 	File_builds_proto = out.File
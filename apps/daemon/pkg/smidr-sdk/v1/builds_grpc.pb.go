@@ -26,6 +26,8 @@ const (
 	BuildService_GetBuild_FullMethodName       = "/smidr.v1.BuildService/GetBuild"
 	BuildService_DeleteBuild_FullMethodName    = "/smidr.v1.BuildService/DeleteBuild"
 	BuildService_PurgeBuilds_FullMethodName    = "/smidr.v1.BuildService/PurgeBuilds"
+	BuildService_WatchBuild_FullMethodName     = "/smidr.v1.BuildService/WatchBuild"
+	BuildService_RetryBuild_FullMethodName     = "/smidr.v1.BuildService/RetryBuild"
 )
 
 // BuildServiceClient is the client API for BuildService service.
@@ -39,6 +41,8 @@ type BuildServiceClient interface {
 	GetBuild(ctx context.Context, in *GetBuildRequest, opts ...grpc.CallOption) (*BuildDetails, error)
 	DeleteBuild(ctx context.Context, in *DeleteBuildRequest, opts ...grpc.CallOption) (*DeleteBuildResponse, error)
 	PurgeBuilds(ctx context.Context, in *PurgeBuildsRequest, opts ...grpc.CallOption) (*PurgeBuildsResponse, error)
+	WatchBuild(ctx context.Context, in *WatchBuildRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BuildEvent], error)
+	RetryBuild(ctx context.Context, in *RetryBuildRequest, opts ...grpc.CallOption) (*BuildStatusResponse, error)
 }
 
 type buildServiceClient struct {
@@ -119,6 +123,35 @@ func (c *buildServiceClient) PurgeBuilds(ctx context.Context, in *PurgeBuildsReq
 	return out, nil
 }
 
+func (c *buildServiceClient) WatchBuild(ctx context.Context, in *WatchBuildRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BuildEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BuildService_ServiceDesc.Streams[0], BuildService_WatchBuild_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBuildRequest, BuildEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BuildService_WatchBuildClient = grpc.ServerStreamingClient[BuildEvent]
+
+func (c *buildServiceClient) RetryBuild(ctx context.Context, in *RetryBuildRequest, opts ...grpc.CallOption) (*BuildStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuildStatusResponse)
+	err := c.cc.Invoke(ctx, BuildService_RetryBuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BuildServiceServer is the server API for BuildService service.
 // All implementations must embed UnimplementedBuildServiceServer
 // for forward compatibility.
@@ -130,6 +163,8 @@ type BuildServiceServer interface {
 	GetBuild(context.Context, *GetBuildRequest) (*BuildDetails, error)
 	DeleteBuild(context.Context, *DeleteBuildRequest) (*DeleteBuildResponse, error)
 	PurgeBuilds(context.Context, *PurgeBuildsRequest) (*PurgeBuildsResponse, error)
+	WatchBuild(*WatchBuildRequest, grpc.ServerStreamingServer[BuildEvent]) error
+	RetryBuild(context.Context, *RetryBuildRequest) (*BuildStatusResponse, error)
 	mustEmbedUnimplementedBuildServiceServer()
 }
 
@@ -161,6 +196,12 @@ func (UnimplementedBuildServiceServer) DeleteBuild(context.Context, *DeleteBuild
 func (UnimplementedBuildServiceServer) PurgeBuilds(context.Context, *PurgeBuildsRequest) (*PurgeBuildsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PurgeBuilds not implemented")
 }
+func (UnimplementedBuildServiceServer) WatchBuild(*WatchBuildRequest, grpc.ServerStreamingServer[BuildEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBuild not implemented")
+}
+func (UnimplementedBuildServiceServer) RetryBuild(context.Context, *RetryBuildRequest) (*BuildStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryBuild not implemented")
+}
 func (UnimplementedBuildServiceServer) mustEmbedUnimplementedBuildServiceServer() {}
 func (UnimplementedBuildServiceServer) testEmbeddedByValue()                      {}
 
@@ -308,6 +349,35 @@ func _BuildService_PurgeBuilds_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BuildService_WatchBuild_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBuildRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BuildServiceServer).WatchBuild(m, &grpc.GenericServerStream[WatchBuildRequest, BuildEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BuildService_WatchBuildServer = grpc.ServerStreamingServer[BuildEvent]
+
+func _BuildService_RetryBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).RetryBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_RetryBuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).RetryBuild(ctx, req.(*RetryBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BuildService_ServiceDesc is the grpc.ServiceDesc for BuildService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,7 +413,17 @@ var BuildService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PurgeBuilds",
 			Handler:    _BuildService_PurgeBuilds_Handler,
 		},
+		{
+			MethodName: "RetryBuild",
+			Handler:    _BuildService_RetryBuild_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBuild",
+			Handler:       _BuildService_WatchBuild_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "builds.proto",
 }
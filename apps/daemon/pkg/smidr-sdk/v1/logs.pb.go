@@ -27,7 +27,21 @@ type StreamBuildLogsRequest struct {
 	// Identifier of the build whose logs are to be streamed.
 	BuildIdentifier *BuildIdentifier `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
 	// If true, continue streaming new logs as they are generated.
-	Follow        bool `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`
+	// Ignored for builds that are no longer running (logs are replayed from disk).
+	Follow bool `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`
+	// Only return entries from this stream (e.g., stdout, stderr). Empty means all streams.
+	Stream string `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	// Minimum log level to return (DEBUG, INFO, WARN, ERROR). Empty means all levels.
+	Level string `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	// Only return entries at or after this time (Unix seconds). Zero means no lower bound.
+	SinceUnixSeconds int64 `protobuf:"varint,5,opt,name=since_unix_seconds,json=sinceUnixSeconds,proto3" json:"since_unix_seconds,omitempty"`
+	// Only return entries before this time (Unix seconds). Zero means no upper bound.
+	UntilUnixSeconds int64 `protobuf:"varint,6,opt,name=until_unix_seconds,json=untilUnixSeconds,proto3" json:"until_unix_seconds,omitempty"`
+	// Only return the last N matching historical entries. Zero returns all of them.
+	Tail int32 `protobuf:"varint,7,opt,name=tail,proto3" json:"tail,omitempty"`
+	// Resume after this sequence number (the last LogEntry.sequence the client received).
+	// Zero starts from the beginning of the log.
+	AfterSequence uint64 `protobuf:"varint,8,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -76,6 +90,48 @@ func (x *StreamBuildLogsRequest) GetFollow() bool {
 	return false
 }
 
+func (x *StreamBuildLogsRequest) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *StreamBuildLogsRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *StreamBuildLogsRequest) GetSinceUnixSeconds() int64 {
+	if x != nil {
+		return x.SinceUnixSeconds
+	}
+	return 0
+}
+
+func (x *StreamBuildLogsRequest) GetUntilUnixSeconds() int64 {
+	if x != nil {
+		return x.UntilUnixSeconds
+	}
+	return 0
+}
+
+func (x *StreamBuildLogsRequest) GetTail() int32 {
+	if x != nil {
+		return x.Tail
+	}
+	return 0
+}
+
+func (x *StreamBuildLogsRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
 type LogEntry struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Timestamp of the log entry in Unix seconds.
@@ -87,7 +143,10 @@ type LogEntry struct {
 	// Source of the log entry (e.g., build step name).
 	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
 	// Which stream the log belongs to (e.g., stdout, stderr).
-	Stream        string `protobuf:"bytes,5,opt,name=stream,proto3" json:"stream,omitempty"`
+	Stream string `protobuf:"bytes,5,opt,name=stream,proto3" json:"stream,omitempty"`
+	// Position of the entry in the build log, starting at 1. Zero if unknown
+	// (e.g., logs replayed from builds that predate sequence numbers).
+	Sequence      uint64 `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -157,24 +216,206 @@ func (x *LogEntry) GetStream() string {
 	return ""
 }
 
+func (x *LogEntry) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+// GetTaskLogRequest requests the BitBake log (log.do_<task>) of a task that failed
+// in a build, as collected from the work directory after the failure.
+type GetTaskLogRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BuildIdentifier *BuildIdentifier       `protobuf:"bytes,1,opt,name=build_identifier,json=buildIdentifier,proto3" json:"build_identifier,omitempty"`
+	// Recipe name without version (e.g., busybox).
+	Recipe string `protobuf:"bytes,2,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	// Task name (e.g., do_compile). The do_ prefix may be omitted.
+	Task string `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	// Only return the last N bytes of the log. Zero returns the whole log, up to a
+	// server-side limit.
+	TailBytes     int64 `protobuf:"varint,4,opt,name=tail_bytes,json=tailBytes,proto3" json:"tail_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskLogRequest) Reset() {
+	*x = GetTaskLogRequest{}
+	mi := &file_logs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskLogRequest) ProtoMessage() {}
+
+func (x *GetTaskLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_logs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskLogRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskLogRequest) Descriptor() ([]byte, []int) {
+	return file_logs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTaskLogRequest) GetBuildIdentifier() *BuildIdentifier {
+	if x != nil {
+		return x.BuildIdentifier
+	}
+	return nil
+}
+
+func (x *GetTaskLogRequest) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *GetTaskLogRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *GetTaskLogRequest) GetTailBytes() int64 {
+	if x != nil {
+		return x.TailBytes
+	}
+	return 0
+}
+
+type GetTaskLogResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Recipe string                 `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Task   string                 `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Content of the log, or its end when truncated is set.
+	Content []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Size of the complete log.
+	SizeBytes int64 `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// True if content holds only the end of the log.
+	Truncated     bool `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskLogResponse) Reset() {
+	*x = GetTaskLogResponse{}
+	mi := &file_logs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskLogResponse) ProtoMessage() {}
+
+func (x *GetTaskLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_logs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskLogResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskLogResponse) Descriptor() ([]byte, []int) {
+	return file_logs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTaskLogResponse) GetRecipe() string {
+	if x != nil {
+		return x.Recipe
+	}
+	return ""
+}
+
+func (x *GetTaskLogResponse) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *GetTaskLogResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *GetTaskLogResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *GetTaskLogResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
 var File_logs_proto protoreflect.FileDescriptor
 
 const file_logs_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"logs.proto\x12\bsmidr.v1\x1a\fcommon.proto\"v\n" +
+	"logs.proto\x12\bsmidr.v1\x1a\fcommon.proto\"\xbb\x02\n" +
 	"\x16StreamBuildLogsRequest\x12D\n" +
 	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\x12\x16\n" +
-	"\x06follow\x18\x02 \x01(\bR\x06follow\"\xa0\x01\n" +
+	"\x06follow\x18\x02 \x01(\bR\x06follow\x12\x16\n" +
+	"\x06stream\x18\x03 \x01(\tR\x06stream\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12,\n" +
+	"\x12since_unix_seconds\x18\x05 \x01(\x03R\x10sinceUnixSeconds\x12,\n" +
+	"\x12until_unix_seconds\x18\x06 \x01(\x03R\x10untilUnixSeconds\x12\x12\n" +
+	"\x04tail\x18\a \x01(\x05R\x04tail\x12%\n" +
+	"\x0eafter_sequence\x18\b \x01(\x04R\rafterSequence\"\xbc\x01\n" +
 	"\bLogEntry\x124\n" +
 	"\x16timestamp_unix_seconds\x18\x01 \x01(\x03R\x14timestampUnixSeconds\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
 	"\x05level\x18\x03 \x01(\tR\x05level\x12\x16\n" +
 	"\x06source\x18\x04 \x01(\tR\x06source\x12\x16\n" +
-	"\x06stream\x18\x05 \x01(\tR\x06stream2W\n" +
+	"\x06stream\x18\x05 \x01(\tR\x06stream\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x04R\bsequence\"\xa4\x01\n" +
+	"\x11GetTaskLogRequest\x12D\n" +
+	"\x10build_identifier\x18\x01 \x01(\v2\x19.smidr.v1.BuildIdentifierR\x0fbuildIdentifier\x12\x16\n" +
+	"\x06recipe\x18\x02 \x01(\tR\x06recipe\x12\x12\n" +
+	"\x04task\x18\x03 \x01(\tR\x04task\x12\x1d\n" +
+	"\n" +
+	"tail_bytes\x18\x04 \x01(\x03R\ttailBytes\"\x97\x01\n" +
+	"\x12GetTaskLogResponse\x12\x16\n" +
+	"\x06recipe\x18\x01 \x01(\tR\x06recipe\x12\x12\n" +
+	"\x04task\x18\x02 \x01(\tR\x04task\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\x12\x1c\n" +
+	"\ttruncated\x18\x05 \x01(\bR\ttruncated2\xa0\x01\n" +
 	"\n" +
 	"LogService\x12I\n" +
-	"\x0fStreamBuildLogs\x12 .smidr.v1.StreamBuildLogsRequest\x1a\x12.smidr.v1.LogEntry0\x01B\x94\x01\n" +
+	"\x0fStreamBuildLogs\x12 .smidr.v1.StreamBuildLogsRequest\x1a\x12.smidr.v1.LogEntry0\x01\x12G\n" +
+	"\n" +
+	"GetTaskLog\x12\x1b.smidr.v1.GetTaskLogRequest\x1a\x1c.smidr.v1.GetTaskLogResponseB\x94\x01\n" +
 	"\fcom.smidr.v1B\tLogsProtoP\x01Z8github.com/schererja/smidr/sdks/pkg/smidr-sdk/v1;smidrv1\xa2\x02\x03SXX\xaa\x02\bSmidr.V1\xca\x02\bSmidr\\V1\xe2\x02\x14Smidr\\V1\\GPBMetadata\xea\x02\tSmidr::V1b\x06proto3"
 
 var (
@@ -189,21 +430,26 @@ func file_logs_proto_rawDescGZIP() []byte {
 	return file_logs_proto_rawDescData
 }
 
-var file_logs_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_logs_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_logs_proto_goTypes = []any{
 	(*StreamBuildLogsRequest)(nil), // 0: smidr.v1.StreamBuildLogsRequest
 	(*LogEntry)(nil),               // 1: smidr.v1.LogEntry
-	(*BuildIdentifier)(nil),        // 2: smidr.v1.BuildIdentifier
+	(*GetTaskLogRequest)(nil),      // 2: smidr.v1.GetTaskLogRequest
+	(*GetTaskLogResponse)(nil),     // 3: smidr.v1.GetTaskLogResponse
+	(*BuildIdentifier)(nil),        // 4: smidr.v1.BuildIdentifier
 }
 var file_logs_proto_depIdxs = []int32{
-	2, // 0: smidr.v1.StreamBuildLogsRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
-	0, // 1: smidr.v1.LogService.StreamBuildLogs:input_type -> smidr.v1.StreamBuildLogsRequest
-	1, // 2: smidr.v1.LogService.StreamBuildLogs:output_type -> smidr.v1.LogEntry
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 0: smidr.v1.StreamBuildLogsRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	4, // 1: smidr.v1.GetTaskLogRequest.build_identifier:type_name -> smidr.v1.BuildIdentifier
+	0, // 2: smidr.v1.LogService.StreamBuildLogs:input_type -> smidr.v1.StreamBuildLogsRequest
+	2, // 3: smidr.v1.LogService.GetTaskLog:input_type -> smidr.v1.GetTaskLogRequest
+	1, // 4: smidr.v1.LogService.StreamBuildLogs:output_type -> smidr.v1.LogEntry
+	3, // 5: smidr.v1.LogService.GetTaskLog:output_type -> smidr.v1.GetTaskLogResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_logs_proto_init() }
@@ -218,7 +464,7 @@ func file_logs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_logs_proto_rawDesc), len(file_logs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
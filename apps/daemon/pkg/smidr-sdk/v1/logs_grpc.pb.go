@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	LogService_StreamBuildLogs_FullMethodName = "/smidr.v1.LogService/StreamBuildLogs"
+	LogService_GetTaskLog_FullMethodName      = "/smidr.v1.LogService/GetTaskLog"
 )
 
 // LogServiceClient is the client API for LogService service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LogServiceClient interface {
 	StreamBuildLogs(ctx context.Context, in *StreamBuildLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error)
+	GetTaskLog(ctx context.Context, in *GetTaskLogRequest, opts ...grpc.CallOption) (*GetTaskLogResponse, error)
 }
 
 type logServiceClient struct {
@@ -56,11 +58,22 @@ func (c *logServiceClient) StreamBuildLogs(ctx context.Context, in *StreamBuildL
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type LogService_StreamBuildLogsClient = grpc.ServerStreamingClient[LogEntry]
 
+func (c *logServiceClient) GetTaskLog(ctx context.Context, in *GetTaskLogRequest, opts ...grpc.CallOption) (*GetTaskLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskLogResponse)
+	err := c.cc.Invoke(ctx, LogService_GetTaskLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogServiceServer is the server API for LogService service.
 // All implementations must embed UnimplementedLogServiceServer
 // for forward compatibility.
 type LogServiceServer interface {
 	StreamBuildLogs(*StreamBuildLogsRequest, grpc.ServerStreamingServer[LogEntry]) error
+	GetTaskLog(context.Context, *GetTaskLogRequest) (*GetTaskLogResponse, error)
 	mustEmbedUnimplementedLogServiceServer()
 }
 
@@ -74,6 +87,9 @@ type UnimplementedLogServiceServer struct{}
 func (UnimplementedLogServiceServer) StreamBuildLogs(*StreamBuildLogsRequest, grpc.ServerStreamingServer[LogEntry]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamBuildLogs not implemented")
 }
+func (UnimplementedLogServiceServer) GetTaskLog(context.Context, *GetTaskLogRequest) (*GetTaskLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskLog not implemented")
+}
 func (UnimplementedLogServiceServer) mustEmbedUnimplementedLogServiceServer() {}
 func (UnimplementedLogServiceServer) testEmbeddedByValue()                    {}
 
@@ -106,13 +122,36 @@ func _LogService_StreamBuildLogs_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type LogService_StreamBuildLogsServer = grpc.ServerStreamingServer[LogEntry]
 
+func _LogService_GetTaskLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogServiceServer).GetTaskLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogService_GetTaskLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogServiceServer).GetTaskLog(ctx, req.(*GetTaskLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LogService_ServiceDesc is the grpc.ServiceDesc for LogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var LogService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "smidr.v1.LogService",
 	HandlerType: (*LogServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTaskLog",
+			Handler:    _LogService_GetTaskLog_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "StreamBuildLogs",
@@ -31,36 +31,40 @@ namespace Smidr.V1 {
             "Mhkuc21pZHIudjEuQXJ0aWZhY3RTdW1tYXJ5UglhcnRpZmFjdHMSRAoQYnVp",
             "bGRfaWRlbnRpZmllchgCIAEoCzIZLnNtaWRyLnYxLkJ1aWxkSWRlbnRpZmll",
             "clIPYnVpbGRJZGVudGlmaWVyIjUKEkdldEFydGlmYWN0UmVxdWVzdBIfCgth",
-            "cnRpZmFjdF9pZBgBIAEoCVIKYXJ0aWZhY3RJZCI6ChdEb3dubG9hZEFydGlm",
-            "YWN0UmVxdWVzdBIfCgthcnRpZmFjdF9pZBgBIAEoCVIKYXJ0aWZhY3RJZCI9",
-            "ChhEb3dubG9hZEFydGlmYWN0UmVzcG9uc2USIQoMZG93bmxvYWRfdXJsGAEg",
-            "ASgJUgtkb3dubG9hZFVybCI4ChVEZWxldGVBcnRpZmFjdFJlcXVlc3QSHwoL",
-            "YXJ0aWZhY3RfaWQYASABKAlSCmFydGlmYWN0SWQiMgoWRGVsZXRlQXJ0aWZh",
-            "Y3RSZXNwb25zZRIYCgdzdWNjZXNzGAEgASgIUgdzdWNjZXNzIrgBCg9BcnRp",
-            "ZmFjdFN1bW1hcnkSHwoLYXJ0aWZhY3RfaWQYASABKAlSCmFydGlmYWN0SWQS",
-            "EgoEbmFtZRgCIAEoCVIEbmFtZRISCgR0eXBlGAMgASgJUgR0eXBlEiEKDGRv",
-            "d25sb2FkX3VybBgEIAEoCVILZG93bmxvYWRVcmwSHQoKc2l6ZV9ieXRlcxgF",
-            "IAEoA1IJc2l6ZUJ5dGVzEhoKCGNoZWNrc3VtGAYgASgJUghjaGVja3N1bTLb",
-            "AgoPQXJ0aWZhY3RTZXJ2aWNlElAKDUxpc3RBcnRpZmFjdHMSHi5zbWlkci52",
-            "MS5MaXN0QXJ0aWZhY3RzUmVxdWVzdBofLnNtaWRyLnYxLkxpc3RBcnRpZmFj",
-            "dHNSZXNwb25zZRJGCgtHZXRBcnRpZmFjdBIcLnNtaWRyLnYxLkdldEFydGlm",
-            "YWN0UmVxdWVzdBoZLnNtaWRyLnYxLkFydGlmYWN0U3VtbWFyeRJZChBEb3du",
-            "bG9hZEFydGlmYWN0EiEuc21pZHIudjEuRG93bmxvYWRBcnRpZmFjdFJlcXVl",
-            "c3QaIi5zbWlkci52MS5Eb3dubG9hZEFydGlmYWN0UmVzcG9uc2USUwoORGVs",
-            "ZXRlQXJ0aWZhY3QSHy5zbWlkci52MS5EZWxldGVBcnRpZmFjdFJlcXVlc3Qa",
-            "IC5zbWlkci52MS5EZWxldGVBcnRpZmFjdFJlc3BvbnNlQpkBCgxjb20uc21p",
-            "ZHIudjFCDkFydGlmYWN0c1Byb3RvUAFaOGdpdGh1Yi5jb20vc2NoZXJlcmph",
-            "L3NtaWRyL3Nka3MvcGtnL3NtaWRyLXNkay92MTtzbWlkcnYxogIDU1hYqgII",
-            "U21pZHIuVjHKAghTbWlkclxWMeICFFNtaWRyXFYxXEdQQk1ldGFkYXRh6gIJ",
-            "U21pZHI6OlYxYgZwcm90bzM="));
+            "cnRpZmFjdF9pZBgBIAEoCVIKYXJ0aWZhY3RJZCJxChdEb3dubG9hZEFydGlm",
+            "YWN0UmVxdWVzdBIfCgthcnRpZmFjdF9pZBgBIAEoCVIKYXJ0aWZhY3RJZBIW",
+            "CgZvZmZzZXQYAiABKANSBm9mZnNldBIdCgpjaHVua19zaXplGAMgASgFUglj",
+            "aHVua1NpemUiuAEKGERvd25sb2FkQXJ0aWZhY3RSZXNwb25zZRIhCgxkb3du",
+            "bG9hZF91cmwYASABKAlSC2Rvd25sb2FkVXJsEhIKBGRhdGEYAiABKAxSBGRh",
+            "dGESFgoGb2Zmc2V0GAMgASgDUgZvZmZzZXQSHQoKdG90YWxfc2l6ZRgEIAEo",
+            "A1IJdG90YWxTaXplEhoKCGNoZWNrc3VtGAUgASgJUghjaGVja3N1bRISCgRs",
+            "YXN0GAYgASgIUgRsYXN0IjgKFURlbGV0ZUFydGlmYWN0UmVxdWVzdBIfCgth",
+            "cnRpZmFjdF9pZBgBIAEoCVIKYXJ0aWZhY3RJZCIyChZEZWxldGVBcnRpZmFj",
+            "dFJlc3BvbnNlEhgKB3N1Y2Nlc3MYASABKAhSB3N1Y2Nlc3MiuAEKD0FydGlm",
+            "YWN0U3VtbWFyeRIfCgthcnRpZmFjdF9pZBgBIAEoCVIKYXJ0aWZhY3RJZBIS",
+            "CgRuYW1lGAIgASgJUgRuYW1lEhIKBHR5cGUYAyABKAlSBHR5cGUSIQoMZG93",
+            "bmxvYWRfdXJsGAQgASgJUgtkb3dubG9hZFVybBIdCgpzaXplX2J5dGVzGAUg",
+            "ASgDUglzaXplQnl0ZXMSGgoIY2hlY2tzdW0YBiABKAlSCGNoZWNrc3VtMt0C",
+            "Cg9BcnRpZmFjdFNlcnZpY2USUAoNTGlzdEFydGlmYWN0cxIeLnNtaWRyLnYx",
+            "Lkxpc3RBcnRpZmFjdHNSZXF1ZXN0Gh8uc21pZHIudjEuTGlzdEFydGlmYWN0",
+            "c1Jlc3BvbnNlEkYKC0dldEFydGlmYWN0Ehwuc21pZHIudjEuR2V0QXJ0aWZh",
+            "Y3RSZXF1ZXN0Ghkuc21pZHIudjEuQXJ0aWZhY3RTdW1tYXJ5ElsKEERvd25s",
+            "b2FkQXJ0aWZhY3QSIS5zbWlkci52MS5Eb3dubG9hZEFydGlmYWN0UmVxdWVz",
+            "dBoiLnNtaWRyLnYxLkRvd25sb2FkQXJ0aWZhY3RSZXNwb25zZTABElMKDkRl",
+            "bGV0ZUFydGlmYWN0Eh8uc21pZHIudjEuRGVsZXRlQXJ0aWZhY3RSZXF1ZXN0",
+            "GiAuc21pZHIudjEuRGVsZXRlQXJ0aWZhY3RSZXNwb25zZUKZAQoMY29tLnNt",
+            "aWRyLnYxQg5BcnRpZmFjdHNQcm90b1ABWjhnaXRodWIuY29tL3NjaGVyZXJq",
+            "YS9zbWlkci9zZGtzL3BrZy9zbWlkci1zZGsvdjE7c21pZHJ2MaICA1NYWKoC",
+            "CFNtaWRyLlYxygIIU21pZHJcVjHiAhRTbWlkclxWMVxHUEJNZXRhZGF0YeoC",
+            "CVNtaWRyOjpWMWIGcHJvdG8z"));
       descriptor = pbr::FileDescriptor.FromGeneratedCode(descriptorData,
           new pbr::FileDescriptor[] { global::Smidr.V1.CommonReflection.Descriptor, },
           new pbr::GeneratedClrTypeInfo(null, null, new pbr::GeneratedClrTypeInfo[] {
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.ListArtifactsRequest), global::Smidr.V1.ListArtifactsRequest.Parser, new[]{ "BuildIdentifier" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.ListArtifactsResponse), global::Smidr.V1.ListArtifactsResponse.Parser, new[]{ "Artifacts", "BuildIdentifier" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.GetArtifactRequest), global::Smidr.V1.GetArtifactRequest.Parser, new[]{ "ArtifactId" }, null, null, null, null),
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DownloadArtifactRequest), global::Smidr.V1.DownloadArtifactRequest.Parser, new[]{ "ArtifactId" }, null, null, null, null),
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DownloadArtifactResponse), global::Smidr.V1.DownloadArtifactResponse.Parser, new[]{ "DownloadUrl" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DownloadArtifactRequest), global::Smidr.V1.DownloadArtifactRequest.Parser, new[]{ "ArtifactId", "Offset", "ChunkSize" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DownloadArtifactResponse), global::Smidr.V1.DownloadArtifactResponse.Parser, new[]{ "DownloadUrl", "Data", "Offset", "TotalSize", "Checksum", "Last" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DeleteArtifactRequest), global::Smidr.V1.DeleteArtifactRequest.Parser, new[]{ "ArtifactId" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DeleteArtifactResponse), global::Smidr.V1.DeleteArtifactResponse.Parser, new[]{ "Success" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.ArtifactSummary), global::Smidr.V1.ArtifactSummary.Parser, new[]{ "ArtifactId", "Name", "Type", "DownloadUrl", "SizeBytes", "Checksum" }, null, null, null, null)
@@ -756,6 +760,8 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public DownloadArtifactRequest(DownloadArtifactRequest other) : this() {
       artifactId_ = other.artifactId_;
+      offset_ = other.offset_;
+      chunkSize_ = other.chunkSize_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
@@ -777,6 +783,36 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "offset" field.</summary>
+    public const int OffsetFieldNumber = 2;
+    private long offset_;
+    /// <summary>
+    /// Byte offset to start streaming from, used to resume partial downloads.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long Offset {
+      get { return offset_; }
+      set {
+        offset_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "chunk_size" field.</summary>
+    public const int ChunkSizeFieldNumber = 3;
+    private int chunkSize_;
+    /// <summary>
+    /// Preferred chunk size in bytes (0 = server default).
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int ChunkSize {
+      get { return chunkSize_; }
+      set {
+        chunkSize_ = value;
+      }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
@@ -793,6 +829,8 @@ namespace Smidr.V1 {
         return true;
       }
       if (ArtifactId != other.ArtifactId) return false;
+      if (Offset != other.Offset) return false;
+      if (ChunkSize != other.ChunkSize) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -801,6 +839,8 @@ namespace Smidr.V1 {
     public override int GetHashCode() {
       int hash = 1;
       if (ArtifactId.Length != 0) hash ^= ArtifactId.GetHashCode();
+      if (Offset != 0L) hash ^= Offset.GetHashCode();
+      if (ChunkSize != 0) hash ^= ChunkSize.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -823,6 +863,14 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteString(ArtifactId);
       }
+      if (Offset != 0L) {
+        output.WriteRawTag(16);
+        output.WriteInt64(Offset);
+      }
+      if (ChunkSize != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(ChunkSize);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -837,6 +885,14 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteString(ArtifactId);
       }
+      if (Offset != 0L) {
+        output.WriteRawTag(16);
+        output.WriteInt64(Offset);
+      }
+      if (ChunkSize != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(ChunkSize);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -850,6 +906,12 @@ namespace Smidr.V1 {
       if (ArtifactId.Length != 0) {
         size += 1 + pb::CodedOutputStream.ComputeStringSize(ArtifactId);
       }
+      if (Offset != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(Offset);
+      }
+      if (ChunkSize != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(ChunkSize);
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -865,6 +927,12 @@ namespace Smidr.V1 {
       if (other.ArtifactId.Length != 0) {
         ArtifactId = other.ArtifactId;
       }
+      if (other.Offset != 0L) {
+        Offset = other.Offset;
+      }
+      if (other.ChunkSize != 0) {
+        ChunkSize = other.ChunkSize;
+      }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -888,6 +956,14 @@ namespace Smidr.V1 {
             ArtifactId = input.ReadString();
             break;
           }
+          case 16: {
+            Offset = input.ReadInt64();
+            break;
+          }
+          case 24: {
+            ChunkSize = input.ReadInt32();
+            break;
+          }
         }
       }
     #endif
@@ -911,6 +987,14 @@ namespace Smidr.V1 {
             ArtifactId = input.ReadString();
             break;
           }
+          case 16: {
+            Offset = input.ReadInt64();
+            break;
+          }
+          case 24: {
+            ChunkSize = input.ReadInt32();
+            break;
+          }
         }
       }
     }
@@ -919,7 +1003,8 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// DownloadArtifactResponse provides the download URL for a requested artifact.
+  /// DownloadArtifactResponse carries one chunk of artifact content.
+  /// The final chunk has last set and carries the SHA-256 of the whole file.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
   public sealed partial class DownloadArtifactResponse : pb::IMessage<DownloadArtifactResponse>
@@ -957,6 +1042,11 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public DownloadArtifactResponse(DownloadArtifactResponse other) : this() {
       downloadUrl_ = other.downloadUrl_;
+      data_ = other.data_;
+      offset_ = other.offset_;
+      totalSize_ = other.totalSize_;
+      checksum_ = other.checksum_;
+      last_ = other.last_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
@@ -978,6 +1068,81 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "data" field.</summary>
+    public const int DataFieldNumber = 2;
+    private pb::ByteString data_ = pb::ByteString.Empty;
+    /// <summary>
+    /// Raw artifact bytes for this chunk.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pb::ByteString Data {
+      get { return data_; }
+      set {
+        data_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "offset" field.</summary>
+    public const int OffsetFieldNumber = 3;
+    private long offset_;
+    /// <summary>
+    /// Byte offset of data within the artifact.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long Offset {
+      get { return offset_; }
+      set {
+        offset_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "total_size" field.</summary>
+    public const int TotalSizeFieldNumber = 4;
+    private long totalSize_;
+    /// <summary>
+    /// Total size of the artifact in bytes.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long TotalSize {
+      get { return totalSize_; }
+      set {
+        totalSize_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "checksum" field.</summary>
+    public const int ChecksumFieldNumber = 5;
+    private string checksum_ = "";
+    /// <summary>
+    /// Hex-encoded SHA-256 of the complete artifact (set on the last chunk).
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Checksum {
+      get { return checksum_; }
+      set {
+        checksum_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "last" field.</summary>
+    public const int LastFieldNumber = 6;
+    private bool last_;
+    /// <summary>
+    /// True on the final chunk of the stream.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Last {
+      get { return last_; }
+      set {
+        last_ = value;
+      }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
@@ -994,6 +1159,11 @@ namespace Smidr.V1 {
         return true;
       }
       if (DownloadUrl != other.DownloadUrl) return false;
+      if (Data != other.Data) return false;
+      if (Offset != other.Offset) return false;
+      if (TotalSize != other.TotalSize) return false;
+      if (Checksum != other.Checksum) return false;
+      if (Last != other.Last) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -1002,6 +1172,11 @@ namespace Smidr.V1 {
     public override int GetHashCode() {
       int hash = 1;
       if (DownloadUrl.Length != 0) hash ^= DownloadUrl.GetHashCode();
+      if (Data.Length != 0) hash ^= Data.GetHashCode();
+      if (Offset != 0L) hash ^= Offset.GetHashCode();
+      if (TotalSize != 0L) hash ^= TotalSize.GetHashCode();
+      if (Checksum.Length != 0) hash ^= Checksum.GetHashCode();
+      if (Last != false) hash ^= Last.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -1024,6 +1199,26 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteString(DownloadUrl);
       }
+      if (Data.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteBytes(Data);
+      }
+      if (Offset != 0L) {
+        output.WriteRawTag(24);
+        output.WriteInt64(Offset);
+      }
+      if (TotalSize != 0L) {
+        output.WriteRawTag(32);
+        output.WriteInt64(TotalSize);
+      }
+      if (Checksum.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Checksum);
+      }
+      if (Last != false) {
+        output.WriteRawTag(48);
+        output.WriteBool(Last);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -1038,6 +1233,26 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteString(DownloadUrl);
       }
+      if (Data.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteBytes(Data);
+      }
+      if (Offset != 0L) {
+        output.WriteRawTag(24);
+        output.WriteInt64(Offset);
+      }
+      if (TotalSize != 0L) {
+        output.WriteRawTag(32);
+        output.WriteInt64(TotalSize);
+      }
+      if (Checksum.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Checksum);
+      }
+      if (Last != false) {
+        output.WriteRawTag(48);
+        output.WriteBool(Last);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -1051,6 +1266,21 @@ namespace Smidr.V1 {
       if (DownloadUrl.Length != 0) {
         size += 1 + pb::CodedOutputStream.ComputeStringSize(DownloadUrl);
       }
+      if (Data.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeBytesSize(Data);
+      }
+      if (Offset != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(Offset);
+      }
+      if (TotalSize != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(TotalSize);
+      }
+      if (Checksum.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Checksum);
+      }
+      if (Last != false) {
+        size += 1 + 1;
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -1066,6 +1296,21 @@ namespace Smidr.V1 {
       if (other.DownloadUrl.Length != 0) {
         DownloadUrl = other.DownloadUrl;
       }
+      if (other.Data.Length != 0) {
+        Data = other.Data;
+      }
+      if (other.Offset != 0L) {
+        Offset = other.Offset;
+      }
+      if (other.TotalSize != 0L) {
+        TotalSize = other.TotalSize;
+      }
+      if (other.Checksum.Length != 0) {
+        Checksum = other.Checksum;
+      }
+      if (other.Last != false) {
+        Last = other.Last;
+      }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -1089,6 +1334,26 @@ namespace Smidr.V1 {
             DownloadUrl = input.ReadString();
             break;
           }
+          case 18: {
+            Data = input.ReadBytes();
+            break;
+          }
+          case 24: {
+            Offset = input.ReadInt64();
+            break;
+          }
+          case 32: {
+            TotalSize = input.ReadInt64();
+            break;
+          }
+          case 42: {
+            Checksum = input.ReadString();
+            break;
+          }
+          case 48: {
+            Last = input.ReadBool();
+            break;
+          }
         }
       }
     #endif
@@ -1112,6 +1377,26 @@ namespace Smidr.V1 {
             DownloadUrl = input.ReadString();
             break;
           }
+          case 18: {
+            Data = input.ReadBytes();
+            break;
+          }
+          case 24: {
+            Offset = input.ReadInt64();
+            break;
+          }
+          case 32: {
+            TotalSize = input.ReadInt64();
+            break;
+          }
+          case 42: {
+            Checksum = input.ReadString();
+            break;
+          }
+          case 48: {
+            Last = input.ReadBool();
+            break;
+          }
         }
       }
     }
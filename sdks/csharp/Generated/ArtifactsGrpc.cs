@@ -83,7 +83,7 @@ namespace Smidr.V1 {
 
     [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
     static readonly grpc::Method<global::Smidr.V1.DownloadArtifactRequest, global::Smidr.V1.DownloadArtifactResponse> __Method_DownloadArtifact = new grpc::Method<global::Smidr.V1.DownloadArtifactRequest, global::Smidr.V1.DownloadArtifactResponse>(
-        grpc::MethodType.Unary,
+        grpc::MethodType.ServerStreaming,
         __ServiceName,
         "DownloadArtifact",
         __Marshaller_smidr_v1_DownloadArtifactRequest,
@@ -120,7 +120,7 @@ namespace Smidr.V1 {
       }
 
       [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
-      public virtual global::System.Threading.Tasks.Task<global::Smidr.V1.DownloadArtifactResponse> DownloadArtifact(global::Smidr.V1.DownloadArtifactRequest request, grpc::ServerCallContext context)
+      public virtual global::System.Threading.Tasks.Task DownloadArtifact(global::Smidr.V1.DownloadArtifactRequest request, grpc::IServerStreamWriter<global::Smidr.V1.DownloadArtifactResponse> responseStream, grpc::ServerCallContext context)
       {
         throw new grpc::RpcException(new grpc::Status(grpc::StatusCode.Unimplemented, ""));
       }
@@ -201,24 +201,14 @@ namespace Smidr.V1 {
         return CallInvoker.AsyncUnaryCall(__Method_GetArtifact, null, options, request);
       }
       [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
-      public virtual global::Smidr.V1.DownloadArtifactResponse DownloadArtifact(global::Smidr.V1.DownloadArtifactRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
+      public virtual grpc::AsyncServerStreamingCall<global::Smidr.V1.DownloadArtifactResponse> DownloadArtifact(global::Smidr.V1.DownloadArtifactRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
       {
         return DownloadArtifact(request, new grpc::CallOptions(headers, deadline, cancellationToken));
       }
       [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
-      public virtual global::Smidr.V1.DownloadArtifactResponse DownloadArtifact(global::Smidr.V1.DownloadArtifactRequest request, grpc::CallOptions options)
-      {
-        return CallInvoker.BlockingUnaryCall(__Method_DownloadArtifact, null, options, request);
-      }
-      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
-      public virtual grpc::AsyncUnaryCall<global::Smidr.V1.DownloadArtifactResponse> DownloadArtifactAsync(global::Smidr.V1.DownloadArtifactRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
-      {
-        return DownloadArtifactAsync(request, new grpc::CallOptions(headers, deadline, cancellationToken));
-      }
-      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
-      public virtual grpc::AsyncUnaryCall<global::Smidr.V1.DownloadArtifactResponse> DownloadArtifactAsync(global::Smidr.V1.DownloadArtifactRequest request, grpc::CallOptions options)
+      public virtual grpc::AsyncServerStreamingCall<global::Smidr.V1.DownloadArtifactResponse> DownloadArtifact(global::Smidr.V1.DownloadArtifactRequest request, grpc::CallOptions options)
       {
-        return CallInvoker.AsyncUnaryCall(__Method_DownloadArtifact, null, options, request);
+        return CallInvoker.AsyncServerStreamingCall(__Method_DownloadArtifact, null, options, request);
       }
       [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
       public virtual global::Smidr.V1.DeleteArtifactResponse DeleteArtifact(global::Smidr.V1.DeleteArtifactRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
@@ -269,7 +259,7 @@ namespace Smidr.V1 {
     {
       serviceBinder.AddMethod(__Method_ListArtifacts, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.ListArtifactsRequest, global::Smidr.V1.ListArtifactsResponse>(serviceImpl.ListArtifacts));
       serviceBinder.AddMethod(__Method_GetArtifact, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.GetArtifactRequest, global::Smidr.V1.ArtifactSummary>(serviceImpl.GetArtifact));
-      serviceBinder.AddMethod(__Method_DownloadArtifact, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.DownloadArtifactRequest, global::Smidr.V1.DownloadArtifactResponse>(serviceImpl.DownloadArtifact));
+      serviceBinder.AddMethod(__Method_DownloadArtifact, serviceImpl == null ? null : new grpc::ServerStreamingServerMethod<global::Smidr.V1.DownloadArtifactRequest, global::Smidr.V1.DownloadArtifactResponse>(serviceImpl.DownloadArtifact));
       serviceBinder.AddMethod(__Method_DeleteArtifact, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.DeleteArtifactRequest, global::Smidr.V1.DeleteArtifactResponse>(serviceImpl.DeleteArtifact));
     }
 
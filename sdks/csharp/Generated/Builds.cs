@@ -24,93 +24,163 @@ namespace Smidr.V1 {
     static BuildsReflection() {
       byte[] descriptorData = global::System.Convert.FromBase64String(
           string.Concat(
-            "CgxidWlsZHMucHJvdG8SCHNtaWRyLnYxGgxjb21tb24ucHJvdG8i5QIKEVN0",
+            "CgxidWlsZHMucHJvdG8SCHNtaWRyLnYxGgxjb21tb24ucHJvdG8itAMKEVN0",
             "YXJ0QnVpbGRSZXF1ZXN0EhYKBmNvbmZpZxgBIAEoCVIGY29uZmlnEhYKBnRh",
             "cmdldBgCIAEoCVIGdGFyZ2V0Eh8KC2ZvcmNlX2NsZWFuGAMgASgIUgpmb3Jj",
             "ZUNsZWFuEi4KE2ZvcmNlX2ltYWdlX3JlYnVpbGQYBCABKAhSEWZvcmNlSW1h",
             "Z2VSZWJ1aWxkEmoKFWVudmlyb25tZW50X3ZhcmlhYmxlcxgFIAMoCzI1LnNt",
             "aWRyLnYxLlN0YXJ0QnVpbGRSZXF1ZXN0LkVudmlyb25tZW50VmFyaWFibGVz",
             "RW50cnlSFGVudmlyb25tZW50VmFyaWFibGVzEhoKCGN1c3RvbWVyGAYgASgJ",
-            "UghjdXN0b21lchpHChlFbnZpcm9ubWVudFZhcmlhYmxlc0VudHJ5EhAKA2tl",
-            "eRgBIAEoCVIDa2V5EhQKBXZhbHVlGAIgASgJUgV2YWx1ZToCOAEi8gIKE0J1",
-            "aWxkU3RhdHVzUmVzcG9uc2USRAoQYnVpbGRfaWRlbnRpZmllchgBIAEoCzIZ",
-            "LnNtaWRyLnYxLkJ1aWxkSWRlbnRpZmllclIPYnVpbGRJZGVudGlmaWVyEhYK",
-            "BnRhcmdldBgCIAEoCVIGdGFyZ2V0EioKBXN0YXRlGAMgASgOMhQuc21pZHIu",
-            "djEuQnVpbGRTdGF0ZVIFc3RhdGUSGwoJZXhpdF9jb2RlGAQgASgFUghleGl0",
-            "Q29kZRIjCg1lcnJvcl9tZXNzYWdlGAUgASgJUgxlcnJvck1lc3NhZ2USOAoK",
-            "dGltZXN0YW1wcxgGIAEoCzIYLnNtaWRyLnYxLlRpbWVTdGFtcFJhbmdlUgp0",
-            "aW1lc3RhbXBzEh8KC2NvbmZpZ19wYXRoGAcgASgJUgpjb25maWdQYXRoEhoK",
-            "CGN1c3RvbWVyGAggASgJUghjdXN0b21lchIYCgdkZWxldGVkGAkgASgIUgdk",
-            "ZWxldGVkIloKEkJ1aWxkU3RhdHVzUmVxdWVzdBJEChBidWlsZF9pZGVudGlm",
-            "aWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyUg9idWlsZElk",
-            "ZW50aWZpZXIi/gYKDEJ1aWxkRGV0YWlscxJEChBidWlsZF9pZGVudGlmaWVy",
-            "GAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyUg9idWlsZElkZW50",
-            "aWZpZXISGgoIY3VzdG9tZXIYAiABKAlSCGN1c3RvbWVyEiEKDHByb2plY3Rf",
-            "bmFtZRgDIAEoCVILcHJvamVjdE5hbWUSIQoMdGFyZ2V0X2ltYWdlGAQgASgJ",
-            "Ugt0YXJnZXRJbWFnZRIYCgdtYWNoaW5lGAUgASgJUgdtYWNoaW5lEjUKC2J1",
-            "aWxkX3N0YXRlGAYgASgOMhQuc21pZHIudjEuQnVpbGRTdGF0ZVIKYnVpbGRT",
-            "dGF0ZRIbCglleGl0X2NvZGUYByABKAVSCGV4aXRDb2RlEicKD2J1aWxkX2Rp",
-            "cmVjdG9yeRgIIAEoCVIOYnVpbGREaXJlY3RvcnkSLQoSZG93bmxvYWRfZGly",
-            "ZWN0b3J5GAkgASgJUhFkb3dubG9hZERpcmVjdG9yeRIkCg5sb2dfZmlsZV9w",
-            "bGFpbhgKIAEoCVIMbG9nRmlsZVBsYWluEiQKDmxvZ19maWxlX2pzb25sGAsg",
-            "ASgJUgxsb2dGaWxlSnNvbmwSHwoLY29uZmlnX2ZpbGUYDCABKAlSCmNvbmZp",
-            "Z0ZpbGUSJwoPY29uZmlnX3NuYXBzaG90GA0gASgJUg5jb25maWdTbmFwc2hv",
-            "dBISCgR1c2VyGA4gASgJUgR1c2VyEhIKBGhvc3QYDyABKAlSBGhvc3QSHQoK",
-            "Y3JlYXRlZF9hdBgQIAEoA1IJY3JlYXRlZEF0EjgKCnRpbWVzdGFtcHMYESAB",
-            "KAsyGC5zbWlkci52MS5UaW1lU3RhbXBSYW5nZVIKdGltZXN0YW1wcxIpChBk",
-            "dXJhdGlvbl9zZWNvbmRzGBIgASgFUg9kdXJhdGlvblNlY29uZHMSGAoHZGVs",
-            "ZXRlZBgTIAEoCFIHZGVsZXRlZBIdCgpkZWxldGVkX2F0GBQgASgDUglkZWxl",
-            "dGVkQXQSIwoNZXJyb3JfbWVzc2FnZRgVIAEoCVIMZXJyb3JNZXNzYWdlEiUK",
-            "DmFydGlmYWN0X2NvdW50GBYgASgFUg1hcnRpZmFjdENvdW50EjkKGXRvdGFs",
-            "X2FydGlmYWN0X3NpemVfYnl0ZXMYFyABKANSFnRvdGFsQXJ0aWZhY3RTaXpl",
-            "Qnl0ZXMihgIKEUxpc3RCdWlsZHNSZXF1ZXN0EjcKDHN0YXRlX2ZpbHRlchgB",
-            "IAMoDjIULnNtaWRyLnYxLkJ1aWxkU3RhdGVSC3N0YXRlRmlsdGVyEjcKCnRp",
-            "bWVfcmFuZ2UYAiABKAsyGC5zbWlkci52MS5UaW1lU3RhbXBSYW5nZVIJdGlt",
-            "ZVJhbmdlEhsKCXBhZ2Vfc2l6ZRgDIAEoBVIIcGFnZVNpemUSHQoKcGFnZV90",
-            "b2tlbhgEIAEoCVIJcGFnZVRva2VuEhoKCGN1c3RvbWVyGAUgASgJUghjdXN0",
-            "b21lchInCg9pbmNsdWRlX2RlbGV0ZWQYBiABKAhSDmluY2x1ZGVEZWxldGVk",
-            "Io8BChJMaXN0QnVpbGRzUmVzcG9uc2USLgoGYnVpbGRzGAEgAygLMhYuc21p",
-            "ZHIudjEuQnVpbGREZXRhaWxzUgZidWlsZHMSJgoPbmV4dF9wYWdlX3Rva2Vu",
-            "GAIgASgJUg1uZXh0UGFnZVRva2VuEiEKDHRvdGFsX2J1aWxkcxgDIAEoBVIL",
-            "dG90YWxCdWlsZHMiWgoSQ2FuY2VsQnVpbGRSZXF1ZXN0EkQKEGJ1aWxkX2lk",
-            "ZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXJSD2J1",
-            "aWxkSWRlbnRpZmllciJJChNDYW5jZWxCdWlsZFJlc3BvbnNlEhgKB3N1Y2Nl",
-            "c3MYASABKAhSB3N1Y2Nlc3MSGAoHbWVzc2FnZRgCIAEoCVIHbWVzc2FnZSJX",
-            "Cg9HZXRCdWlsZFJlcXVlc3QSRAoQYnVpbGRfaWRlbnRpZmllchgBIAEoCzIZ",
-            "LnNtaWRyLnYxLkJ1aWxkSWRlbnRpZmllclIPYnVpbGRJZGVudGlmaWVyIloK",
-            "EkRlbGV0ZUJ1aWxkUmVxdWVzdBJEChBidWlsZF9pZGVudGlmaWVyGAEgASgL",
-            "Mhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyUg9idWlsZElkZW50aWZpZXIi",
-            "SQoTRGVsZXRlQnVpbGRSZXNwb25zZRIYCgdzdWNjZXNzGAEgASgIUgdzdWNj",
-            "ZXNzEhgKB21lc3NhZ2UYAiABKAlSB21lc3NhZ2UiZwoSUHVyZ2VCdWlsZHNS",
-            "ZXF1ZXN0EjUKF29sZGVyX3RoYW5fdW5peF9zZWNvbmRzGAEgASgDUhRvbGRl",
-            "clRoYW5Vbml4U2Vjb25kcxIaCghjdXN0b21lchgCIAEoCVIIY3VzdG9tZXIi",
-            "swEKE1B1cmdlQnVpbGRzUmVzcG9uc2USLAoScHVyZ2VkX2J1aWxkX2NvdW50",
-            "GAEgASgFUhBwdXJnZWRCdWlsZENvdW50EigKEHB1cmdlZF9idWlsZF9pZHMY",
-            "AiADKAlSDnB1cmdlZEJ1aWxkSWRzEioKEWZyZWVkX3NwYWNlX2J5dGVzGAMg",
-            "ASgDUg9mcmVlZFNwYWNlQnl0ZXMSGAoHbWVzc2FnZRgEIAEoCVIHbWVzc2Fn",
-            "ZTKTBAoMQnVpbGRTZXJ2aWNlEkgKClN0YXJ0QnVpbGQSGy5zbWlkci52MS5T",
-            "dGFydEJ1aWxkUmVxdWVzdBodLnNtaWRyLnYxLkJ1aWxkU3RhdHVzUmVzcG9u",
-            "c2USTQoOR2V0QnVpbGRTdGF0dXMSHC5zbWlkci52MS5CdWlsZFN0YXR1c1Jl",
-            "cXVlc3QaHS5zbWlkci52MS5CdWlsZFN0YXR1c1Jlc3BvbnNlEkcKCkxpc3RC",
-            "dWlsZHMSGy5zbWlkci52MS5MaXN0QnVpbGRzUmVxdWVzdBocLnNtaWRyLnYx",
-            "Lkxpc3RCdWlsZHNSZXNwb25zZRJKCgtDYW5jZWxCdWlsZBIcLnNtaWRyLnYx",
-            "LkNhbmNlbEJ1aWxkUmVxdWVzdBodLnNtaWRyLnYxLkNhbmNlbEJ1aWxkUmVz",
-            "cG9uc2USPQoIR2V0QnVpbGQSGS5zbWlkci52MS5HZXRCdWlsZFJlcXVlc3Qa",
-            "Fi5zbWlkci52MS5CdWlsZERldGFpbHMSSgoLRGVsZXRlQnVpbGQSHC5zbWlk",
-            "ci52MS5EZWxldGVCdWlsZFJlcXVlc3QaHS5zbWlkci52MS5EZWxldGVCdWls",
-            "ZFJlc3BvbnNlEkoKC1B1cmdlQnVpbGRzEhwuc21pZHIudjEuUHVyZ2VCdWls",
-            "ZHNSZXF1ZXN0Gh0uc21pZHIudjEuUHVyZ2VCdWlsZHNSZXNwb25zZUKWAQoM",
-            "Y29tLnNtaWRyLnYxQgtCdWlsZHNQcm90b1ABWjhnaXRodWIuY29tL3NjaGVy",
-            "ZXJqYS9zbWlkci9zZGtzL3BrZy9zbWlkci1zZGsvdjE7c21pZHJ2MaICA1NY",
-            "WKoCCFNtaWRyLlYxygIIU21pZHJcVjHiAhRTbWlkclxWMVxHUEJNZXRhZGF0",
-            "YeoCCVNtaWRyOjpWMWIGcHJvdG8z"));
+            "UghjdXN0b21lchIaCghwcmlvcml0eRgHIAEoBVIIcHJpb3JpdHkSEgoEdGFz",
+            "axgIIAEoCVIEdGFzaxIdCgpmb3JjZV90YXNrGAkgASgIUglmb3JjZVRhc2sa",
+            "RwoZRW52aXJvbm1lbnRWYXJpYWJsZXNFbnRyeRIQCgNrZXkYASABKAlSA2tl",
+            "eRIUCgV2YWx1ZRgCIAEoCVIFdmFsdWU6AjgBIrwDChNCdWlsZFN0YXR1c1Jl",
+            "c3BvbnNlEkQKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5C",
+            "dWlsZElkZW50aWZpZXJSD2J1aWxkSWRlbnRpZmllchIWCgZ0YXJnZXQYAiAB",
+            "KAlSBnRhcmdldBIqCgVzdGF0ZRgDIAEoDjIULnNtaWRyLnYxLkJ1aWxkU3Rh",
+            "dGVSBXN0YXRlEhsKCWV4aXRfY29kZRgEIAEoBVIIZXhpdENvZGUSIwoNZXJy",
+            "b3JfbWVzc2FnZRgFIAEoCVIMZXJyb3JNZXNzYWdlEjgKCnRpbWVzdGFtcHMY",
+            "BiABKAsyGC5zbWlkci52MS5UaW1lU3RhbXBSYW5nZVIKdGltZXN0YW1wcxIf",
+            "Cgtjb25maWdfcGF0aBgHIAEoCVIKY29uZmlnUGF0aBIaCghjdXN0b21lchgI",
+            "IAEoCVIIY3VzdG9tZXISGAoHZGVsZXRlZBgJIAEoCFIHZGVsZXRlZBIlCg5x",
+            "dWV1ZV9wb3NpdGlvbhgKIAEoBVINcXVldWVQb3NpdGlvbhIhCgxyZXRyaWVk",
+            "X2Zyb20YCyABKAlSC3JldHJpZWRGcm9tIloKEkJ1aWxkU3RhdHVzUmVxdWVz",
+            "dBJEChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJ",
+            "ZGVudGlmaWVyUg9idWlsZElkZW50aWZpZXIizgkKDEJ1aWxkRGV0YWlscxJE",
+            "ChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVu",
+            "dGlmaWVyUg9idWlsZElkZW50aWZpZXISGgoIY3VzdG9tZXIYAiABKAlSCGN1",
+            "c3RvbWVyEiEKDHByb2plY3RfbmFtZRgDIAEoCVILcHJvamVjdE5hbWUSIQoM",
+            "dGFyZ2V0X2ltYWdlGAQgASgJUgt0YXJnZXRJbWFnZRIYCgdtYWNoaW5lGAUg",
+            "ASgJUgdtYWNoaW5lEjUKC2J1aWxkX3N0YXRlGAYgASgOMhQuc21pZHIudjEu",
+            "QnVpbGRTdGF0ZVIKYnVpbGRTdGF0ZRIbCglleGl0X2NvZGUYByABKAVSCGV4",
+            "aXRDb2RlEicKD2J1aWxkX2RpcmVjdG9yeRgIIAEoCVIOYnVpbGREaXJlY3Rv",
+            "cnkSLQoSZG93bmxvYWRfZGlyZWN0b3J5GAkgASgJUhFkb3dubG9hZERpcmVj",
+            "dG9yeRIkCg5sb2dfZmlsZV9wbGFpbhgKIAEoCVIMbG9nRmlsZVBsYWluEiQK",
+            "DmxvZ19maWxlX2pzb25sGAsgASgJUgxsb2dGaWxlSnNvbmwSHwoLY29uZmln",
+            "X2ZpbGUYDCABKAlSCmNvbmZpZ0ZpbGUSJwoPY29uZmlnX3NuYXBzaG90GA0g",
+            "ASgJUg5jb25maWdTbmFwc2hvdBISCgR1c2VyGA4gASgJUgR1c2VyEhIKBGhv",
+            "c3QYDyABKAlSBGhvc3QSHQoKY3JlYXRlZF9hdBgQIAEoA1IJY3JlYXRlZEF0",
+            "EjgKCnRpbWVzdGFtcHMYESABKAsyGC5zbWlkci52MS5UaW1lU3RhbXBSYW5n",
+            "ZVIKdGltZXN0YW1wcxIpChBkdXJhdGlvbl9zZWNvbmRzGBIgASgFUg9kdXJh",
+            "dGlvblNlY29uZHMSGAoHZGVsZXRlZBgTIAEoCFIHZGVsZXRlZBIdCgpkZWxl",
+            "dGVkX2F0GBQgASgDUglkZWxldGVkQXQSIwoNZXJyb3JfbWVzc2FnZRgVIAEo",
+            "CVIMZXJyb3JNZXNzYWdlEiUKDmFydGlmYWN0X2NvdW50GBYgASgFUg1hcnRp",
+            "ZmFjdENvdW50EjkKGXRvdGFsX2FydGlmYWN0X3NpemVfYnl0ZXMYFyABKANS",
+            "FnRvdGFsQXJ0aWZhY3RTaXplQnl0ZXMSIQoMcmV0cmllZF9mcm9tGBggASgJ",
+            "UgtyZXRyaWVkRnJvbRI7CgtkaWFnbm9zdGljcxgZIAMoCzIZLnNtaWRyLnYx",
+            "LkJ1aWxkRGlhZ25vc3RpY1ILZGlhZ25vc3RpY3MSMgoJdGFza19sb2dzGBog",
+            "AygLMhUuc21pZHIudjEuVGFza0xvZ0luZm9SCHRhc2tMb2dzEkMKEHJlY292",
+            "ZXJ5X2FjdGlvbnMYGyADKAsyGC5zbWlkci52MS5SZWNvdmVyeUFjdGlvblIP",
+            "cmVjb3ZlcnlBY3Rpb25zEjoKDWxheWVyX2NvbW1pdHMYHCADKAsyFS5zbWlk",
+            "ci52MS5MYXllckNvbW1pdFIMbGF5ZXJDb21taXRzEjkKDWxheWVyX2ZldGNo",
+            "ZXMYHSADKAsyFC5zbWlkci52MS5MYXllckZldGNoUgxsYXllckZldGNoZXMi",
+            "WAoLVGFza0xvZ0luZm8SFgoGcmVjaXBlGAEgASgJUgZyZWNpcGUSEgoEdGFz",
+            "axgCIAEoCVIEdGFzaxIdCgpzaXplX2J5dGVzGAMgASgDUglzaXplQnl0ZXMi",
+            "6gEKD0J1aWxkRGlhZ25vc3RpYxISCgRraW5kGAEgASgJUgRraW5kEhYKBnJl",
+            "Y2lwZRgCIAEoCVIGcmVjaXBlEhIKBHRhc2sYAyABKAlSBHRhc2sSEAoDdXJs",
+            "GAQgASgJUgN1cmwSEgoEZmlsZRgFIAEoCVIEZmlsZRISCgRsaW5lGAYgASgF",
+            "UgRsaW5lEhQKBWxheWVyGAcgASgJUgVsYXllchIZCghsb2dfZmlsZRgIIAEo",
+            "CVIHbG9nRmlsZRIYCgdtZXNzYWdlGAkgASgJUgdtZXNzYWdlEhIKBGhpbnQY",
+            "CiABKAlSBGhpbnQi7AEKDlJlY292ZXJ5QWN0aW9uEhoKCHN0cmF0ZWd5GAEg",
+            "ASgJUghzdHJhdGVneRIWCgZyZWNpcGUYAiABKAlSBnJlY2lwZRIYCgdhdHRl",
+            "bXB0GAMgASgFUgdhdHRlbXB0EhwKCXN1Y2NlZWRlZBgEIAEoCFIJc3VjY2Vl",
+            "ZGVkEhYKBmRldGFpbBgFIAEoCVIGZGV0YWlsEjUKF3N0YXJ0ZWRfYXRfdW5p",
+            "eF9zZWNvbmRzGAYgASgDUhRzdGFydGVkQXRVbml4U2Vjb25kcxIfCgtkdXJh",
+            "dGlvbl9tcxgHIAEoA1IKZHVyYXRpb25NcyJjCgtMYXllckNvbW1pdBISCgRu",
+            "YW1lGAEgASgJUgRuYW1lEhAKA2dpdBgCIAEoCVIDZ2l0EhYKBmJyYW5jaBgD",
+            "IAEoCVIGYnJhbmNoEhYKBmNvbW1pdBgEIAEoCVIGY29tbWl0ItkBCgpMYXll",
+            "ckZldGNoEhIKBG5hbWUYASABKAlSBG5hbWUSEAoDZ2l0GAIgASgJUgNnaXQS",
+            "FgoGc3RhdHVzGAMgASgJUgZzdGF0dXMSEAoDdXJsGAQgASgJUgN1cmwSEAoD",
+            "cmVmGAUgASgJUgNyZWYSFgoGY29tbWl0GAYgASgJUgZjb21taXQSGgoIYXR0",
+            "ZW1wdHMYByABKAVSCGF0dGVtcHRzEh8KC2R1cmF0aW9uX21zGAggASgDUgpk",
+            "dXJhdGlvbk1zEhQKBWVycm9yGAkgASgJUgVlcnJvciKGAgoRTGlzdEJ1aWxk",
+            "c1JlcXVlc3QSNwoMc3RhdGVfZmlsdGVyGAEgAygOMhQuc21pZHIudjEuQnVp",
+            "bGRTdGF0ZVILc3RhdGVGaWx0ZXISNwoKdGltZV9yYW5nZRgCIAEoCzIYLnNt",
+            "aWRyLnYxLlRpbWVTdGFtcFJhbmdlUgl0aW1lUmFuZ2USGwoJcGFnZV9zaXpl",
+            "GAMgASgFUghwYWdlU2l6ZRIdCgpwYWdlX3Rva2VuGAQgASgJUglwYWdlVG9r",
+            "ZW4SGgoIY3VzdG9tZXIYBSABKAlSCGN1c3RvbWVyEicKD2luY2x1ZGVfZGVs",
+            "ZXRlZBgGIAEoCFIOaW5jbHVkZURlbGV0ZWQijwEKEkxpc3RCdWlsZHNSZXNw",
+            "b25zZRIuCgZidWlsZHMYASADKAsyFi5zbWlkci52MS5CdWlsZERldGFpbHNS",
+            "BmJ1aWxkcxImCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAlSDW5leHRQYWdlVG9r",
+            "ZW4SIQoMdG90YWxfYnVpbGRzGAMgASgFUgt0b3RhbEJ1aWxkcyJaChJDYW5j",
+            "ZWxCdWlsZFJlcXVlc3QSRAoQYnVpbGRfaWRlbnRpZmllchgBIAEoCzIZLnNt",
+            "aWRyLnYxLkJ1aWxkSWRlbnRpZmllclIPYnVpbGRJZGVudGlmaWVyIkkKE0Nh",
+            "bmNlbEJ1aWxkUmVzcG9uc2USGAoHc3VjY2VzcxgBIAEoCFIHc3VjY2VzcxIY",
+            "CgdtZXNzYWdlGAIgASgJUgdtZXNzYWdlIlcKD0dldEJ1aWxkUmVxdWVzdBJE",
+            "ChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVu",
+            "dGlmaWVyUg9idWlsZElkZW50aWZpZXIiWgoSRGVsZXRlQnVpbGRSZXF1ZXN0",
+            "EkQKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElk",
+            "ZW50aWZpZXJSD2J1aWxkSWRlbnRpZmllciJJChNEZWxldGVCdWlsZFJlc3Bv",
+            "bnNlEhgKB3N1Y2Nlc3MYASABKAhSB3N1Y2Nlc3MSGAoHbWVzc2FnZRgCIAEo",
+            "CVIHbWVzc2FnZSJnChJQdXJnZUJ1aWxkc1JlcXVlc3QSNQoXb2xkZXJfdGhh",
+            "bl91bml4X3NlY29uZHMYASABKANSFG9sZGVyVGhhblVuaXhTZWNvbmRzEhoK",
+            "CGN1c3RvbWVyGAIgASgJUghjdXN0b21lciKzAQoTUHVyZ2VCdWlsZHNSZXNw",
+            "b25zZRIsChJwdXJnZWRfYnVpbGRfY291bnQYASABKAVSEHB1cmdlZEJ1aWxk",
+            "Q291bnQSKAoQcHVyZ2VkX2J1aWxkX2lkcxgCIAMoCVIOcHVyZ2VkQnVpbGRJ",
+            "ZHMSKgoRZnJlZWRfc3BhY2VfYnl0ZXMYAyABKANSD2ZyZWVkU3BhY2VCeXRl",
+            "cxIYCgdtZXNzYWdlGAQgASgJUgdtZXNzYWdlIlkKEVdhdGNoQnVpbGRSZXF1",
+            "ZXN0EkQKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWls",
+            "ZElkZW50aWZpZXJSD2J1aWxkSWRlbnRpZmllciLeAQoRUmV0cnlCdWlsZFJl",
+            "cXVlc3QSRAoQYnVpbGRfaWRlbnRpZmllchgBIAEoCzIZLnNtaWRyLnYxLkJ1",
+            "aWxkSWRlbnRpZmllclIPYnVpbGRJZGVudGlmaWVyEhYKBnRhcmdldBgCIAEo",
+            "CVIGdGFyZ2V0Eh8KC2ZvcmNlX2NsZWFuGAMgASgIUgpmb3JjZUNsZWFuEi4K",
+            "E2ZvcmNlX2ltYWdlX3JlYnVpbGQYBCABKAhSEWZvcmNlSW1hZ2VSZWJ1aWxk",
+            "EhoKCHByaW9yaXR5GAUgASgFUghwcmlvcml0eSLDAwoKQnVpbGRFdmVudBJE",
+            "ChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVu",
+            "dGlmaWVyUg9idWlsZElkZW50aWZpZXISNAoWdGltZXN0YW1wX3VuaXhfc2Vj",
+            "b25kcxgCIAEoA1IUdGltZXN0YW1wVW5peFNlY29uZHMSPwoMc3RhdGVfY2hh",
+            "bmdlGAMgASgLMhouc21pZHIudjEuQnVpbGRTdGF0ZUNoYW5nZUgAUgtzdGF0",
+            "ZUNoYW5nZRI/CgxwaGFzZV9jaGFuZ2UYBCABKAsyGi5zbWlkci52MS5CdWls",
+            "ZFBoYXNlQ2hhbmdlSABSC3BoYXNlQ2hhbmdlEj0KDXRhc2tfcHJvZ3Jlc3MY",
+            "BSABKAsyFi5zbWlkci52MS5UYXNrUHJvZ3Jlc3NIAFIMdGFza1Byb2dyZXNz",
+            "EjYKCHJlY292ZXJ5GAYgASgLMhguc21pZHIudjEuUmVjb3ZlcnlBY3Rpb25I",
+            "AFIIcmVjb3ZlcnkSNwoLbGF5ZXJfZmV0Y2gYByABKAsyFC5zbWlkci52MS5M",
+            "YXllckZldGNoSABSCmxheWVyRmV0Y2hCBwoFZXZlbnQilQEKEEJ1aWxkU3Rh",
+            "dGVDaGFuZ2USOwoOcHJldmlvdXNfc3RhdGUYASABKA4yFC5zbWlkci52MS5C",
+            "dWlsZFN0YXRlUg1wcmV2aW91c1N0YXRlEioKBXN0YXRlGAIgASgOMhQuc21p",
+            "ZHIudjEuQnVpbGRTdGF0ZVIFc3RhdGUSGAoHbWVzc2FnZRgDIAEoCVIHbWVz",
+            "c2FnZSI+ChBCdWlsZFBoYXNlQ2hhbmdlEioKBXBoYXNlGAEgASgOMhQuc21p",
+            "ZHIudjEuQnVpbGRQaGFzZVIFcGhhc2UimgIKDFRhc2tQcm9ncmVzcxIYCgdj",
+            "dXJyZW50GAEgASgFUgdjdXJyZW50EhQKBXRvdGFsGAIgASgFUgV0b3RhbBIW",
+            "CgZyZWNpcGUYAyABKAlSBnJlY2lwZRISCgR0YXNrGAQgASgJUgR0YXNrEhoK",
+            "CHNldHNjZW5lGAUgASgIUghzZXRzY2VuZRIpChBzZXRzY2VuZV9jdXJyZW50",
+            "GAYgASgFUg9zZXRzY2VuZUN1cnJlbnQSJQoOc2V0c2NlbmVfdG90YWwYByAB",
+            "KAVSDXNldHNjZW5lVG90YWwSIQoMdGFza19jdXJyZW50GAggASgFUgt0YXNr",
+            "Q3VycmVudBIdCgp0YXNrX3RvdGFsGAkgASgFUgl0YXNrVG90YWwqhwEKCkJ1",
+            "aWxkUGhhc2USGwoXQlVJTERfUEhBU0VfVU5TUEVDSUZJRUQQABIVChFCVUlM",
+            "RF9QSEFTRV9GRVRDSBABEhUKEUJVSUxEX1BIQVNFX1BBUlNFEAISFQoRQlVJ",
+            "TERfUEhBU0VfQlVJTEQQAxIXChNCVUlMRF9QSEFTRV9FWFRSQUNUEAQyoAUK",
+            "DEJ1aWxkU2VydmljZRJICgpTdGFydEJ1aWxkEhsuc21pZHIudjEuU3RhcnRC",
+            "dWlsZFJlcXVlc3QaHS5zbWlkci52MS5CdWlsZFN0YXR1c1Jlc3BvbnNlEk0K",
+            "DkdldEJ1aWxkU3RhdHVzEhwuc21pZHIudjEuQnVpbGRTdGF0dXNSZXF1ZXN0",
+            "Gh0uc21pZHIudjEuQnVpbGRTdGF0dXNSZXNwb25zZRJHCgpMaXN0QnVpbGRz",
+            "Ehsuc21pZHIudjEuTGlzdEJ1aWxkc1JlcXVlc3QaHC5zbWlkci52MS5MaXN0",
+            "QnVpbGRzUmVzcG9uc2USSgoLQ2FuY2VsQnVpbGQSHC5zbWlkci52MS5DYW5j",
+            "ZWxCdWlsZFJlcXVlc3QaHS5zbWlkci52MS5DYW5jZWxCdWlsZFJlc3BvbnNl",
+            "Ej0KCEdldEJ1aWxkEhkuc21pZHIudjEuR2V0QnVpbGRSZXF1ZXN0GhYuc21p",
+            "ZHIudjEuQnVpbGREZXRhaWxzEkoKC0RlbGV0ZUJ1aWxkEhwuc21pZHIudjEu",
+            "RGVsZXRlQnVpbGRSZXF1ZXN0Gh0uc21pZHIudjEuRGVsZXRlQnVpbGRSZXNw",
+            "b25zZRJKCgtQdXJnZUJ1aWxkcxIcLnNtaWRyLnYxLlB1cmdlQnVpbGRzUmVx",
+            "dWVzdBodLnNtaWRyLnYxLlB1cmdlQnVpbGRzUmVzcG9uc2USQQoKV2F0Y2hC",
+            "dWlsZBIbLnNtaWRyLnYxLldhdGNoQnVpbGRSZXF1ZXN0GhQuc21pZHIudjEu",
+            "QnVpbGRFdmVudDABEkgKClJldHJ5QnVpbGQSGy5zbWlkci52MS5SZXRyeUJ1",
+            "aWxkUmVxdWVzdBodLnNtaWRyLnYxLkJ1aWxkU3RhdHVzUmVzcG9uc2VClgEK",
+            "DGNvbS5zbWlkci52MUILQnVpbGRzUHJvdG9QAVo4Z2l0aHViLmNvbS9zY2hl",
+            "cmVyamEvc21pZHIvc2Rrcy9wa2cvc21pZHItc2RrL3YxO3NtaWRydjGiAgNT",
+            "WFiqAghTbWlkci5WMcoCCFNtaWRyXFYx4gIUU21pZHJcVjFcR1BCTWV0YWRh",
+            "dGHqAglTbWlkcjo6VjFiBnByb3RvMw=="));
       descriptor = pbr::FileDescriptor.FromGeneratedCode(descriptorData,
           new pbr::FileDescriptor[] { global::Smidr.V1.CommonReflection.Descriptor, },
-          new pbr::GeneratedClrTypeInfo(null, null, new pbr::GeneratedClrTypeInfo[] {
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.StartBuildRequest), global::Smidr.V1.StartBuildRequest.Parser, new[]{ "Config", "Target", "ForceClean", "ForceImageRebuild", "EnvironmentVariables", "Customer" }, null, null, null, new pbr::GeneratedClrTypeInfo[] { null, }),
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildStatusResponse), global::Smidr.V1.BuildStatusResponse.Parser, new[]{ "BuildIdentifier", "Target", "State", "ExitCode", "ErrorMessage", "Timestamps", "ConfigPath", "Customer", "Deleted" }, null, null, null, null),
+          new pbr::GeneratedClrTypeInfo(new[] {typeof(global::Smidr.V1.BuildPhase), }, null, new pbr::GeneratedClrTypeInfo[] {
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.StartBuildRequest), global::Smidr.V1.StartBuildRequest.Parser, new[]{ "Config", "Target", "ForceClean", "ForceImageRebuild", "EnvironmentVariables", "Customer", "Priority", "Task", "ForceTask" }, null, null, null, new pbr::GeneratedClrTypeInfo[] { null, }),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildStatusResponse), global::Smidr.V1.BuildStatusResponse.Parser, new[]{ "BuildIdentifier", "Target", "State", "ExitCode", "ErrorMessage", "Timestamps", "ConfigPath", "Customer", "Deleted", "QueuePosition", "RetriedFrom" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildStatusRequest), global::Smidr.V1.BuildStatusRequest.Parser, new[]{ "BuildIdentifier" }, null, null, null, null),
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildDetails), global::Smidr.V1.BuildDetails.Parser, new[]{ "BuildIdentifier", "Customer", "ProjectName", "TargetImage", "Machine", "BuildState", "ExitCode", "BuildDirectory", "DownloadDirectory", "LogFilePlain", "LogFileJsonl", "ConfigFile", "ConfigSnapshot", "User", "Host", "CreatedAt", "Timestamps", "DurationSeconds", "Deleted", "DeletedAt", "ErrorMessage", "ArtifactCount", "TotalArtifactSizeBytes" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildDetails), global::Smidr.V1.BuildDetails.Parser, new[]{ "BuildIdentifier", "Customer", "ProjectName", "TargetImage", "Machine", "BuildState", "ExitCode", "BuildDirectory", "DownloadDirectory", "LogFilePlain", "LogFileJsonl", "ConfigFile", "ConfigSnapshot", "User", "Host", "CreatedAt", "Timestamps", "DurationSeconds", "Deleted", "DeletedAt", "ErrorMessage", "ArtifactCount", "TotalArtifactSizeBytes", "RetriedFrom", "Diagnostics", "TaskLogs", "RecoveryActions", "LayerCommits", "LayerFetches" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.TaskLogInfo), global::Smidr.V1.TaskLogInfo.Parser, new[]{ "Recipe", "Task", "SizeBytes" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildDiagnostic), global::Smidr.V1.BuildDiagnostic.Parser, new[]{ "Kind", "Recipe", "Task", "Url", "File", "Line", "Layer", "LogFile", "Message", "Hint" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.RecoveryAction), global::Smidr.V1.RecoveryAction.Parser, new[]{ "Strategy", "Recipe", "Attempt", "Succeeded", "Detail", "StartedAtUnixSeconds", "DurationMs" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.LayerCommit), global::Smidr.V1.LayerCommit.Parser, new[]{ "Name", "Git", "Branch", "Commit" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.LayerFetch), global::Smidr.V1.LayerFetch.Parser, new[]{ "Name", "Git", "Status", "Url", "Ref", "Commit", "Attempts", "DurationMs", "Error" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.ListBuildsRequest), global::Smidr.V1.ListBuildsRequest.Parser, new[]{ "StateFilter", "TimeRange", "PageSize", "PageToken", "Customer", "IncludeDeleted" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.ListBuildsResponse), global::Smidr.V1.ListBuildsResponse.Parser, new[]{ "Builds", "NextPageToken", "TotalBuilds" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.CancelBuildRequest), global::Smidr.V1.CancelBuildRequest.Parser, new[]{ "BuildIdentifier" }, null, null, null, null),
@@ -119,12 +189,44 @@ namespace Smidr.V1 {
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DeleteBuildRequest), global::Smidr.V1.DeleteBuildRequest.Parser, new[]{ "BuildIdentifier" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.DeleteBuildResponse), global::Smidr.V1.DeleteBuildResponse.Parser, new[]{ "Success", "Message" }, null, null, null, null),
             new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.PurgeBuildsRequest), global::Smidr.V1.PurgeBuildsRequest.Parser, new[]{ "OlderThanUnixSeconds", "Customer" }, null, null, null, null),
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.PurgeBuildsResponse), global::Smidr.V1.PurgeBuildsResponse.Parser, new[]{ "PurgedBuildCount", "PurgedBuildIds", "FreedSpaceBytes", "Message" }, null, null, null, null)
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.PurgeBuildsResponse), global::Smidr.V1.PurgeBuildsResponse.Parser, new[]{ "PurgedBuildCount", "PurgedBuildIds", "FreedSpaceBytes", "Message" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.WatchBuildRequest), global::Smidr.V1.WatchBuildRequest.Parser, new[]{ "BuildIdentifier" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.RetryBuildRequest), global::Smidr.V1.RetryBuildRequest.Parser, new[]{ "BuildIdentifier", "Target", "ForceClean", "ForceImageRebuild", "Priority" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildEvent), global::Smidr.V1.BuildEvent.Parser, new[]{ "BuildIdentifier", "TimestampUnixSeconds", "StateChange", "PhaseChange", "TaskProgress", "Recovery", "LayerFetch" }, new[]{ "Event" }, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildStateChange), global::Smidr.V1.BuildStateChange.Parser, new[]{ "PreviousState", "State", "Message" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.BuildPhaseChange), global::Smidr.V1.BuildPhaseChange.Parser, new[]{ "Phase" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.TaskProgress), global::Smidr.V1.TaskProgress.Parser, new[]{ "Current", "Total", "Recipe", "Task", "Setscene", "SetsceneCurrent", "SetsceneTotal", "TaskCurrent", "TaskTotal" }, null, null, null, null)
           }));
     }
     #endregion
 
   }
+  #region Enums
+  /// <summary>
+  /// BuildPhase is a coarse stage of the build pipeline.
+  /// </summary>
+  public enum BuildPhase {
+    [pbr::OriginalName("BUILD_PHASE_UNSPECIFIED")] Unspecified = 0,
+    /// <summary>
+    /// Fetching layers and downloading sources
+    /// </summary>
+    [pbr::OriginalName("BUILD_PHASE_FETCH")] Fetch = 1,
+    /// <summary>
+    /// BitBake is parsing recipes
+    /// </summary>
+    [pbr::OriginalName("BUILD_PHASE_PARSE")] Parse = 2,
+    /// <summary>
+    /// BitBake is executing tasks
+    /// </summary>
+    [pbr::OriginalName("BUILD_PHASE_BUILD")] Build = 3,
+    /// <summary>
+    /// Artifacts are being copied into the artifact store
+    /// </summary>
+    [pbr::OriginalName("BUILD_PHASE_EXTRACT")] Extract = 4,
+  }
+
+  #endregion
+
   #region Messages
   /// <summary>
   /// StartBuildRequest is used to initiate a new build, specifying configuration.
@@ -170,6 +272,9 @@ namespace Smidr.V1 {
       forceImageRebuild_ = other.forceImageRebuild_;
       environmentVariables_ = other.environmentVariables_.Clone();
       customer_ = other.customer_;
+      priority_ = other.priority_;
+      task_ = other.task_;
+      forceTask_ = other.forceTask_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
@@ -198,7 +303,10 @@ namespace Smidr.V1 {
     public const int TargetFieldNumber = 2;
     private string target_ = "";
     /// <summary>
-    /// Build target (e.g., core-image-minimal).
+    /// Build targets in bitbake command line form: one or more space separated
+    /// targets (images or recipes), optionally followed by "-c &lt;task>" and "-f",
+    /// e.g. "core-image-minimal meta-toolchain" or "busybox -c compile -f".
+    /// Empty builds the config's build.image.
     /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
@@ -268,6 +376,51 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "priority" field.</summary>
+    public const int PriorityFieldNumber = 7;
+    private int priority_;
+    /// <summary>
+    /// Scheduling priority; queued builds with a higher priority start first (default 0)
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int Priority {
+      get { return priority_; }
+      set {
+        priority_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "task" field.</summary>
+    public const int TaskFieldNumber = 8;
+    private string task_ = "";
+    /// <summary>
+    /// Run only this task of the targets (bitbake -c), e.g. populate_sdk; overrides a task in target
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Task {
+      get { return task_; }
+      set {
+        task_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "force_task" field.</summary>
+    public const int ForceTaskFieldNumber = 9;
+    private bool forceTask_;
+    /// <summary>
+    /// Run the task even if its stamp is valid (bitbake -f)
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool ForceTask {
+      get { return forceTask_; }
+      set {
+        forceTask_ = value;
+      }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
@@ -289,6 +442,9 @@ namespace Smidr.V1 {
       if (ForceImageRebuild != other.ForceImageRebuild) return false;
       if (!EnvironmentVariables.Equals(other.EnvironmentVariables)) return false;
       if (Customer != other.Customer) return false;
+      if (Priority != other.Priority) return false;
+      if (Task != other.Task) return false;
+      if (ForceTask != other.ForceTask) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -302,6 +458,9 @@ namespace Smidr.V1 {
       if (ForceImageRebuild != false) hash ^= ForceImageRebuild.GetHashCode();
       hash ^= EnvironmentVariables.GetHashCode();
       if (Customer.Length != 0) hash ^= Customer.GetHashCode();
+      if (Priority != 0) hash ^= Priority.GetHashCode();
+      if (Task.Length != 0) hash ^= Task.GetHashCode();
+      if (ForceTask != false) hash ^= ForceTask.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -341,6 +500,18 @@ namespace Smidr.V1 {
         output.WriteRawTag(50);
         output.WriteString(Customer);
       }
+      if (Priority != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(Priority);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(66);
+        output.WriteString(Task);
+      }
+      if (ForceTask != false) {
+        output.WriteRawTag(72);
+        output.WriteBool(ForceTask);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -372,6 +543,18 @@ namespace Smidr.V1 {
         output.WriteRawTag(50);
         output.WriteString(Customer);
       }
+      if (Priority != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(Priority);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(66);
+        output.WriteString(Task);
+      }
+      if (ForceTask != false) {
+        output.WriteRawTag(72);
+        output.WriteBool(ForceTask);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -398,6 +581,15 @@ namespace Smidr.V1 {
       if (Customer.Length != 0) {
         size += 1 + pb::CodedOutputStream.ComputeStringSize(Customer);
       }
+      if (Priority != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Priority);
+      }
+      if (Task.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Task);
+      }
+      if (ForceTask != false) {
+        size += 1 + 1;
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -426,6 +618,15 @@ namespace Smidr.V1 {
       if (other.Customer.Length != 0) {
         Customer = other.Customer;
       }
+      if (other.Priority != 0) {
+        Priority = other.Priority;
+      }
+      if (other.Task.Length != 0) {
+        Task = other.Task;
+      }
+      if (other.ForceTask != false) {
+        ForceTask = other.ForceTask;
+      }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -469,6 +670,18 @@ namespace Smidr.V1 {
             Customer = input.ReadString();
             break;
           }
+          case 56: {
+            Priority = input.ReadInt32();
+            break;
+          }
+          case 66: {
+            Task = input.ReadString();
+            break;
+          }
+          case 72: {
+            ForceTask = input.ReadBool();
+            break;
+          }
         }
       }
     #endif
@@ -512,6 +725,18 @@ namespace Smidr.V1 {
             Customer = input.ReadString();
             break;
           }
+          case 56: {
+            Priority = input.ReadInt32();
+            break;
+          }
+          case 66: {
+            Task = input.ReadString();
+            break;
+          }
+          case 72: {
+            ForceTask = input.ReadBool();
+            break;
+          }
         }
       }
     }
@@ -566,6 +791,8 @@ namespace Smidr.V1 {
       configPath_ = other.configPath_;
       customer_ = other.customer_;
       deleted_ = other.deleted_;
+      queuePosition_ = other.queuePosition_;
+      retriedFrom_ = other.retriedFrom_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
@@ -683,6 +910,36 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "queue_position" field.</summary>
+    public const int QueuePositionFieldNumber = 10;
+    private int queuePosition_;
+    /// <summary>
+    /// 1-based position in the build queue while QUEUED (estimated start order), 0 otherwise
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int QueuePosition {
+      get { return queuePosition_; }
+      set {
+        queuePosition_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "retried_from" field.</summary>
+    public const int RetriedFromFieldNumber = 11;
+    private string retriedFrom_ = "";
+    /// <summary>
+    /// ID of the build this build is a retry of, empty for new builds
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string RetriedFrom {
+      get { return retriedFrom_; }
+      set {
+        retriedFrom_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
@@ -707,6 +964,8 @@ namespace Smidr.V1 {
       if (ConfigPath != other.ConfigPath) return false;
       if (Customer != other.Customer) return false;
       if (Deleted != other.Deleted) return false;
+      if (QueuePosition != other.QueuePosition) return false;
+      if (RetriedFrom != other.RetriedFrom) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -723,6 +982,8 @@ namespace Smidr.V1 {
       if (ConfigPath.Length != 0) hash ^= ConfigPath.GetHashCode();
       if (Customer.Length != 0) hash ^= Customer.GetHashCode();
       if (Deleted != false) hash ^= Deleted.GetHashCode();
+      if (QueuePosition != 0) hash ^= QueuePosition.GetHashCode();
+      if (RetriedFrom.Length != 0) hash ^= RetriedFrom.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -777,6 +1038,14 @@ namespace Smidr.V1 {
         output.WriteRawTag(72);
         output.WriteBool(Deleted);
       }
+      if (QueuePosition != 0) {
+        output.WriteRawTag(80);
+        output.WriteInt32(QueuePosition);
+      }
+      if (RetriedFrom.Length != 0) {
+        output.WriteRawTag(90);
+        output.WriteString(RetriedFrom);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -823,6 +1092,14 @@ namespace Smidr.V1 {
         output.WriteRawTag(72);
         output.WriteBool(Deleted);
       }
+      if (QueuePosition != 0) {
+        output.WriteRawTag(80);
+        output.WriteInt32(QueuePosition);
+      }
+      if (RetriedFrom.Length != 0) {
+        output.WriteRawTag(90);
+        output.WriteString(RetriedFrom);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -860,6 +1137,12 @@ namespace Smidr.V1 {
       if (Deleted != false) {
         size += 1 + 1;
       }
+      if (QueuePosition != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(QueuePosition);
+      }
+      if (RetriedFrom.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(RetriedFrom);
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -905,6 +1188,12 @@ namespace Smidr.V1 {
       if (other.Deleted != false) {
         Deleted = other.Deleted;
       }
+      if (other.QueuePosition != 0) {
+        QueuePosition = other.QueuePosition;
+      }
+      if (other.RetriedFrom.Length != 0) {
+        RetriedFrom = other.RetriedFrom;
+      }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -966,6 +1255,14 @@ namespace Smidr.V1 {
             Deleted = input.ReadBool();
             break;
           }
+          case 80: {
+            QueuePosition = input.ReadInt32();
+            break;
+          }
+          case 90: {
+            RetriedFrom = input.ReadString();
+            break;
+          }
         }
       }
     #endif
@@ -1027,6 +1324,14 @@ namespace Smidr.V1 {
             Deleted = input.ReadBool();
             break;
           }
+          case 80: {
+            QueuePosition = input.ReadInt32();
+            break;
+          }
+          case 90: {
+            RetriedFrom = input.ReadString();
+            break;
+          }
         }
       }
     }
@@ -1305,6 +1610,12 @@ namespace Smidr.V1 {
       errorMessage_ = other.errorMessage_;
       artifactCount_ = other.artifactCount_;
       totalArtifactSizeBytes_ = other.totalArtifactSizeBytes_;
+      retriedFrom_ = other.retriedFrom_;
+      diagnostics_ = other.diagnostics_.Clone();
+      taskLogs_ = other.taskLogs_.Clone();
+      recoveryActions_ = other.recoveryActions_.Clone();
+      layerCommits_ = other.layerCommits_.Clone();
+      layerFetches_ = other.layerFetches_.Clone();
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
@@ -1608,6 +1919,91 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "retried_from" field.</summary>
+    public const int RetriedFromFieldNumber = 24;
+    private string retriedFrom_ = "";
+    /// <summary>
+    /// Lineage: ID of the build this build is a retry of
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string RetriedFrom {
+      get { return retriedFrom_; }
+      set {
+        retriedFrom_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "diagnostics" field.</summary>
+    public const int DiagnosticsFieldNumber = 25;
+    private static readonly pb::FieldCodec<global::Smidr.V1.BuildDiagnostic> _repeated_diagnostics_codec
+        = pb::FieldCodec.ForMessage(202, global::Smidr.V1.BuildDiagnostic.Parser);
+    private readonly pbc::RepeatedField<global::Smidr.V1.BuildDiagnostic> diagnostics_ = new pbc::RepeatedField<global::Smidr.V1.BuildDiagnostic>();
+    /// <summary>
+    /// Failures recognised in the BitBake output of a failed build
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<global::Smidr.V1.BuildDiagnostic> Diagnostics {
+      get { return diagnostics_; }
+    }
+
+    /// <summary>Field number for the "task_logs" field.</summary>
+    public const int TaskLogsFieldNumber = 26;
+    private static readonly pb::FieldCodec<global::Smidr.V1.TaskLogInfo> _repeated_taskLogs_codec
+        = pb::FieldCodec.ForMessage(210, global::Smidr.V1.TaskLogInfo.Parser);
+    private readonly pbc::RepeatedField<global::Smidr.V1.TaskLogInfo> taskLogs_ = new pbc::RepeatedField<global::Smidr.V1.TaskLogInfo>();
+    /// <summary>
+    /// Logs of failed tasks, retrievable with LogService.GetTaskLog
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<global::Smidr.V1.TaskLogInfo> TaskLogs {
+      get { return taskLogs_; }
+    }
+
+    /// <summary>Field number for the "recovery_actions" field.</summary>
+    public const int RecoveryActionsFieldNumber = 27;
+    private static readonly pb::FieldCodec<global::Smidr.V1.RecoveryAction> _repeated_recoveryActions_codec
+        = pb::FieldCodec.ForMessage(218, global::Smidr.V1.RecoveryAction.Parser);
+    private readonly pbc::RepeatedField<global::Smidr.V1.RecoveryAction> recoveryActions_ = new pbc::RepeatedField<global::Smidr.V1.RecoveryAction>();
+    /// <summary>
+    /// Recovery steps the executor took, also when a retry made the build succeed
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<global::Smidr.V1.RecoveryAction> RecoveryActions {
+      get { return recoveryActions_; }
+    }
+
+    /// <summary>Field number for the "layer_commits" field.</summary>
+    public const int LayerCommitsFieldNumber = 28;
+    private static readonly pb::FieldCodec<global::Smidr.V1.LayerCommit> _repeated_layerCommits_codec
+        = pb::FieldCodec.ForMessage(226, global::Smidr.V1.LayerCommit.Parser);
+    private readonly pbc::RepeatedField<global::Smidr.V1.LayerCommit> layerCommits_ = new pbc::RepeatedField<global::Smidr.V1.LayerCommit>();
+    /// <summary>
+    /// Commits the git layers were checked out at, as recorded in smidr.lock
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<global::Smidr.V1.LayerCommit> LayerCommits {
+      get { return layerCommits_; }
+    }
+
+    /// <summary>Field number for the "layer_fetches" field.</summary>
+    public const int LayerFetchesFieldNumber = 29;
+    private static readonly pb::FieldCodec<global::Smidr.V1.LayerFetch> _repeated_layerFetches_codec
+        = pb::FieldCodec.ForMessage(234, global::Smidr.V1.LayerFetch.Parser);
+    private readonly pbc::RepeatedField<global::Smidr.V1.LayerFetch> layerFetches_ = new pbc::RepeatedField<global::Smidr.V1.LayerFetch>();
+    /// <summary>
+    /// How each layer repository was fetched before the build started
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<global::Smidr.V1.LayerFetch> LayerFetches {
+      get { return layerFetches_; }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
@@ -1646,6 +2042,12 @@ namespace Smidr.V1 {
       if (ErrorMessage != other.ErrorMessage) return false;
       if (ArtifactCount != other.ArtifactCount) return false;
       if (TotalArtifactSizeBytes != other.TotalArtifactSizeBytes) return false;
+      if (RetriedFrom != other.RetriedFrom) return false;
+      if(!diagnostics_.Equals(other.diagnostics_)) return false;
+      if(!taskLogs_.Equals(other.taskLogs_)) return false;
+      if(!recoveryActions_.Equals(other.recoveryActions_)) return false;
+      if(!layerCommits_.Equals(other.layerCommits_)) return false;
+      if(!layerFetches_.Equals(other.layerFetches_)) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -1676,6 +2078,12 @@ namespace Smidr.V1 {
       if (ErrorMessage.Length != 0) hash ^= ErrorMessage.GetHashCode();
       if (ArtifactCount != 0) hash ^= ArtifactCount.GetHashCode();
       if (TotalArtifactSizeBytes != 0L) hash ^= TotalArtifactSizeBytes.GetHashCode();
+      if (RetriedFrom.Length != 0) hash ^= RetriedFrom.GetHashCode();
+      hash ^= diagnostics_.GetHashCode();
+      hash ^= taskLogs_.GetHashCode();
+      hash ^= recoveryActions_.GetHashCode();
+      hash ^= layerCommits_.GetHashCode();
+      hash ^= layerFetches_.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -1786,6 +2194,15 @@ namespace Smidr.V1 {
         output.WriteRawTag(184, 1);
         output.WriteInt64(TotalArtifactSizeBytes);
       }
+      if (RetriedFrom.Length != 0) {
+        output.WriteRawTag(194, 1);
+        output.WriteString(RetriedFrom);
+      }
+      diagnostics_.WriteTo(output, _repeated_diagnostics_codec);
+      taskLogs_.WriteTo(output, _repeated_taskLogs_codec);
+      recoveryActions_.WriteTo(output, _repeated_recoveryActions_codec);
+      layerCommits_.WriteTo(output, _repeated_layerCommits_codec);
+      layerFetches_.WriteTo(output, _repeated_layerFetches_codec);
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -1888,6 +2305,15 @@ namespace Smidr.V1 {
         output.WriteRawTag(184, 1);
         output.WriteInt64(TotalArtifactSizeBytes);
       }
+      if (RetriedFrom.Length != 0) {
+        output.WriteRawTag(194, 1);
+        output.WriteString(RetriedFrom);
+      }
+      diagnostics_.WriteTo(ref output, _repeated_diagnostics_codec);
+      taskLogs_.WriteTo(ref output, _repeated_taskLogs_codec);
+      recoveryActions_.WriteTo(ref output, _repeated_recoveryActions_codec);
+      layerCommits_.WriteTo(ref output, _repeated_layerCommits_codec);
+      layerFetches_.WriteTo(ref output, _repeated_layerFetches_codec);
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -1967,6 +2393,14 @@ namespace Smidr.V1 {
       if (TotalArtifactSizeBytes != 0L) {
         size += 2 + pb::CodedOutputStream.ComputeInt64Size(TotalArtifactSizeBytes);
       }
+      if (RetriedFrom.Length != 0) {
+        size += 2 + pb::CodedOutputStream.ComputeStringSize(RetriedFrom);
+      }
+      size += diagnostics_.CalculateSize(_repeated_diagnostics_codec);
+      size += taskLogs_.CalculateSize(_repeated_taskLogs_codec);
+      size += recoveryActions_.CalculateSize(_repeated_recoveryActions_codec);
+      size += layerCommits_.CalculateSize(_repeated_layerCommits_codec);
+      size += layerFetches_.CalculateSize(_repeated_layerFetches_codec);
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -2054,6 +2488,14 @@ namespace Smidr.V1 {
       if (other.TotalArtifactSizeBytes != 0L) {
         TotalArtifactSizeBytes = other.TotalArtifactSizeBytes;
       }
+      if (other.RetriedFrom.Length != 0) {
+        RetriedFrom = other.RetriedFrom;
+      }
+      diagnostics_.Add(other.diagnostics_);
+      taskLogs_.Add(other.taskLogs_);
+      recoveryActions_.Add(other.recoveryActions_);
+      layerCommits_.Add(other.layerCommits_);
+      layerFetches_.Add(other.layerFetches_);
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -2171,6 +2613,30 @@ namespace Smidr.V1 {
             TotalArtifactSizeBytes = input.ReadInt64();
             break;
           }
+          case 194: {
+            RetriedFrom = input.ReadString();
+            break;
+          }
+          case 202: {
+            diagnostics_.AddEntriesFrom(input, _repeated_diagnostics_codec);
+            break;
+          }
+          case 210: {
+            taskLogs_.AddEntriesFrom(input, _repeated_taskLogs_codec);
+            break;
+          }
+          case 218: {
+            recoveryActions_.AddEntriesFrom(input, _repeated_recoveryActions_codec);
+            break;
+          }
+          case 226: {
+            layerCommits_.AddEntriesFrom(input, _repeated_layerCommits_codec);
+            break;
+          }
+          case 234: {
+            layerFetches_.AddEntriesFrom(input, _repeated_layerFetches_codec);
+            break;
+          }
         }
       }
     #endif
@@ -2288,6 +2754,30 @@ namespace Smidr.V1 {
             TotalArtifactSizeBytes = input.ReadInt64();
             break;
           }
+          case 194: {
+            RetriedFrom = input.ReadString();
+            break;
+          }
+          case 202: {
+            diagnostics_.AddEntriesFrom(ref input, _repeated_diagnostics_codec);
+            break;
+          }
+          case 210: {
+            taskLogs_.AddEntriesFrom(ref input, _repeated_taskLogs_codec);
+            break;
+          }
+          case 218: {
+            recoveryActions_.AddEntriesFrom(ref input, _repeated_recoveryActions_codec);
+            break;
+          }
+          case 226: {
+            layerCommits_.AddEntriesFrom(ref input, _repeated_layerCommits_codec);
+            break;
+          }
+          case 234: {
+            layerFetches_.AddEntriesFrom(ref input, _repeated_layerFetches_codec);
+            break;
+          }
         }
       }
     }
@@ -2296,19 +2786,19 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// ListBuildsRequest is used to request a list of builds with optional filters.
+  /// TaskLogInfo names a task log collected from a failed build
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class ListBuildsRequest : pb::IMessage<ListBuildsRequest>
+  public sealed partial class TaskLogInfo : pb::IMessage<TaskLogInfo>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<ListBuildsRequest> _parser = new pb::MessageParser<ListBuildsRequest>(() => new ListBuildsRequest());
+    private static readonly pb::MessageParser<TaskLogInfo> _parser = new pb::MessageParser<TaskLogInfo>(() => new TaskLogInfo());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<ListBuildsRequest> Parser { get { return _parser; } }
+    public static pb::MessageParser<TaskLogInfo> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
@@ -2324,7 +2814,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public ListBuildsRequest() {
+    public TaskLogInfo() {
       OnConstruction();
     }
 
@@ -2332,129 +2822,3649 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public ListBuildsRequest(ListBuildsRequest other) : this() {
-      stateFilter_ = other.stateFilter_.Clone();
-      timeRange_ = other.timeRange_ != null ? other.timeRange_.Clone() : null;
-      pageSize_ = other.pageSize_;
-      pageToken_ = other.pageToken_;
-      customer_ = other.customer_;
-      includeDeleted_ = other.includeDeleted_;
+    public TaskLogInfo(TaskLogInfo other) : this() {
+      recipe_ = other.recipe_;
+      task_ = other.task_;
+      sizeBytes_ = other.sizeBytes_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public ListBuildsRequest Clone() {
-      return new ListBuildsRequest(this);
+    public TaskLogInfo Clone() {
+      return new TaskLogInfo(this);
     }
 
-    /// <summary>Field number for the "state_filter" field.</summary>
-    public const int StateFilterFieldNumber = 1;
-    private static readonly pb::FieldCodec<global::Smidr.V1.BuildState> _repeated_stateFilter_codec
-        = pb::FieldCodec.ForEnum(10, x => (int) x, x => (global::Smidr.V1.BuildState) x);
-    private readonly pbc::RepeatedField<global::Smidr.V1.BuildState> stateFilter_ = new pbc::RepeatedField<global::Smidr.V1.BuildState>();
-    /// <summary>
-    /// Optional filter by build states
-    /// </summary>
+    /// <summary>Field number for the "recipe" field.</summary>
+    public const int RecipeFieldNumber = 1;
+    private string recipe_ = "";
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public pbc::RepeatedField<global::Smidr.V1.BuildState> StateFilter {
-      get { return stateFilter_; }
+    public string Recipe {
+      get { return recipe_; }
+      set {
+        recipe_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
     }
 
-    /// <summary>Field number for the "time_range" field.</summary>
-    public const int TimeRangeFieldNumber = 2;
-    private global::Smidr.V1.TimeStampRange timeRange_;
-    /// <summary>
-    /// Optional time range filter
-    /// </summary>
+    /// <summary>Field number for the "task" field.</summary>
+    public const int TaskFieldNumber = 2;
+    private string task_ = "";
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public global::Smidr.V1.TimeStampRange TimeRange {
-      get { return timeRange_; }
+    public string Task {
+      get { return task_; }
       set {
-        timeRange_ = value;
+        task_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
       }
     }
 
-    /// <summary>Field number for the "page_size" field.</summary>
-    public const int PageSizeFieldNumber = 3;
-    private int pageSize_;
-    /// <summary>
-    /// Pagination parameters
-    /// </summary>
+    /// <summary>Field number for the "size_bytes" field.</summary>
+    public const int SizeBytesFieldNumber = 3;
+    private long sizeBytes_;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public int PageSize {
-      get { return pageSize_; }
+    public long SizeBytes {
+      get { return sizeBytes_; }
       set {
-        pageSize_ = value;
+        sizeBytes_ = value;
       }
     }
 
-    /// <summary>Field number for the "page_token" field.</summary>
-    public const int PageTokenFieldNumber = 4;
-    private string pageToken_ = "";
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public string PageToken {
-      get { return pageToken_; }
-      set {
-        pageToken_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
-      }
+    public override bool Equals(object other) {
+      return Equals(other as TaskLogInfo);
     }
 
-    /// <summary>Field number for the "customer" field.</summary>
-    public const int CustomerFieldNumber = 5;
-    private string customer_ = "";
-    /// <summary>
-    /// Optional customer identifier filter
-    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public string Customer {
-      get { return customer_; }
-      set {
-        customer_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+    public bool Equals(TaskLogInfo other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
       }
+      if (Recipe != other.Recipe) return false;
+      if (Task != other.Task) return false;
+      if (SizeBytes != other.SizeBytes) return false;
+      return Equals(_unknownFields, other._unknownFields);
     }
 
-    /// <summary>Field number for the "include_deleted" field.</summary>
-    public const int IncludeDeletedFieldNumber = 6;
-    private bool includeDeleted_;
-    /// <summary>
-    /// Include deleted builds in the response
-    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool IncludeDeleted {
-      get { return includeDeleted_; }
-      set {
-        includeDeleted_ = value;
+    public override int GetHashCode() {
+      int hash = 1;
+      if (Recipe.Length != 0) hash ^= Recipe.GetHashCode();
+      if (Task.Length != 0) hash ^= Task.GetHashCode();
+      if (SizeBytes != 0L) hash ^= SizeBytes.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Task);
+      }
+      if (SizeBytes != 0L) {
+        output.WriteRawTag(24);
+        output.WriteInt64(SizeBytes);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Task);
+      }
+      if (SizeBytes != 0L) {
+        output.WriteRawTag(24);
+        output.WriteInt64(SizeBytes);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (Recipe.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Recipe);
+      }
+      if (Task.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Task);
+      }
+      if (SizeBytes != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(SizeBytes);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(TaskLogInfo other) {
+      if (other == null) {
+        return;
+      }
+      if (other.Recipe.Length != 0) {
+        Recipe = other.Recipe;
+      }
+      if (other.Task.Length != 0) {
+        Task = other.Task;
+      }
+      if (other.SizeBytes != 0L) {
+        SizeBytes = other.SizeBytes;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 18: {
+            Task = input.ReadString();
+            break;
+          }
+          case 24: {
+            SizeBytes = input.ReadInt64();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 18: {
+            Task = input.ReadString();
+            break;
+          }
+          case 24: {
+            SizeBytes = input.ReadInt64();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// BuildDiagnostic is a structured build failure with a remediation hint
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class BuildDiagnostic : pb::IMessage<BuildDiagnostic>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<BuildDiagnostic> _parser = new pb::MessageParser<BuildDiagnostic>(() => new BuildDiagnostic());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<BuildDiagnostic> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[5]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public BuildDiagnostic() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public BuildDiagnostic(BuildDiagnostic other) : this() {
+      kind_ = other.kind_;
+      recipe_ = other.recipe_;
+      task_ = other.task_;
+      url_ = other.url_;
+      file_ = other.file_;
+      line_ = other.line_;
+      layer_ = other.layer_;
+      logFile_ = other.logFile_;
+      message_ = other.message_;
+      hint_ = other.hint_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public BuildDiagnostic Clone() {
+      return new BuildDiagnostic(this);
+    }
+
+    /// <summary>Field number for the "kind" field.</summary>
+    public const int KindFieldNumber = 1;
+    private string kind_ = "";
+    /// <summary>
+    /// fetch, checksum_mismatch, parse_error, missing_layer_dependency,
+    /// layerseries_compat, pseudo_abort, oom_killed, disk_full or task_failed
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Kind {
+      get { return kind_; }
+      set {
+        kind_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "recipe" field.</summary>
+    public const int RecipeFieldNumber = 2;
+    private string recipe_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Recipe {
+      get { return recipe_; }
+      set {
+        recipe_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "task" field.</summary>
+    public const int TaskFieldNumber = 3;
+    private string task_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Task {
+      get { return task_; }
+      set {
+        task_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "url" field.</summary>
+    public const int UrlFieldNumber = 4;
+    private string url_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Url {
+      get { return url_; }
+      set {
+        url_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "file" field.</summary>
+    public const int FileFieldNumber = 5;
+    private string file_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string File {
+      get { return file_; }
+      set {
+        file_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "line" field.</summary>
+    public const int LineFieldNumber = 6;
+    private int line_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int Line {
+      get { return line_; }
+      set {
+        line_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "layer" field.</summary>
+    public const int LayerFieldNumber = 7;
+    private string layer_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Layer {
+      get { return layer_; }
+      set {
+        layer_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "log_file" field.</summary>
+    public const int LogFileFieldNumber = 8;
+    private string logFile_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string LogFile {
+      get { return logFile_; }
+      set {
+        logFile_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "message" field.</summary>
+    public const int MessageFieldNumber = 9;
+    private string message_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Message {
+      get { return message_; }
+      set {
+        message_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "hint" field.</summary>
+    public const int HintFieldNumber = 10;
+    private string hint_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Hint {
+      get { return hint_; }
+      set {
+        hint_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as BuildDiagnostic);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(BuildDiagnostic other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (Kind != other.Kind) return false;
+      if (Recipe != other.Recipe) return false;
+      if (Task != other.Task) return false;
+      if (Url != other.Url) return false;
+      if (File != other.File) return false;
+      if (Line != other.Line) return false;
+      if (Layer != other.Layer) return false;
+      if (LogFile != other.LogFile) return false;
+      if (Message != other.Message) return false;
+      if (Hint != other.Hint) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (Kind.Length != 0) hash ^= Kind.GetHashCode();
+      if (Recipe.Length != 0) hash ^= Recipe.GetHashCode();
+      if (Task.Length != 0) hash ^= Task.GetHashCode();
+      if (Url.Length != 0) hash ^= Url.GetHashCode();
+      if (File.Length != 0) hash ^= File.GetHashCode();
+      if (Line != 0) hash ^= Line.GetHashCode();
+      if (Layer.Length != 0) hash ^= Layer.GetHashCode();
+      if (LogFile.Length != 0) hash ^= LogFile.GetHashCode();
+      if (Message.Length != 0) hash ^= Message.GetHashCode();
+      if (Hint.Length != 0) hash ^= Hint.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (Kind.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Kind);
+      }
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Task);
+      }
+      if (Url.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Url);
+      }
+      if (File.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(File);
+      }
+      if (Line != 0) {
+        output.WriteRawTag(48);
+        output.WriteInt32(Line);
+      }
+      if (Layer.Length != 0) {
+        output.WriteRawTag(58);
+        output.WriteString(Layer);
+      }
+      if (LogFile.Length != 0) {
+        output.WriteRawTag(66);
+        output.WriteString(LogFile);
+      }
+      if (Message.Length != 0) {
+        output.WriteRawTag(74);
+        output.WriteString(Message);
+      }
+      if (Hint.Length != 0) {
+        output.WriteRawTag(82);
+        output.WriteString(Hint);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (Kind.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Kind);
+      }
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Task);
+      }
+      if (Url.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Url);
+      }
+      if (File.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(File);
+      }
+      if (Line != 0) {
+        output.WriteRawTag(48);
+        output.WriteInt32(Line);
+      }
+      if (Layer.Length != 0) {
+        output.WriteRawTag(58);
+        output.WriteString(Layer);
+      }
+      if (LogFile.Length != 0) {
+        output.WriteRawTag(66);
+        output.WriteString(LogFile);
+      }
+      if (Message.Length != 0) {
+        output.WriteRawTag(74);
+        output.WriteString(Message);
+      }
+      if (Hint.Length != 0) {
+        output.WriteRawTag(82);
+        output.WriteString(Hint);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (Kind.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Kind);
+      }
+      if (Recipe.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Recipe);
+      }
+      if (Task.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Task);
+      }
+      if (Url.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Url);
+      }
+      if (File.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(File);
+      }
+      if (Line != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Line);
+      }
+      if (Layer.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Layer);
+      }
+      if (LogFile.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(LogFile);
+      }
+      if (Message.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Message);
+      }
+      if (Hint.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Hint);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(BuildDiagnostic other) {
+      if (other == null) {
+        return;
+      }
+      if (other.Kind.Length != 0) {
+        Kind = other.Kind;
+      }
+      if (other.Recipe.Length != 0) {
+        Recipe = other.Recipe;
+      }
+      if (other.Task.Length != 0) {
+        Task = other.Task;
+      }
+      if (other.Url.Length != 0) {
+        Url = other.Url;
+      }
+      if (other.File.Length != 0) {
+        File = other.File;
+      }
+      if (other.Line != 0) {
+        Line = other.Line;
+      }
+      if (other.Layer.Length != 0) {
+        Layer = other.Layer;
+      }
+      if (other.LogFile.Length != 0) {
+        LogFile = other.LogFile;
+      }
+      if (other.Message.Length != 0) {
+        Message = other.Message;
+      }
+      if (other.Hint.Length != 0) {
+        Hint = other.Hint;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            Kind = input.ReadString();
+            break;
+          }
+          case 18: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 26: {
+            Task = input.ReadString();
+            break;
+          }
+          case 34: {
+            Url = input.ReadString();
+            break;
+          }
+          case 42: {
+            File = input.ReadString();
+            break;
+          }
+          case 48: {
+            Line = input.ReadInt32();
+            break;
+          }
+          case 58: {
+            Layer = input.ReadString();
+            break;
+          }
+          case 66: {
+            LogFile = input.ReadString();
+            break;
+          }
+          case 74: {
+            Message = input.ReadString();
+            break;
+          }
+          case 82: {
+            Hint = input.ReadString();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            Kind = input.ReadString();
+            break;
+          }
+          case 18: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 26: {
+            Task = input.ReadString();
+            break;
+          }
+          case 34: {
+            Url = input.ReadString();
+            break;
+          }
+          case 42: {
+            File = input.ReadString();
+            break;
+          }
+          case 48: {
+            Line = input.ReadInt32();
+            break;
+          }
+          case 58: {
+            Layer = input.ReadString();
+            break;
+          }
+          case 66: {
+            LogFile = input.ReadString();
+            break;
+          }
+          case 74: {
+            Message = input.ReadString();
+            break;
+          }
+          case 82: {
+            Hint = input.ReadString();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// RecoveryAction is a self-heal step taken after a failed BitBake run (see build.recovery)
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class RecoveryAction : pb::IMessage<RecoveryAction>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<RecoveryAction> _parser = new pb::MessageParser<RecoveryAction>(() => new RecoveryAction());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<RecoveryAction> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[6]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public RecoveryAction() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public RecoveryAction(RecoveryAction other) : this() {
+      strategy_ = other.strategy_;
+      recipe_ = other.recipe_;
+      attempt_ = other.attempt_;
+      succeeded_ = other.succeeded_;
+      detail_ = other.detail_;
+      startedAtUnixSeconds_ = other.startedAtUnixSeconds_;
+      durationMs_ = other.durationMs_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public RecoveryAction Clone() {
+      return new RecoveryAction(this);
+    }
+
+    /// <summary>Field number for the "strategy" field.</summary>
+    public const int StrategyFieldNumber = 1;
+    private string strategy_ = "";
+    /// <summary>
+    /// package_index, pseudo_cleanup, cleansstate or retry
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Strategy {
+      get { return strategy_; }
+      set {
+        strategy_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "recipe" field.</summary>
+    public const int RecipeFieldNumber = 2;
+    private string recipe_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Recipe {
+      get { return recipe_; }
+      set {
+        recipe_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "attempt" field.</summary>
+    public const int AttemptFieldNumber = 3;
+    private int attempt_;
+    /// <summary>
+    /// Retry the step belongs to, starting at 1; 0 for steps outside the retry loop
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int Attempt {
+      get { return attempt_; }
+      set {
+        attempt_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "succeeded" field.</summary>
+    public const int SucceededFieldNumber = 4;
+    private bool succeeded_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Succeeded {
+      get { return succeeded_; }
+      set {
+        succeeded_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "detail" field.</summary>
+    public const int DetailFieldNumber = 5;
+    private string detail_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Detail {
+      get { return detail_; }
+      set {
+        detail_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "started_at_unix_seconds" field.</summary>
+    public const int StartedAtUnixSecondsFieldNumber = 6;
+    private long startedAtUnixSeconds_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long StartedAtUnixSeconds {
+      get { return startedAtUnixSeconds_; }
+      set {
+        startedAtUnixSeconds_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "duration_ms" field.</summary>
+    public const int DurationMsFieldNumber = 7;
+    private long durationMs_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long DurationMs {
+      get { return durationMs_; }
+      set {
+        durationMs_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as RecoveryAction);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(RecoveryAction other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (Strategy != other.Strategy) return false;
+      if (Recipe != other.Recipe) return false;
+      if (Attempt != other.Attempt) return false;
+      if (Succeeded != other.Succeeded) return false;
+      if (Detail != other.Detail) return false;
+      if (StartedAtUnixSeconds != other.StartedAtUnixSeconds) return false;
+      if (DurationMs != other.DurationMs) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (Strategy.Length != 0) hash ^= Strategy.GetHashCode();
+      if (Recipe.Length != 0) hash ^= Recipe.GetHashCode();
+      if (Attempt != 0) hash ^= Attempt.GetHashCode();
+      if (Succeeded != false) hash ^= Succeeded.GetHashCode();
+      if (Detail.Length != 0) hash ^= Detail.GetHashCode();
+      if (StartedAtUnixSeconds != 0L) hash ^= StartedAtUnixSeconds.GetHashCode();
+      if (DurationMs != 0L) hash ^= DurationMs.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (Strategy.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Strategy);
+      }
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Recipe);
+      }
+      if (Attempt != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(Attempt);
+      }
+      if (Succeeded != false) {
+        output.WriteRawTag(32);
+        output.WriteBool(Succeeded);
+      }
+      if (Detail.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Detail);
+      }
+      if (StartedAtUnixSeconds != 0L) {
+        output.WriteRawTag(48);
+        output.WriteInt64(StartedAtUnixSeconds);
+      }
+      if (DurationMs != 0L) {
+        output.WriteRawTag(56);
+        output.WriteInt64(DurationMs);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (Strategy.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Strategy);
+      }
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Recipe);
+      }
+      if (Attempt != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(Attempt);
+      }
+      if (Succeeded != false) {
+        output.WriteRawTag(32);
+        output.WriteBool(Succeeded);
+      }
+      if (Detail.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Detail);
+      }
+      if (StartedAtUnixSeconds != 0L) {
+        output.WriteRawTag(48);
+        output.WriteInt64(StartedAtUnixSeconds);
+      }
+      if (DurationMs != 0L) {
+        output.WriteRawTag(56);
+        output.WriteInt64(DurationMs);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (Strategy.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Strategy);
+      }
+      if (Recipe.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Recipe);
+      }
+      if (Attempt != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Attempt);
+      }
+      if (Succeeded != false) {
+        size += 1 + 1;
+      }
+      if (Detail.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Detail);
+      }
+      if (StartedAtUnixSeconds != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(StartedAtUnixSeconds);
+      }
+      if (DurationMs != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(DurationMs);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(RecoveryAction other) {
+      if (other == null) {
+        return;
+      }
+      if (other.Strategy.Length != 0) {
+        Strategy = other.Strategy;
+      }
+      if (other.Recipe.Length != 0) {
+        Recipe = other.Recipe;
+      }
+      if (other.Attempt != 0) {
+        Attempt = other.Attempt;
+      }
+      if (other.Succeeded != false) {
+        Succeeded = other.Succeeded;
+      }
+      if (other.Detail.Length != 0) {
+        Detail = other.Detail;
+      }
+      if (other.StartedAtUnixSeconds != 0L) {
+        StartedAtUnixSeconds = other.StartedAtUnixSeconds;
+      }
+      if (other.DurationMs != 0L) {
+        DurationMs = other.DurationMs;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            Strategy = input.ReadString();
+            break;
+          }
+          case 18: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 24: {
+            Attempt = input.ReadInt32();
+            break;
+          }
+          case 32: {
+            Succeeded = input.ReadBool();
+            break;
+          }
+          case 42: {
+            Detail = input.ReadString();
+            break;
+          }
+          case 48: {
+            StartedAtUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 56: {
+            DurationMs = input.ReadInt64();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            Strategy = input.ReadString();
+            break;
+          }
+          case 18: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 24: {
+            Attempt = input.ReadInt32();
+            break;
+          }
+          case 32: {
+            Succeeded = input.ReadBool();
+            break;
+          }
+          case 42: {
+            Detail = input.ReadString();
+            break;
+          }
+          case 48: {
+            StartedAtUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 56: {
+            DurationMs = input.ReadInt64();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// LayerCommit is the commit a layer repository was built from
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class LayerCommit : pb::IMessage<LayerCommit>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<LayerCommit> _parser = new pb::MessageParser<LayerCommit>(() => new LayerCommit());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<LayerCommit> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[7]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public LayerCommit() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public LayerCommit(LayerCommit other) : this() {
+      name_ = other.name_;
+      git_ = other.git_;
+      branch_ = other.branch_;
+      commit_ = other.commit_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public LayerCommit Clone() {
+      return new LayerCommit(this);
+    }
+
+    /// <summary>Field number for the "name" field.</summary>
+    public const int NameFieldNumber = 1;
+    private string name_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Name {
+      get { return name_; }
+      set {
+        name_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "git" field.</summary>
+    public const int GitFieldNumber = 2;
+    private string git_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Git {
+      get { return git_; }
+      set {
+        git_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "branch" field.</summary>
+    public const int BranchFieldNumber = 3;
+    private string branch_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Branch {
+      get { return branch_; }
+      set {
+        branch_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "commit" field.</summary>
+    public const int CommitFieldNumber = 4;
+    private string commit_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Commit {
+      get { return commit_; }
+      set {
+        commit_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as LayerCommit);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(LayerCommit other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (Name != other.Name) return false;
+      if (Git != other.Git) return false;
+      if (Branch != other.Branch) return false;
+      if (Commit != other.Commit) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (Name.Length != 0) hash ^= Name.GetHashCode();
+      if (Git.Length != 0) hash ^= Git.GetHashCode();
+      if (Branch.Length != 0) hash ^= Branch.GetHashCode();
+      if (Commit.Length != 0) hash ^= Commit.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (Name.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Name);
+      }
+      if (Git.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Git);
+      }
+      if (Branch.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Branch);
+      }
+      if (Commit.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Commit);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (Name.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Name);
+      }
+      if (Git.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Git);
+      }
+      if (Branch.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Branch);
+      }
+      if (Commit.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Commit);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (Name.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Name);
+      }
+      if (Git.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Git);
+      }
+      if (Branch.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Branch);
+      }
+      if (Commit.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Commit);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(LayerCommit other) {
+      if (other == null) {
+        return;
+      }
+      if (other.Name.Length != 0) {
+        Name = other.Name;
+      }
+      if (other.Git.Length != 0) {
+        Git = other.Git;
+      }
+      if (other.Branch.Length != 0) {
+        Branch = other.Branch;
+      }
+      if (other.Commit.Length != 0) {
+        Commit = other.Commit;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            Name = input.ReadString();
+            break;
+          }
+          case 18: {
+            Git = input.ReadString();
+            break;
+          }
+          case 26: {
+            Branch = input.ReadString();
+            break;
+          }
+          case 34: {
+            Commit = input.ReadString();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            Name = input.ReadString();
+            break;
+          }
+          case 18: {
+            Git = input.ReadString();
+            break;
+          }
+          case 26: {
+            Branch = input.ReadString();
+            break;
+          }
+          case 34: {
+            Commit = input.ReadString();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// LayerFetch is the outcome of fetching a layer repository (see fetch in smidr.yaml)
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class LayerFetch : pb::IMessage<LayerFetch>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<LayerFetch> _parser = new pb::MessageParser<LayerFetch>(() => new LayerFetch());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<LayerFetch> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[8]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public LayerFetch() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public LayerFetch(LayerFetch other) : this() {
+      name_ = other.name_;
+      git_ = other.git_;
+      status_ = other.status_;
+      url_ = other.url_;
+      ref_ = other.ref_;
+      commit_ = other.commit_;
+      attempts_ = other.attempts_;
+      durationMs_ = other.durationMs_;
+      error_ = other.error_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public LayerFetch Clone() {
+      return new LayerFetch(this);
+    }
+
+    /// <summary>Field number for the "name" field.</summary>
+    public const int NameFieldNumber = 1;
+    private string name_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Name {
+      get { return name_; }
+      set {
+        name_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "git" field.</summary>
+    public const int GitFieldNumber = 2;
+    private string git_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Git {
+      get { return git_; }
+      set {
+        git_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "status" field.</summary>
+    public const int StatusFieldNumber = 3;
+    private string status_ = "";
+    /// <summary>
+    /// cloned, updated, cached, stale or failed
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Status {
+      get { return status_; }
+      set {
+        status_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "url" field.</summary>
+    public const int UrlFieldNumber = 4;
+    private string url_ = "";
+    /// <summary>
+    /// URL the fetch succeeded from; differs from git when a mirror was used
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Url {
+      get { return url_; }
+      set {
+        url_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "ref" field.</summary>
+    public const int RefFieldNumber = 5;
+    private string ref_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Ref {
+      get { return ref_; }
+      set {
+        ref_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "commit" field.</summary>
+    public const int CommitFieldNumber = 6;
+    private string commit_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Commit {
+      get { return commit_; }
+      set {
+        commit_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "attempts" field.</summary>
+    public const int AttemptsFieldNumber = 7;
+    private int attempts_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int Attempts {
+      get { return attempts_; }
+      set {
+        attempts_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "duration_ms" field.</summary>
+    public const int DurationMsFieldNumber = 8;
+    private long durationMs_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long DurationMs {
+      get { return durationMs_; }
+      set {
+        durationMs_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "error" field.</summary>
+    public const int ErrorFieldNumber = 9;
+    private string error_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Error {
+      get { return error_; }
+      set {
+        error_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as LayerFetch);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(LayerFetch other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (Name != other.Name) return false;
+      if (Git != other.Git) return false;
+      if (Status != other.Status) return false;
+      if (Url != other.Url) return false;
+      if (Ref != other.Ref) return false;
+      if (Commit != other.Commit) return false;
+      if (Attempts != other.Attempts) return false;
+      if (DurationMs != other.DurationMs) return false;
+      if (Error != other.Error) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (Name.Length != 0) hash ^= Name.GetHashCode();
+      if (Git.Length != 0) hash ^= Git.GetHashCode();
+      if (Status.Length != 0) hash ^= Status.GetHashCode();
+      if (Url.Length != 0) hash ^= Url.GetHashCode();
+      if (Ref.Length != 0) hash ^= Ref.GetHashCode();
+      if (Commit.Length != 0) hash ^= Commit.GetHashCode();
+      if (Attempts != 0) hash ^= Attempts.GetHashCode();
+      if (DurationMs != 0L) hash ^= DurationMs.GetHashCode();
+      if (Error.Length != 0) hash ^= Error.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (Name.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Name);
+      }
+      if (Git.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Git);
+      }
+      if (Status.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Status);
+      }
+      if (Url.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Url);
+      }
+      if (Ref.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Ref);
+      }
+      if (Commit.Length != 0) {
+        output.WriteRawTag(50);
+        output.WriteString(Commit);
+      }
+      if (Attempts != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(Attempts);
+      }
+      if (DurationMs != 0L) {
+        output.WriteRawTag(64);
+        output.WriteInt64(DurationMs);
+      }
+      if (Error.Length != 0) {
+        output.WriteRawTag(74);
+        output.WriteString(Error);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (Name.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Name);
+      }
+      if (Git.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Git);
+      }
+      if (Status.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Status);
+      }
+      if (Url.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Url);
+      }
+      if (Ref.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Ref);
+      }
+      if (Commit.Length != 0) {
+        output.WriteRawTag(50);
+        output.WriteString(Commit);
+      }
+      if (Attempts != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(Attempts);
+      }
+      if (DurationMs != 0L) {
+        output.WriteRawTag(64);
+        output.WriteInt64(DurationMs);
+      }
+      if (Error.Length != 0) {
+        output.WriteRawTag(74);
+        output.WriteString(Error);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (Name.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Name);
+      }
+      if (Git.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Git);
+      }
+      if (Status.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Status);
+      }
+      if (Url.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Url);
+      }
+      if (Ref.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Ref);
+      }
+      if (Commit.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Commit);
+      }
+      if (Attempts != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Attempts);
+      }
+      if (DurationMs != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(DurationMs);
+      }
+      if (Error.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Error);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(LayerFetch other) {
+      if (other == null) {
+        return;
+      }
+      if (other.Name.Length != 0) {
+        Name = other.Name;
+      }
+      if (other.Git.Length != 0) {
+        Git = other.Git;
+      }
+      if (other.Status.Length != 0) {
+        Status = other.Status;
+      }
+      if (other.Url.Length != 0) {
+        Url = other.Url;
+      }
+      if (other.Ref.Length != 0) {
+        Ref = other.Ref;
+      }
+      if (other.Commit.Length != 0) {
+        Commit = other.Commit;
+      }
+      if (other.Attempts != 0) {
+        Attempts = other.Attempts;
+      }
+      if (other.DurationMs != 0L) {
+        DurationMs = other.DurationMs;
+      }
+      if (other.Error.Length != 0) {
+        Error = other.Error;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            Name = input.ReadString();
+            break;
+          }
+          case 18: {
+            Git = input.ReadString();
+            break;
+          }
+          case 26: {
+            Status = input.ReadString();
+            break;
+          }
+          case 34: {
+            Url = input.ReadString();
+            break;
+          }
+          case 42: {
+            Ref = input.ReadString();
+            break;
+          }
+          case 50: {
+            Commit = input.ReadString();
+            break;
+          }
+          case 56: {
+            Attempts = input.ReadInt32();
+            break;
+          }
+          case 64: {
+            DurationMs = input.ReadInt64();
+            break;
+          }
+          case 74: {
+            Error = input.ReadString();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            Name = input.ReadString();
+            break;
+          }
+          case 18: {
+            Git = input.ReadString();
+            break;
+          }
+          case 26: {
+            Status = input.ReadString();
+            break;
+          }
+          case 34: {
+            Url = input.ReadString();
+            break;
+          }
+          case 42: {
+            Ref = input.ReadString();
+            break;
+          }
+          case 50: {
+            Commit = input.ReadString();
+            break;
+          }
+          case 56: {
+            Attempts = input.ReadInt32();
+            break;
+          }
+          case 64: {
+            DurationMs = input.ReadInt64();
+            break;
+          }
+          case 74: {
+            Error = input.ReadString();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// ListBuildsRequest is used to request a list of builds with optional filters.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class ListBuildsRequest : pb::IMessage<ListBuildsRequest>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<ListBuildsRequest> _parser = new pb::MessageParser<ListBuildsRequest>(() => new ListBuildsRequest());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<ListBuildsRequest> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[9]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ListBuildsRequest() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ListBuildsRequest(ListBuildsRequest other) : this() {
+      stateFilter_ = other.stateFilter_.Clone();
+      timeRange_ = other.timeRange_ != null ? other.timeRange_.Clone() : null;
+      pageSize_ = other.pageSize_;
+      pageToken_ = other.pageToken_;
+      customer_ = other.customer_;
+      includeDeleted_ = other.includeDeleted_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ListBuildsRequest Clone() {
+      return new ListBuildsRequest(this);
+    }
+
+    /// <summary>Field number for the "state_filter" field.</summary>
+    public const int StateFilterFieldNumber = 1;
+    private static readonly pb::FieldCodec<global::Smidr.V1.BuildState> _repeated_stateFilter_codec
+        = pb::FieldCodec.ForEnum(10, x => (int) x, x => (global::Smidr.V1.BuildState) x);
+    private readonly pbc::RepeatedField<global::Smidr.V1.BuildState> stateFilter_ = new pbc::RepeatedField<global::Smidr.V1.BuildState>();
+    /// <summary>
+    /// Optional filter by build states
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<global::Smidr.V1.BuildState> StateFilter {
+      get { return stateFilter_; }
+    }
+
+    /// <summary>Field number for the "time_range" field.</summary>
+    public const int TimeRangeFieldNumber = 2;
+    private global::Smidr.V1.TimeStampRange timeRange_;
+    /// <summary>
+    /// Optional time range filter
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.TimeStampRange TimeRange {
+      get { return timeRange_; }
+      set {
+        timeRange_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "page_size" field.</summary>
+    public const int PageSizeFieldNumber = 3;
+    private int pageSize_;
+    /// <summary>
+    /// Pagination parameters
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int PageSize {
+      get { return pageSize_; }
+      set {
+        pageSize_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "page_token" field.</summary>
+    public const int PageTokenFieldNumber = 4;
+    private string pageToken_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string PageToken {
+      get { return pageToken_; }
+      set {
+        pageToken_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "customer" field.</summary>
+    public const int CustomerFieldNumber = 5;
+    private string customer_ = "";
+    /// <summary>
+    /// Optional customer identifier filter
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Customer {
+      get { return customer_; }
+      set {
+        customer_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "include_deleted" field.</summary>
+    public const int IncludeDeletedFieldNumber = 6;
+    private bool includeDeleted_;
+    /// <summary>
+    /// Include deleted builds in the response
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool IncludeDeleted {
+      get { return includeDeleted_; }
+      set {
+        includeDeleted_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as ListBuildsRequest);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(ListBuildsRequest other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if(!stateFilter_.Equals(other.stateFilter_)) return false;
+      if (!object.Equals(TimeRange, other.TimeRange)) return false;
+      if (PageSize != other.PageSize) return false;
+      if (PageToken != other.PageToken) return false;
+      if (Customer != other.Customer) return false;
+      if (IncludeDeleted != other.IncludeDeleted) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      hash ^= stateFilter_.GetHashCode();
+      if (timeRange_ != null) hash ^= TimeRange.GetHashCode();
+      if (PageSize != 0) hash ^= PageSize.GetHashCode();
+      if (PageToken.Length != 0) hash ^= PageToken.GetHashCode();
+      if (Customer.Length != 0) hash ^= Customer.GetHashCode();
+      if (IncludeDeleted != false) hash ^= IncludeDeleted.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      stateFilter_.WriteTo(output, _repeated_stateFilter_codec);
+      if (timeRange_ != null) {
+        output.WriteRawTag(18);
+        output.WriteMessage(TimeRange);
+      }
+      if (PageSize != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(PageSize);
+      }
+      if (PageToken.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(PageToken);
+      }
+      if (Customer.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Customer);
+      }
+      if (IncludeDeleted != false) {
+        output.WriteRawTag(48);
+        output.WriteBool(IncludeDeleted);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      stateFilter_.WriteTo(ref output, _repeated_stateFilter_codec);
+      if (timeRange_ != null) {
+        output.WriteRawTag(18);
+        output.WriteMessage(TimeRange);
+      }
+      if (PageSize != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(PageSize);
+      }
+      if (PageToken.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(PageToken);
+      }
+      if (Customer.Length != 0) {
+        output.WriteRawTag(42);
+        output.WriteString(Customer);
+      }
+      if (IncludeDeleted != false) {
+        output.WriteRawTag(48);
+        output.WriteBool(IncludeDeleted);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      size += stateFilter_.CalculateSize(_repeated_stateFilter_codec);
+      if (timeRange_ != null) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(TimeRange);
+      }
+      if (PageSize != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(PageSize);
+      }
+      if (PageToken.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(PageToken);
+      }
+      if (Customer.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Customer);
+      }
+      if (IncludeDeleted != false) {
+        size += 1 + 1;
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(ListBuildsRequest other) {
+      if (other == null) {
+        return;
+      }
+      stateFilter_.Add(other.stateFilter_);
+      if (other.timeRange_ != null) {
+        if (timeRange_ == null) {
+          TimeRange = new global::Smidr.V1.TimeStampRange();
+        }
+        TimeRange.MergeFrom(other.TimeRange);
+      }
+      if (other.PageSize != 0) {
+        PageSize = other.PageSize;
+      }
+      if (other.PageToken.Length != 0) {
+        PageToken = other.PageToken;
+      }
+      if (other.Customer.Length != 0) {
+        Customer = other.Customer;
+      }
+      if (other.IncludeDeleted != false) {
+        IncludeDeleted = other.IncludeDeleted;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10:
+          case 8: {
+            stateFilter_.AddEntriesFrom(input, _repeated_stateFilter_codec);
+            break;
+          }
+          case 18: {
+            if (timeRange_ == null) {
+              TimeRange = new global::Smidr.V1.TimeStampRange();
+            }
+            input.ReadMessage(TimeRange);
+            break;
+          }
+          case 24: {
+            PageSize = input.ReadInt32();
+            break;
+          }
+          case 34: {
+            PageToken = input.ReadString();
+            break;
+          }
+          case 42: {
+            Customer = input.ReadString();
+            break;
+          }
+          case 48: {
+            IncludeDeleted = input.ReadBool();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10:
+          case 8: {
+            stateFilter_.AddEntriesFrom(ref input, _repeated_stateFilter_codec);
+            break;
+          }
+          case 18: {
+            if (timeRange_ == null) {
+              TimeRange = new global::Smidr.V1.TimeStampRange();
+            }
+            input.ReadMessage(TimeRange);
+            break;
+          }
+          case 24: {
+            PageSize = input.ReadInt32();
+            break;
+          }
+          case 34: {
+            PageToken = input.ReadString();
+            break;
+          }
+          case 42: {
+            Customer = input.ReadString();
+            break;
+          }
+          case 48: {
+            IncludeDeleted = input.ReadBool();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// ListBuildsResponse provides a list of builds matching the request criteria.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class ListBuildsResponse : pb::IMessage<ListBuildsResponse>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<ListBuildsResponse> _parser = new pb::MessageParser<ListBuildsResponse>(() => new ListBuildsResponse());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<ListBuildsResponse> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[10]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ListBuildsResponse() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ListBuildsResponse(ListBuildsResponse other) : this() {
+      builds_ = other.builds_.Clone();
+      nextPageToken_ = other.nextPageToken_;
+      totalBuilds_ = other.totalBuilds_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ListBuildsResponse Clone() {
+      return new ListBuildsResponse(this);
+    }
+
+    /// <summary>Field number for the "builds" field.</summary>
+    public const int BuildsFieldNumber = 1;
+    private static readonly pb::FieldCodec<global::Smidr.V1.BuildDetails> _repeated_builds_codec
+        = pb::FieldCodec.ForMessage(10, global::Smidr.V1.BuildDetails.Parser);
+    private readonly pbc::RepeatedField<global::Smidr.V1.BuildDetails> builds_ = new pbc::RepeatedField<global::Smidr.V1.BuildDetails>();
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<global::Smidr.V1.BuildDetails> Builds {
+      get { return builds_; }
+    }
+
+    /// <summary>Field number for the "next_page_token" field.</summary>
+    public const int NextPageTokenFieldNumber = 2;
+    private string nextPageToken_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string NextPageToken {
+      get { return nextPageToken_; }
+      set {
+        nextPageToken_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "total_builds" field.</summary>
+    public const int TotalBuildsFieldNumber = 3;
+    private int totalBuilds_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int TotalBuilds {
+      get { return totalBuilds_; }
+      set {
+        totalBuilds_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as ListBuildsResponse);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(ListBuildsResponse other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if(!builds_.Equals(other.builds_)) return false;
+      if (NextPageToken != other.NextPageToken) return false;
+      if (TotalBuilds != other.TotalBuilds) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      hash ^= builds_.GetHashCode();
+      if (NextPageToken.Length != 0) hash ^= NextPageToken.GetHashCode();
+      if (TotalBuilds != 0) hash ^= TotalBuilds.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      builds_.WriteTo(output, _repeated_builds_codec);
+      if (NextPageToken.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(NextPageToken);
+      }
+      if (TotalBuilds != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(TotalBuilds);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      builds_.WriteTo(ref output, _repeated_builds_codec);
+      if (NextPageToken.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(NextPageToken);
+      }
+      if (TotalBuilds != 0) {
+        output.WriteRawTag(24);
+        output.WriteInt32(TotalBuilds);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      size += builds_.CalculateSize(_repeated_builds_codec);
+      if (NextPageToken.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(NextPageToken);
+      }
+      if (TotalBuilds != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(TotalBuilds);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(ListBuildsResponse other) {
+      if (other == null) {
+        return;
+      }
+      builds_.Add(other.builds_);
+      if (other.NextPageToken.Length != 0) {
+        NextPageToken = other.NextPageToken;
+      }
+      if (other.TotalBuilds != 0) {
+        TotalBuilds = other.TotalBuilds;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            builds_.AddEntriesFrom(input, _repeated_builds_codec);
+            break;
+          }
+          case 18: {
+            NextPageToken = input.ReadString();
+            break;
+          }
+          case 24: {
+            TotalBuilds = input.ReadInt32();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            builds_.AddEntriesFrom(ref input, _repeated_builds_codec);
+            break;
+          }
+          case 18: {
+            NextPageToken = input.ReadString();
+            break;
+          }
+          case 24: {
+            TotalBuilds = input.ReadInt32();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// CancelBuildRequest is used to request the cancellation of a specific build.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class CancelBuildRequest : pb::IMessage<CancelBuildRequest>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<CancelBuildRequest> _parser = new pb::MessageParser<CancelBuildRequest>(() => new CancelBuildRequest());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<CancelBuildRequest> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[11]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public CancelBuildRequest() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public CancelBuildRequest(CancelBuildRequest other) : this() {
+      buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public CancelBuildRequest Clone() {
+      return new CancelBuildRequest(this);
+    }
+
+    /// <summary>Field number for the "build_identifier" field.</summary>
+    public const int BuildIdentifierFieldNumber = 1;
+    private global::Smidr.V1.BuildIdentifier buildIdentifier_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.BuildIdentifier BuildIdentifier {
+      get { return buildIdentifier_; }
+      set {
+        buildIdentifier_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as CancelBuildRequest);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(CancelBuildRequest other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (buildIdentifier_ != null) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(CancelBuildRequest other) {
+      if (other == null) {
+        return;
+      }
+      if (other.buildIdentifier_ != null) {
+        if (buildIdentifier_ == null) {
+          BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+        }
+        BuildIdentifier.MergeFrom(other.BuildIdentifier);
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// CancelBuildResponse provides the result of a cancellation request.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class CancelBuildResponse : pb::IMessage<CancelBuildResponse>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<CancelBuildResponse> _parser = new pb::MessageParser<CancelBuildResponse>(() => new CancelBuildResponse());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<CancelBuildResponse> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[12]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public CancelBuildResponse() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public CancelBuildResponse(CancelBuildResponse other) : this() {
+      success_ = other.success_;
+      message_ = other.message_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public CancelBuildResponse Clone() {
+      return new CancelBuildResponse(this);
+    }
+
+    /// <summary>Field number for the "success" field.</summary>
+    public const int SuccessFieldNumber = 1;
+    private bool success_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Success {
+      get { return success_; }
+      set {
+        success_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "message" field.</summary>
+    public const int MessageFieldNumber = 2;
+    private string message_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Message {
+      get { return message_; }
+      set {
+        message_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as CancelBuildResponse);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(CancelBuildResponse other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (Success != other.Success) return false;
+      if (Message != other.Message) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (Success != false) hash ^= Success.GetHashCode();
+      if (Message.Length != 0) hash ^= Message.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (Success != false) {
+        output.WriteRawTag(8);
+        output.WriteBool(Success);
+      }
+      if (Message.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Message);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (Success != false) {
+        output.WriteRawTag(8);
+        output.WriteBool(Success);
+      }
+      if (Message.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Message);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (Success != false) {
+        size += 1 + 1;
+      }
+      if (Message.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Message);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(CancelBuildResponse other) {
+      if (other == null) {
+        return;
+      }
+      if (other.Success != false) {
+        Success = other.Success;
+      }
+      if (other.Message.Length != 0) {
+        Message = other.Message;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 8: {
+            Success = input.ReadBool();
+            break;
+          }
+          case 18: {
+            Message = input.ReadString();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 8: {
+            Success = input.ReadBool();
+            break;
+          }
+          case 18: {
+            Message = input.ReadString();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// GetBuildRequest is used to request detailed information about a build.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class GetBuildRequest : pb::IMessage<GetBuildRequest>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<GetBuildRequest> _parser = new pb::MessageParser<GetBuildRequest>(() => new GetBuildRequest());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<GetBuildRequest> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[13]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetBuildRequest() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetBuildRequest(GetBuildRequest other) : this() {
+      buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetBuildRequest Clone() {
+      return new GetBuildRequest(this);
+    }
+
+    /// <summary>Field number for the "build_identifier" field.</summary>
+    public const int BuildIdentifierFieldNumber = 1;
+    private global::Smidr.V1.BuildIdentifier buildIdentifier_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.BuildIdentifier BuildIdentifier {
+      get { return buildIdentifier_; }
+      set {
+        buildIdentifier_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as GetBuildRequest);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(GetBuildRequest other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (buildIdentifier_ != null) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(GetBuildRequest other) {
+      if (other == null) {
+        return;
+      }
+      if (other.buildIdentifier_ != null) {
+        if (buildIdentifier_ == null) {
+          BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+        }
+        BuildIdentifier.MergeFrom(other.BuildIdentifier);
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// DeleteBuildRequest is used to request the deletion of a specific build.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class DeleteBuildRequest : pb::IMessage<DeleteBuildRequest>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<DeleteBuildRequest> _parser = new pb::MessageParser<DeleteBuildRequest>(() => new DeleteBuildRequest());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<DeleteBuildRequest> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[14]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public DeleteBuildRequest() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public DeleteBuildRequest(DeleteBuildRequest other) : this() {
+      buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public DeleteBuildRequest Clone() {
+      return new DeleteBuildRequest(this);
+    }
+
+    /// <summary>Field number for the "build_identifier" field.</summary>
+    public const int BuildIdentifierFieldNumber = 1;
+    private global::Smidr.V1.BuildIdentifier buildIdentifier_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.BuildIdentifier BuildIdentifier {
+      get { return buildIdentifier_; }
+      set {
+        buildIdentifier_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as DeleteBuildRequest);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(DeleteBuildRequest other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (buildIdentifier_ != null) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(DeleteBuildRequest other) {
+      if (other == null) {
+        return;
+      }
+      if (other.buildIdentifier_ != null) {
+        if (buildIdentifier_ == null) {
+          BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+        }
+        BuildIdentifier.MergeFrom(other.BuildIdentifier);
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// DeleteBuildResponse provides the result of a deletion request.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class DeleteBuildResponse : pb::IMessage<DeleteBuildResponse>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<DeleteBuildResponse> _parser = new pb::MessageParser<DeleteBuildResponse>(() => new DeleteBuildResponse());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<DeleteBuildResponse> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[15]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public DeleteBuildResponse() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public DeleteBuildResponse(DeleteBuildResponse other) : this() {
+      success_ = other.success_;
+      message_ = other.message_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public DeleteBuildResponse Clone() {
+      return new DeleteBuildResponse(this);
+    }
+
+    /// <summary>Field number for the "success" field.</summary>
+    public const int SuccessFieldNumber = 1;
+    private bool success_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Success {
+      get { return success_; }
+      set {
+        success_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "message" field.</summary>
+    public const int MessageFieldNumber = 2;
+    private string message_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Message {
+      get { return message_; }
+      set {
+        message_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
       }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as ListBuildsRequest);
+      return Equals(other as DeleteBuildResponse);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(ListBuildsRequest other) {
+    public bool Equals(DeleteBuildResponse other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
       if (ReferenceEquals(other, this)) {
         return true;
       }
-      if(!stateFilter_.Equals(other.stateFilter_)) return false;
-      if (!object.Equals(TimeRange, other.TimeRange)) return false;
-      if (PageSize != other.PageSize) return false;
-      if (PageToken != other.PageToken) return false;
-      if (Customer != other.Customer) return false;
-      if (IncludeDeleted != other.IncludeDeleted) return false;
+      if (Success != other.Success) return false;
+      if (Message != other.Message) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -2462,12 +6472,8 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override int GetHashCode() {
       int hash = 1;
-      hash ^= stateFilter_.GetHashCode();
-      if (timeRange_ != null) hash ^= TimeRange.GetHashCode();
-      if (PageSize != 0) hash ^= PageSize.GetHashCode();
-      if (PageToken.Length != 0) hash ^= PageToken.GetHashCode();
-      if (Customer.Length != 0) hash ^= Customer.GetHashCode();
-      if (IncludeDeleted != false) hash ^= IncludeDeleted.GetHashCode();
+      if (Success != false) hash ^= Success.GetHashCode();
+      if (Message.Length != 0) hash ^= Message.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -2486,26 +6492,13 @@ namespace Smidr.V1 {
     #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       output.WriteRawMessage(this);
     #else
-      stateFilter_.WriteTo(output, _repeated_stateFilter_codec);
-      if (timeRange_ != null) {
-        output.WriteRawTag(18);
-        output.WriteMessage(TimeRange);
-      }
-      if (PageSize != 0) {
-        output.WriteRawTag(24);
-        output.WriteInt32(PageSize);
-      }
-      if (PageToken.Length != 0) {
-        output.WriteRawTag(34);
-        output.WriteString(PageToken);
-      }
-      if (Customer.Length != 0) {
-        output.WriteRawTag(42);
-        output.WriteString(Customer);
+      if (Success != false) {
+        output.WriteRawTag(8);
+        output.WriteBool(Success);
       }
-      if (IncludeDeleted != false) {
-        output.WriteRawTag(48);
-        output.WriteBool(IncludeDeleted);
+      if (Message.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Message);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
@@ -2517,26 +6510,13 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
-      stateFilter_.WriteTo(ref output, _repeated_stateFilter_codec);
-      if (timeRange_ != null) {
-        output.WriteRawTag(18);
-        output.WriteMessage(TimeRange);
-      }
-      if (PageSize != 0) {
-        output.WriteRawTag(24);
-        output.WriteInt32(PageSize);
-      }
-      if (PageToken.Length != 0) {
-        output.WriteRawTag(34);
-        output.WriteString(PageToken);
-      }
-      if (Customer.Length != 0) {
-        output.WriteRawTag(42);
-        output.WriteString(Customer);
+      if (Success != false) {
+        output.WriteRawTag(8);
+        output.WriteBool(Success);
       }
-      if (IncludeDeleted != false) {
-        output.WriteRawTag(48);
-        output.WriteBool(IncludeDeleted);
+      if (Message.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Message);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
@@ -2548,22 +6528,12 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public int CalculateSize() {
       int size = 0;
-      size += stateFilter_.CalculateSize(_repeated_stateFilter_codec);
-      if (timeRange_ != null) {
-        size += 1 + pb::CodedOutputStream.ComputeMessageSize(TimeRange);
-      }
-      if (PageSize != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeInt32Size(PageSize);
-      }
-      if (PageToken.Length != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeStringSize(PageToken);
-      }
-      if (Customer.Length != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeStringSize(Customer);
-      }
-      if (IncludeDeleted != false) {
+      if (Success != false) {
         size += 1 + 1;
       }
+      if (Message.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Message);
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -2572,28 +6542,15 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(ListBuildsRequest other) {
+    public void MergeFrom(DeleteBuildResponse other) {
       if (other == null) {
         return;
       }
-      stateFilter_.Add(other.stateFilter_);
-      if (other.timeRange_ != null) {
-        if (timeRange_ == null) {
-          TimeRange = new global::Smidr.V1.TimeStampRange();
-        }
-        TimeRange.MergeFrom(other.TimeRange);
-      }
-      if (other.PageSize != 0) {
-        PageSize = other.PageSize;
-      }
-      if (other.PageToken.Length != 0) {
-        PageToken = other.PageToken;
-      }
-      if (other.Customer.Length != 0) {
-        Customer = other.Customer;
+      if (other.Success != false) {
+        Success = other.Success;
       }
-      if (other.IncludeDeleted != false) {
-        IncludeDeleted = other.IncludeDeleted;
+      if (other.Message.Length != 0) {
+        Message = other.Message;
       }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
@@ -2614,32 +6571,12 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
-          case 10:
           case 8: {
-            stateFilter_.AddEntriesFrom(input, _repeated_stateFilter_codec);
+            Success = input.ReadBool();
             break;
           }
           case 18: {
-            if (timeRange_ == null) {
-              TimeRange = new global::Smidr.V1.TimeStampRange();
-            }
-            input.ReadMessage(TimeRange);
-            break;
-          }
-          case 24: {
-            PageSize = input.ReadInt32();
-            break;
-          }
-          case 34: {
-            PageToken = input.ReadString();
-            break;
-          }
-          case 42: {
-            Customer = input.ReadString();
-            break;
-          }
-          case 48: {
-            IncludeDeleted = input.ReadBool();
+            Message = input.ReadString();
             break;
           }
         }
@@ -2661,32 +6598,12 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
             break;
-          case 10:
           case 8: {
-            stateFilter_.AddEntriesFrom(ref input, _repeated_stateFilter_codec);
+            Success = input.ReadBool();
             break;
           }
           case 18: {
-            if (timeRange_ == null) {
-              TimeRange = new global::Smidr.V1.TimeStampRange();
-            }
-            input.ReadMessage(TimeRange);
-            break;
-          }
-          case 24: {
-            PageSize = input.ReadInt32();
-            break;
-          }
-          case 34: {
-            PageToken = input.ReadString();
-            break;
-          }
-          case 42: {
-            Customer = input.ReadString();
-            break;
-          }
-          case 48: {
-            IncludeDeleted = input.ReadBool();
+            Message = input.ReadString();
             break;
           }
         }
@@ -2697,24 +6614,24 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// ListBuildsResponse provides a list of builds matching the request criteria.
+  /// PurgeBuildsRequest is used to request the purging of old builds.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class ListBuildsResponse : pb::IMessage<ListBuildsResponse>
+  public sealed partial class PurgeBuildsRequest : pb::IMessage<PurgeBuildsRequest>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<ListBuildsResponse> _parser = new pb::MessageParser<ListBuildsResponse>(() => new ListBuildsResponse());
+    private static readonly pb::MessageParser<PurgeBuildsRequest> _parser = new pb::MessageParser<PurgeBuildsRequest>(() => new PurgeBuildsRequest());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<ListBuildsResponse> Parser { get { return _parser; } }
+    public static pb::MessageParser<PurgeBuildsRequest> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[5]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[16]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -2725,7 +6642,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public ListBuildsResponse() {
+    public PurgeBuildsRequest() {
       OnConstruction();
     }
 
@@ -2733,72 +6650,65 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public ListBuildsResponse(ListBuildsResponse other) : this() {
-      builds_ = other.builds_.Clone();
-      nextPageToken_ = other.nextPageToken_;
-      totalBuilds_ = other.totalBuilds_;
+    public PurgeBuildsRequest(PurgeBuildsRequest other) : this() {
+      olderThanUnixSeconds_ = other.olderThanUnixSeconds_;
+      customer_ = other.customer_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public ListBuildsResponse Clone() {
-      return new ListBuildsResponse(this);
-    }
-
-    /// <summary>Field number for the "builds" field.</summary>
-    public const int BuildsFieldNumber = 1;
-    private static readonly pb::FieldCodec<global::Smidr.V1.BuildDetails> _repeated_builds_codec
-        = pb::FieldCodec.ForMessage(10, global::Smidr.V1.BuildDetails.Parser);
-    private readonly pbc::RepeatedField<global::Smidr.V1.BuildDetails> builds_ = new pbc::RepeatedField<global::Smidr.V1.BuildDetails>();
-    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
-    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public pbc::RepeatedField<global::Smidr.V1.BuildDetails> Builds {
-      get { return builds_; }
+    public PurgeBuildsRequest Clone() {
+      return new PurgeBuildsRequest(this);
     }
 
-    /// <summary>Field number for the "next_page_token" field.</summary>
-    public const int NextPageTokenFieldNumber = 2;
-    private string nextPageToken_ = "";
+    /// <summary>Field number for the "older_than_unix_seconds" field.</summary>
+    public const int OlderThanUnixSecondsFieldNumber = 1;
+    private long olderThanUnixSeconds_;
+    /// <summary>
+    /// Builds older than this timestamp will be purged
+    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public string NextPageToken {
-      get { return nextPageToken_; }
+    public long OlderThanUnixSeconds {
+      get { return olderThanUnixSeconds_; }
       set {
-        nextPageToken_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+        olderThanUnixSeconds_ = value;
       }
     }
 
-    /// <summary>Field number for the "total_builds" field.</summary>
-    public const int TotalBuildsFieldNumber = 3;
-    private int totalBuilds_;
+    /// <summary>Field number for the "customer" field.</summary>
+    public const int CustomerFieldNumber = 2;
+    private string customer_ = "";
+    /// <summary>
+    /// Optional customer identifier filter
+    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public int TotalBuilds {
-      get { return totalBuilds_; }
+    public string Customer {
+      get { return customer_; }
       set {
-        totalBuilds_ = value;
+        customer_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
       }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as ListBuildsResponse);
+      return Equals(other as PurgeBuildsRequest);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(ListBuildsResponse other) {
+    public bool Equals(PurgeBuildsRequest other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
       if (ReferenceEquals(other, this)) {
         return true;
       }
-      if(!builds_.Equals(other.builds_)) return false;
-      if (NextPageToken != other.NextPageToken) return false;
-      if (TotalBuilds != other.TotalBuilds) return false;
+      if (OlderThanUnixSeconds != other.OlderThanUnixSeconds) return false;
+      if (Customer != other.Customer) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -2806,9 +6716,8 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override int GetHashCode() {
       int hash = 1;
-      hash ^= builds_.GetHashCode();
-      if (NextPageToken.Length != 0) hash ^= NextPageToken.GetHashCode();
-      if (TotalBuilds != 0) hash ^= TotalBuilds.GetHashCode();
+      if (OlderThanUnixSeconds != 0L) hash ^= OlderThanUnixSeconds.GetHashCode();
+      if (Customer.Length != 0) hash ^= Customer.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -2827,14 +6736,13 @@ namespace Smidr.V1 {
     #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       output.WriteRawMessage(this);
     #else
-      builds_.WriteTo(output, _repeated_builds_codec);
-      if (NextPageToken.Length != 0) {
-        output.WriteRawTag(18);
-        output.WriteString(NextPageToken);
+      if (OlderThanUnixSeconds != 0L) {
+        output.WriteRawTag(8);
+        output.WriteInt64(OlderThanUnixSeconds);
       }
-      if (TotalBuilds != 0) {
-        output.WriteRawTag(24);
-        output.WriteInt32(TotalBuilds);
+      if (Customer.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Customer);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
@@ -2846,14 +6754,13 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
-      builds_.WriteTo(ref output, _repeated_builds_codec);
-      if (NextPageToken.Length != 0) {
-        output.WriteRawTag(18);
-        output.WriteString(NextPageToken);
+      if (OlderThanUnixSeconds != 0L) {
+        output.WriteRawTag(8);
+        output.WriteInt64(OlderThanUnixSeconds);
       }
-      if (TotalBuilds != 0) {
-        output.WriteRawTag(24);
-        output.WriteInt32(TotalBuilds);
+      if (Customer.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Customer);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
@@ -2865,12 +6772,11 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public int CalculateSize() {
       int size = 0;
-      size += builds_.CalculateSize(_repeated_builds_codec);
-      if (NextPageToken.Length != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeStringSize(NextPageToken);
+      if (OlderThanUnixSeconds != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(OlderThanUnixSeconds);
       }
-      if (TotalBuilds != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeInt32Size(TotalBuilds);
+      if (Customer.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Customer);
       }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
@@ -2880,16 +6786,15 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(ListBuildsResponse other) {
+    public void MergeFrom(PurgeBuildsRequest other) {
       if (other == null) {
         return;
       }
-      builds_.Add(other.builds_);
-      if (other.NextPageToken.Length != 0) {
-        NextPageToken = other.NextPageToken;
+      if (other.OlderThanUnixSeconds != 0L) {
+        OlderThanUnixSeconds = other.OlderThanUnixSeconds;
       }
-      if (other.TotalBuilds != 0) {
-        TotalBuilds = other.TotalBuilds;
+      if (other.Customer.Length != 0) {
+        Customer = other.Customer;
       }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
@@ -2910,16 +6815,12 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
-          case 10: {
-            builds_.AddEntriesFrom(input, _repeated_builds_codec);
+          case 8: {
+            OlderThanUnixSeconds = input.ReadInt64();
             break;
           }
           case 18: {
-            NextPageToken = input.ReadString();
-            break;
-          }
-          case 24: {
-            TotalBuilds = input.ReadInt32();
+            Customer = input.ReadString();
             break;
           }
         }
@@ -2941,16 +6842,12 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
             break;
-          case 10: {
-            builds_.AddEntriesFrom(ref input, _repeated_builds_codec);
+          case 8: {
+            OlderThanUnixSeconds = input.ReadInt64();
             break;
           }
           case 18: {
-            NextPageToken = input.ReadString();
-            break;
-          }
-          case 24: {
-            TotalBuilds = input.ReadInt32();
+            Customer = input.ReadString();
             break;
           }
         }
@@ -2961,24 +6858,24 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// CancelBuildRequest is used to request the cancellation of a specific build.
+  /// PurgeBuildsResponse provides the result of a purge request.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class CancelBuildRequest : pb::IMessage<CancelBuildRequest>
+  public sealed partial class PurgeBuildsResponse : pb::IMessage<PurgeBuildsResponse>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<CancelBuildRequest> _parser = new pb::MessageParser<CancelBuildRequest>(() => new CancelBuildRequest());
+    private static readonly pb::MessageParser<PurgeBuildsResponse> _parser = new pb::MessageParser<PurgeBuildsResponse>(() => new PurgeBuildsResponse());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<CancelBuildRequest> Parser { get { return _parser; } }
+    public static pb::MessageParser<PurgeBuildsResponse> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[6]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[17]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -2989,7 +6886,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public CancelBuildRequest() {
+    public PurgeBuildsResponse() {
       OnConstruction();
     }
 
@@ -2997,45 +6894,86 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public CancelBuildRequest(CancelBuildRequest other) : this() {
-      buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
+    public PurgeBuildsResponse(PurgeBuildsResponse other) : this() {
+      purgedBuildCount_ = other.purgedBuildCount_;
+      purgedBuildIds_ = other.purgedBuildIds_.Clone();
+      freedSpaceBytes_ = other.freedSpaceBytes_;
+      message_ = other.message_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public CancelBuildRequest Clone() {
-      return new CancelBuildRequest(this);
+    public PurgeBuildsResponse Clone() {
+      return new PurgeBuildsResponse(this);
     }
 
-    /// <summary>Field number for the "build_identifier" field.</summary>
-    public const int BuildIdentifierFieldNumber = 1;
-    private global::Smidr.V1.BuildIdentifier buildIdentifier_;
+    /// <summary>Field number for the "purged_build_count" field.</summary>
+    public const int PurgedBuildCountFieldNumber = 1;
+    private int purgedBuildCount_;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public global::Smidr.V1.BuildIdentifier BuildIdentifier {
-      get { return buildIdentifier_; }
+    public int PurgedBuildCount {
+      get { return purgedBuildCount_; }
       set {
-        buildIdentifier_ = value;
+        purgedBuildCount_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "purged_build_ids" field.</summary>
+    public const int PurgedBuildIdsFieldNumber = 2;
+    private static readonly pb::FieldCodec<string> _repeated_purgedBuildIds_codec
+        = pb::FieldCodec.ForString(18);
+    private readonly pbc::RepeatedField<string> purgedBuildIds_ = new pbc::RepeatedField<string>();
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pbc::RepeatedField<string> PurgedBuildIds {
+      get { return purgedBuildIds_; }
+    }
+
+    /// <summary>Field number for the "freed_space_bytes" field.</summary>
+    public const int FreedSpaceBytesFieldNumber = 3;
+    private long freedSpaceBytes_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long FreedSpaceBytes {
+      get { return freedSpaceBytes_; }
+      set {
+        freedSpaceBytes_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "message" field.</summary>
+    public const int MessageFieldNumber = 4;
+    private string message_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Message {
+      get { return message_; }
+      set {
+        message_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
       }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as CancelBuildRequest);
+      return Equals(other as PurgeBuildsResponse);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(CancelBuildRequest other) {
+    public bool Equals(PurgeBuildsResponse other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
       if (ReferenceEquals(other, this)) {
         return true;
       }
-      if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
+      if (PurgedBuildCount != other.PurgedBuildCount) return false;
+      if(!purgedBuildIds_.Equals(other.purgedBuildIds_)) return false;
+      if (FreedSpaceBytes != other.FreedSpaceBytes) return false;
+      if (Message != other.Message) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -3043,7 +6981,10 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override int GetHashCode() {
       int hash = 1;
-      if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
+      if (PurgedBuildCount != 0) hash ^= PurgedBuildCount.GetHashCode();
+      hash ^= purgedBuildIds_.GetHashCode();
+      if (FreedSpaceBytes != 0L) hash ^= FreedSpaceBytes.GetHashCode();
+      if (Message.Length != 0) hash ^= Message.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -3062,9 +7003,18 @@ namespace Smidr.V1 {
     #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       output.WriteRawMessage(this);
     #else
-      if (buildIdentifier_ != null) {
-        output.WriteRawTag(10);
-        output.WriteMessage(BuildIdentifier);
+      if (PurgedBuildCount != 0) {
+        output.WriteRawTag(8);
+        output.WriteInt32(PurgedBuildCount);
+      }
+      purgedBuildIds_.WriteTo(output, _repeated_purgedBuildIds_codec);
+      if (FreedSpaceBytes != 0L) {
+        output.WriteRawTag(24);
+        output.WriteInt64(FreedSpaceBytes);
+      }
+      if (Message.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Message);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
@@ -3076,9 +7026,18 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
-      if (buildIdentifier_ != null) {
-        output.WriteRawTag(10);
-        output.WriteMessage(BuildIdentifier);
+      if (PurgedBuildCount != 0) {
+        output.WriteRawTag(8);
+        output.WriteInt32(PurgedBuildCount);
+      }
+      purgedBuildIds_.WriteTo(ref output, _repeated_purgedBuildIds_codec);
+      if (FreedSpaceBytes != 0L) {
+        output.WriteRawTag(24);
+        output.WriteInt64(FreedSpaceBytes);
+      }
+      if (Message.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Message);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
@@ -3090,8 +7049,15 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public int CalculateSize() {
       int size = 0;
-      if (buildIdentifier_ != null) {
-        size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
+      if (PurgedBuildCount != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(PurgedBuildCount);
+      }
+      size += purgedBuildIds_.CalculateSize(_repeated_purgedBuildIds_codec);
+      if (FreedSpaceBytes != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(FreedSpaceBytes);
+      }
+      if (Message.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Message);
       }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
@@ -3101,15 +7067,19 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(CancelBuildRequest other) {
+    public void MergeFrom(PurgeBuildsResponse other) {
       if (other == null) {
         return;
       }
-      if (other.buildIdentifier_ != null) {
-        if (buildIdentifier_ == null) {
-          BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
-        }
-        BuildIdentifier.MergeFrom(other.BuildIdentifier);
+      if (other.PurgedBuildCount != 0) {
+        PurgedBuildCount = other.PurgedBuildCount;
+      }
+      purgedBuildIds_.Add(other.purgedBuildIds_);
+      if (other.FreedSpaceBytes != 0L) {
+        FreedSpaceBytes = other.FreedSpaceBytes;
+      }
+      if (other.Message.Length != 0) {
+        Message = other.Message;
       }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
@@ -3130,11 +7100,20 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
-          case 10: {
-            if (buildIdentifier_ == null) {
-              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
-            }
-            input.ReadMessage(BuildIdentifier);
+          case 8: {
+            PurgedBuildCount = input.ReadInt32();
+            break;
+          }
+          case 18: {
+            purgedBuildIds_.AddEntriesFrom(input, _repeated_purgedBuildIds_codec);
+            break;
+          }
+          case 24: {
+            FreedSpaceBytes = input.ReadInt64();
+            break;
+          }
+          case 34: {
+            Message = input.ReadString();
             break;
           }
         }
@@ -3156,11 +7135,20 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
             break;
-          case 10: {
-            if (buildIdentifier_ == null) {
-              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
-            }
-            input.ReadMessage(BuildIdentifier);
+          case 8: {
+            PurgedBuildCount = input.ReadInt32();
+            break;
+          }
+          case 18: {
+            purgedBuildIds_.AddEntriesFrom(ref input, _repeated_purgedBuildIds_codec);
+            break;
+          }
+          case 24: {
+            FreedSpaceBytes = input.ReadInt64();
+            break;
+          }
+          case 34: {
+            Message = input.ReadString();
             break;
           }
         }
@@ -3171,24 +7159,24 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// CancelBuildResponse provides the result of a cancellation request.
+  /// WatchBuildRequest is used to subscribe to structured events of a build.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class CancelBuildResponse : pb::IMessage<CancelBuildResponse>
+  public sealed partial class WatchBuildRequest : pb::IMessage<WatchBuildRequest>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<CancelBuildResponse> _parser = new pb::MessageParser<CancelBuildResponse>(() => new CancelBuildResponse());
+    private static readonly pb::MessageParser<WatchBuildRequest> _parser = new pb::MessageParser<WatchBuildRequest>(() => new WatchBuildRequest());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<CancelBuildResponse> Parser { get { return _parser; } }
+    public static pb::MessageParser<WatchBuildRequest> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[7]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[18]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -3199,7 +7187,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public CancelBuildResponse() {
+    public WatchBuildRequest() {
       OnConstruction();
     }
 
@@ -3207,59 +7195,45 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public CancelBuildResponse(CancelBuildResponse other) : this() {
-      success_ = other.success_;
-      message_ = other.message_;
+    public WatchBuildRequest(WatchBuildRequest other) : this() {
+      buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public CancelBuildResponse Clone() {
-      return new CancelBuildResponse(this);
-    }
-
-    /// <summary>Field number for the "success" field.</summary>
-    public const int SuccessFieldNumber = 1;
-    private bool success_;
-    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
-    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Success {
-      get { return success_; }
-      set {
-        success_ = value;
-      }
+    public WatchBuildRequest Clone() {
+      return new WatchBuildRequest(this);
     }
 
-    /// <summary>Field number for the "message" field.</summary>
-    public const int MessageFieldNumber = 2;
-    private string message_ = "";
+    /// <summary>Field number for the "build_identifier" field.</summary>
+    public const int BuildIdentifierFieldNumber = 1;
+    private global::Smidr.V1.BuildIdentifier buildIdentifier_;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public string Message {
-      get { return message_; }
+    public global::Smidr.V1.BuildIdentifier BuildIdentifier {
+      get { return buildIdentifier_; }
       set {
-        message_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+        buildIdentifier_ = value;
       }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as CancelBuildResponse);
+      return Equals(other as WatchBuildRequest);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(CancelBuildResponse other) {
+    public bool Equals(WatchBuildRequest other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
       if (ReferenceEquals(other, this)) {
         return true;
       }
-      if (Success != other.Success) return false;
-      if (Message != other.Message) return false;
+      if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -3267,8 +7241,7 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override int GetHashCode() {
       int hash = 1;
-      if (Success != false) hash ^= Success.GetHashCode();
-      if (Message.Length != 0) hash ^= Message.GetHashCode();
+      if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -3287,13 +7260,9 @@ namespace Smidr.V1 {
     #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       output.WriteRawMessage(this);
     #else
-      if (Success != false) {
-        output.WriteRawTag(8);
-        output.WriteBool(Success);
-      }
-      if (Message.Length != 0) {
-        output.WriteRawTag(18);
-        output.WriteString(Message);
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
@@ -3305,13 +7274,9 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
-      if (Success != false) {
-        output.WriteRawTag(8);
-        output.WriteBool(Success);
-      }
-      if (Message.Length != 0) {
-        output.WriteRawTag(18);
-        output.WriteString(Message);
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
@@ -3323,11 +7288,8 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public int CalculateSize() {
       int size = 0;
-      if (Success != false) {
-        size += 1 + 1;
-      }
-      if (Message.Length != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeStringSize(Message);
+      if (buildIdentifier_ != null) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
       }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
@@ -3337,15 +7299,15 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(CancelBuildResponse other) {
+    public void MergeFrom(WatchBuildRequest other) {
       if (other == null) {
         return;
       }
-      if (other.Success != false) {
-        Success = other.Success;
-      }
-      if (other.Message.Length != 0) {
-        Message = other.Message;
+      if (other.buildIdentifier_ != null) {
+        if (buildIdentifier_ == null) {
+          BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+        }
+        BuildIdentifier.MergeFrom(other.BuildIdentifier);
       }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
@@ -3366,12 +7328,11 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
-          case 8: {
-            Success = input.ReadBool();
-            break;
-          }
-          case 18: {
-            Message = input.ReadString();
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
             break;
           }
         }
@@ -3393,12 +7354,11 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
             break;
-          case 8: {
-            Success = input.ReadBool();
-            break;
-          }
-          case 18: {
-            Message = input.ReadString();
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
             break;
           }
         }
@@ -3409,24 +7369,25 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// GetBuildRequest is used to request detailed information about a build.
+  /// RetryBuildRequest starts a new build from the config snapshot, target and
+  /// customer of a finished build.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class GetBuildRequest : pb::IMessage<GetBuildRequest>
+  public sealed partial class RetryBuildRequest : pb::IMessage<RetryBuildRequest>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<GetBuildRequest> _parser = new pb::MessageParser<GetBuildRequest>(() => new GetBuildRequest());
+    private static readonly pb::MessageParser<RetryBuildRequest> _parser = new pb::MessageParser<RetryBuildRequest>(() => new RetryBuildRequest());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<GetBuildRequest> Parser { get { return _parser; } }
+    public static pb::MessageParser<RetryBuildRequest> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[8]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[19]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -3437,7 +7398,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public GetBuildRequest() {
+    public RetryBuildRequest() {
       OnConstruction();
     }
 
@@ -3445,20 +7406,27 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public GetBuildRequest(GetBuildRequest other) : this() {
+    public RetryBuildRequest(RetryBuildRequest other) : this() {
       buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
+      target_ = other.target_;
+      forceClean_ = other.forceClean_;
+      forceImageRebuild_ = other.forceImageRebuild_;
+      priority_ = other.priority_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public GetBuildRequest Clone() {
-      return new GetBuildRequest(this);
+    public RetryBuildRequest Clone() {
+      return new RetryBuildRequest(this);
     }
 
     /// <summary>Field number for the "build_identifier" field.</summary>
     public const int BuildIdentifierFieldNumber = 1;
     private global::Smidr.V1.BuildIdentifier buildIdentifier_;
+    /// <summary>
+    /// Build to retry
+    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public global::Smidr.V1.BuildIdentifier BuildIdentifier {
@@ -3468,15 +7436,75 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "target" field.</summary>
+    public const int TargetFieldNumber = 2;
+    private string target_ = "";
+    /// <summary>
+    /// Optional target override; defaults to the original build's target
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Target {
+      get { return target_; }
+      set {
+        target_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "force_clean" field.</summary>
+    public const int ForceCleanFieldNumber = 3;
+    private bool forceClean_;
+    /// <summary>
+    /// Force a clean rebuild
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool ForceClean {
+      get { return forceClean_; }
+      set {
+        forceClean_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "force_image_rebuild" field.</summary>
+    public const int ForceImageRebuildFieldNumber = 4;
+    private bool forceImageRebuild_;
+    /// <summary>
+    /// Force image rebuild only
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool ForceImageRebuild {
+      get { return forceImageRebuild_; }
+      set {
+        forceImageRebuild_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "priority" field.</summary>
+    public const int PriorityFieldNumber = 5;
+    private int priority_;
+    /// <summary>
+    /// Scheduling priority of the new build (default 0)
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int Priority {
+      get { return priority_; }
+      set {
+        priority_ = value;
+      }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as GetBuildRequest);
+      return Equals(other as RetryBuildRequest);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(GetBuildRequest other) {
+    public bool Equals(RetryBuildRequest other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
@@ -3484,6 +7512,10 @@ namespace Smidr.V1 {
         return true;
       }
       if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
+      if (Target != other.Target) return false;
+      if (ForceClean != other.ForceClean) return false;
+      if (ForceImageRebuild != other.ForceImageRebuild) return false;
+      if (Priority != other.Priority) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -3492,6 +7524,10 @@ namespace Smidr.V1 {
     public override int GetHashCode() {
       int hash = 1;
       if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
+      if (Target.Length != 0) hash ^= Target.GetHashCode();
+      if (ForceClean != false) hash ^= ForceClean.GetHashCode();
+      if (ForceImageRebuild != false) hash ^= ForceImageRebuild.GetHashCode();
+      if (Priority != 0) hash ^= Priority.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -3514,6 +7550,22 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteMessage(BuildIdentifier);
       }
+      if (Target.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Target);
+      }
+      if (ForceClean != false) {
+        output.WriteRawTag(24);
+        output.WriteBool(ForceClean);
+      }
+      if (ForceImageRebuild != false) {
+        output.WriteRawTag(32);
+        output.WriteBool(ForceImageRebuild);
+      }
+      if (Priority != 0) {
+        output.WriteRawTag(40);
+        output.WriteInt32(Priority);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -3528,6 +7580,22 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteMessage(BuildIdentifier);
       }
+      if (Target.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Target);
+      }
+      if (ForceClean != false) {
+        output.WriteRawTag(24);
+        output.WriteBool(ForceClean);
+      }
+      if (ForceImageRebuild != false) {
+        output.WriteRawTag(32);
+        output.WriteBool(ForceImageRebuild);
+      }
+      if (Priority != 0) {
+        output.WriteRawTag(40);
+        output.WriteInt32(Priority);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -3541,6 +7609,18 @@ namespace Smidr.V1 {
       if (buildIdentifier_ != null) {
         size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
       }
+      if (Target.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Target);
+      }
+      if (ForceClean != false) {
+        size += 1 + 1;
+      }
+      if (ForceImageRebuild != false) {
+        size += 1 + 1;
+      }
+      if (Priority != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Priority);
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -3549,7 +7629,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(GetBuildRequest other) {
+    public void MergeFrom(RetryBuildRequest other) {
       if (other == null) {
         return;
       }
@@ -3559,6 +7639,18 @@ namespace Smidr.V1 {
         }
         BuildIdentifier.MergeFrom(other.BuildIdentifier);
       }
+      if (other.Target.Length != 0) {
+        Target = other.Target;
+      }
+      if (other.ForceClean != false) {
+        ForceClean = other.ForceClean;
+      }
+      if (other.ForceImageRebuild != false) {
+        ForceImageRebuild = other.ForceImageRebuild;
+      }
+      if (other.Priority != 0) {
+        Priority = other.Priority;
+      }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -3578,11 +7670,27 @@ namespace Smidr.V1 {
           default:
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
-          case 10: {
-            if (buildIdentifier_ == null) {
-              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
-            }
-            input.ReadMessage(BuildIdentifier);
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+          case 18: {
+            Target = input.ReadString();
+            break;
+          }
+          case 24: {
+            ForceClean = input.ReadBool();
+            break;
+          }
+          case 32: {
+            ForceImageRebuild = input.ReadBool();
+            break;
+          }
+          case 40: {
+            Priority = input.ReadInt32();
             break;
           }
         }
@@ -3611,6 +7719,22 @@ namespace Smidr.V1 {
             input.ReadMessage(BuildIdentifier);
             break;
           }
+          case 18: {
+            Target = input.ReadString();
+            break;
+          }
+          case 24: {
+            ForceClean = input.ReadBool();
+            break;
+          }
+          case 32: {
+            ForceImageRebuild = input.ReadBool();
+            break;
+          }
+          case 40: {
+            Priority = input.ReadInt32();
+            break;
+          }
         }
       }
     }
@@ -3619,24 +7743,24 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// DeleteBuildRequest is used to request the deletion of a specific build.
+  /// BuildEvent is a single structured event emitted while a build runs.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class DeleteBuildRequest : pb::IMessage<DeleteBuildRequest>
+  public sealed partial class BuildEvent : pb::IMessage<BuildEvent>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<DeleteBuildRequest> _parser = new pb::MessageParser<DeleteBuildRequest>(() => new DeleteBuildRequest());
+    private static readonly pb::MessageParser<BuildEvent> _parser = new pb::MessageParser<BuildEvent>(() => new BuildEvent());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<DeleteBuildRequest> Parser { get { return _parser; } }
+    public static pb::MessageParser<BuildEvent> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[9]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[20]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -3647,7 +7771,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public DeleteBuildRequest() {
+    public BuildEvent() {
       OnConstruction();
     }
 
@@ -3655,15 +7779,34 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public DeleteBuildRequest(DeleteBuildRequest other) : this() {
+    public BuildEvent(BuildEvent other) : this() {
       buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
+      timestampUnixSeconds_ = other.timestampUnixSeconds_;
+      switch (other.EventCase) {
+        case EventOneofCase.StateChange:
+          StateChange = other.StateChange.Clone();
+          break;
+        case EventOneofCase.PhaseChange:
+          PhaseChange = other.PhaseChange.Clone();
+          break;
+        case EventOneofCase.TaskProgress:
+          TaskProgress = other.TaskProgress.Clone();
+          break;
+        case EventOneofCase.Recovery:
+          Recovery = other.Recovery.Clone();
+          break;
+        case EventOneofCase.LayerFetch:
+          LayerFetch = other.LayerFetch.Clone();
+          break;
+      }
+
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public DeleteBuildRequest Clone() {
-      return new DeleteBuildRequest(this);
+    public BuildEvent Clone() {
+      return new BuildEvent(this);
     }
 
     /// <summary>Field number for the "build_identifier" field.</summary>
@@ -3678,15 +7821,111 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "timestamp_unix_seconds" field.</summary>
+    public const int TimestampUnixSecondsFieldNumber = 2;
+    private long timestampUnixSeconds_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long TimestampUnixSeconds {
+      get { return timestampUnixSeconds_; }
+      set {
+        timestampUnixSeconds_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "state_change" field.</summary>
+    public const int StateChangeFieldNumber = 3;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.BuildStateChange StateChange {
+      get { return eventCase_ == EventOneofCase.StateChange ? (global::Smidr.V1.BuildStateChange) event_ : null; }
+      set {
+        event_ = value;
+        eventCase_ = value == null ? EventOneofCase.None : EventOneofCase.StateChange;
+      }
+    }
+
+    /// <summary>Field number for the "phase_change" field.</summary>
+    public const int PhaseChangeFieldNumber = 4;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.BuildPhaseChange PhaseChange {
+      get { return eventCase_ == EventOneofCase.PhaseChange ? (global::Smidr.V1.BuildPhaseChange) event_ : null; }
+      set {
+        event_ = value;
+        eventCase_ = value == null ? EventOneofCase.None : EventOneofCase.PhaseChange;
+      }
+    }
+
+    /// <summary>Field number for the "task_progress" field.</summary>
+    public const int TaskProgressFieldNumber = 5;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.TaskProgress TaskProgress {
+      get { return eventCase_ == EventOneofCase.TaskProgress ? (global::Smidr.V1.TaskProgress) event_ : null; }
+      set {
+        event_ = value;
+        eventCase_ = value == null ? EventOneofCase.None : EventOneofCase.TaskProgress;
+      }
+    }
+
+    /// <summary>Field number for the "recovery" field.</summary>
+    public const int RecoveryFieldNumber = 6;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.RecoveryAction Recovery {
+      get { return eventCase_ == EventOneofCase.Recovery ? (global::Smidr.V1.RecoveryAction) event_ : null; }
+      set {
+        event_ = value;
+        eventCase_ = value == null ? EventOneofCase.None : EventOneofCase.Recovery;
+      }
+    }
+
+    /// <summary>Field number for the "layer_fetch" field.</summary>
+    public const int LayerFetchFieldNumber = 7;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.LayerFetch LayerFetch {
+      get { return eventCase_ == EventOneofCase.LayerFetch ? (global::Smidr.V1.LayerFetch) event_ : null; }
+      set {
+        event_ = value;
+        eventCase_ = value == null ? EventOneofCase.None : EventOneofCase.LayerFetch;
+      }
+    }
+
+    private object event_;
+    /// <summary>Enum of possible cases for the "event" oneof.</summary>
+    public enum EventOneofCase {
+      None = 0,
+      StateChange = 3,
+      PhaseChange = 4,
+      TaskProgress = 5,
+      Recovery = 6,
+      LayerFetch = 7,
+    }
+    private EventOneofCase eventCase_ = EventOneofCase.None;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public EventOneofCase EventCase {
+      get { return eventCase_; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void ClearEvent() {
+      eventCase_ = EventOneofCase.None;
+      event_ = null;
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as DeleteBuildRequest);
+      return Equals(other as BuildEvent);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(DeleteBuildRequest other) {
+    public bool Equals(BuildEvent other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
@@ -3694,6 +7933,13 @@ namespace Smidr.V1 {
         return true;
       }
       if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
+      if (TimestampUnixSeconds != other.TimestampUnixSeconds) return false;
+      if (!object.Equals(StateChange, other.StateChange)) return false;
+      if (!object.Equals(PhaseChange, other.PhaseChange)) return false;
+      if (!object.Equals(TaskProgress, other.TaskProgress)) return false;
+      if (!object.Equals(Recovery, other.Recovery)) return false;
+      if (!object.Equals(LayerFetch, other.LayerFetch)) return false;
+      if (EventCase != other.EventCase) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -3702,6 +7948,13 @@ namespace Smidr.V1 {
     public override int GetHashCode() {
       int hash = 1;
       if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
+      if (TimestampUnixSeconds != 0L) hash ^= TimestampUnixSeconds.GetHashCode();
+      if (eventCase_ == EventOneofCase.StateChange) hash ^= StateChange.GetHashCode();
+      if (eventCase_ == EventOneofCase.PhaseChange) hash ^= PhaseChange.GetHashCode();
+      if (eventCase_ == EventOneofCase.TaskProgress) hash ^= TaskProgress.GetHashCode();
+      if (eventCase_ == EventOneofCase.Recovery) hash ^= Recovery.GetHashCode();
+      if (eventCase_ == EventOneofCase.LayerFetch) hash ^= LayerFetch.GetHashCode();
+      hash ^= (int) eventCase_;
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -3724,6 +7977,30 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteMessage(BuildIdentifier);
       }
+      if (TimestampUnixSeconds != 0L) {
+        output.WriteRawTag(16);
+        output.WriteInt64(TimestampUnixSeconds);
+      }
+      if (eventCase_ == EventOneofCase.StateChange) {
+        output.WriteRawTag(26);
+        output.WriteMessage(StateChange);
+      }
+      if (eventCase_ == EventOneofCase.PhaseChange) {
+        output.WriteRawTag(34);
+        output.WriteMessage(PhaseChange);
+      }
+      if (eventCase_ == EventOneofCase.TaskProgress) {
+        output.WriteRawTag(42);
+        output.WriteMessage(TaskProgress);
+      }
+      if (eventCase_ == EventOneofCase.Recovery) {
+        output.WriteRawTag(50);
+        output.WriteMessage(Recovery);
+      }
+      if (eventCase_ == EventOneofCase.LayerFetch) {
+        output.WriteRawTag(58);
+        output.WriteMessage(LayerFetch);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -3738,6 +8015,30 @@ namespace Smidr.V1 {
         output.WriteRawTag(10);
         output.WriteMessage(BuildIdentifier);
       }
+      if (TimestampUnixSeconds != 0L) {
+        output.WriteRawTag(16);
+        output.WriteInt64(TimestampUnixSeconds);
+      }
+      if (eventCase_ == EventOneofCase.StateChange) {
+        output.WriteRawTag(26);
+        output.WriteMessage(StateChange);
+      }
+      if (eventCase_ == EventOneofCase.PhaseChange) {
+        output.WriteRawTag(34);
+        output.WriteMessage(PhaseChange);
+      }
+      if (eventCase_ == EventOneofCase.TaskProgress) {
+        output.WriteRawTag(42);
+        output.WriteMessage(TaskProgress);
+      }
+      if (eventCase_ == EventOneofCase.Recovery) {
+        output.WriteRawTag(50);
+        output.WriteMessage(Recovery);
+      }
+      if (eventCase_ == EventOneofCase.LayerFetch) {
+        output.WriteRawTag(58);
+        output.WriteMessage(LayerFetch);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -3751,6 +8052,24 @@ namespace Smidr.V1 {
       if (buildIdentifier_ != null) {
         size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
       }
+      if (TimestampUnixSeconds != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(TimestampUnixSeconds);
+      }
+      if (eventCase_ == EventOneofCase.StateChange) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(StateChange);
+      }
+      if (eventCase_ == EventOneofCase.PhaseChange) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(PhaseChange);
+      }
+      if (eventCase_ == EventOneofCase.TaskProgress) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(TaskProgress);
+      }
+      if (eventCase_ == EventOneofCase.Recovery) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(Recovery);
+      }
+      if (eventCase_ == EventOneofCase.LayerFetch) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(LayerFetch);
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -3759,7 +8078,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(DeleteBuildRequest other) {
+    public void MergeFrom(BuildEvent other) {
       if (other == null) {
         return;
       }
@@ -3769,6 +8088,42 @@ namespace Smidr.V1 {
         }
         BuildIdentifier.MergeFrom(other.BuildIdentifier);
       }
+      if (other.TimestampUnixSeconds != 0L) {
+        TimestampUnixSeconds = other.TimestampUnixSeconds;
+      }
+      switch (other.EventCase) {
+        case EventOneofCase.StateChange:
+          if (StateChange == null) {
+            StateChange = new global::Smidr.V1.BuildStateChange();
+          }
+          StateChange.MergeFrom(other.StateChange);
+          break;
+        case EventOneofCase.PhaseChange:
+          if (PhaseChange == null) {
+            PhaseChange = new global::Smidr.V1.BuildPhaseChange();
+          }
+          PhaseChange.MergeFrom(other.PhaseChange);
+          break;
+        case EventOneofCase.TaskProgress:
+          if (TaskProgress == null) {
+            TaskProgress = new global::Smidr.V1.TaskProgress();
+          }
+          TaskProgress.MergeFrom(other.TaskProgress);
+          break;
+        case EventOneofCase.Recovery:
+          if (Recovery == null) {
+            Recovery = new global::Smidr.V1.RecoveryAction();
+          }
+          Recovery.MergeFrom(other.Recovery);
+          break;
+        case EventOneofCase.LayerFetch:
+          if (LayerFetch == null) {
+            LayerFetch = new global::Smidr.V1.LayerFetch();
+          }
+          LayerFetch.MergeFrom(other.LayerFetch);
+          break;
+      }
+
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -3795,6 +8150,55 @@ namespace Smidr.V1 {
             input.ReadMessage(BuildIdentifier);
             break;
           }
+          case 16: {
+            TimestampUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 26: {
+            global::Smidr.V1.BuildStateChange subBuilder = new global::Smidr.V1.BuildStateChange();
+            if (eventCase_ == EventOneofCase.StateChange) {
+              subBuilder.MergeFrom(StateChange);
+            }
+            input.ReadMessage(subBuilder);
+            StateChange = subBuilder;
+            break;
+          }
+          case 34: {
+            global::Smidr.V1.BuildPhaseChange subBuilder = new global::Smidr.V1.BuildPhaseChange();
+            if (eventCase_ == EventOneofCase.PhaseChange) {
+              subBuilder.MergeFrom(PhaseChange);
+            }
+            input.ReadMessage(subBuilder);
+            PhaseChange = subBuilder;
+            break;
+          }
+          case 42: {
+            global::Smidr.V1.TaskProgress subBuilder = new global::Smidr.V1.TaskProgress();
+            if (eventCase_ == EventOneofCase.TaskProgress) {
+              subBuilder.MergeFrom(TaskProgress);
+            }
+            input.ReadMessage(subBuilder);
+            TaskProgress = subBuilder;
+            break;
+          }
+          case 50: {
+            global::Smidr.V1.RecoveryAction subBuilder = new global::Smidr.V1.RecoveryAction();
+            if (eventCase_ == EventOneofCase.Recovery) {
+              subBuilder.MergeFrom(Recovery);
+            }
+            input.ReadMessage(subBuilder);
+            Recovery = subBuilder;
+            break;
+          }
+          case 58: {
+            global::Smidr.V1.LayerFetch subBuilder = new global::Smidr.V1.LayerFetch();
+            if (eventCase_ == EventOneofCase.LayerFetch) {
+              subBuilder.MergeFrom(LayerFetch);
+            }
+            input.ReadMessage(subBuilder);
+            LayerFetch = subBuilder;
+            break;
+          }
         }
       }
     #endif
@@ -3821,6 +8225,55 @@ namespace Smidr.V1 {
             input.ReadMessage(BuildIdentifier);
             break;
           }
+          case 16: {
+            TimestampUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 26: {
+            global::Smidr.V1.BuildStateChange subBuilder = new global::Smidr.V1.BuildStateChange();
+            if (eventCase_ == EventOneofCase.StateChange) {
+              subBuilder.MergeFrom(StateChange);
+            }
+            input.ReadMessage(subBuilder);
+            StateChange = subBuilder;
+            break;
+          }
+          case 34: {
+            global::Smidr.V1.BuildPhaseChange subBuilder = new global::Smidr.V1.BuildPhaseChange();
+            if (eventCase_ == EventOneofCase.PhaseChange) {
+              subBuilder.MergeFrom(PhaseChange);
+            }
+            input.ReadMessage(subBuilder);
+            PhaseChange = subBuilder;
+            break;
+          }
+          case 42: {
+            global::Smidr.V1.TaskProgress subBuilder = new global::Smidr.V1.TaskProgress();
+            if (eventCase_ == EventOneofCase.TaskProgress) {
+              subBuilder.MergeFrom(TaskProgress);
+            }
+            input.ReadMessage(subBuilder);
+            TaskProgress = subBuilder;
+            break;
+          }
+          case 50: {
+            global::Smidr.V1.RecoveryAction subBuilder = new global::Smidr.V1.RecoveryAction();
+            if (eventCase_ == EventOneofCase.Recovery) {
+              subBuilder.MergeFrom(Recovery);
+            }
+            input.ReadMessage(subBuilder);
+            Recovery = subBuilder;
+            break;
+          }
+          case 58: {
+            global::Smidr.V1.LayerFetch subBuilder = new global::Smidr.V1.LayerFetch();
+            if (eventCase_ == EventOneofCase.LayerFetch) {
+              subBuilder.MergeFrom(LayerFetch);
+            }
+            input.ReadMessage(subBuilder);
+            LayerFetch = subBuilder;
+            break;
+          }
         }
       }
     }
@@ -3829,24 +8282,24 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// DeleteBuildResponse provides the result of a deletion request.
+  /// BuildStateChange reports a transition of the build state.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class DeleteBuildResponse : pb::IMessage<DeleteBuildResponse>
+  public sealed partial class BuildStateChange : pb::IMessage<BuildStateChange>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<DeleteBuildResponse> _parser = new pb::MessageParser<DeleteBuildResponse>(() => new DeleteBuildResponse());
+    private static readonly pb::MessageParser<BuildStateChange> _parser = new pb::MessageParser<BuildStateChange>(() => new BuildStateChange());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<DeleteBuildResponse> Parser { get { return _parser; } }
+    public static pb::MessageParser<BuildStateChange> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[10]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[21]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -3857,7 +8310,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public DeleteBuildResponse() {
+    public BuildStateChange() {
       OnConstruction();
     }
 
@@ -3865,33 +8318,49 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public DeleteBuildResponse(DeleteBuildResponse other) : this() {
-      success_ = other.success_;
+    public BuildStateChange(BuildStateChange other) : this() {
+      previousState_ = other.previousState_;
+      state_ = other.state_;
       message_ = other.message_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public DeleteBuildResponse Clone() {
-      return new DeleteBuildResponse(this);
+    public BuildStateChange Clone() {
+      return new BuildStateChange(this);
     }
 
-    /// <summary>Field number for the "success" field.</summary>
-    public const int SuccessFieldNumber = 1;
-    private bool success_;
+    /// <summary>Field number for the "previous_state" field.</summary>
+    public const int PreviousStateFieldNumber = 1;
+    private global::Smidr.V1.BuildState previousState_ = global::Smidr.V1.BuildState.Unspecified;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Success {
-      get { return success_; }
+    public global::Smidr.V1.BuildState PreviousState {
+      get { return previousState_; }
       set {
-        success_ = value;
+        previousState_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "state" field.</summary>
+    public const int StateFieldNumber = 2;
+    private global::Smidr.V1.BuildState state_ = global::Smidr.V1.BuildState.Unspecified;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.BuildState State {
+      get { return state_; }
+      set {
+        state_ = value;
       }
     }
 
     /// <summary>Field number for the "message" field.</summary>
-    public const int MessageFieldNumber = 2;
+    public const int MessageFieldNumber = 3;
     private string message_ = "";
+    /// <summary>
+    /// Optional human-readable reason (e.g., error or cancellation message)
+    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public string Message {
@@ -3904,19 +8373,20 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as DeleteBuildResponse);
+      return Equals(other as BuildStateChange);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(DeleteBuildResponse other) {
+    public bool Equals(BuildStateChange other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
       if (ReferenceEquals(other, this)) {
         return true;
       }
-      if (Success != other.Success) return false;
+      if (PreviousState != other.PreviousState) return false;
+      if (State != other.State) return false;
       if (Message != other.Message) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
@@ -3925,7 +8395,8 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override int GetHashCode() {
       int hash = 1;
-      if (Success != false) hash ^= Success.GetHashCode();
+      if (PreviousState != global::Smidr.V1.BuildState.Unspecified) hash ^= PreviousState.GetHashCode();
+      if (State != global::Smidr.V1.BuildState.Unspecified) hash ^= State.GetHashCode();
       if (Message.Length != 0) hash ^= Message.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
@@ -3945,12 +8416,16 @@ namespace Smidr.V1 {
     #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       output.WriteRawMessage(this);
     #else
-      if (Success != false) {
+      if (PreviousState != global::Smidr.V1.BuildState.Unspecified) {
         output.WriteRawTag(8);
-        output.WriteBool(Success);
+        output.WriteEnum((int) PreviousState);
+      }
+      if (State != global::Smidr.V1.BuildState.Unspecified) {
+        output.WriteRawTag(16);
+        output.WriteEnum((int) State);
       }
       if (Message.Length != 0) {
-        output.WriteRawTag(18);
+        output.WriteRawTag(26);
         output.WriteString(Message);
       }
       if (_unknownFields != null) {
@@ -3963,12 +8438,16 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
-      if (Success != false) {
+      if (PreviousState != global::Smidr.V1.BuildState.Unspecified) {
         output.WriteRawTag(8);
-        output.WriteBool(Success);
+        output.WriteEnum((int) PreviousState);
+      }
+      if (State != global::Smidr.V1.BuildState.Unspecified) {
+        output.WriteRawTag(16);
+        output.WriteEnum((int) State);
       }
       if (Message.Length != 0) {
-        output.WriteRawTag(18);
+        output.WriteRawTag(26);
         output.WriteString(Message);
       }
       if (_unknownFields != null) {
@@ -3981,8 +8460,11 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public int CalculateSize() {
       int size = 0;
-      if (Success != false) {
-        size += 1 + 1;
+      if (PreviousState != global::Smidr.V1.BuildState.Unspecified) {
+        size += 1 + pb::CodedOutputStream.ComputeEnumSize((int) PreviousState);
+      }
+      if (State != global::Smidr.V1.BuildState.Unspecified) {
+        size += 1 + pb::CodedOutputStream.ComputeEnumSize((int) State);
       }
       if (Message.Length != 0) {
         size += 1 + pb::CodedOutputStream.ComputeStringSize(Message);
@@ -3995,12 +8477,15 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(DeleteBuildResponse other) {
+    public void MergeFrom(BuildStateChange other) {
       if (other == null) {
         return;
       }
-      if (other.Success != false) {
-        Success = other.Success;
+      if (other.PreviousState != global::Smidr.V1.BuildState.Unspecified) {
+        PreviousState = other.PreviousState;
+      }
+      if (other.State != global::Smidr.V1.BuildState.Unspecified) {
+        State = other.State;
       }
       if (other.Message.Length != 0) {
         Message = other.Message;
@@ -4025,10 +8510,14 @@ namespace Smidr.V1 {
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
           case 8: {
-            Success = input.ReadBool();
+            PreviousState = (global::Smidr.V1.BuildState) input.ReadEnum();
             break;
           }
-          case 18: {
+          case 16: {
+            State = (global::Smidr.V1.BuildState) input.ReadEnum();
+            break;
+          }
+          case 26: {
             Message = input.ReadString();
             break;
           }
@@ -4052,10 +8541,14 @@ namespace Smidr.V1 {
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
             break;
           case 8: {
-            Success = input.ReadBool();
+            PreviousState = (global::Smidr.V1.BuildState) input.ReadEnum();
             break;
           }
-          case 18: {
+          case 16: {
+            State = (global::Smidr.V1.BuildState) input.ReadEnum();
+            break;
+          }
+          case 26: {
             Message = input.ReadString();
             break;
           }
@@ -4067,24 +8560,24 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// PurgeBuildsRequest is used to request the purging of old builds.
+  /// BuildPhaseChange reports that the build entered a new phase.
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class PurgeBuildsRequest : pb::IMessage<PurgeBuildsRequest>
+  public sealed partial class BuildPhaseChange : pb::IMessage<BuildPhaseChange>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<PurgeBuildsRequest> _parser = new pb::MessageParser<PurgeBuildsRequest>(() => new PurgeBuildsRequest());
+    private static readonly pb::MessageParser<BuildPhaseChange> _parser = new pb::MessageParser<BuildPhaseChange>(() => new BuildPhaseChange());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<PurgeBuildsRequest> Parser { get { return _parser; } }
+    public static pb::MessageParser<BuildPhaseChange> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[11]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[22]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -4095,73 +8588,53 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public PurgeBuildsRequest() {
+    public BuildPhaseChange() {
       OnConstruction();
     }
 
-    partial void OnConstruction();
-
-    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
-    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public PurgeBuildsRequest(PurgeBuildsRequest other) : this() {
-      olderThanUnixSeconds_ = other.olderThanUnixSeconds_;
-      customer_ = other.customer_;
-      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
-    }
-
+    partial void OnConstruction();
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public PurgeBuildsRequest Clone() {
-      return new PurgeBuildsRequest(this);
+    public BuildPhaseChange(BuildPhaseChange other) : this() {
+      phase_ = other.phase_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
-    /// <summary>Field number for the "older_than_unix_seconds" field.</summary>
-    public const int OlderThanUnixSecondsFieldNumber = 1;
-    private long olderThanUnixSeconds_;
-    /// <summary>
-    /// Builds older than this timestamp will be purged
-    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public long OlderThanUnixSeconds {
-      get { return olderThanUnixSeconds_; }
-      set {
-        olderThanUnixSeconds_ = value;
-      }
+    public BuildPhaseChange Clone() {
+      return new BuildPhaseChange(this);
     }
 
-    /// <summary>Field number for the "customer" field.</summary>
-    public const int CustomerFieldNumber = 2;
-    private string customer_ = "";
-    /// <summary>
-    /// Optional customer identifier filter
-    /// </summary>
+    /// <summary>Field number for the "phase" field.</summary>
+    public const int PhaseFieldNumber = 1;
+    private global::Smidr.V1.BuildPhase phase_ = global::Smidr.V1.BuildPhase.Unspecified;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public string Customer {
-      get { return customer_; }
+    public global::Smidr.V1.BuildPhase Phase {
+      get { return phase_; }
       set {
-        customer_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+        phase_ = value;
       }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as PurgeBuildsRequest);
+      return Equals(other as BuildPhaseChange);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(PurgeBuildsRequest other) {
+    public bool Equals(BuildPhaseChange other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
       if (ReferenceEquals(other, this)) {
         return true;
       }
-      if (OlderThanUnixSeconds != other.OlderThanUnixSeconds) return false;
-      if (Customer != other.Customer) return false;
+      if (Phase != other.Phase) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -4169,8 +8642,7 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override int GetHashCode() {
       int hash = 1;
-      if (OlderThanUnixSeconds != 0L) hash ^= OlderThanUnixSeconds.GetHashCode();
-      if (Customer.Length != 0) hash ^= Customer.GetHashCode();
+      if (Phase != global::Smidr.V1.BuildPhase.Unspecified) hash ^= Phase.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -4189,13 +8661,9 @@ namespace Smidr.V1 {
     #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       output.WriteRawMessage(this);
     #else
-      if (OlderThanUnixSeconds != 0L) {
+      if (Phase != global::Smidr.V1.BuildPhase.Unspecified) {
         output.WriteRawTag(8);
-        output.WriteInt64(OlderThanUnixSeconds);
-      }
-      if (Customer.Length != 0) {
-        output.WriteRawTag(18);
-        output.WriteString(Customer);
+        output.WriteEnum((int) Phase);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
@@ -4207,13 +8675,9 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
-      if (OlderThanUnixSeconds != 0L) {
+      if (Phase != global::Smidr.V1.BuildPhase.Unspecified) {
         output.WriteRawTag(8);
-        output.WriteInt64(OlderThanUnixSeconds);
-      }
-      if (Customer.Length != 0) {
-        output.WriteRawTag(18);
-        output.WriteString(Customer);
+        output.WriteEnum((int) Phase);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
@@ -4225,11 +8689,8 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public int CalculateSize() {
       int size = 0;
-      if (OlderThanUnixSeconds != 0L) {
-        size += 1 + pb::CodedOutputStream.ComputeInt64Size(OlderThanUnixSeconds);
-      }
-      if (Customer.Length != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeStringSize(Customer);
+      if (Phase != global::Smidr.V1.BuildPhase.Unspecified) {
+        size += 1 + pb::CodedOutputStream.ComputeEnumSize((int) Phase);
       }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
@@ -4239,15 +8700,12 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(PurgeBuildsRequest other) {
+    public void MergeFrom(BuildPhaseChange other) {
       if (other == null) {
         return;
       }
-      if (other.OlderThanUnixSeconds != 0L) {
-        OlderThanUnixSeconds = other.OlderThanUnixSeconds;
-      }
-      if (other.Customer.Length != 0) {
-        Customer = other.Customer;
+      if (other.Phase != global::Smidr.V1.BuildPhase.Unspecified) {
+        Phase = other.Phase;
       }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
@@ -4269,11 +8727,7 @@ namespace Smidr.V1 {
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
           case 8: {
-            OlderThanUnixSeconds = input.ReadInt64();
-            break;
-          }
-          case 18: {
-            Customer = input.ReadString();
+            Phase = (global::Smidr.V1.BuildPhase) input.ReadEnum();
             break;
           }
         }
@@ -4296,11 +8750,7 @@ namespace Smidr.V1 {
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
             break;
           case 8: {
-            OlderThanUnixSeconds = input.ReadInt64();
-            break;
-          }
-          case 18: {
-            Customer = input.ReadString();
+            Phase = (global::Smidr.V1.BuildPhase) input.ReadEnum();
             break;
           }
         }
@@ -4311,24 +8761,24 @@ namespace Smidr.V1 {
   }
 
   /// <summary>
-  /// PurgeBuildsResponse provides the result of a purge request.
+  /// TaskProgress reports BitBake task execution progress ("Running task N of M").
   /// </summary>
   [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
-  public sealed partial class PurgeBuildsResponse : pb::IMessage<PurgeBuildsResponse>
+  public sealed partial class TaskProgress : pb::IMessage<TaskProgress>
   #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       , pb::IBufferMessage
   #endif
   {
-    private static readonly pb::MessageParser<PurgeBuildsResponse> _parser = new pb::MessageParser<PurgeBuildsResponse>(() => new PurgeBuildsResponse());
+    private static readonly pb::MessageParser<TaskProgress> _parser = new pb::MessageParser<TaskProgress>(() => new TaskProgress());
     private pb::UnknownFieldSet _unknownFields;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public static pb::MessageParser<PurgeBuildsResponse> Parser { get { return _parser; } }
+    public static pb::MessageParser<TaskProgress> Parser { get { return _parser; } }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public static pbr::MessageDescriptor Descriptor {
-      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[12]; }
+      get { return global::Smidr.V1.BuildsReflection.Descriptor.MessageTypes[23]; }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
@@ -4339,7 +8789,7 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public PurgeBuildsResponse() {
+    public TaskProgress() {
       OnConstruction();
     }
 
@@ -4347,86 +8797,169 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public PurgeBuildsResponse(PurgeBuildsResponse other) : this() {
-      purgedBuildCount_ = other.purgedBuildCount_;
-      purgedBuildIds_ = other.purgedBuildIds_.Clone();
-      freedSpaceBytes_ = other.freedSpaceBytes_;
-      message_ = other.message_;
+    public TaskProgress(TaskProgress other) : this() {
+      current_ = other.current_;
+      total_ = other.total_;
+      recipe_ = other.recipe_;
+      task_ = other.task_;
+      setscene_ = other.setscene_;
+      setsceneCurrent_ = other.setsceneCurrent_;
+      setsceneTotal_ = other.setsceneTotal_;
+      taskCurrent_ = other.taskCurrent_;
+      taskTotal_ = other.taskTotal_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public PurgeBuildsResponse Clone() {
-      return new PurgeBuildsResponse(this);
+    public TaskProgress Clone() {
+      return new TaskProgress(this);
     }
 
-    /// <summary>Field number for the "purged_build_count" field.</summary>
-    public const int PurgedBuildCountFieldNumber = 1;
-    private int purgedBuildCount_;
+    /// <summary>Field number for the "current" field.</summary>
+    public const int CurrentFieldNumber = 1;
+    private int current_;
+    /// <summary>
+    /// Position of this task within its kind (setscene or real tasks)
+    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public int PurgedBuildCount {
-      get { return purgedBuildCount_; }
+    public int Current {
+      get { return current_; }
       set {
-        purgedBuildCount_ = value;
+        current_ = value;
       }
     }
 
-    /// <summary>Field number for the "purged_build_ids" field.</summary>
-    public const int PurgedBuildIdsFieldNumber = 2;
-    private static readonly pb::FieldCodec<string> _repeated_purgedBuildIds_codec
-        = pb::FieldCodec.ForString(18);
-    private readonly pbc::RepeatedField<string> purgedBuildIds_ = new pbc::RepeatedField<string>();
+    /// <summary>Field number for the "total" field.</summary>
+    public const int TotalFieldNumber = 2;
+    private int total_;
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public pbc::RepeatedField<string> PurgedBuildIds {
-      get { return purgedBuildIds_; }
+    public int Total {
+      get { return total_; }
+      set {
+        total_ = value;
+      }
     }
 
-    /// <summary>Field number for the "freed_space_bytes" field.</summary>
-    public const int FreedSpaceBytesFieldNumber = 3;
-    private long freedSpaceBytes_;
+    /// <summary>Field number for the "recipe" field.</summary>
+    public const int RecipeFieldNumber = 3;
+    private string recipe_ = "";
+    /// <summary>
+    /// Recipe name (e.g., busybox) and task name (e.g., do_compile)
+    /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public long FreedSpaceBytes {
-      get { return freedSpaceBytes_; }
+    public string Recipe {
+      get { return recipe_; }
       set {
-        freedSpaceBytes_ = value;
+        recipe_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
       }
     }
 
-    /// <summary>Field number for the "message" field.</summary>
-    public const int MessageFieldNumber = 4;
-    private string message_ = "";
+    /// <summary>Field number for the "task" field.</summary>
+    public const int TaskFieldNumber = 4;
+    private string task_ = "";
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public string Message {
-      get { return message_; }
+    public string Task {
+      get { return task_; }
       set {
-        message_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+        task_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "setscene" field.</summary>
+    public const int SetsceneFieldNumber = 5;
+    private bool setscene_;
+    /// <summary>
+    /// True for setscene tasks restored from sstate
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Setscene {
+      get { return setscene_; }
+      set {
+        setscene_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "setscene_current" field.</summary>
+    public const int SetsceneCurrentFieldNumber = 6;
+    private int setsceneCurrent_;
+    /// <summary>
+    /// Latest counts seen for each kind of task
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int SetsceneCurrent {
+      get { return setsceneCurrent_; }
+      set {
+        setsceneCurrent_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "setscene_total" field.</summary>
+    public const int SetsceneTotalFieldNumber = 7;
+    private int setsceneTotal_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int SetsceneTotal {
+      get { return setsceneTotal_; }
+      set {
+        setsceneTotal_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "task_current" field.</summary>
+    public const int TaskCurrentFieldNumber = 8;
+    private int taskCurrent_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int TaskCurrent {
+      get { return taskCurrent_; }
+      set {
+        taskCurrent_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "task_total" field.</summary>
+    public const int TaskTotalFieldNumber = 9;
+    private int taskTotal_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int TaskTotal {
+      get { return taskTotal_; }
+      set {
+        taskTotal_ = value;
       }
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
-      return Equals(other as PurgeBuildsResponse);
+      return Equals(other as TaskProgress);
     }
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public bool Equals(PurgeBuildsResponse other) {
+    public bool Equals(TaskProgress other) {
       if (ReferenceEquals(other, null)) {
         return false;
       }
       if (ReferenceEquals(other, this)) {
         return true;
       }
-      if (PurgedBuildCount != other.PurgedBuildCount) return false;
-      if(!purgedBuildIds_.Equals(other.purgedBuildIds_)) return false;
-      if (FreedSpaceBytes != other.FreedSpaceBytes) return false;
-      if (Message != other.Message) return false;
+      if (Current != other.Current) return false;
+      if (Total != other.Total) return false;
+      if (Recipe != other.Recipe) return false;
+      if (Task != other.Task) return false;
+      if (Setscene != other.Setscene) return false;
+      if (SetsceneCurrent != other.SetsceneCurrent) return false;
+      if (SetsceneTotal != other.SetsceneTotal) return false;
+      if (TaskCurrent != other.TaskCurrent) return false;
+      if (TaskTotal != other.TaskTotal) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -4434,10 +8967,15 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override int GetHashCode() {
       int hash = 1;
-      if (PurgedBuildCount != 0) hash ^= PurgedBuildCount.GetHashCode();
-      hash ^= purgedBuildIds_.GetHashCode();
-      if (FreedSpaceBytes != 0L) hash ^= FreedSpaceBytes.GetHashCode();
-      if (Message.Length != 0) hash ^= Message.GetHashCode();
+      if (Current != 0) hash ^= Current.GetHashCode();
+      if (Total != 0) hash ^= Total.GetHashCode();
+      if (Recipe.Length != 0) hash ^= Recipe.GetHashCode();
+      if (Task.Length != 0) hash ^= Task.GetHashCode();
+      if (Setscene != false) hash ^= Setscene.GetHashCode();
+      if (SetsceneCurrent != 0) hash ^= SetsceneCurrent.GetHashCode();
+      if (SetsceneTotal != 0) hash ^= SetsceneTotal.GetHashCode();
+      if (TaskCurrent != 0) hash ^= TaskCurrent.GetHashCode();
+      if (TaskTotal != 0) hash ^= TaskTotal.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -4456,18 +8994,41 @@ namespace Smidr.V1 {
     #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
       output.WriteRawMessage(this);
     #else
-      if (PurgedBuildCount != 0) {
+      if (Current != 0) {
         output.WriteRawTag(8);
-        output.WriteInt32(PurgedBuildCount);
+        output.WriteInt32(Current);
       }
-      purgedBuildIds_.WriteTo(output, _repeated_purgedBuildIds_codec);
-      if (FreedSpaceBytes != 0L) {
-        output.WriteRawTag(24);
-        output.WriteInt64(FreedSpaceBytes);
+      if (Total != 0) {
+        output.WriteRawTag(16);
+        output.WriteInt32(Total);
       }
-      if (Message.Length != 0) {
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
         output.WriteRawTag(34);
-        output.WriteString(Message);
+        output.WriteString(Task);
+      }
+      if (Setscene != false) {
+        output.WriteRawTag(40);
+        output.WriteBool(Setscene);
+      }
+      if (SetsceneCurrent != 0) {
+        output.WriteRawTag(48);
+        output.WriteInt32(SetsceneCurrent);
+      }
+      if (SetsceneTotal != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(SetsceneTotal);
+      }
+      if (TaskCurrent != 0) {
+        output.WriteRawTag(64);
+        output.WriteInt32(TaskCurrent);
+      }
+      if (TaskTotal != 0) {
+        output.WriteRawTag(72);
+        output.WriteInt32(TaskTotal);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
@@ -4479,18 +9040,41 @@ namespace Smidr.V1 {
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
-      if (PurgedBuildCount != 0) {
+      if (Current != 0) {
         output.WriteRawTag(8);
-        output.WriteInt32(PurgedBuildCount);
+        output.WriteInt32(Current);
       }
-      purgedBuildIds_.WriteTo(ref output, _repeated_purgedBuildIds_codec);
-      if (FreedSpaceBytes != 0L) {
-        output.WriteRawTag(24);
-        output.WriteInt64(FreedSpaceBytes);
+      if (Total != 0) {
+        output.WriteRawTag(16);
+        output.WriteInt32(Total);
       }
-      if (Message.Length != 0) {
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
         output.WriteRawTag(34);
-        output.WriteString(Message);
+        output.WriteString(Task);
+      }
+      if (Setscene != false) {
+        output.WriteRawTag(40);
+        output.WriteBool(Setscene);
+      }
+      if (SetsceneCurrent != 0) {
+        output.WriteRawTag(48);
+        output.WriteInt32(SetsceneCurrent);
+      }
+      if (SetsceneTotal != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(SetsceneTotal);
+      }
+      if (TaskCurrent != 0) {
+        output.WriteRawTag(64);
+        output.WriteInt32(TaskCurrent);
+      }
+      if (TaskTotal != 0) {
+        output.WriteRawTag(72);
+        output.WriteInt32(TaskTotal);
       }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
@@ -4502,15 +9086,32 @@ namespace Smidr.V1 {
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public int CalculateSize() {
       int size = 0;
-      if (PurgedBuildCount != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeInt32Size(PurgedBuildCount);
+      if (Current != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Current);
       }
-      size += purgedBuildIds_.CalculateSize(_repeated_purgedBuildIds_codec);
-      if (FreedSpaceBytes != 0L) {
-        size += 1 + pb::CodedOutputStream.ComputeInt64Size(FreedSpaceBytes);
+      if (Total != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Total);
       }
-      if (Message.Length != 0) {
-        size += 1 + pb::CodedOutputStream.ComputeStringSize(Message);
+      if (Recipe.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Recipe);
+      }
+      if (Task.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Task);
+      }
+      if (Setscene != false) {
+        size += 1 + 1;
+      }
+      if (SetsceneCurrent != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(SetsceneCurrent);
+      }
+      if (SetsceneTotal != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(SetsceneTotal);
+      }
+      if (TaskCurrent != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(TaskCurrent);
+      }
+      if (TaskTotal != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(TaskTotal);
       }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
@@ -4520,19 +9121,36 @@ namespace Smidr.V1 {
 
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
-    public void MergeFrom(PurgeBuildsResponse other) {
+    public void MergeFrom(TaskProgress other) {
       if (other == null) {
         return;
       }
-      if (other.PurgedBuildCount != 0) {
-        PurgedBuildCount = other.PurgedBuildCount;
+      if (other.Current != 0) {
+        Current = other.Current;
       }
-      purgedBuildIds_.Add(other.purgedBuildIds_);
-      if (other.FreedSpaceBytes != 0L) {
-        FreedSpaceBytes = other.FreedSpaceBytes;
+      if (other.Total != 0) {
+        Total = other.Total;
       }
-      if (other.Message.Length != 0) {
-        Message = other.Message;
+      if (other.Recipe.Length != 0) {
+        Recipe = other.Recipe;
+      }
+      if (other.Task.Length != 0) {
+        Task = other.Task;
+      }
+      if (other.Setscene != false) {
+        Setscene = other.Setscene;
+      }
+      if (other.SetsceneCurrent != 0) {
+        SetsceneCurrent = other.SetsceneCurrent;
+      }
+      if (other.SetsceneTotal != 0) {
+        SetsceneTotal = other.SetsceneTotal;
+      }
+      if (other.TaskCurrent != 0) {
+        TaskCurrent = other.TaskCurrent;
+      }
+      if (other.TaskTotal != 0) {
+        TaskTotal = other.TaskTotal;
       }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
@@ -4554,19 +9172,39 @@ namespace Smidr.V1 {
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
             break;
           case 8: {
-            PurgedBuildCount = input.ReadInt32();
+            Current = input.ReadInt32();
             break;
           }
-          case 18: {
-            purgedBuildIds_.AddEntriesFrom(input, _repeated_purgedBuildIds_codec);
+          case 16: {
+            Total = input.ReadInt32();
             break;
           }
-          case 24: {
-            FreedSpaceBytes = input.ReadInt64();
+          case 26: {
+            Recipe = input.ReadString();
             break;
           }
           case 34: {
-            Message = input.ReadString();
+            Task = input.ReadString();
+            break;
+          }
+          case 40: {
+            Setscene = input.ReadBool();
+            break;
+          }
+          case 48: {
+            SetsceneCurrent = input.ReadInt32();
+            break;
+          }
+          case 56: {
+            SetsceneTotal = input.ReadInt32();
+            break;
+          }
+          case 64: {
+            TaskCurrent = input.ReadInt32();
+            break;
+          }
+          case 72: {
+            TaskTotal = input.ReadInt32();
             break;
           }
         }
@@ -4589,19 +9227,39 @@ namespace Smidr.V1 {
             _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
             break;
           case 8: {
-            PurgedBuildCount = input.ReadInt32();
+            Current = input.ReadInt32();
             break;
           }
-          case 18: {
-            purgedBuildIds_.AddEntriesFrom(ref input, _repeated_purgedBuildIds_codec);
+          case 16: {
+            Total = input.ReadInt32();
             break;
           }
-          case 24: {
-            FreedSpaceBytes = input.ReadInt64();
+          case 26: {
+            Recipe = input.ReadString();
             break;
           }
           case 34: {
-            Message = input.ReadString();
+            Task = input.ReadString();
+            break;
+          }
+          case 40: {
+            Setscene = input.ReadBool();
+            break;
+          }
+          case 48: {
+            SetsceneCurrent = input.ReadInt32();
+            break;
+          }
+          case 56: {
+            SetsceneTotal = input.ReadInt32();
+            break;
+          }
+          case 64: {
+            TaskCurrent = input.ReadInt32();
+            break;
+          }
+          case 72: {
+            TaskTotal = input.ReadInt32();
             break;
           }
         }
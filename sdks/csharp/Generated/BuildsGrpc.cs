@@ -71,6 +71,12 @@ namespace Smidr.V1 {
     static readonly grpc::Marshaller<global::Smidr.V1.PurgeBuildsRequest> __Marshaller_smidr_v1_PurgeBuildsRequest = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.PurgeBuildsRequest.Parser));
     [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
     static readonly grpc::Marshaller<global::Smidr.V1.PurgeBuildsResponse> __Marshaller_smidr_v1_PurgeBuildsResponse = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.PurgeBuildsResponse.Parser));
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Marshaller<global::Smidr.V1.WatchBuildRequest> __Marshaller_smidr_v1_WatchBuildRequest = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.WatchBuildRequest.Parser));
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Marshaller<global::Smidr.V1.BuildEvent> __Marshaller_smidr_v1_BuildEvent = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.BuildEvent.Parser));
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Marshaller<global::Smidr.V1.RetryBuildRequest> __Marshaller_smidr_v1_RetryBuildRequest = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.RetryBuildRequest.Parser));
 
     [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
     static readonly grpc::Method<global::Smidr.V1.StartBuildRequest, global::Smidr.V1.BuildStatusResponse> __Method_StartBuild = new grpc::Method<global::Smidr.V1.StartBuildRequest, global::Smidr.V1.BuildStatusResponse>(
@@ -128,6 +134,22 @@ namespace Smidr.V1 {
         __Marshaller_smidr_v1_PurgeBuildsRequest,
         __Marshaller_smidr_v1_PurgeBuildsResponse);
 
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Method<global::Smidr.V1.WatchBuildRequest, global::Smidr.V1.BuildEvent> __Method_WatchBuild = new grpc::Method<global::Smidr.V1.WatchBuildRequest, global::Smidr.V1.BuildEvent>(
+        grpc::MethodType.ServerStreaming,
+        __ServiceName,
+        "WatchBuild",
+        __Marshaller_smidr_v1_WatchBuildRequest,
+        __Marshaller_smidr_v1_BuildEvent);
+
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Method<global::Smidr.V1.RetryBuildRequest, global::Smidr.V1.BuildStatusResponse> __Method_RetryBuild = new grpc::Method<global::Smidr.V1.RetryBuildRequest, global::Smidr.V1.BuildStatusResponse>(
+        grpc::MethodType.Unary,
+        __ServiceName,
+        "RetryBuild",
+        __Marshaller_smidr_v1_RetryBuildRequest,
+        __Marshaller_smidr_v1_BuildStatusResponse);
+
     /// <summary>Service descriptor</summary>
     public static global::Google.Protobuf.Reflection.ServiceDescriptor Descriptor
     {
@@ -180,6 +202,18 @@ namespace Smidr.V1 {
         throw new grpc::RpcException(new grpc::Status(grpc::StatusCode.Unimplemented, ""));
       }
 
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual global::System.Threading.Tasks.Task WatchBuild(global::Smidr.V1.WatchBuildRequest request, grpc::IServerStreamWriter<global::Smidr.V1.BuildEvent> responseStream, grpc::ServerCallContext context)
+      {
+        throw new grpc::RpcException(new grpc::Status(grpc::StatusCode.Unimplemented, ""));
+      }
+
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual global::System.Threading.Tasks.Task<global::Smidr.V1.BuildStatusResponse> RetryBuild(global::Smidr.V1.RetryBuildRequest request, grpc::ServerCallContext context)
+      {
+        throw new grpc::RpcException(new grpc::Status(grpc::StatusCode.Unimplemented, ""));
+      }
+
     }
 
     /// <summary>Client for BuildService</summary>
@@ -349,6 +383,36 @@ namespace Smidr.V1 {
       {
         return CallInvoker.AsyncUnaryCall(__Method_PurgeBuilds, null, options, request);
       }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual grpc::AsyncServerStreamingCall<global::Smidr.V1.BuildEvent> WatchBuild(global::Smidr.V1.WatchBuildRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
+      {
+        return WatchBuild(request, new grpc::CallOptions(headers, deadline, cancellationToken));
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual grpc::AsyncServerStreamingCall<global::Smidr.V1.BuildEvent> WatchBuild(global::Smidr.V1.WatchBuildRequest request, grpc::CallOptions options)
+      {
+        return CallInvoker.AsyncServerStreamingCall(__Method_WatchBuild, null, options, request);
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual global::Smidr.V1.BuildStatusResponse RetryBuild(global::Smidr.V1.RetryBuildRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
+      {
+        return RetryBuild(request, new grpc::CallOptions(headers, deadline, cancellationToken));
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual global::Smidr.V1.BuildStatusResponse RetryBuild(global::Smidr.V1.RetryBuildRequest request, grpc::CallOptions options)
+      {
+        return CallInvoker.BlockingUnaryCall(__Method_RetryBuild, null, options, request);
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual grpc::AsyncUnaryCall<global::Smidr.V1.BuildStatusResponse> RetryBuildAsync(global::Smidr.V1.RetryBuildRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
+      {
+        return RetryBuildAsync(request, new grpc::CallOptions(headers, deadline, cancellationToken));
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual grpc::AsyncUnaryCall<global::Smidr.V1.BuildStatusResponse> RetryBuildAsync(global::Smidr.V1.RetryBuildRequest request, grpc::CallOptions options)
+      {
+        return CallInvoker.AsyncUnaryCall(__Method_RetryBuild, null, options, request);
+      }
       /// <summary>Creates a new instance of client from given <c>ClientBaseConfiguration</c>.</summary>
       [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
       protected override BuildServiceClient NewInstance(ClientBaseConfiguration configuration)
@@ -369,7 +433,9 @@ namespace Smidr.V1 {
           .AddMethod(__Method_CancelBuild, serviceImpl.CancelBuild)
           .AddMethod(__Method_GetBuild, serviceImpl.GetBuild)
           .AddMethod(__Method_DeleteBuild, serviceImpl.DeleteBuild)
-          .AddMethod(__Method_PurgeBuilds, serviceImpl.PurgeBuilds).Build();
+          .AddMethod(__Method_PurgeBuilds, serviceImpl.PurgeBuilds)
+          .AddMethod(__Method_WatchBuild, serviceImpl.WatchBuild)
+          .AddMethod(__Method_RetryBuild, serviceImpl.RetryBuild).Build();
     }
 
     /// <summary>Register service method with a service binder with or without implementation. Useful when customizing the service binding logic.
@@ -386,6 +452,8 @@ namespace Smidr.V1 {
       serviceBinder.AddMethod(__Method_GetBuild, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.GetBuildRequest, global::Smidr.V1.BuildDetails>(serviceImpl.GetBuild));
       serviceBinder.AddMethod(__Method_DeleteBuild, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.DeleteBuildRequest, global::Smidr.V1.DeleteBuildResponse>(serviceImpl.DeleteBuild));
       serviceBinder.AddMethod(__Method_PurgeBuilds, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.PurgeBuildsRequest, global::Smidr.V1.PurgeBuildsResponse>(serviceImpl.PurgeBuilds));
+      serviceBinder.AddMethod(__Method_WatchBuild, serviceImpl == null ? null : new grpc::ServerStreamingServerMethod<global::Smidr.V1.WatchBuildRequest, global::Smidr.V1.BuildEvent>(serviceImpl.WatchBuild));
+      serviceBinder.AddMethod(__Method_RetryBuild, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.RetryBuildRequest, global::Smidr.V1.BuildStatusResponse>(serviceImpl.RetryBuild));
     }
 
   }
@@ -24,24 +24,40 @@ namespace Smidr.V1 {
     static LogsReflection() {
       byte[] descriptorData = global::System.Convert.FromBase64String(
           string.Concat(
-            "Cgpsb2dzLnByb3RvEghzbWlkci52MRoMY29tbW9uLnByb3RvInYKFlN0cmVh",
-            "bUJ1aWxkTG9nc1JlcXVlc3QSRAoQYnVpbGRfaWRlbnRpZmllchgBIAEoCzIZ",
-            "LnNtaWRyLnYxLkJ1aWxkSWRlbnRpZmllclIPYnVpbGRJZGVudGlmaWVyEhYK",
-            "BmZvbGxvdxgCIAEoCFIGZm9sbG93IqABCghMb2dFbnRyeRI0ChZ0aW1lc3Rh",
-            "bXBfdW5peF9zZWNvbmRzGAEgASgDUhR0aW1lc3RhbXBVbml4U2Vjb25kcxIY",
-            "CgdtZXNzYWdlGAIgASgJUgdtZXNzYWdlEhQKBWxldmVsGAMgASgJUgVsZXZl",
-            "bBIWCgZzb3VyY2UYBCABKAlSBnNvdXJjZRIWCgZzdHJlYW0YBSABKAlSBnN0",
-            "cmVhbTJXCgpMb2dTZXJ2aWNlEkkKD1N0cmVhbUJ1aWxkTG9ncxIgLnNtaWRy",
-            "LnYxLlN0cmVhbUJ1aWxkTG9nc1JlcXVlc3QaEi5zbWlkci52MS5Mb2dFbnRy",
-            "eTABQpQBCgxjb20uc21pZHIudjFCCUxvZ3NQcm90b1ABWjhnaXRodWIuY29t",
-            "L3NjaGVyZXJqYS9zbWlkci9zZGtzL3BrZy9zbWlkci1zZGsvdjE7c21pZHJ2",
-            "MaICA1NYWKoCCFNtaWRyLlYxygIIU21pZHJcVjHiAhRTbWlkclxWMVxHUEJN",
-            "ZXRhZGF0YeoCCVNtaWRyOjpWMWIGcHJvdG8z"));
+            "Cgpsb2dzLnByb3RvEghzbWlkci52MRoMY29tbW9uLnByb3RvIrsCChZTdHJl",
+            "YW1CdWlsZExvZ3NSZXF1ZXN0EkQKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsy",
+            "GS5zbWlkci52MS5CdWlsZElkZW50aWZpZXJSD2J1aWxkSWRlbnRpZmllchIW",
+            "CgZmb2xsb3cYAiABKAhSBmZvbGxvdxIWCgZzdHJlYW0YAyABKAlSBnN0cmVh",
+            "bRIUCgVsZXZlbBgEIAEoCVIFbGV2ZWwSLAoSc2luY2VfdW5peF9zZWNvbmRz",
+            "GAUgASgDUhBzaW5jZVVuaXhTZWNvbmRzEiwKEnVudGlsX3VuaXhfc2Vjb25k",
+            "cxgGIAEoA1IQdW50aWxVbml4U2Vjb25kcxISCgR0YWlsGAcgASgFUgR0YWls",
+            "EiUKDmFmdGVyX3NlcXVlbmNlGAggASgEUg1hZnRlclNlcXVlbmNlIrwBCghM",
+            "b2dFbnRyeRI0ChZ0aW1lc3RhbXBfdW5peF9zZWNvbmRzGAEgASgDUhR0aW1l",
+            "c3RhbXBVbml4U2Vjb25kcxIYCgdtZXNzYWdlGAIgASgJUgdtZXNzYWdlEhQK",
+            "BWxldmVsGAMgASgJUgVsZXZlbBIWCgZzb3VyY2UYBCABKAlSBnNvdXJjZRIW",
+            "CgZzdHJlYW0YBSABKAlSBnN0cmVhbRIaCghzZXF1ZW5jZRgGIAEoBFIIc2Vx",
+            "dWVuY2UipAEKEUdldFRhc2tMb2dSZXF1ZXN0EkQKEGJ1aWxkX2lkZW50aWZp",
+            "ZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXJSD2J1aWxkSWRl",
+            "bnRpZmllchIWCgZyZWNpcGUYAiABKAlSBnJlY2lwZRISCgR0YXNrGAMgASgJ",
+            "UgR0YXNrEh0KCnRhaWxfYnl0ZXMYBCABKANSCXRhaWxCeXRlcyKXAQoSR2V0",
+            "VGFza0xvZ1Jlc3BvbnNlEhYKBnJlY2lwZRgBIAEoCVIGcmVjaXBlEhIKBHRh",
+            "c2sYAiABKAlSBHRhc2sSGAoHY29udGVudBgDIAEoDFIHY29udGVudBIdCgpz",
+            "aXplX2J5dGVzGAQgASgDUglzaXplQnl0ZXMSHAoJdHJ1bmNhdGVkGAUgASgI",
+            "Ugl0cnVuY2F0ZWQyoAEKCkxvZ1NlcnZpY2USSQoPU3RyZWFtQnVpbGRMb2dz",
+            "EiAuc21pZHIudjEuU3RyZWFtQnVpbGRMb2dzUmVxdWVzdBoSLnNtaWRyLnYx",
+            "LkxvZ0VudHJ5MAESRwoKR2V0VGFza0xvZxIbLnNtaWRyLnYxLkdldFRhc2tM",
+            "b2dSZXF1ZXN0Ghwuc21pZHIudjEuR2V0VGFza0xvZ1Jlc3BvbnNlQpQBCgxj",
+            "b20uc21pZHIudjFCCUxvZ3NQcm90b1ABWjhnaXRodWIuY29tL3NjaGVyZXJq",
+            "YS9zbWlkci9zZGtzL3BrZy9zbWlkci1zZGsvdjE7c21pZHJ2MaICA1NYWKoC",
+            "CFNtaWRyLlYxygIIU21pZHJcVjHiAhRTbWlkclxWMVxHUEJNZXRhZGF0YeoC",
+            "CVNtaWRyOjpWMWIGcHJvdG8z"));
       descriptor = pbr::FileDescriptor.FromGeneratedCode(descriptorData,
           new pbr::FileDescriptor[] { global::Smidr.V1.CommonReflection.Descriptor, },
           new pbr::GeneratedClrTypeInfo(null, null, new pbr::GeneratedClrTypeInfo[] {
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.StreamBuildLogsRequest), global::Smidr.V1.StreamBuildLogsRequest.Parser, new[]{ "BuildIdentifier", "Follow" }, null, null, null, null),
-            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.LogEntry), global::Smidr.V1.LogEntry.Parser, new[]{ "TimestampUnixSeconds", "Message", "Level", "Source", "Stream" }, null, null, null, null)
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.StreamBuildLogsRequest), global::Smidr.V1.StreamBuildLogsRequest.Parser, new[]{ "BuildIdentifier", "Follow", "Stream", "Level", "SinceUnixSeconds", "UntilUnixSeconds", "Tail", "AfterSequence" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.LogEntry), global::Smidr.V1.LogEntry.Parser, new[]{ "TimestampUnixSeconds", "Message", "Level", "Source", "Stream", "Sequence" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.GetTaskLogRequest), global::Smidr.V1.GetTaskLogRequest.Parser, new[]{ "BuildIdentifier", "Recipe", "Task", "TailBytes" }, null, null, null, null),
+            new pbr::GeneratedClrTypeInfo(typeof(global::Smidr.V1.GetTaskLogResponse), global::Smidr.V1.GetTaskLogResponse.Parser, new[]{ "Recipe", "Task", "Content", "SizeBytes", "Truncated" }, null, null, null, null)
           }));
     }
     #endregion
@@ -88,6 +104,12 @@ namespace Smidr.V1 {
     public StreamBuildLogsRequest(StreamBuildLogsRequest other) : this() {
       buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
       follow_ = other.follow_;
+      stream_ = other.stream_;
+      level_ = other.level_;
+      sinceUnixSeconds_ = other.sinceUnixSeconds_;
+      untilUnixSeconds_ = other.untilUnixSeconds_;
+      tail_ = other.tail_;
+      afterSequence_ = other.afterSequence_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
@@ -117,6 +139,7 @@ namespace Smidr.V1 {
     private bool follow_;
     /// <summary>
     /// If true, continue streaming new logs as they are generated.
+    /// Ignored for builds that are no longer running (logs are replayed from disk).
     /// </summary>
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
@@ -127,6 +150,97 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "stream" field.</summary>
+    public const int StreamFieldNumber = 3;
+    private string stream_ = "";
+    /// <summary>
+    /// Only return entries from this stream (e.g., stdout, stderr). Empty means all streams.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Stream {
+      get { return stream_; }
+      set {
+        stream_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "level" field.</summary>
+    public const int LevelFieldNumber = 4;
+    private string level_ = "";
+    /// <summary>
+    /// Minimum log level to return (DEBUG, INFO, WARN, ERROR). Empty means all levels.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Level {
+      get { return level_; }
+      set {
+        level_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "since_unix_seconds" field.</summary>
+    public const int SinceUnixSecondsFieldNumber = 5;
+    private long sinceUnixSeconds_;
+    /// <summary>
+    /// Only return entries at or after this time (Unix seconds). Zero means no lower bound.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long SinceUnixSeconds {
+      get { return sinceUnixSeconds_; }
+      set {
+        sinceUnixSeconds_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "until_unix_seconds" field.</summary>
+    public const int UntilUnixSecondsFieldNumber = 6;
+    private long untilUnixSeconds_;
+    /// <summary>
+    /// Only return entries before this time (Unix seconds). Zero means no upper bound.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long UntilUnixSeconds {
+      get { return untilUnixSeconds_; }
+      set {
+        untilUnixSeconds_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "tail" field.</summary>
+    public const int TailFieldNumber = 7;
+    private int tail_;
+    /// <summary>
+    /// Only return the last N matching historical entries. Zero returns all of them.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int Tail {
+      get { return tail_; }
+      set {
+        tail_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "after_sequence" field.</summary>
+    public const int AfterSequenceFieldNumber = 8;
+    private ulong afterSequence_;
+    /// <summary>
+    /// Resume after this sequence number (the last LogEntry.sequence the client received).
+    /// Zero starts from the beginning of the log.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ulong AfterSequence {
+      get { return afterSequence_; }
+      set {
+        afterSequence_ = value;
+      }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
@@ -144,6 +258,12 @@ namespace Smidr.V1 {
       }
       if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
       if (Follow != other.Follow) return false;
+      if (Stream != other.Stream) return false;
+      if (Level != other.Level) return false;
+      if (SinceUnixSeconds != other.SinceUnixSeconds) return false;
+      if (UntilUnixSeconds != other.UntilUnixSeconds) return false;
+      if (Tail != other.Tail) return false;
+      if (AfterSequence != other.AfterSequence) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -153,6 +273,12 @@ namespace Smidr.V1 {
       int hash = 1;
       if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
       if (Follow != false) hash ^= Follow.GetHashCode();
+      if (Stream.Length != 0) hash ^= Stream.GetHashCode();
+      if (Level.Length != 0) hash ^= Level.GetHashCode();
+      if (SinceUnixSeconds != 0L) hash ^= SinceUnixSeconds.GetHashCode();
+      if (UntilUnixSeconds != 0L) hash ^= UntilUnixSeconds.GetHashCode();
+      if (Tail != 0) hash ^= Tail.GetHashCode();
+      if (AfterSequence != 0UL) hash ^= AfterSequence.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -179,6 +305,30 @@ namespace Smidr.V1 {
         output.WriteRawTag(16);
         output.WriteBool(Follow);
       }
+      if (Stream.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Stream);
+      }
+      if (Level.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Level);
+      }
+      if (SinceUnixSeconds != 0L) {
+        output.WriteRawTag(40);
+        output.WriteInt64(SinceUnixSeconds);
+      }
+      if (UntilUnixSeconds != 0L) {
+        output.WriteRawTag(48);
+        output.WriteInt64(UntilUnixSeconds);
+      }
+      if (Tail != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(Tail);
+      }
+      if (AfterSequence != 0UL) {
+        output.WriteRawTag(64);
+        output.WriteUInt64(AfterSequence);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -197,6 +347,30 @@ namespace Smidr.V1 {
         output.WriteRawTag(16);
         output.WriteBool(Follow);
       }
+      if (Stream.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Stream);
+      }
+      if (Level.Length != 0) {
+        output.WriteRawTag(34);
+        output.WriteString(Level);
+      }
+      if (SinceUnixSeconds != 0L) {
+        output.WriteRawTag(40);
+        output.WriteInt64(SinceUnixSeconds);
+      }
+      if (UntilUnixSeconds != 0L) {
+        output.WriteRawTag(48);
+        output.WriteInt64(UntilUnixSeconds);
+      }
+      if (Tail != 0) {
+        output.WriteRawTag(56);
+        output.WriteInt32(Tail);
+      }
+      if (AfterSequence != 0UL) {
+        output.WriteRawTag(64);
+        output.WriteUInt64(AfterSequence);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -213,6 +387,24 @@ namespace Smidr.V1 {
       if (Follow != false) {
         size += 1 + 1;
       }
+      if (Stream.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Stream);
+      }
+      if (Level.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Level);
+      }
+      if (SinceUnixSeconds != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(SinceUnixSeconds);
+      }
+      if (UntilUnixSeconds != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(UntilUnixSeconds);
+      }
+      if (Tail != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeInt32Size(Tail);
+      }
+      if (AfterSequence != 0UL) {
+        size += 1 + pb::CodedOutputStream.ComputeUInt64Size(AfterSequence);
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -234,6 +426,24 @@ namespace Smidr.V1 {
       if (other.Follow != false) {
         Follow = other.Follow;
       }
+      if (other.Stream.Length != 0) {
+        Stream = other.Stream;
+      }
+      if (other.Level.Length != 0) {
+        Level = other.Level;
+      }
+      if (other.SinceUnixSeconds != 0L) {
+        SinceUnixSeconds = other.SinceUnixSeconds;
+      }
+      if (other.UntilUnixSeconds != 0L) {
+        UntilUnixSeconds = other.UntilUnixSeconds;
+      }
+      if (other.Tail != 0) {
+        Tail = other.Tail;
+      }
+      if (other.AfterSequence != 0UL) {
+        AfterSequence = other.AfterSequence;
+      }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -264,6 +474,30 @@ namespace Smidr.V1 {
             Follow = input.ReadBool();
             break;
           }
+          case 26: {
+            Stream = input.ReadString();
+            break;
+          }
+          case 34: {
+            Level = input.ReadString();
+            break;
+          }
+          case 40: {
+            SinceUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 48: {
+            UntilUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 56: {
+            Tail = input.ReadInt32();
+            break;
+          }
+          case 64: {
+            AfterSequence = input.ReadUInt64();
+            break;
+          }
         }
       }
     #endif
@@ -294,6 +528,30 @@ namespace Smidr.V1 {
             Follow = input.ReadBool();
             break;
           }
+          case 26: {
+            Stream = input.ReadString();
+            break;
+          }
+          case 34: {
+            Level = input.ReadString();
+            break;
+          }
+          case 40: {
+            SinceUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 48: {
+            UntilUnixSeconds = input.ReadInt64();
+            break;
+          }
+          case 56: {
+            Tail = input.ReadInt32();
+            break;
+          }
+          case 64: {
+            AfterSequence = input.ReadUInt64();
+            break;
+          }
         }
       }
     }
@@ -341,6 +599,7 @@ namespace Smidr.V1 {
       level_ = other.level_;
       source_ = other.source_;
       stream_ = other.stream_;
+      sequence_ = other.sequence_;
       _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
     }
 
@@ -425,6 +684,22 @@ namespace Smidr.V1 {
       }
     }
 
+    /// <summary>Field number for the "sequence" field.</summary>
+    public const int SequenceFieldNumber = 6;
+    private ulong sequence_;
+    /// <summary>
+    /// Position of the entry in the build log, starting at 1. Zero if unknown
+    /// (e.g., logs replayed from builds that predate sequence numbers).
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public ulong Sequence {
+      get { return sequence_; }
+      set {
+        sequence_ = value;
+      }
+    }
+
     [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
     [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
     public override bool Equals(object other) {
@@ -445,6 +720,7 @@ namespace Smidr.V1 {
       if (Level != other.Level) return false;
       if (Source != other.Source) return false;
       if (Stream != other.Stream) return false;
+      if (Sequence != other.Sequence) return false;
       return Equals(_unknownFields, other._unknownFields);
     }
 
@@ -457,6 +733,7 @@ namespace Smidr.V1 {
       if (Level.Length != 0) hash ^= Level.GetHashCode();
       if (Source.Length != 0) hash ^= Source.GetHashCode();
       if (Stream.Length != 0) hash ^= Stream.GetHashCode();
+      if (Sequence != 0UL) hash ^= Sequence.GetHashCode();
       if (_unknownFields != null) {
         hash ^= _unknownFields.GetHashCode();
       }
@@ -495,6 +772,10 @@ namespace Smidr.V1 {
         output.WriteRawTag(42);
         output.WriteString(Stream);
       }
+      if (Sequence != 0UL) {
+        output.WriteRawTag(48);
+        output.WriteUInt64(Sequence);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(output);
       }
@@ -525,6 +806,10 @@ namespace Smidr.V1 {
         output.WriteRawTag(42);
         output.WriteString(Stream);
       }
+      if (Sequence != 0UL) {
+        output.WriteRawTag(48);
+        output.WriteUInt64(Sequence);
+      }
       if (_unknownFields != null) {
         _unknownFields.WriteTo(ref output);
       }
@@ -550,6 +835,9 @@ namespace Smidr.V1 {
       if (Stream.Length != 0) {
         size += 1 + pb::CodedOutputStream.ComputeStringSize(Stream);
       }
+      if (Sequence != 0UL) {
+        size += 1 + pb::CodedOutputStream.ComputeUInt64Size(Sequence);
+      }
       if (_unknownFields != null) {
         size += _unknownFields.CalculateSize();
       }
@@ -577,6 +865,9 @@ namespace Smidr.V1 {
       if (other.Stream.Length != 0) {
         Stream = other.Stream;
       }
+      if (other.Sequence != 0UL) {
+        Sequence = other.Sequence;
+      }
       _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
     }
 
@@ -616,6 +907,10 @@ namespace Smidr.V1 {
             Stream = input.ReadString();
             break;
           }
+          case 48: {
+            Sequence = input.ReadUInt64();
+            break;
+          }
         }
       }
     #endif
@@ -655,6 +950,697 @@ namespace Smidr.V1 {
             Stream = input.ReadString();
             break;
           }
+          case 48: {
+            Sequence = input.ReadUInt64();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  /// <summary>
+  /// GetTaskLogRequest requests the BitBake log (log.do_&lt;task>) of a task that failed
+  /// in a build, as collected from the work directory after the failure.
+  /// </summary>
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class GetTaskLogRequest : pb::IMessage<GetTaskLogRequest>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<GetTaskLogRequest> _parser = new pb::MessageParser<GetTaskLogRequest>(() => new GetTaskLogRequest());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<GetTaskLogRequest> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.LogsReflection.Descriptor.MessageTypes[2]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetTaskLogRequest() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetTaskLogRequest(GetTaskLogRequest other) : this() {
+      buildIdentifier_ = other.buildIdentifier_ != null ? other.buildIdentifier_.Clone() : null;
+      recipe_ = other.recipe_;
+      task_ = other.task_;
+      tailBytes_ = other.tailBytes_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetTaskLogRequest Clone() {
+      return new GetTaskLogRequest(this);
+    }
+
+    /// <summary>Field number for the "build_identifier" field.</summary>
+    public const int BuildIdentifierFieldNumber = 1;
+    private global::Smidr.V1.BuildIdentifier buildIdentifier_;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public global::Smidr.V1.BuildIdentifier BuildIdentifier {
+      get { return buildIdentifier_; }
+      set {
+        buildIdentifier_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "recipe" field.</summary>
+    public const int RecipeFieldNumber = 2;
+    private string recipe_ = "";
+    /// <summary>
+    /// Recipe name without version (e.g., busybox).
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Recipe {
+      get { return recipe_; }
+      set {
+        recipe_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "task" field.</summary>
+    public const int TaskFieldNumber = 3;
+    private string task_ = "";
+    /// <summary>
+    /// Task name (e.g., do_compile). The do_ prefix may be omitted.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Task {
+      get { return task_; }
+      set {
+        task_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "tail_bytes" field.</summary>
+    public const int TailBytesFieldNumber = 4;
+    private long tailBytes_;
+    /// <summary>
+    /// Only return the last N bytes of the log. Zero returns the whole log, up to a
+    /// server-side limit.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long TailBytes {
+      get { return tailBytes_; }
+      set {
+        tailBytes_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as GetTaskLogRequest);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(GetTaskLogRequest other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (!object.Equals(BuildIdentifier, other.BuildIdentifier)) return false;
+      if (Recipe != other.Recipe) return false;
+      if (Task != other.Task) return false;
+      if (TailBytes != other.TailBytes) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (buildIdentifier_ != null) hash ^= BuildIdentifier.GetHashCode();
+      if (Recipe.Length != 0) hash ^= Recipe.GetHashCode();
+      if (Task.Length != 0) hash ^= Task.GetHashCode();
+      if (TailBytes != 0L) hash ^= TailBytes.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Task);
+      }
+      if (TailBytes != 0L) {
+        output.WriteRawTag(32);
+        output.WriteInt64(TailBytes);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (buildIdentifier_ != null) {
+        output.WriteRawTag(10);
+        output.WriteMessage(BuildIdentifier);
+      }
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteString(Task);
+      }
+      if (TailBytes != 0L) {
+        output.WriteRawTag(32);
+        output.WriteInt64(TailBytes);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (buildIdentifier_ != null) {
+        size += 1 + pb::CodedOutputStream.ComputeMessageSize(BuildIdentifier);
+      }
+      if (Recipe.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Recipe);
+      }
+      if (Task.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Task);
+      }
+      if (TailBytes != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(TailBytes);
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(GetTaskLogRequest other) {
+      if (other == null) {
+        return;
+      }
+      if (other.buildIdentifier_ != null) {
+        if (buildIdentifier_ == null) {
+          BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+        }
+        BuildIdentifier.MergeFrom(other.BuildIdentifier);
+      }
+      if (other.Recipe.Length != 0) {
+        Recipe = other.Recipe;
+      }
+      if (other.Task.Length != 0) {
+        Task = other.Task;
+      }
+      if (other.TailBytes != 0L) {
+        TailBytes = other.TailBytes;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+          case 18: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 26: {
+            Task = input.ReadString();
+            break;
+          }
+          case 32: {
+            TailBytes = input.ReadInt64();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            if (buildIdentifier_ == null) {
+              BuildIdentifier = new global::Smidr.V1.BuildIdentifier();
+            }
+            input.ReadMessage(BuildIdentifier);
+            break;
+          }
+          case 18: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 26: {
+            Task = input.ReadString();
+            break;
+          }
+          case 32: {
+            TailBytes = input.ReadInt64();
+            break;
+          }
+        }
+      }
+    }
+    #endif
+
+  }
+
+  [global::System.Diagnostics.DebuggerDisplayAttribute("{ToString(),nq}")]
+  public sealed partial class GetTaskLogResponse : pb::IMessage<GetTaskLogResponse>
+  #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      , pb::IBufferMessage
+  #endif
+  {
+    private static readonly pb::MessageParser<GetTaskLogResponse> _parser = new pb::MessageParser<GetTaskLogResponse>(() => new GetTaskLogResponse());
+    private pb::UnknownFieldSet _unknownFields;
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pb::MessageParser<GetTaskLogResponse> Parser { get { return _parser; } }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public static pbr::MessageDescriptor Descriptor {
+      get { return global::Smidr.V1.LogsReflection.Descriptor.MessageTypes[3]; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    pbr::MessageDescriptor pb::IMessage.Descriptor {
+      get { return Descriptor; }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetTaskLogResponse() {
+      OnConstruction();
+    }
+
+    partial void OnConstruction();
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetTaskLogResponse(GetTaskLogResponse other) : this() {
+      recipe_ = other.recipe_;
+      task_ = other.task_;
+      content_ = other.content_;
+      sizeBytes_ = other.sizeBytes_;
+      truncated_ = other.truncated_;
+      _unknownFields = pb::UnknownFieldSet.Clone(other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public GetTaskLogResponse Clone() {
+      return new GetTaskLogResponse(this);
+    }
+
+    /// <summary>Field number for the "recipe" field.</summary>
+    public const int RecipeFieldNumber = 1;
+    private string recipe_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Recipe {
+      get { return recipe_; }
+      set {
+        recipe_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "task" field.</summary>
+    public const int TaskFieldNumber = 2;
+    private string task_ = "";
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public string Task {
+      get { return task_; }
+      set {
+        task_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "content" field.</summary>
+    public const int ContentFieldNumber = 3;
+    private pb::ByteString content_ = pb::ByteString.Empty;
+    /// <summary>
+    /// Content of the log, or its end when truncated is set.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public pb::ByteString Content {
+      get { return content_; }
+      set {
+        content_ = pb::ProtoPreconditions.CheckNotNull(value, "value");
+      }
+    }
+
+    /// <summary>Field number for the "size_bytes" field.</summary>
+    public const int SizeBytesFieldNumber = 4;
+    private long sizeBytes_;
+    /// <summary>
+    /// Size of the complete log.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public long SizeBytes {
+      get { return sizeBytes_; }
+      set {
+        sizeBytes_ = value;
+      }
+    }
+
+    /// <summary>Field number for the "truncated" field.</summary>
+    public const int TruncatedFieldNumber = 5;
+    private bool truncated_;
+    /// <summary>
+    /// True if content holds only the end of the log.
+    /// </summary>
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Truncated {
+      get { return truncated_; }
+      set {
+        truncated_ = value;
+      }
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override bool Equals(object other) {
+      return Equals(other as GetTaskLogResponse);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public bool Equals(GetTaskLogResponse other) {
+      if (ReferenceEquals(other, null)) {
+        return false;
+      }
+      if (ReferenceEquals(other, this)) {
+        return true;
+      }
+      if (Recipe != other.Recipe) return false;
+      if (Task != other.Task) return false;
+      if (Content != other.Content) return false;
+      if (SizeBytes != other.SizeBytes) return false;
+      if (Truncated != other.Truncated) return false;
+      return Equals(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override int GetHashCode() {
+      int hash = 1;
+      if (Recipe.Length != 0) hash ^= Recipe.GetHashCode();
+      if (Task.Length != 0) hash ^= Task.GetHashCode();
+      if (Content.Length != 0) hash ^= Content.GetHashCode();
+      if (SizeBytes != 0L) hash ^= SizeBytes.GetHashCode();
+      if (Truncated != false) hash ^= Truncated.GetHashCode();
+      if (_unknownFields != null) {
+        hash ^= _unknownFields.GetHashCode();
+      }
+      return hash;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public override string ToString() {
+      return pb::JsonFormatter.ToDiagnosticString(this);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void WriteTo(pb::CodedOutputStream output) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      output.WriteRawMessage(this);
+    #else
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Task);
+      }
+      if (Content.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteBytes(Content);
+      }
+      if (SizeBytes != 0L) {
+        output.WriteRawTag(32);
+        output.WriteInt64(SizeBytes);
+      }
+      if (Truncated != false) {
+        output.WriteRawTag(40);
+        output.WriteBool(Truncated);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(output);
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalWriteTo(ref pb::WriteContext output) {
+      if (Recipe.Length != 0) {
+        output.WriteRawTag(10);
+        output.WriteString(Recipe);
+      }
+      if (Task.Length != 0) {
+        output.WriteRawTag(18);
+        output.WriteString(Task);
+      }
+      if (Content.Length != 0) {
+        output.WriteRawTag(26);
+        output.WriteBytes(Content);
+      }
+      if (SizeBytes != 0L) {
+        output.WriteRawTag(32);
+        output.WriteInt64(SizeBytes);
+      }
+      if (Truncated != false) {
+        output.WriteRawTag(40);
+        output.WriteBool(Truncated);
+      }
+      if (_unknownFields != null) {
+        _unknownFields.WriteTo(ref output);
+      }
+    }
+    #endif
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public int CalculateSize() {
+      int size = 0;
+      if (Recipe.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Recipe);
+      }
+      if (Task.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeStringSize(Task);
+      }
+      if (Content.Length != 0) {
+        size += 1 + pb::CodedOutputStream.ComputeBytesSize(Content);
+      }
+      if (SizeBytes != 0L) {
+        size += 1 + pb::CodedOutputStream.ComputeInt64Size(SizeBytes);
+      }
+      if (Truncated != false) {
+        size += 1 + 1;
+      }
+      if (_unknownFields != null) {
+        size += _unknownFields.CalculateSize();
+      }
+      return size;
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(GetTaskLogResponse other) {
+      if (other == null) {
+        return;
+      }
+      if (other.Recipe.Length != 0) {
+        Recipe = other.Recipe;
+      }
+      if (other.Task.Length != 0) {
+        Task = other.Task;
+      }
+      if (other.Content.Length != 0) {
+        Content = other.Content;
+      }
+      if (other.SizeBytes != 0L) {
+        SizeBytes = other.SizeBytes;
+      }
+      if (other.Truncated != false) {
+        Truncated = other.Truncated;
+      }
+      _unknownFields = pb::UnknownFieldSet.MergeFrom(_unknownFields, other._unknownFields);
+    }
+
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    public void MergeFrom(pb::CodedInputStream input) {
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+      input.ReadRawMessage(this);
+    #else
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, input);
+            break;
+          case 10: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 18: {
+            Task = input.ReadString();
+            break;
+          }
+          case 26: {
+            Content = input.ReadBytes();
+            break;
+          }
+          case 32: {
+            SizeBytes = input.ReadInt64();
+            break;
+          }
+          case 40: {
+            Truncated = input.ReadBool();
+            break;
+          }
+        }
+      }
+    #endif
+    }
+
+    #if !GOOGLE_PROTOBUF_REFSTRUCT_COMPATIBILITY_MODE
+    [global::System.Diagnostics.DebuggerNonUserCodeAttribute]
+    [global::System.CodeDom.Compiler.GeneratedCode("protoc", null)]
+    void pb::IBufferMessage.InternalMergeFrom(ref pb::ParseContext input) {
+      uint tag;
+      while ((tag = input.ReadTag()) != 0) {
+      if ((tag & 7) == 4) {
+        // Abort on any end group tag.
+        return;
+      }
+      switch(tag) {
+          default:
+            _unknownFields = pb::UnknownFieldSet.MergeFieldFrom(_unknownFields, ref input);
+            break;
+          case 10: {
+            Recipe = input.ReadString();
+            break;
+          }
+          case 18: {
+            Task = input.ReadString();
+            break;
+          }
+          case 26: {
+            Content = input.ReadBytes();
+            break;
+          }
+          case 32: {
+            SizeBytes = input.ReadInt64();
+            break;
+          }
+          case 40: {
+            Truncated = input.ReadBool();
+            break;
+          }
         }
       }
     }
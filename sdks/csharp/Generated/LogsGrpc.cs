@@ -49,6 +49,10 @@ namespace Smidr.V1 {
     static readonly grpc::Marshaller<global::Smidr.V1.StreamBuildLogsRequest> __Marshaller_smidr_v1_StreamBuildLogsRequest = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.StreamBuildLogsRequest.Parser));
     [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
     static readonly grpc::Marshaller<global::Smidr.V1.LogEntry> __Marshaller_smidr_v1_LogEntry = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.LogEntry.Parser));
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Marshaller<global::Smidr.V1.GetTaskLogRequest> __Marshaller_smidr_v1_GetTaskLogRequest = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.GetTaskLogRequest.Parser));
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Marshaller<global::Smidr.V1.GetTaskLogResponse> __Marshaller_smidr_v1_GetTaskLogResponse = grpc::Marshallers.Create(__Helper_SerializeMessage, context => __Helper_DeserializeMessage(context, global::Smidr.V1.GetTaskLogResponse.Parser));
 
     [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
     static readonly grpc::Method<global::Smidr.V1.StreamBuildLogsRequest, global::Smidr.V1.LogEntry> __Method_StreamBuildLogs = new grpc::Method<global::Smidr.V1.StreamBuildLogsRequest, global::Smidr.V1.LogEntry>(
@@ -58,6 +62,14 @@ namespace Smidr.V1 {
         __Marshaller_smidr_v1_StreamBuildLogsRequest,
         __Marshaller_smidr_v1_LogEntry);
 
+    [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+    static readonly grpc::Method<global::Smidr.V1.GetTaskLogRequest, global::Smidr.V1.GetTaskLogResponse> __Method_GetTaskLog = new grpc::Method<global::Smidr.V1.GetTaskLogRequest, global::Smidr.V1.GetTaskLogResponse>(
+        grpc::MethodType.Unary,
+        __ServiceName,
+        "GetTaskLog",
+        __Marshaller_smidr_v1_GetTaskLogRequest,
+        __Marshaller_smidr_v1_GetTaskLogResponse);
+
     /// <summary>Service descriptor</summary>
     public static global::Google.Protobuf.Reflection.ServiceDescriptor Descriptor
     {
@@ -74,6 +86,12 @@ namespace Smidr.V1 {
         throw new grpc::RpcException(new grpc::Status(grpc::StatusCode.Unimplemented, ""));
       }
 
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual global::System.Threading.Tasks.Task<global::Smidr.V1.GetTaskLogResponse> GetTaskLog(global::Smidr.V1.GetTaskLogRequest request, grpc::ServerCallContext context)
+      {
+        throw new grpc::RpcException(new grpc::Status(grpc::StatusCode.Unimplemented, ""));
+      }
+
     }
 
     /// <summary>Client for LogService</summary>
@@ -113,6 +131,26 @@ namespace Smidr.V1 {
       {
         return CallInvoker.AsyncServerStreamingCall(__Method_StreamBuildLogs, null, options, request);
       }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual global::Smidr.V1.GetTaskLogResponse GetTaskLog(global::Smidr.V1.GetTaskLogRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
+      {
+        return GetTaskLog(request, new grpc::CallOptions(headers, deadline, cancellationToken));
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual global::Smidr.V1.GetTaskLogResponse GetTaskLog(global::Smidr.V1.GetTaskLogRequest request, grpc::CallOptions options)
+      {
+        return CallInvoker.BlockingUnaryCall(__Method_GetTaskLog, null, options, request);
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual grpc::AsyncUnaryCall<global::Smidr.V1.GetTaskLogResponse> GetTaskLogAsync(global::Smidr.V1.GetTaskLogRequest request, grpc::Metadata headers = null, global::System.DateTime? deadline = null, global::System.Threading.CancellationToken cancellationToken = default(global::System.Threading.CancellationToken))
+      {
+        return GetTaskLogAsync(request, new grpc::CallOptions(headers, deadline, cancellationToken));
+      }
+      [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
+      public virtual grpc::AsyncUnaryCall<global::Smidr.V1.GetTaskLogResponse> GetTaskLogAsync(global::Smidr.V1.GetTaskLogRequest request, grpc::CallOptions options)
+      {
+        return CallInvoker.AsyncUnaryCall(__Method_GetTaskLog, null, options, request);
+      }
       /// <summary>Creates a new instance of client from given <c>ClientBaseConfiguration</c>.</summary>
       [global::System.CodeDom.Compiler.GeneratedCode("grpc_csharp_plugin", null)]
       protected override LogServiceClient NewInstance(ClientBaseConfiguration configuration)
@@ -127,7 +165,8 @@ namespace Smidr.V1 {
     public static grpc::ServerServiceDefinition BindService(LogServiceBase serviceImpl)
     {
       return grpc::ServerServiceDefinition.CreateBuilder()
-          .AddMethod(__Method_StreamBuildLogs, serviceImpl.StreamBuildLogs).Build();
+          .AddMethod(__Method_StreamBuildLogs, serviceImpl.StreamBuildLogs)
+          .AddMethod(__Method_GetTaskLog, serviceImpl.GetTaskLog).Build();
     }
 
     /// <summary>Register service method with a service binder with or without implementation. Useful when customizing the service binding logic.
@@ -138,6 +177,7 @@ namespace Smidr.V1 {
     public static void BindService(grpc::ServiceBinderBase serviceBinder, LogServiceBase serviceImpl)
     {
       serviceBinder.AddMethod(__Method_StreamBuildLogs, serviceImpl == null ? null : new grpc::ServerStreamingServerMethod<global::Smidr.V1.StreamBuildLogsRequest, global::Smidr.V1.LogEntry>(serviceImpl.StreamBuildLogs));
+      serviceBinder.AddMethod(__Method_GetTaskLog, serviceImpl == null ? null : new grpc::UnaryServerMethod<global::Smidr.V1.GetTaskLogRequest, global::Smidr.V1.GetTaskLogResponse>(serviceImpl.GetTaskLog));
     }
 
   }
@@ -39,7 +39,7 @@ export const ArtifactService = {
       name: "DownloadArtifact",
       I: DownloadArtifactRequest,
       O: DownloadArtifactResponse,
-      kind: MethodKind.Unary,
+      kind: MethodKind.ServerStreaming,
     },
     /**
      * @generated from rpc smidr.v1.ArtifactService.DeleteArtifact
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file artifacts.proto.
  */
 export const file_artifacts: GenFile = /*@__PURE__*/
-  fileDesc("Cg9hcnRpZmFjdHMucHJvdG8SCHNtaWRyLnYxIksKFExpc3RBcnRpZmFjdHNSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXIiegoVTGlzdEFydGlmYWN0c1Jlc3BvbnNlEiwKCWFydGlmYWN0cxgBIAMoCzIZLnNtaWRyLnYxLkFydGlmYWN0U3VtbWFyeRIzChBidWlsZF9pZGVudGlmaWVyGAIgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyIikKEkdldEFydGlmYWN0UmVxdWVzdBITCgthcnRpZmFjdF9pZBgBIAEoCSIuChdEb3dubG9hZEFydGlmYWN0UmVxdWVzdBITCgthcnRpZmFjdF9pZBgBIAEoCSIwChhEb3dubG9hZEFydGlmYWN0UmVzcG9uc2USFAoMZG93bmxvYWRfdXJsGAEgASgJIiwKFURlbGV0ZUFydGlmYWN0UmVxdWVzdBITCgthcnRpZmFjdF9pZBgBIAEoCSIpChZEZWxldGVBcnRpZmFjdFJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgifgoPQXJ0aWZhY3RTdW1tYXJ5EhMKC2FydGlmYWN0X2lkGAEgASgJEgwKBG5hbWUYAiABKAkSDAoEdHlwZRgDIAEoCRIUCgxkb3dubG9hZF91cmwYBCABKAkSEgoKc2l6ZV9ieXRlcxgFIAEoAxIQCghjaGVja3N1bRgGIAEoCTLbAgoPQXJ0aWZhY3RTZXJ2aWNlElAKDUxpc3RBcnRpZmFjdHMSHi5zbWlkci52MS5MaXN0QXJ0aWZhY3RzUmVxdWVzdBofLnNtaWRyLnYxLkxpc3RBcnRpZmFjdHNSZXNwb25zZRJGCgtHZXRBcnRpZmFjdBIcLnNtaWRyLnYxLkdldEFydGlmYWN0UmVxdWVzdBoZLnNtaWRyLnYxLkFydGlmYWN0U3VtbWFyeRJZChBEb3dubG9hZEFydGlmYWN0EiEuc21pZHIudjEuRG93bmxvYWRBcnRpZmFjdFJlcXVlc3QaIi5zbWlkci52MS5Eb3dubG9hZEFydGlmYWN0UmVzcG9uc2USUwoORGVsZXRlQXJ0aWZhY3QSHy5zbWlkci52MS5EZWxldGVBcnRpZmFjdFJlcXVlc3QaIC5zbWlkci52MS5EZWxldGVBcnRpZmFjdFJlc3BvbnNlQpkBCgxjb20uc21pZHIudjFCDkFydGlmYWN0c1Byb3RvUAFaOGdpdGh1Yi5jb20vc2NoZXJlcmphL3NtaWRyL3Nka3MvcGtnL3NtaWRyLXNkay92MTtzbWlkcnYxogIDU1hYqgIIU21pZHIuVjHKAghTbWlkclxWMeICFFNtaWRyXFYxXEdQQk1ldGFkYXRh6gIJU21pZHI6OlYxYgZwcm90bzM", [file_common]);
+  fileDesc("Cg9hcnRpZmFjdHMucHJvdG8SCHNtaWRyLnYxIksKFExpc3RBcnRpZmFjdHNSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXIiegoVTGlzdEFydGlmYWN0c1Jlc3BvbnNlEiwKCWFydGlmYWN0cxgBIAMoCzIZLnNtaWRyLnYxLkFydGlmYWN0U3VtbWFyeRIzChBidWlsZF9pZGVudGlmaWVyGAIgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyIikKEkdldEFydGlmYWN0UmVxdWVzdBITCgthcnRpZmFjdF9pZBgBIAEoCSJSChdEb3dubG9hZEFydGlmYWN0UmVxdWVzdBITCgthcnRpZmFjdF9pZBgBIAEoCRIOCgZvZmZzZXQYAiABKAMSEgoKY2h1bmtfc2l6ZRgDIAEoBSKCAQoYRG93bmxvYWRBcnRpZmFjdFJlc3BvbnNlEhQKDGRvd25sb2FkX3VybBgBIAEoCRIMCgRkYXRhGAIgASgMEg4KBm9mZnNldBgDIAEoAxISCgp0b3RhbF9zaXplGAQgASgDEhAKCGNoZWNrc3VtGAUgASgJEgwKBGxhc3QYBiABKAgiLAoVRGVsZXRlQXJ0aWZhY3RSZXF1ZXN0EhMKC2FydGlmYWN0X2lkGAEgASgJIikKFkRlbGV0ZUFydGlmYWN0UmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCJ+Cg9BcnRpZmFjdFN1bW1hcnkSEwoLYXJ0aWZhY3RfaWQYASABKAkSDAoEbmFtZRgCIAEoCRIMCgR0eXBlGAMgASgJEhQKDGRvd25sb2FkX3VybBgEIAEoCRISCgpzaXplX2J5dGVzGAUgASgDEhAKCGNoZWNrc3VtGAYgASgJMt0CCg9BcnRpZmFjdFNlcnZpY2USUAoNTGlzdEFydGlmYWN0cxIeLnNtaWRyLnYxLkxpc3RBcnRpZmFjdHNSZXF1ZXN0Gh8uc21pZHIudjEuTGlzdEFydGlmYWN0c1Jlc3BvbnNlEkYKC0dldEFydGlmYWN0Ehwuc21pZHIudjEuR2V0QXJ0aWZhY3RSZXF1ZXN0Ghkuc21pZHIudjEuQXJ0aWZhY3RTdW1tYXJ5ElsKEERvd25sb2FkQXJ0aWZhY3QSIS5zbWlkci52MS5Eb3dubG9hZEFydGlmYWN0UmVxdWVzdBoiLnNtaWRyLnYxLkRvd25sb2FkQXJ0aWZhY3RSZXNwb25zZTABElMKDkRlbGV0ZUFydGlmYWN0Eh8uc21pZHIudjEuRGVsZXRlQXJ0aWZhY3RSZXF1ZXN0GiAuc21pZHIudjEuRGVsZXRlQXJ0aWZhY3RSZXNwb25zZUKZAQoMY29tLnNtaWRyLnYxQg5BcnRpZmFjdHNQcm90b1ABWjhnaXRodWIuY29tL3NjaGVyZXJqYS9zbWlkci9zZGtzL3BrZy9zbWlkci1zZGsvdjE7c21pZHJ2MaICA1NYWKoCCFNtaWRyLlYxygIIU21pZHJcVjHiAhRTbWlkclxWMVxHUEJNZXRhZGF0YeoCCVNtaWRyOjpWMWIGcHJvdG8z", [file_common]);
 
 /**
  * ListArtifactsRequest is used to request a list of artifacts for an build.
@@ -86,6 +86,20 @@ export type DownloadArtifactRequest = Message<"smidr.v1.DownloadArtifactRequest"
    * @generated from field: string artifact_id = 1;
    */
   artifactId: string;
+
+  /**
+   * Byte offset to start streaming from, used to resume partial downloads.
+   *
+   * @generated from field: int64 offset = 2;
+   */
+  offset: bigint;
+
+  /**
+   * Preferred chunk size in bytes (0 = server default).
+   *
+   * @generated from field: int32 chunk_size = 3;
+   */
+  chunkSize: number;
 };
 
 /**
@@ -96,7 +110,8 @@ export const DownloadArtifactRequestSchema: GenMessage<DownloadArtifactRequest>
   messageDesc(file_artifacts, 3);
 
 /**
- * DownloadArtifactResponse provides the download URL for a requested artifact.
+ * DownloadArtifactResponse carries one chunk of artifact content.
+ * The final chunk has last set and carries the SHA-256 of the whole file.
  *
  * @generated from message smidr.v1.DownloadArtifactResponse
  */
@@ -105,6 +120,41 @@ export type DownloadArtifactResponse = Message<"smidr.v1.DownloadArtifactRespons
    * @generated from field: string download_url = 1;
    */
   downloadUrl: string;
+
+  /**
+   * Raw artifact bytes for this chunk.
+   *
+   * @generated from field: bytes data = 2;
+   */
+  data: Uint8Array;
+
+  /**
+   * Byte offset of data within the artifact.
+   *
+   * @generated from field: int64 offset = 3;
+   */
+  offset: bigint;
+
+  /**
+   * Total size of the artifact in bytes.
+   *
+   * @generated from field: int64 total_size = 4;
+   */
+  totalSize: bigint;
+
+  /**
+   * Hex-encoded SHA-256 of the complete artifact (set on the last chunk).
+   *
+   * @generated from field: string checksum = 5;
+   */
+  checksum: string;
+
+  /**
+   * True on the final chunk of the stream.
+   *
+   * @generated from field: bool last = 6;
+   */
+  last: boolean;
 };
 
 /**
@@ -222,7 +272,7 @@ export const ArtifactService: GenService<{
    * @generated from rpc smidr.v1.ArtifactService.DownloadArtifact
    */
   downloadArtifact: {
-    methodKind: "unary";
+    methodKind: "server_streaming";
     input: typeof DownloadArtifactRequestSchema;
     output: typeof DownloadArtifactResponseSchema;
   },
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { BuildDetails, BuildStatusRequest, BuildStatusResponse, CancelBuildRequest, CancelBuildResponse, DeleteBuildRequest, DeleteBuildResponse, GetBuildRequest, ListBuildsRequest, ListBuildsResponse, PurgeBuildsRequest, PurgeBuildsResponse, StartBuildRequest } from "./builds_pb.js";
+import { BuildDetails, BuildEvent, BuildStatusRequest, BuildStatusResponse, CancelBuildRequest, CancelBuildResponse, DeleteBuildRequest, DeleteBuildResponse, GetBuildRequest, ListBuildsRequest, ListBuildsResponse, PurgeBuildsRequest, PurgeBuildsResponse, RetryBuildRequest, StartBuildRequest, WatchBuildRequest } from "./builds_pb.js";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -75,6 +75,24 @@ export const BuildService = {
       O: PurgeBuildsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * @generated from rpc smidr.v1.BuildService.WatchBuild
+     */
+    watchBuild: {
+      name: "WatchBuild",
+      I: WatchBuildRequest,
+      O: BuildEvent,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * @generated from rpc smidr.v1.BuildService.RetryBuild
+     */
+    retryBuild: {
+      name: "RetryBuild",
+      I: RetryBuildRequest,
+      O: BuildStatusResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
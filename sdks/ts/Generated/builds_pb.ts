@@ -2,8 +2,8 @@
 // @generated from file builds.proto (package smidr.v1, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { BuildIdentifier, BuildState, TimeStampRange } from "./common_pb";
 import { file_common } from "./common_pb";
 import type { Message } from "@bufbuild/protobuf";
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file builds.proto.
  */
 export const file_builds: GenFile = /*@__PURE__*/
-  fileDesc("CgxidWlsZHMucHJvdG8SCHNtaWRyLnYxIooCChFTdGFydEJ1aWxkUmVxdWVzdBIOCgZjb25maWcYASABKAkSDgoGdGFyZ2V0GAIgASgJEhMKC2ZvcmNlX2NsZWFuGAMgASgIEhsKE2ZvcmNlX2ltYWdlX3JlYnVpbGQYBCABKAgSVAoVZW52aXJvbm1lbnRfdmFyaWFibGVzGAUgAygLMjUuc21pZHIudjEuU3RhcnRCdWlsZFJlcXVlc3QuRW52aXJvbm1lbnRWYXJpYWJsZXNFbnRyeRIQCghjdXN0b21lchgGIAEoCRo7ChlFbnZpcm9ubWVudFZhcmlhYmxlc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEijwIKE0J1aWxkU3RhdHVzUmVzcG9uc2USMwoQYnVpbGRfaWRlbnRpZmllchgBIAEoCzIZLnNtaWRyLnYxLkJ1aWxkSWRlbnRpZmllchIOCgZ0YXJnZXQYAiABKAkSIwoFc3RhdGUYAyABKA4yFC5zbWlkci52MS5CdWlsZFN0YXRlEhEKCWV4aXRfY29kZRgEIAEoBRIVCg1lcnJvcl9tZXNzYWdlGAUgASgJEiwKCnRpbWVzdGFtcHMYBiABKAsyGC5zbWlkci52MS5UaW1lU3RhbXBSYW5nZRITCgtjb25maWdfcGF0aBgHIAEoCRIQCghjdXN0b21lchgIIAEoCRIPCgdkZWxldGVkGAkgASgIIkkKEkJ1aWxkU3RhdHVzUmVxdWVzdBIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyItIECgxCdWlsZERldGFpbHMSMwoQYnVpbGRfaWRlbnRpZmllchgBIAEoCzIZLnNtaWRyLnYxLkJ1aWxkSWRlbnRpZmllchIQCghjdXN0b21lchgCIAEoCRIUCgxwcm9qZWN0X25hbWUYAyABKAkSFAoMdGFyZ2V0X2ltYWdlGAQgASgJEg8KB21hY2hpbmUYBSABKAkSKQoLYnVpbGRfc3RhdGUYBiABKA4yFC5zbWlkci52MS5CdWlsZFN0YXRlEhEKCWV4aXRfY29kZRgHIAEoBRIXCg9idWlsZF9kaXJlY3RvcnkYCCABKAkSGgoSZG93bmxvYWRfZGlyZWN0b3J5GAkgASgJEhYKDmxvZ19maWxlX3BsYWluGAogASgJEhYKDmxvZ19maWxlX2pzb25sGAsgASgJEhMKC2NvbmZpZ19maWxlGAwgASgJEhcKD2NvbmZpZ19zbmFwc2hvdBgNIAEoCRIMCgR1c2VyGA4gASgJEgwKBGhvc3QYDyABKAkSEgoKY3JlYXRlZF9hdBgQIAEoAxIsCgp0aW1lc3RhbXBzGBEgASgLMhguc21pZHIudjEuVGltZVN0YW1wUmFuZ2USGAoQZHVyYXRpb25fc2Vjb25kcxgSIAEoBRIPCgdkZWxldGVkGBMgASgIEhIKCmRlbGV0ZWRfYXQYFCABKAMSFQoNZXJyb3JfbWVzc2FnZRgVIAEoCRIWCg5hcnRpZmFjdF9jb3VudBgWIAEoBRIhChl0b3RhbF9hcnRpZmFjdF9zaXplX2J5dGVzGBcgASgDIr8BChFMaXN0QnVpbGRzUmVxdWVzdBIqCgxzdGF0ZV9maWx0ZXIYASADKA4yFC5zbWlkci52MS5CdWlsZFN0YXRlEiwKCnRpbWVfcmFuZ2UYAiABKAsyGC5zbWlkci52MS5UaW1lU3RhbXBSYW5nZRIRCglwYWdlX3NpemUYAyABKAUSEgoKcGFnZV90b2tlbhgEIAEoCRIQCghjdXN0b21lchgFIAEoCRIXCg9pbmNsdWRlX2RlbGV0ZWQYBiABKAgiawoSTGlzdEJ1aWxkc1Jlc3BvbnNlEiYKBmJ1aWxkcxgBIAMoCzIWLnNtaWRyLnYxLkJ1aWxkRGV0YWlscxIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkSFAoMdG90YWxfYnVpbGRzGAMgASgFIkkKEkNhbmNlbEJ1aWxkUmVxdWVzdBIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyIjcKE0NhbmNlbEJ1aWxkUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCBIPCgdtZXNzYWdlGAIgASgJIkYKD0dldEJ1aWxkUmVxdWVzdBIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyIkkKEkRlbGV0ZUJ1aWxkUmVxdWVzdBIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyIjcKE0RlbGV0ZUJ1aWxkUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCBIPCgdtZXNzYWdlGAIgASgJIkcKElB1cmdlQnVpbGRzUmVxdWVzdBIfChdvbGRlcl90aGFuX3VuaXhfc2Vjb25kcxgBIAEoAxIQCghjdXN0b21lchgCIAEoCSJ3ChNQdXJnZUJ1aWxkc1Jlc3BvbnNlEhoKEnB1cmdlZF9idWlsZF9jb3VudBgBIAEoBRIYChBwdXJnZWRfYnVpbGRfaWRzGAIgAygJEhkKEWZyZWVkX3NwYWNlX2J5dGVzGAMgASgDEg8KB21lc3NhZ2UYBCABKAkykwQKDEJ1aWxkU2VydmljZRJICgpTdGFydEJ1aWxkEhsuc21pZHIudjEuU3RhcnRCdWlsZFJlcXVlc3QaHS5zbWlkci52MS5CdWlsZFN0YXR1c1Jlc3BvbnNlEk0KDkdldEJ1aWxkU3RhdHVzEhwuc21pZHIudjEuQnVpbGRTdGF0dXNSZXF1ZXN0Gh0uc21pZHIudjEuQnVpbGRTdGF0dXNSZXNwb25zZRJHCgpMaXN0QnVpbGRzEhsuc21pZHIudjEuTGlzdEJ1aWxkc1JlcXVlc3QaHC5zbWlkci52MS5MaXN0QnVpbGRzUmVzcG9uc2USSgoLQ2FuY2VsQnVpbGQSHC5zbWlkci52MS5DYW5jZWxCdWlsZFJlcXVlc3QaHS5zbWlkci52MS5DYW5jZWxCdWlsZFJlc3BvbnNlEj0KCEdldEJ1aWxkEhkuc21pZHIudjEuR2V0QnVpbGRSZXF1ZXN0GhYuc21pZHIudjEuQnVpbGREZXRhaWxzEkoKC0RlbGV0ZUJ1aWxkEhwuc21pZHIudjEuRGVsZXRlQnVpbGRSZXF1ZXN0Gh0uc21pZHIudjEuRGVsZXRlQnVpbGRSZXNwb25zZRJKCgtQdXJnZUJ1aWxkcxIcLnNtaWRyLnYxLlB1cmdlQnVpbGRzUmVxdWVzdBodLnNtaWRyLnYxLlB1cmdlQnVpbGRzUmVzcG9uc2VClgEKDGNvbS5zbWlkci52MUILQnVpbGRzUHJvdG9QAVo4Z2l0aHViLmNvbS9zY2hlcmVyamEvc21pZHIvc2Rrcy9wa2cvc21pZHItc2RrL3YxO3NtaWRydjGiAgNTWFiqAghTbWlkci5WMcoCCFNtaWRyXFYx4gIUU21pZHJcVjFcR1BCTWV0YWRhdGHqAglTbWlkcjo6VjFiBnByb3RvMw", [file_common]);
+  fileDesc("CgxidWlsZHMucHJvdG8SCHNtaWRyLnYxIr4CChFTdGFydEJ1aWxkUmVxdWVzdBIOCgZjb25maWcYASABKAkSDgoGdGFyZ2V0GAIgASgJEhMKC2ZvcmNlX2NsZWFuGAMgASgIEhsKE2ZvcmNlX2ltYWdlX3JlYnVpbGQYBCABKAgSVAoVZW52aXJvbm1lbnRfdmFyaWFibGVzGAUgAygLMjUuc21pZHIudjEuU3RhcnRCdWlsZFJlcXVlc3QuRW52aXJvbm1lbnRWYXJpYWJsZXNFbnRyeRIQCghjdXN0b21lchgGIAEoCRIQCghwcmlvcml0eRgHIAEoBRIMCgR0YXNrGAggASgJEhIKCmZvcmNlX3Rhc2sYCSABKAgaOwoZRW52aXJvbm1lbnRWYXJpYWJsZXNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIr0CChNCdWlsZFN0YXR1c1Jlc3BvbnNlEjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXISDgoGdGFyZ2V0GAIgASgJEiMKBXN0YXRlGAMgASgOMhQuc21pZHIudjEuQnVpbGRTdGF0ZRIRCglleGl0X2NvZGUYBCABKAUSFQoNZXJyb3JfbWVzc2FnZRgFIAEoCRIsCgp0aW1lc3RhbXBzGAYgASgLMhguc21pZHIudjEuVGltZVN0YW1wUmFuZ2USEwoLY29uZmlnX3BhdGgYByABKAkSEAoIY3VzdG9tZXIYCCABKAkSDwoHZGVsZXRlZBgJIAEoCBIWCg5xdWV1ZV9wb3NpdGlvbhgKIAEoBRIUCgxyZXRyaWVkX2Zyb20YCyABKAkiSQoSQnVpbGRTdGF0dXNSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXIi0QYKDEJ1aWxkRGV0YWlscxIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyEhAKCGN1c3RvbWVyGAIgASgJEhQKDHByb2plY3RfbmFtZRgDIAEoCRIUCgx0YXJnZXRfaW1hZ2UYBCABKAkSDwoHbWFjaGluZRgFIAEoCRIpCgtidWlsZF9zdGF0ZRgGIAEoDjIULnNtaWRyLnYxLkJ1aWxkU3RhdGUSEQoJZXhpdF9jb2RlGAcgASgFEhcKD2J1aWxkX2RpcmVjdG9yeRgIIAEoCRIaChJkb3dubG9hZF9kaXJlY3RvcnkYCSABKAkSFgoObG9nX2ZpbGVfcGxhaW4YCiABKAkSFgoObG9nX2ZpbGVfanNvbmwYCyABKAkSEwoLY29uZmlnX2ZpbGUYDCABKAkSFwoPY29uZmlnX3NuYXBzaG90GA0gASgJEgwKBHVzZXIYDiABKAkSDAoEaG9zdBgPIAEoCRISCgpjcmVhdGVkX2F0GBAgASgDEiwKCnRpbWVzdGFtcHMYESABKAsyGC5zbWlkci52MS5UaW1lU3RhbXBSYW5nZRIYChBkdXJhdGlvbl9zZWNvbmRzGBIgASgFEg8KB2RlbGV0ZWQYEyABKAgSEgoKZGVsZXRlZF9hdBgUIAEoAxIVCg1lcnJvcl9tZXNzYWdlGBUgASgJEhYKDmFydGlmYWN0X2NvdW50GBYgASgFEiEKGXRvdGFsX2FydGlmYWN0X3NpemVfYnl0ZXMYFyABKAMSFAoMcmV0cmllZF9mcm9tGBggASgJEi4KC2RpYWdub3N0aWNzGBkgAygLMhkuc21pZHIudjEuQnVpbGREaWFnbm9zdGljEigKCXRhc2tfbG9ncxgaIAMoCzIVLnNtaWRyLnYxLlRhc2tMb2dJbmZvEjIKEHJlY292ZXJ5X2FjdGlvbnMYGyADKAsyGC5zbWlkci52MS5SZWNvdmVyeUFjdGlvbhIsCg1sYXllcl9jb21taXRzGBwgAygLMhUuc21pZHIudjEuTGF5ZXJDb21taXQSKwoNbGF5ZXJfZmV0Y2hlcxgdIAMoCzIULnNtaWRyLnYxLkxheWVyRmV0Y2giPwoLVGFza0xvZ0luZm8SDgoGcmVjaXBlGAEgASgJEgwKBHRhc2sYAiABKAkSEgoKc2l6ZV9ieXRlcxgDIAEoAyKmAQoPQnVpbGREaWFnbm9zdGljEgwKBGtpbmQYASABKAkSDgoGcmVjaXBlGAIgASgJEgwKBHRhc2sYAyABKAkSCwoDdXJsGAQgASgJEgwKBGZpbGUYBSABKAkSDAoEbGluZRgGIAEoBRINCgVsYXllchgHIAEoCRIQCghsb2dfZmlsZRgIIAEoCRIPCgdtZXNzYWdlGAkgASgJEgwKBGhpbnQYCiABKAkinAEKDlJlY292ZXJ5QWN0aW9uEhAKCHN0cmF0ZWd5GAEgASgJEg4KBnJlY2lwZRgCIAEoCRIPCgdhdHRlbXB0GAMgASgFEhEKCXN1Y2NlZWRlZBgEIAEoCBIOCgZkZXRhaWwYBSABKAkSHwoXc3RhcnRlZF9hdF91bml4X3NlY29uZHMYBiABKAMSEwoLZHVyYXRpb25fbXMYByABKAMiSAoLTGF5ZXJDb21taXQSDAoEbmFtZRgBIAEoCRILCgNnaXQYAiABKAkSDgoGYnJhbmNoGAMgASgJEg4KBmNvbW1pdBgEIAEoCSKXAQoKTGF5ZXJGZXRjaBIMCgRuYW1lGAEgASgJEgsKA2dpdBgCIAEoCRIOCgZzdGF0dXMYAyABKAkSCwoDdXJsGAQgASgJEgsKA3JlZhgFIAEoCRIOCgZjb21taXQYBiABKAkSEAoIYXR0ZW1wdHMYByABKAUSEwoLZHVyYXRpb25fbXMYCCABKAMSDQoFZXJyb3IYCSABKAkivwEKEUxpc3RCdWlsZHNSZXF1ZXN0EioKDHN0YXRlX2ZpbHRlchgBIAMoDjIULnNtaWRyLnYxLkJ1aWxkU3RhdGUSLAoKdGltZV9yYW5nZRgCIAEoCzIYLnNtaWRyLnYxLlRpbWVTdGFtcFJhbmdlEhEKCXBhZ2Vfc2l6ZRgDIAEoBRISCgpwYWdlX3Rva2VuGAQgASgJEhAKCGN1c3RvbWVyGAUgASgJEhcKD2luY2x1ZGVfZGVsZXRlZBgGIAEoCCJrChJMaXN0QnVpbGRzUmVzcG9uc2USJgoGYnVpbGRzGAEgAygLMhYuc21pZHIudjEuQnVpbGREZXRhaWxzEhcKD25leHRfcGFnZV90b2tlbhgCIAEoCRIUCgx0b3RhbF9idWlsZHMYAyABKAUiSQoSQ2FuY2VsQnVpbGRSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXIiNwoTQ2FuY2VsQnVpbGRSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEg8KB21lc3NhZ2UYAiABKAkiRgoPR2V0QnVpbGRSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXIiSQoSRGVsZXRlQnVpbGRSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXIiNwoTRGVsZXRlQnVpbGRSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEg8KB21lc3NhZ2UYAiABKAkiRwoSUHVyZ2VCdWlsZHNSZXF1ZXN0Eh8KF29sZGVyX3RoYW5fdW5peF9zZWNvbmRzGAEgASgDEhAKCGN1c3RvbWVyGAIgASgJIncKE1B1cmdlQnVpbGRzUmVzcG9uc2USGgoScHVyZ2VkX2J1aWxkX2NvdW50GAEgASgFEhgKEHB1cmdlZF9idWlsZF9pZHMYAiADKAkSGQoRZnJlZWRfc3BhY2VfYnl0ZXMYAyABKAMSDwoHbWVzc2FnZRgEIAEoCSJIChFXYXRjaEJ1aWxkUmVxdWVzdBIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyIpwBChFSZXRyeUJ1aWxkUmVxdWVzdBIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyEg4KBnRhcmdldBgCIAEoCRITCgtmb3JjZV9jbGVhbhgDIAEoCBIbChNmb3JjZV9pbWFnZV9yZWJ1aWxkGAQgASgIEhAKCHByaW9yaXR5GAUgASgFIt4CCgpCdWlsZEV2ZW50EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXISHgoWdGltZXN0YW1wX3VuaXhfc2Vjb25kcxgCIAEoAxIyCgxzdGF0ZV9jaGFuZ2UYAyABKAsyGi5zbWlkci52MS5CdWlsZFN0YXRlQ2hhbmdlSAASMgoMcGhhc2VfY2hhbmdlGAQgASgLMhouc21pZHIudjEuQnVpbGRQaGFzZUNoYW5nZUgAEi8KDXRhc2tfcHJvZ3Jlc3MYBSABKAsyFi5zbWlkci52MS5UYXNrUHJvZ3Jlc3NIABIsCghyZWNvdmVyeRgGIAEoCzIYLnNtaWRyLnYxLlJlY292ZXJ5QWN0aW9uSAASKwoLbGF5ZXJfZmV0Y2gYByABKAsyFC5zbWlkci52MS5MYXllckZldGNoSABCBwoFZXZlbnQidgoQQnVpbGRTdGF0ZUNoYW5nZRIsCg5wcmV2aW91c19zdGF0ZRgBIAEoDjIULnNtaWRyLnYxLkJ1aWxkU3RhdGUSIwoFc3RhdGUYAiABKA4yFC5zbWlkci52MS5CdWlsZFN0YXRlEg8KB21lc3NhZ2UYAyABKAkiNwoQQnVpbGRQaGFzZUNoYW5nZRIjCgVwaGFzZRgBIAEoDjIULnNtaWRyLnYxLkJ1aWxkUGhhc2UiugEKDFRhc2tQcm9ncmVzcxIPCgdjdXJyZW50GAEgASgFEg0KBXRvdGFsGAIgASgFEg4KBnJlY2lwZRgDIAEoCRIMCgR0YXNrGAQgASgJEhAKCHNldHNjZW5lGAUgASgIEhgKEHNldHNjZW5lX2N1cnJlbnQYBiABKAUSFgoOc2V0c2NlbmVfdG90YWwYByABKAUSFAoMdGFza19jdXJyZW50GAggASgFEhIKCnRhc2tfdG90YWwYCSABKAUqhwEKCkJ1aWxkUGhhc2USGwoXQlVJTERfUEhBU0VfVU5TUEVDSUZJRUQQABIVChFCVUlMRF9QSEFTRV9GRVRDSBABEhUKEUJVSUxEX1BIQVNFX1BBUlNFEAISFQoRQlVJTERfUEhBU0VfQlVJTEQQAxIXChNCVUlMRF9QSEFTRV9FWFRSQUNUEAQyoAUKDEJ1aWxkU2VydmljZRJICgpTdGFydEJ1aWxkEhsuc21pZHIudjEuU3RhcnRCdWlsZFJlcXVlc3QaHS5zbWlkci52MS5CdWlsZFN0YXR1c1Jlc3BvbnNlEk0KDkdldEJ1aWxkU3RhdHVzEhwuc21pZHIudjEuQnVpbGRTdGF0dXNSZXF1ZXN0Gh0uc21pZHIudjEuQnVpbGRTdGF0dXNSZXNwb25zZRJHCgpMaXN0QnVpbGRzEhsuc21pZHIudjEuTGlzdEJ1aWxkc1JlcXVlc3QaHC5zbWlkci52MS5MaXN0QnVpbGRzUmVzcG9uc2USSgoLQ2FuY2VsQnVpbGQSHC5zbWlkci52MS5DYW5jZWxCdWlsZFJlcXVlc3QaHS5zbWlkci52MS5DYW5jZWxCdWlsZFJlc3BvbnNlEj0KCEdldEJ1aWxkEhkuc21pZHIudjEuR2V0QnVpbGRSZXF1ZXN0GhYuc21pZHIudjEuQnVpbGREZXRhaWxzEkoKC0RlbGV0ZUJ1aWxkEhwuc21pZHIudjEuRGVsZXRlQnVpbGRSZXF1ZXN0Gh0uc21pZHIudjEuRGVsZXRlQnVpbGRSZXNwb25zZRJKCgtQdXJnZUJ1aWxkcxIcLnNtaWRyLnYxLlB1cmdlQnVpbGRzUmVxdWVzdBodLnNtaWRyLnYxLlB1cmdlQnVpbGRzUmVzcG9uc2USQQoKV2F0Y2hCdWlsZBIbLnNtaWRyLnYxLldhdGNoQnVpbGRSZXF1ZXN0GhQuc21pZHIudjEuQnVpbGRFdmVudDABEkgKClJldHJ5QnVpbGQSGy5zbWlkci52MS5SZXRyeUJ1aWxkUmVxdWVzdBodLnNtaWRyLnYxLkJ1aWxkU3RhdHVzUmVzcG9uc2VClgEKDGNvbS5zbWlkci52MUILQnVpbGRzUHJvdG9QAVo4Z2l0aHViLmNvbS9zY2hlcmVyamEvc21pZHIvc2Rrcy9wa2cvc21pZHItc2RrL3YxO3NtaWRydjGiAgNTWFiqAghTbWlkci5WMcoCCFNtaWRyXFYx4gIUU21pZHJcVjFcR1BCTWV0YWRhdGHqAglTbWlkcjo6VjFiBnByb3RvMw", [file_common]);
 
 /**
  * StartBuildRequest is used to initiate a new build, specifying configuration.
@@ -28,7 +28,10 @@ export type StartBuildRequest = Message<"smidr.v1.StartBuildRequest"> & {
   config: string;
 
   /**
-   * Build target (e.g., core-image-minimal).
+   * Build targets in bitbake command line form: one or more space separated
+   * targets (images or recipes), optionally followed by "-c <task>" and "-f",
+   * e.g. "core-image-minimal meta-toolchain" or "busybox -c compile -f".
+   * Empty builds the config's build.image.
    *
    * @generated from field: string target = 2;
    */
@@ -61,6 +64,27 @@ export type StartBuildRequest = Message<"smidr.v1.StartBuildRequest"> & {
    * @generated from field: string customer = 6;
    */
   customer: string;
+
+  /**
+   * Scheduling priority; queued builds with a higher priority start first (default 0)
+   *
+   * @generated from field: int32 priority = 7;
+   */
+  priority: number;
+
+  /**
+   * Run only this task of the targets (bitbake -c), e.g. populate_sdk; overrides a task in target
+   *
+   * @generated from field: string task = 8;
+   */
+  task: string;
+
+  /**
+   * Run the task even if its stamp is valid (bitbake -f)
+   *
+   * @generated from field: bool force_task = 9;
+   */
+  forceTask: boolean;
 };
 
 /**
@@ -120,6 +144,20 @@ export type BuildStatusResponse = Message<"smidr.v1.BuildStatusResponse"> & {
    * @generated from field: bool deleted = 9;
    */
   deleted: boolean;
+
+  /**
+   * 1-based position in the build queue while QUEUED (estimated start order), 0 otherwise
+   *
+   * @generated from field: int32 queue_position = 10;
+   */
+  queuePosition: number;
+
+  /**
+   * ID of the build this build is a retry of, empty for new builds
+   *
+   * @generated from field: string retried_from = 11;
+   */
+  retriedFrom: string;
 };
 
 /**
@@ -280,6 +318,48 @@ export type BuildDetails = Message<"smidr.v1.BuildDetails"> & {
    * @generated from field: int64 total_artifact_size_bytes = 23;
    */
   totalArtifactSizeBytes: bigint;
+
+  /**
+   * Lineage: ID of the build this build is a retry of
+   *
+   * @generated from field: string retried_from = 24;
+   */
+  retriedFrom: string;
+
+  /**
+   * Failures recognised in the BitBake output of a failed build
+   *
+   * @generated from field: repeated smidr.v1.BuildDiagnostic diagnostics = 25;
+   */
+  diagnostics: BuildDiagnostic[];
+
+  /**
+   * Logs of failed tasks, retrievable with LogService.GetTaskLog
+   *
+   * @generated from field: repeated smidr.v1.TaskLogInfo task_logs = 26;
+   */
+  taskLogs: TaskLogInfo[];
+
+  /**
+   * Recovery steps the executor took, also when a retry made the build succeed
+   *
+   * @generated from field: repeated smidr.v1.RecoveryAction recovery_actions = 27;
+   */
+  recoveryActions: RecoveryAction[];
+
+  /**
+   * Commits the git layers were checked out at, as recorded in smidr.lock
+   *
+   * @generated from field: repeated smidr.v1.LayerCommit layer_commits = 28;
+   */
+  layerCommits: LayerCommit[];
+
+  /**
+   * How each layer repository was fetched before the build started
+   *
+   * @generated from field: repeated smidr.v1.LayerFetch layer_fetches = 29;
+   */
+  layerFetches: LayerFetch[];
 };
 
 /**
@@ -289,6 +369,252 @@ export type BuildDetails = Message<"smidr.v1.BuildDetails"> & {
 export const BuildDetailsSchema: GenMessage<BuildDetails> = /*@__PURE__*/
   messageDesc(file_builds, 3);
 
+/**
+ * TaskLogInfo names a task log collected from a failed build
+ *
+ * @generated from message smidr.v1.TaskLogInfo
+ */
+export type TaskLogInfo = Message<"smidr.v1.TaskLogInfo"> & {
+  /**
+   * @generated from field: string recipe = 1;
+   */
+  recipe: string;
+
+  /**
+   * @generated from field: string task = 2;
+   */
+  task: string;
+
+  /**
+   * @generated from field: int64 size_bytes = 3;
+   */
+  sizeBytes: bigint;
+};
+
+/**
+ * Describes the message smidr.v1.TaskLogInfo.
+ * Use `create(TaskLogInfoSchema)` to create a new message.
+ */
+export const TaskLogInfoSchema: GenMessage<TaskLogInfo> = /*@__PURE__*/
+  messageDesc(file_builds, 4);
+
+/**
+ * BuildDiagnostic is a structured build failure with a remediation hint
+ *
+ * @generated from message smidr.v1.BuildDiagnostic
+ */
+export type BuildDiagnostic = Message<"smidr.v1.BuildDiagnostic"> & {
+  /**
+   * fetch, checksum_mismatch, parse_error, missing_layer_dependency,
+   * layerseries_compat, pseudo_abort, oom_killed, disk_full or task_failed
+   *
+   * @generated from field: string kind = 1;
+   */
+  kind: string;
+
+  /**
+   * @generated from field: string recipe = 2;
+   */
+  recipe: string;
+
+  /**
+   * @generated from field: string task = 3;
+   */
+  task: string;
+
+  /**
+   * @generated from field: string url = 4;
+   */
+  url: string;
+
+  /**
+   * @generated from field: string file = 5;
+   */
+  file: string;
+
+  /**
+   * @generated from field: int32 line = 6;
+   */
+  line: number;
+
+  /**
+   * @generated from field: string layer = 7;
+   */
+  layer: string;
+
+  /**
+   * @generated from field: string log_file = 8;
+   */
+  logFile: string;
+
+  /**
+   * @generated from field: string message = 9;
+   */
+  message: string;
+
+  /**
+   * @generated from field: string hint = 10;
+   */
+  hint: string;
+};
+
+/**
+ * Describes the message smidr.v1.BuildDiagnostic.
+ * Use `create(BuildDiagnosticSchema)` to create a new message.
+ */
+export const BuildDiagnosticSchema: GenMessage<BuildDiagnostic> = /*@__PURE__*/
+  messageDesc(file_builds, 5);
+
+/**
+ * RecoveryAction is a self-heal step taken after a failed BitBake run (see build.recovery)
+ *
+ * @generated from message smidr.v1.RecoveryAction
+ */
+export type RecoveryAction = Message<"smidr.v1.RecoveryAction"> & {
+  /**
+   * package_index, pseudo_cleanup, cleansstate or retry
+   *
+   * @generated from field: string strategy = 1;
+   */
+  strategy: string;
+
+  /**
+   * @generated from field: string recipe = 2;
+   */
+  recipe: string;
+
+  /**
+   * Retry the step belongs to, starting at 1; 0 for steps outside the retry loop
+   *
+   * @generated from field: int32 attempt = 3;
+   */
+  attempt: number;
+
+  /**
+   * @generated from field: bool succeeded = 4;
+   */
+  succeeded: boolean;
+
+  /**
+   * @generated from field: string detail = 5;
+   */
+  detail: string;
+
+  /**
+   * @generated from field: int64 started_at_unix_seconds = 6;
+   */
+  startedAtUnixSeconds: bigint;
+
+  /**
+   * @generated from field: int64 duration_ms = 7;
+   */
+  durationMs: bigint;
+};
+
+/**
+ * Describes the message smidr.v1.RecoveryAction.
+ * Use `create(RecoveryActionSchema)` to create a new message.
+ */
+export const RecoveryActionSchema: GenMessage<RecoveryAction> = /*@__PURE__*/
+  messageDesc(file_builds, 6);
+
+/**
+ * LayerCommit is the commit a layer repository was built from
+ *
+ * @generated from message smidr.v1.LayerCommit
+ */
+export type LayerCommit = Message<"smidr.v1.LayerCommit"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string git = 2;
+   */
+  git: string;
+
+  /**
+   * @generated from field: string branch = 3;
+   */
+  branch: string;
+
+  /**
+   * @generated from field: string commit = 4;
+   */
+  commit: string;
+};
+
+/**
+ * Describes the message smidr.v1.LayerCommit.
+ * Use `create(LayerCommitSchema)` to create a new message.
+ */
+export const LayerCommitSchema: GenMessage<LayerCommit> = /*@__PURE__*/
+  messageDesc(file_builds, 7);
+
+/**
+ * LayerFetch is the outcome of fetching a layer repository (see fetch in smidr.yaml)
+ *
+ * @generated from message smidr.v1.LayerFetch
+ */
+export type LayerFetch = Message<"smidr.v1.LayerFetch"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string git = 2;
+   */
+  git: string;
+
+  /**
+   * cloned, updated, cached, stale or failed
+   *
+   * @generated from field: string status = 3;
+   */
+  status: string;
+
+  /**
+   * URL the fetch succeeded from; differs from git when a mirror was used
+   *
+   * @generated from field: string url = 4;
+   */
+  url: string;
+
+  /**
+   * @generated from field: string ref = 5;
+   */
+  ref: string;
+
+  /**
+   * @generated from field: string commit = 6;
+   */
+  commit: string;
+
+  /**
+   * @generated from field: int32 attempts = 7;
+   */
+  attempts: number;
+
+  /**
+   * @generated from field: int64 duration_ms = 8;
+   */
+  durationMs: bigint;
+
+  /**
+   * @generated from field: string error = 9;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message smidr.v1.LayerFetch.
+ * Use `create(LayerFetchSchema)` to create a new message.
+ */
+export const LayerFetchSchema: GenMessage<LayerFetch> = /*@__PURE__*/
+  messageDesc(file_builds, 8);
+
 /**
  * ListBuildsRequest is used to request a list of builds with optional filters.
  *
@@ -341,7 +667,7 @@ export type ListBuildsRequest = Message<"smidr.v1.ListBuildsRequest"> & {
  * Use `create(ListBuildsRequestSchema)` to create a new message.
  */
 export const ListBuildsRequestSchema: GenMessage<ListBuildsRequest> = /*@__PURE__*/
-  messageDesc(file_builds, 4);
+  messageDesc(file_builds, 9);
 
 /**
  * ListBuildsResponse provides a list of builds matching the request criteria.
@@ -370,7 +696,7 @@ export type ListBuildsResponse = Message<"smidr.v1.ListBuildsResponse"> & {
  * Use `create(ListBuildsResponseSchema)` to create a new message.
  */
 export const ListBuildsResponseSchema: GenMessage<ListBuildsResponse> = /*@__PURE__*/
-  messageDesc(file_builds, 5);
+  messageDesc(file_builds, 10);
 
 /**
  * CancelBuildRequest is used to request the cancellation of a specific build.
@@ -389,7 +715,7 @@ export type CancelBuildRequest = Message<"smidr.v1.CancelBuildRequest"> & {
  * Use `create(CancelBuildRequestSchema)` to create a new message.
  */
 export const CancelBuildRequestSchema: GenMessage<CancelBuildRequest> = /*@__PURE__*/
-  messageDesc(file_builds, 6);
+  messageDesc(file_builds, 11);
 
 /**
  * CancelBuildResponse provides the result of a cancellation request.
@@ -413,7 +739,7 @@ export type CancelBuildResponse = Message<"smidr.v1.CancelBuildResponse"> & {
  * Use `create(CancelBuildResponseSchema)` to create a new message.
  */
 export const CancelBuildResponseSchema: GenMessage<CancelBuildResponse> = /*@__PURE__*/
-  messageDesc(file_builds, 7);
+  messageDesc(file_builds, 12);
 
 /**
  * GetBuildRequest is used to request detailed information about a build.
@@ -432,7 +758,7 @@ export type GetBuildRequest = Message<"smidr.v1.GetBuildRequest"> & {
  * Use `create(GetBuildRequestSchema)` to create a new message.
  */
 export const GetBuildRequestSchema: GenMessage<GetBuildRequest> = /*@__PURE__*/
-  messageDesc(file_builds, 8);
+  messageDesc(file_builds, 13);
 
 /**
  * DeleteBuildRequest is used to request the deletion of a specific build.
@@ -451,7 +777,7 @@ export type DeleteBuildRequest = Message<"smidr.v1.DeleteBuildRequest"> & {
  * Use `create(DeleteBuildRequestSchema)` to create a new message.
  */
 export const DeleteBuildRequestSchema: GenMessage<DeleteBuildRequest> = /*@__PURE__*/
-  messageDesc(file_builds, 9);
+  messageDesc(file_builds, 14);
 
 /**
  * DeleteBuildResponse provides the result of a deletion request.
@@ -475,7 +801,7 @@ export type DeleteBuildResponse = Message<"smidr.v1.DeleteBuildResponse"> & {
  * Use `create(DeleteBuildResponseSchema)` to create a new message.
  */
 export const DeleteBuildResponseSchema: GenMessage<DeleteBuildResponse> = /*@__PURE__*/
-  messageDesc(file_builds, 10);
+  messageDesc(file_builds, 15);
 
 /**
  * PurgeBuildsRequest is used to request the purging of old builds.
@@ -503,7 +829,7 @@ export type PurgeBuildsRequest = Message<"smidr.v1.PurgeBuildsRequest"> & {
  * Use `create(PurgeBuildsRequestSchema)` to create a new message.
  */
 export const PurgeBuildsRequestSchema: GenMessage<PurgeBuildsRequest> = /*@__PURE__*/
-  messageDesc(file_builds, 11);
+  messageDesc(file_builds, 16);
 
 /**
  * PurgeBuildsResponse provides the result of a purge request.
@@ -537,7 +863,298 @@ export type PurgeBuildsResponse = Message<"smidr.v1.PurgeBuildsResponse"> & {
  * Use `create(PurgeBuildsResponseSchema)` to create a new message.
  */
 export const PurgeBuildsResponseSchema: GenMessage<PurgeBuildsResponse> = /*@__PURE__*/
-  messageDesc(file_builds, 12);
+  messageDesc(file_builds, 17);
+
+/**
+ * WatchBuildRequest is used to subscribe to structured events of a build.
+ *
+ * @generated from message smidr.v1.WatchBuildRequest
+ */
+export type WatchBuildRequest = Message<"smidr.v1.WatchBuildRequest"> & {
+  /**
+   * @generated from field: smidr.v1.BuildIdentifier build_identifier = 1;
+   */
+  buildIdentifier?: BuildIdentifier;
+};
+
+/**
+ * Describes the message smidr.v1.WatchBuildRequest.
+ * Use `create(WatchBuildRequestSchema)` to create a new message.
+ */
+export const WatchBuildRequestSchema: GenMessage<WatchBuildRequest> = /*@__PURE__*/
+  messageDesc(file_builds, 18);
+
+/**
+ * RetryBuildRequest starts a new build from the config snapshot, target and
+ * customer of a finished build.
+ *
+ * @generated from message smidr.v1.RetryBuildRequest
+ */
+export type RetryBuildRequest = Message<"smidr.v1.RetryBuildRequest"> & {
+  /**
+   * Build to retry
+   *
+   * @generated from field: smidr.v1.BuildIdentifier build_identifier = 1;
+   */
+  buildIdentifier?: BuildIdentifier;
+
+  /**
+   * Optional target override; defaults to the original build's target
+   *
+   * @generated from field: string target = 2;
+   */
+  target: string;
+
+  /**
+   * Force a clean rebuild
+   *
+   * @generated from field: bool force_clean = 3;
+   */
+  forceClean: boolean;
+
+  /**
+   * Force image rebuild only
+   *
+   * @generated from field: bool force_image_rebuild = 4;
+   */
+  forceImageRebuild: boolean;
+
+  /**
+   * Scheduling priority of the new build (default 0)
+   *
+   * @generated from field: int32 priority = 5;
+   */
+  priority: number;
+};
+
+/**
+ * Describes the message smidr.v1.RetryBuildRequest.
+ * Use `create(RetryBuildRequestSchema)` to create a new message.
+ */
+export const RetryBuildRequestSchema: GenMessage<RetryBuildRequest> = /*@__PURE__*/
+  messageDesc(file_builds, 19);
+
+/**
+ * BuildEvent is a single structured event emitted while a build runs.
+ *
+ * @generated from message smidr.v1.BuildEvent
+ */
+export type BuildEvent = Message<"smidr.v1.BuildEvent"> & {
+  /**
+   * @generated from field: smidr.v1.BuildIdentifier build_identifier = 1;
+   */
+  buildIdentifier?: BuildIdentifier;
+
+  /**
+   * @generated from field: int64 timestamp_unix_seconds = 2;
+   */
+  timestampUnixSeconds: bigint;
+
+  /**
+   * @generated from oneof smidr.v1.BuildEvent.event
+   */
+  event: {
+    /**
+     * @generated from field: smidr.v1.BuildStateChange state_change = 3;
+     */
+    value: BuildStateChange;
+    case: "stateChange";
+  } | {
+    /**
+     * @generated from field: smidr.v1.BuildPhaseChange phase_change = 4;
+     */
+    value: BuildPhaseChange;
+    case: "phaseChange";
+  } | {
+    /**
+     * @generated from field: smidr.v1.TaskProgress task_progress = 5;
+     */
+    value: TaskProgress;
+    case: "taskProgress";
+  } | {
+    /**
+     * @generated from field: smidr.v1.RecoveryAction recovery = 6;
+     */
+    value: RecoveryAction;
+    case: "recovery";
+  } | {
+    /**
+     * @generated from field: smidr.v1.LayerFetch layer_fetch = 7;
+     */
+    value: LayerFetch;
+    case: "layerFetch";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message smidr.v1.BuildEvent.
+ * Use `create(BuildEventSchema)` to create a new message.
+ */
+export const BuildEventSchema: GenMessage<BuildEvent> = /*@__PURE__*/
+  messageDesc(file_builds, 20);
+
+/**
+ * BuildStateChange reports a transition of the build state.
+ *
+ * @generated from message smidr.v1.BuildStateChange
+ */
+export type BuildStateChange = Message<"smidr.v1.BuildStateChange"> & {
+  /**
+   * @generated from field: smidr.v1.BuildState previous_state = 1;
+   */
+  previousState: BuildState;
+
+  /**
+   * @generated from field: smidr.v1.BuildState state = 2;
+   */
+  state: BuildState;
+
+  /**
+   * Optional human-readable reason (e.g., error or cancellation message)
+   *
+   * @generated from field: string message = 3;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message smidr.v1.BuildStateChange.
+ * Use `create(BuildStateChangeSchema)` to create a new message.
+ */
+export const BuildStateChangeSchema: GenMessage<BuildStateChange> = /*@__PURE__*/
+  messageDesc(file_builds, 21);
+
+/**
+ * BuildPhaseChange reports that the build entered a new phase.
+ *
+ * @generated from message smidr.v1.BuildPhaseChange
+ */
+export type BuildPhaseChange = Message<"smidr.v1.BuildPhaseChange"> & {
+  /**
+   * @generated from field: smidr.v1.BuildPhase phase = 1;
+   */
+  phase: BuildPhase;
+};
+
+/**
+ * Describes the message smidr.v1.BuildPhaseChange.
+ * Use `create(BuildPhaseChangeSchema)` to create a new message.
+ */
+export const BuildPhaseChangeSchema: GenMessage<BuildPhaseChange> = /*@__PURE__*/
+  messageDesc(file_builds, 22);
+
+/**
+ * TaskProgress reports BitBake task execution progress ("Running task N of M").
+ *
+ * @generated from message smidr.v1.TaskProgress
+ */
+export type TaskProgress = Message<"smidr.v1.TaskProgress"> & {
+  /**
+   * Position of this task within its kind (setscene or real tasks)
+   *
+   * @generated from field: int32 current = 1;
+   */
+  current: number;
+
+  /**
+   * @generated from field: int32 total = 2;
+   */
+  total: number;
+
+  /**
+   * Recipe name (e.g., busybox) and task name (e.g., do_compile)
+   *
+   * @generated from field: string recipe = 3;
+   */
+  recipe: string;
+
+  /**
+   * @generated from field: string task = 4;
+   */
+  task: string;
+
+  /**
+   * True for setscene tasks restored from sstate
+   *
+   * @generated from field: bool setscene = 5;
+   */
+  setscene: boolean;
+
+  /**
+   * Latest counts seen for each kind of task
+   *
+   * @generated from field: int32 setscene_current = 6;
+   */
+  setsceneCurrent: number;
+
+  /**
+   * @generated from field: int32 setscene_total = 7;
+   */
+  setsceneTotal: number;
+
+  /**
+   * @generated from field: int32 task_current = 8;
+   */
+  taskCurrent: number;
+
+  /**
+   * @generated from field: int32 task_total = 9;
+   */
+  taskTotal: number;
+};
+
+/**
+ * Describes the message smidr.v1.TaskProgress.
+ * Use `create(TaskProgressSchema)` to create a new message.
+ */
+export const TaskProgressSchema: GenMessage<TaskProgress> = /*@__PURE__*/
+  messageDesc(file_builds, 23);
+
+/**
+ * BuildPhase is a coarse stage of the build pipeline.
+ *
+ * @generated from enum smidr.v1.BuildPhase
+ */
+export enum BuildPhase {
+  /**
+   * @generated from enum value: BUILD_PHASE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Fetching layers and downloading sources
+   *
+   * @generated from enum value: BUILD_PHASE_FETCH = 1;
+   */
+  FETCH = 1,
+
+  /**
+   * BitBake is parsing recipes
+   *
+   * @generated from enum value: BUILD_PHASE_PARSE = 2;
+   */
+  PARSE = 2,
+
+  /**
+   * BitBake is executing tasks
+   *
+   * @generated from enum value: BUILD_PHASE_BUILD = 3;
+   */
+  BUILD = 3,
+
+  /**
+   * Artifacts are being copied into the artifact store
+   *
+   * @generated from enum value: BUILD_PHASE_EXTRACT = 4;
+   */
+  EXTRACT = 4,
+}
+
+/**
+ * Describes the enum smidr.v1.BuildPhase.
+ */
+export const BuildPhaseSchema: GenEnum<BuildPhase> = /*@__PURE__*/
+  enumDesc(file_builds, 0);
 
 /**
  * @generated from service smidr.v1.BuildService
@@ -599,6 +1216,22 @@ export const BuildService: GenService<{
     input: typeof PurgeBuildsRequestSchema;
     output: typeof PurgeBuildsResponseSchema;
   },
+  /**
+   * @generated from rpc smidr.v1.BuildService.WatchBuild
+   */
+  watchBuild: {
+    methodKind: "server_streaming";
+    input: typeof WatchBuildRequestSchema;
+    output: typeof BuildEventSchema;
+  },
+  /**
+   * @generated from rpc smidr.v1.BuildService.RetryBuild
+   */
+  retryBuild: {
+    methodKind: "unary";
+    input: typeof RetryBuildRequestSchema;
+    output: typeof BuildStatusResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_builds, 0);
 
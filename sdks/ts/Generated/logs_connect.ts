@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { LogEntry, StreamBuildLogsRequest } from "./logs_pb.js";
+import { GetTaskLogRequest, GetTaskLogResponse, LogEntry, StreamBuildLogsRequest } from "./logs_pb.js";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -21,6 +21,15 @@ export const LogService = {
       O: LogEntry,
       kind: MethodKind.ServerStreaming,
     },
+    /**
+     * @generated from rpc smidr.v1.LogService.GetTaskLog
+     */
+    getTaskLog: {
+      name: "GetTaskLog",
+      I: GetTaskLogRequest,
+      O: GetTaskLogResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file logs.proto.
  */
 export const file_logs: GenFile = /*@__PURE__*/
-  fileDesc("Cgpsb2dzLnByb3RvEghzbWlkci52MSJdChZTdHJlYW1CdWlsZExvZ3NSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXISDgoGZm9sbG93GAIgASgIImoKCExvZ0VudHJ5Eh4KFnRpbWVzdGFtcF91bml4X3NlY29uZHMYASABKAMSDwoHbWVzc2FnZRgCIAEoCRINCgVsZXZlbBgDIAEoCRIOCgZzb3VyY2UYBCABKAkSDgoGc3RyZWFtGAUgASgJMlcKCkxvZ1NlcnZpY2USSQoPU3RyZWFtQnVpbGRMb2dzEiAuc21pZHIudjEuU3RyZWFtQnVpbGRMb2dzUmVxdWVzdBoSLnNtaWRyLnYxLkxvZ0VudHJ5MAFClAEKDGNvbS5zbWlkci52MUIJTG9nc1Byb3RvUAFaOGdpdGh1Yi5jb20vc2NoZXJlcmphL3NtaWRyL3Nka3MvcGtnL3NtaWRyLXNkay92MTtzbWlkcnYxogIDU1hYqgIIU21pZHIuVjHKAghTbWlkclxWMeICFFNtaWRyXFYxXEdQQk1ldGFkYXRh6gIJU21pZHI6OlYxYgZwcm90bzM", [file_common]);
+  fileDesc("Cgpsb2dzLnByb3RvEghzbWlkci52MSLaAQoWU3RyZWFtQnVpbGRMb2dzUmVxdWVzdBIzChBidWlsZF9pZGVudGlmaWVyGAEgASgLMhkuc21pZHIudjEuQnVpbGRJZGVudGlmaWVyEg4KBmZvbGxvdxgCIAEoCBIOCgZzdHJlYW0YAyABKAkSDQoFbGV2ZWwYBCABKAkSGgoSc2luY2VfdW5peF9zZWNvbmRzGAUgASgDEhoKEnVudGlsX3VuaXhfc2Vjb25kcxgGIAEoAxIMCgR0YWlsGAcgASgFEhYKDmFmdGVyX3NlcXVlbmNlGAggASgEInwKCExvZ0VudHJ5Eh4KFnRpbWVzdGFtcF91bml4X3NlY29uZHMYASABKAMSDwoHbWVzc2FnZRgCIAEoCRINCgVsZXZlbBgDIAEoCRIOCgZzb3VyY2UYBCABKAkSDgoGc3RyZWFtGAUgASgJEhAKCHNlcXVlbmNlGAYgASgEInoKEUdldFRhc2tMb2dSZXF1ZXN0EjMKEGJ1aWxkX2lkZW50aWZpZXIYASABKAsyGS5zbWlkci52MS5CdWlsZElkZW50aWZpZXISDgoGcmVjaXBlGAIgASgJEgwKBHRhc2sYAyABKAkSEgoKdGFpbF9ieXRlcxgEIAEoAyJqChJHZXRUYXNrTG9nUmVzcG9uc2USDgoGcmVjaXBlGAEgASgJEgwKBHRhc2sYAiABKAkSDwoHY29udGVudBgDIAEoDBISCgpzaXplX2J5dGVzGAQgASgDEhEKCXRydW5jYXRlZBgFIAEoCDKgAQoKTG9nU2VydmljZRJJCg9TdHJlYW1CdWlsZExvZ3MSIC5zbWlkci52MS5TdHJlYW1CdWlsZExvZ3NSZXF1ZXN0GhIuc21pZHIudjEuTG9nRW50cnkwARJHCgpHZXRUYXNrTG9nEhsuc21pZHIudjEuR2V0VGFza0xvZ1JlcXVlc3QaHC5zbWlkci52MS5HZXRUYXNrTG9nUmVzcG9uc2VClAEKDGNvbS5zbWlkci52MUIJTG9nc1Byb3RvUAFaOGdpdGh1Yi5jb20vc2NoZXJlcmphL3NtaWRyL3Nka3MvcGtnL3NtaWRyLXNkay92MTtzbWlkcnYxogIDU1hYqgIIU21pZHIuVjHKAghTbWlkclxWMeICFFNtaWRyXFYxXEdQQk1ldGFkYXRh6gIJU21pZHI6OlYxYgZwcm90bzM", [file_common]);
 
 /**
  * StreamBuildLogsRequest is used to request streaming logs for a build.
@@ -29,10 +29,54 @@ export type StreamBuildLogsRequest = Message<"smidr.v1.StreamBuildLogsRequest">
 
   /**
    * If true, continue streaming new logs as they are generated.
+   * Ignored for builds that are no longer running (logs are replayed from disk).
    *
    * @generated from field: bool follow = 2;
    */
   follow: boolean;
+
+  /**
+   * Only return entries from this stream (e.g., stdout, stderr). Empty means all streams.
+   *
+   * @generated from field: string stream = 3;
+   */
+  stream: string;
+
+  /**
+   * Minimum log level to return (DEBUG, INFO, WARN, ERROR). Empty means all levels.
+   *
+   * @generated from field: string level = 4;
+   */
+  level: string;
+
+  /**
+   * Only return entries at or after this time (Unix seconds). Zero means no lower bound.
+   *
+   * @generated from field: int64 since_unix_seconds = 5;
+   */
+  sinceUnixSeconds: bigint;
+
+  /**
+   * Only return entries before this time (Unix seconds). Zero means no upper bound.
+   *
+   * @generated from field: int64 until_unix_seconds = 6;
+   */
+  untilUnixSeconds: bigint;
+
+  /**
+   * Only return the last N matching historical entries. Zero returns all of them.
+   *
+   * @generated from field: int32 tail = 7;
+   */
+  tail: number;
+
+  /**
+   * Resume after this sequence number (the last LogEntry.sequence the client received).
+   * Zero starts from the beginning of the log.
+   *
+   * @generated from field: uint64 after_sequence = 8;
+   */
+  afterSequence: bigint;
 };
 
 /**
@@ -80,6 +124,14 @@ export type LogEntry = Message<"smidr.v1.LogEntry"> & {
    * @generated from field: string stream = 5;
    */
   stream: string;
+
+  /**
+   * Position of the entry in the build log, starting at 1. Zero if unknown
+   * (e.g., logs replayed from builds that predate sequence numbers).
+   *
+   * @generated from field: uint64 sequence = 6;
+   */
+  sequence: bigint;
 };
 
 /**
@@ -89,6 +141,91 @@ export type LogEntry = Message<"smidr.v1.LogEntry"> & {
 export const LogEntrySchema: GenMessage<LogEntry> = /*@__PURE__*/
   messageDesc(file_logs, 1);
 
+/**
+ * GetTaskLogRequest requests the BitBake log (log.do_<task>) of a task that failed
+ * in a build, as collected from the work directory after the failure.
+ *
+ * @generated from message smidr.v1.GetTaskLogRequest
+ */
+export type GetTaskLogRequest = Message<"smidr.v1.GetTaskLogRequest"> & {
+  /**
+   * @generated from field: smidr.v1.BuildIdentifier build_identifier = 1;
+   */
+  buildIdentifier?: BuildIdentifier;
+
+  /**
+   * Recipe name without version (e.g., busybox).
+   *
+   * @generated from field: string recipe = 2;
+   */
+  recipe: string;
+
+  /**
+   * Task name (e.g., do_compile). The do_ prefix may be omitted.
+   *
+   * @generated from field: string task = 3;
+   */
+  task: string;
+
+  /**
+   * Only return the last N bytes of the log. Zero returns the whole log, up to a
+   * server-side limit.
+   *
+   * @generated from field: int64 tail_bytes = 4;
+   */
+  tailBytes: bigint;
+};
+
+/**
+ * Describes the message smidr.v1.GetTaskLogRequest.
+ * Use `create(GetTaskLogRequestSchema)` to create a new message.
+ */
+export const GetTaskLogRequestSchema: GenMessage<GetTaskLogRequest> = /*@__PURE__*/
+  messageDesc(file_logs, 2);
+
+/**
+ * @generated from message smidr.v1.GetTaskLogResponse
+ */
+export type GetTaskLogResponse = Message<"smidr.v1.GetTaskLogResponse"> & {
+  /**
+   * @generated from field: string recipe = 1;
+   */
+  recipe: string;
+
+  /**
+   * @generated from field: string task = 2;
+   */
+  task: string;
+
+  /**
+   * Content of the log, or its end when truncated is set.
+   *
+   * @generated from field: bytes content = 3;
+   */
+  content: Uint8Array;
+
+  /**
+   * Size of the complete log.
+   *
+   * @generated from field: int64 size_bytes = 4;
+   */
+  sizeBytes: bigint;
+
+  /**
+   * True if content holds only the end of the log.
+   *
+   * @generated from field: bool truncated = 5;
+   */
+  truncated: boolean;
+};
+
+/**
+ * Describes the message smidr.v1.GetTaskLogResponse.
+ * Use `create(GetTaskLogResponseSchema)` to create a new message.
+ */
+export const GetTaskLogResponseSchema: GenMessage<GetTaskLogResponse> = /*@__PURE__*/
+  messageDesc(file_logs, 3);
+
 /**
  * @generated from service smidr.v1.LogService
  */
@@ -101,6 +238,14 @@ export const LogService: GenService<{
     input: typeof StreamBuildLogsRequestSchema;
     output: typeof LogEntrySchema;
   },
+  /**
+   * @generated from rpc smidr.v1.LogService.GetTaskLog
+   */
+  getTaskLog: {
+    methodKind: "unary";
+    input: typeof GetTaskLogRequestSchema;
+    output: typeof GetTaskLogResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_logs, 0);
 